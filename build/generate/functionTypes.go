@@ -96,6 +96,7 @@ var providerNames = map[string]string{
 	"CLOUDFLAREAPI": "cloudflare-dns",
 	"CLOUDNS":       "cloudns",
 	"NS1":           "ns1",
+	"POWERDNS":      "powerdns",
 }
 
 func generateFunctionTypes() (string, error) {
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
+	"github.com/StackExchange/dnscontrol/v4/pkg/zonerecs"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/urfave/cli/v2"
+)
+
+// checkCredsLabel is the label of the TXT record which check-creds
+// creates and deletes to test the permission to write in the sandbox zone.
+const checkCredsLabel = "_dnscontrol-check-creds"
+
+// CheckCredsArgs args required for the check-creds subcommand.
+type CheckCredsArgs struct {
+	GetZoneArgs
+	Deep        bool   // Probe the permissions, not only the authentication
+	SandboxZone string // Zone where a record may be created and deleted
+	For         string // Verify the permissions needed by "preview" or "push"
+}
+
+func (args *CheckCredsArgs) flags() []cli.Flag {
+	flags := args.GetZoneArgs.flags()
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "deep",
+		Destination: &args.Deep,
+		Usage:       `Probe the permissions: list the zones, read the records and verify the scopes of the token`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "sandbox-zone",
+		Destination: &args.SandboxZone,
+		Usage:       `With --deep, create and delete a TXT record in this zone to verify the permission to write`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "for",
+		Destination: &args.For,
+		Usage:       `Verify the permissions needed by this command: preview or push (implies --deep, default: push)`,
+		Action: func(ctx *cli.Context, s string) error {
+			if s != "preview" && s != "push" {
+				return fmt.Errorf("invalid value %q for --for, expected preview or push", s)
+			}
+			return nil
+		},
+	})
+	return flags
+}
+
+// checkCredsReport prints the result of the probes and counts the failures.
+type checkCredsReport struct {
+	w        io.Writer
+	failures int
+}
+
+func (r *checkCredsReport) ok(probe, format string, a ...interface{}) {
+	fmt.Fprintf(r.w, "OK      %s: %s\n", probe, fmt.Sprintf(format, a...))
+}
+
+func (r *checkCredsReport) skip(probe, format string, a ...interface{}) {
+	fmt.Fprintf(r.w, "SKIPPED %s: %s\n", probe, fmt.Sprintf(format, a...))
+}
+
+func (r *checkCredsReport) fail(probe string, err error) {
+	r.failures++
+	fmt.Fprintf(r.w, "FAILED  %s: %s\n", probe, err)
+}
+
+// CheckCreds probes the permissions of the credentials and reports the
+// capabilities they lack.
+func CheckCreds(args CheckCredsArgs) error {
+	providerConfigs, err := credsfile.LoadProviderConfigs(args.CredsFile)
+	if err != nil {
+		return fmt.Errorf("failed CheckCreds LoadProviderConfigs(%q): %w", args.CredsFile, err)
+	}
+
+	w := os.Stdout
+	if args.OutputFile != "" {
+		w, err = os.Create(args.OutputFile)
+		if err != nil {
+			return fmt.Errorf("failed CheckCreds Create(%q): %w", args.OutputFile, err)
+		}
+		defer w.Close()
+	}
+	r := &checkCredsReport{w: w}
+
+	err = credsfile.Resolve(args.CredName, providerConfigs[args.CredName])
+	var provider providers.DNSServiceProvider
+	if err == nil {
+		provider, err = providers.CreateDNSProvider(args.ProviderName, providerConfigs[args.CredName], nil)
+	}
+	if err != nil {
+		r.fail("authentication", err)
+		return fmt.Errorf("the credentials %s failed %d check(s)", args.CredName, r.failures)
+	}
+	r.ok("authentication", "the provider is initialized")
+
+	var zones []string
+	if lister, ok := provider.(providers.ZoneLister); !ok {
+		r.skip("list zones", "the provider cannot list zones")
+	} else if zones, err = lister.ListZones(); err != nil {
+		r.fail("list zones", err)
+	} else {
+		r.ok("list zones", "%d zone(s)", len(zones))
+	}
+
+	pType := args.ProviderName
+	if pType == "" || pType == "-" {
+		pType = providerConfigs[args.CredName][providerTypeFieldName]
+	}
+	required := providers.Scopes[pType].For(args.For != "preview")
+	need := ""
+	if len(required) != 0 {
+		need = fmt.Sprintf(", they need: %s", strings.Join(required, ", "))
+	}
+	if checker, ok := provider.(providers.CredentialsChecker); !ok {
+		r.skip("permissions", "the API of the provider does not report the permissions of the credentials%s", need)
+	} else if missing, known, err := checker.CheckCredentials(required); err != nil {
+		r.fail("permissions", err)
+	} else if !known {
+		r.skip("permissions", "the permissions of the credentials cannot be read%s", need)
+	} else {
+		for _, m := range missing {
+			r.fail("permissions", fmt.Errorf("the credentials lack the permission %q", m))
+		}
+		if len(missing) == 0 {
+			r.ok("permissions", "the credentials have all the permissions for %s", cmp.Or(args.For, "push"))
+		}
+	}
+
+	zone := args.SandboxZone
+	if zone == "" && len(zones) > 0 {
+		zone = zones[0]
+	}
+	if zone == "" {
+		r.skip("read records", "no zone, use --sandbox-zone")
+	} else if recs, err := provider.GetZoneRecords(zone, nil); err != nil {
+		r.fail("read records", fmt.Errorf("%s: %w", zone, err))
+	} else {
+		r.ok("read records", "%d record(s) in %s", len(recs), zone)
+	}
+
+	if args.SandboxZone == "" {
+		r.skip("write records", "no sandbox zone, use --sandbox-zone")
+	} else if args.For == "preview" {
+		r.skip("write records", "preview does not write")
+	} else if isReadOnly(providerConfigs[args.CredName]) {
+		r.skip("write records", "the credentials are read-only")
+	} else if err := checkWrite(provider, args.SandboxZone); err != nil {
+		r.fail("write records", fmt.Errorf("%s: %w", args.SandboxZone, err))
+	} else {
+		r.ok("write records", "created and deleted %s.%s", checkCredsLabel, args.SandboxZone)
+	}
+
+	if r.failures != 0 {
+		return fmt.Errorf("the credentials %s failed %d check(s)", args.CredName, r.failures)
+	}
+	return nil
+}
+
+// checkWrite creates a TXT record in the zone, and deletes it. The zone
+// must have no pending corrections, so that nothing else is changed.
+func checkWrite(provider providers.DNSServiceProvider, zone string) error {
+	corrections, err := checkCredsCorrections(provider, zone, nil)
+	if err != nil {
+		return err
+	}
+	if len(corrections) != 0 {
+		return fmt.Errorf("the records of the zone are not stable (%d pending corrections), not going to write", len(corrections))
+	}
+
+	txt := &models.RecordConfig{Type: "TXT", TTL: 300}
+	txt.SetLabel(checkCredsLabel, zone)
+	txt.SetTargetTXT("created by dnscontrol check-creds")
+	if err := runCheckCredsCorrections(provider, zone, txt); err != nil {
+		return fmt.Errorf("failed creating the record: %w", err)
+	}
+	if corrections, err := checkCredsCorrections(provider, zone, txt); err != nil {
+		return err
+	} else if len(corrections) != 0 {
+		return fmt.Errorf("the record %s.%s was not created", checkCredsLabel, zone)
+	}
+	if err := runCheckCredsCorrections(provider, zone, nil); err != nil {
+		return fmt.Errorf("failed deleting the record %s.%s: %w", checkCredsLabel, zone, err)
+	}
+	return nil
+}
+
+// checkCredsCorrections returns the corrections which make the zone have
+// its existing records, and extra if it is not nil. The informational
+// corrections, without F, are left out.
+func checkCredsCorrections(provider providers.DNSServiceProvider, zone string, extra *models.RecordConfig) ([]*models.Correction, error) {
+	existing, err := provider.GetZoneRecords(zone, nil)
+	if err != nil {
+		return nil, err
+	}
+	var recs models.Records
+	for _, rec := range existing {
+		if rec.GetLabel() != checkCredsLabel {
+			recs = append(recs, rec)
+		}
+	}
+	if extra != nil {
+		recs = append(recs, extra)
+	}
+	dc := &models.DomainConfig{Name: zone, Records: recs, Metadata: map[string]string{}}
+	dc.UpdateSplitHorizonNames()
+	_, all, err := zonerecs.CorrectZoneRecords(provider, dc)
+	var corrections []*models.Correction
+	for _, c := range all {
+		if c.F != nil {
+			corrections = append(corrections, c)
+		}
+	}
+	return corrections, err
+}
+
+func runCheckCredsCorrections(provider providers.DNSServiceProvider, zone string, extra *models.RecordConfig) error {
+	corrections, err := checkCredsCorrections(provider, zone, extra)
+	if err != nil {
+		return err
+	}
+	for _, c := range corrections {
+		if err := c.F(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/_all"
+)
+
+func TestCheckCreds(t *testing.T) {
+	dir := t.TempDir()
+	zones := filepath.Join(dir, "zones")
+	if err := os.Mkdir(zones, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	zonefile := filepath.Join(zones, "example.com.zone")
+	zone := "$TTL 300\n@ IN SOA ns1.example.com. admin.example.com. 1 3600 600 86400 300\n@ IN NS ns1.example.com.\nwww IN A 192.0.2.1\n"
+	if err := os.WriteFile(zonefile, []byte(zone), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	creds := filepath.Join(dir, "creds.json")
+	if err := os.WriteFile(creds, []byte(`{"bind": {"TYPE": "BIND", "directory": "`+zones+`"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var args CheckCredsArgs
+	args.CredsFile = creds
+	args.CredName = "bind"
+	args.Deep = true
+	args.SandboxZone = "example.com"
+	args.OutputFile = filepath.Join(dir, "report.txt")
+	if err := CheckCreds(args); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := os.ReadFile(args.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"OK      list zones: 1 zone(s)", "SKIPPED permissions:", "OK      write records:"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("report lacks %q:\n%s", want, report)
+		}
+	}
+	dat, err := os.ReadFile(zonefile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(dat), checkCredsLabel) {
+		t.Errorf("the record %s was not deleted:\n%s", checkCredsLabel, dat)
+	}
+}
+
+func TestCheckCredsForPreview(t *testing.T) {
+	dir := t.TempDir()
+	creds := filepath.Join(dir, "creds.json")
+	if err := os.WriteFile(creds, []byte(`{"bind": {"TYPE": "BIND", "directory": "`+dir+`"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var args CheckCredsArgs
+	args.CredsFile = creds
+	args.CredName = "bind"
+	args.SandboxZone = "example.com"
+	args.For = "preview"
+	args.OutputFile = filepath.Join(dir, "report.txt")
+	if err := CheckCreds(args); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := os.ReadFile(args.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "SKIPPED write records: preview does not write"; !strings.Contains(string(report), want) {
+		t.Errorf("report lacks %q:\n%s", want, report)
+	}
+}
+
+// infoProvider keeps the records of a zone in memory. Its corrections
+// always have an informational one, without F.
+type infoProvider struct{ records models.Records }
+
+func (p *infoProvider) GetNameservers(string) ([]*models.Nameserver, error) { return nil, nil }
+
+func (p *infoProvider) GetZoneRecords(string, map[string]string) (models.Records, error) {
+	return p.records, nil
+}
+
+func (p *infoProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existing models.Records) ([]*models.Correction, error) {
+	corrections := []*models.Correction{{Msg: "INFO: the zone is on the free plan"}}
+	if len(dc.Records) != len(existing) {
+		records := dc.Records
+		corrections = append(corrections, &models.Correction{Msg: "update the records", F: func() error {
+			p.records = records
+			return nil
+		}})
+	}
+	return corrections, nil
+}
+
+func TestCheckWriteInfoCorrections(t *testing.T) {
+	www := &models.RecordConfig{Type: "A", TTL: 300}
+	www.SetLabel("www", "example.com")
+	www.SetTarget("192.0.2.1")
+	p := &infoProvider{records: models.Records{www}}
+
+	if err := checkWrite(p, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.records) != 1 || p.records[0].GetLabel() != "www" {
+		t.Errorf("the records are %v, want www only", p.records)
+	}
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/junit"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/v4/pkg/sarif"
+)
+
+// The rules of the findings of check.
+var checkRules = []sarif.Rule{
+	{ID: "dnscontrol/evaluation", Description: "The configuration cannot be evaluated"},
+	{ID: "dnscontrol/validation", Description: "The configuration is not valid"},
+}
+
+// ottoLine is the position of the syntax errors of the JavaScript engine,
+// e.g. "(anonymous): Line 5:2 Unexpected token ;".
+var ottoLine = regexp.MustCompile(`Line (\d+):(\d+) `)
+
+// checkFinding is an error or a warning of check, and the domain it
+// mentions.
+type checkFinding struct {
+	sarif.Finding
+	Domain string
+}
+
+// checkFindings validates the configuration, and returns its errors and its
+// warnings, and the names of its domains. err is not nil if there are
+// errors.
+func checkFindings(args CheckArgs) (findings []checkFinding, domains []string, err error) {
+	file := args.JSFile
+	if args.JSONFile != "" {
+		file = args.JSONFile
+	}
+	file = filepath.ToSlash(file)
+
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		f := sarif.Finding{RuleID: checkRules[0].ID, Level: sarif.Error, Message: err.Error(), File: file}
+		if m := ottoLine.FindStringSubmatch(err.Error()); m != nil {
+			f.Line, _ = strconv.Atoi(m[1])
+			f.Column, _ = strconv.Atoi(m[2])
+		}
+		return []checkFinding{{Finding: f}}, nil, err
+	}
+
+	for _, d := range cfg.Domains {
+		domains = append(domains, d.Name)
+	}
+	l := newSourceLocator(file, cfg)
+	for _, e := range normalize.ValidateAndNormalizeConfig(cfg) {
+		level := sarif.Error
+		if _, ok := e.(normalize.Warning); ok {
+			level = sarif.Warning
+		} else {
+			err = errors.New("exiting due to validation errors")
+		}
+		f := checkFinding{Finding: sarif.Finding{
+			RuleID:  checkRules[1].ID,
+			Level:   level,
+			Message: e.Error(),
+			File:    file,
+		}}
+		if d := l.domainOf(e.Error()); d != nil {
+			f.Domain = d.Name
+			f.Line = l.locate(d, e.Error())
+		}
+		findings = append(findings, f)
+	}
+	return findings, domains, err
+}
+
+// CheckSARIF validates the configuration and writes the errors and the
+// warnings to w in SARIF. It fails if there are errors.
+func CheckSARIF(args CheckArgs, w io.Writer) error {
+	findings, _, err := checkFindings(args)
+	results := make([]sarif.Finding, len(findings))
+	for i, f := range findings {
+		results[i] = f.Finding
+	}
+	if werr := sarif.Write(w, version, checkRules, results); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// CheckJUnit validates the configuration and writes a JUnit report to w,
+// with a test case per domain, which fails if the domain has errors. The
+// warnings are the output of the test cases. It fails if there are errors.
+func CheckJUnit(args CheckArgs, w io.Writer) error {
+	started := time.Now()
+	findings, domains, err := checkFindings(args)
+
+	suite := junit.Suite{Name: "check", Timestamp: started}
+	index := map[string]int{}
+	get := func(name string) *junit.Case {
+		i, ok := index[name]
+		if !ok {
+			i = len(suite.Cases)
+			index[name] = i
+			suite.Cases = append(suite.Cases, junit.Case{ClassName: "check", Name: name})
+		}
+		return &suite.Cases[i]
+	}
+	for _, d := range domains {
+		get(d)
+	}
+	for _, f := range findings {
+		name := f.Domain
+		if name == "" {
+			name = f.File
+		}
+		c := get(name)
+		switch {
+		case f.RuleID == checkRules[0].ID:
+			c.Error = appendLine(c.Error, f.Message)
+		case f.Level == sarif.Error:
+			c.Failure = appendLine(c.Failure, f.Message)
+		default:
+			c.Output = appendLine(c.Output, "WARNING: "+f.Message)
+		}
+	}
+
+	if werr := junit.Write(w, "dnscontrol check", suite); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// appendLine appends the line line to s.
+func appendLine(s, line string) string {
+	if s == "" {
+		return line
+	}
+	return s + "\n" + line
+}
+
+// sourceLocator finds the lines of dnsconfig.js of the domains and of the
+// records which the messages mention. The configuration is not evaluated
+// with the positions of its statements, so the domains and the records are
+// searched in the source: D("example.com", ...) and A("www", ...).
+type sourceLocator struct {
+	lines   []string
+	domains []*models.DomainConfig
+}
+
+func newSourceLocator(file string, cfg *models.DNSConfig) *sourceLocator {
+	l := &sourceLocator{domains: cfg.Domains}
+	if b, err := os.ReadFile(file); err == nil {
+		l.lines = strings.Split(string(b), "\n")
+	}
+	return l
+}
+
+var domainStatement = regexp.MustCompile(`\bD(_EXTEND)?\(`)
+
+// find returns the index of the first line from start which matches re, or
+// -1. It stops at the next domain if stopAtDomain is true.
+func (l *sourceLocator) find(re *regexp.Regexp, start int, stopAtDomain bool) int {
+	for i := start; i < len(l.lines); i++ {
+		if re.MatchString(l.lines[i]) {
+			return i
+		}
+		if stopAtDomain && i > start && domainStatement.MatchString(l.lines[i]) {
+			break
+		}
+	}
+	return -1
+}
+
+// quoted matches s as a string of JavaScript, followed by suffix.
+func quoted(s, suffix string) string {
+	return "[\"'`]" + regexp.QuoteMeta(s) + suffix + "[\"'`]"
+}
+
+// domainOf returns the domain which msg mentions, or nil.
+func (l *sourceLocator) domainOf(msg string) *models.DomainConfig {
+	var domain *models.DomainConfig
+	for _, d := range l.domains {
+		if strings.Contains(msg, d.Name) && (domain == nil || len(d.Name) > len(domain.Name)) {
+			domain = d
+		}
+	}
+	return domain
+}
+
+// domainLine returns the index of the line of the D() of domain, or -1.
+func (l *sourceLocator) domainLine(domain *models.DomainConfig) int {
+	return l.find(regexp.MustCompile(`\bD(_EXTEND)?\(\s*`+quoted(domain.Name, "(![^\"'`]*)?")), 0, false)
+}
+
+// recordLine returns the index of the line of rec in the D() of its domain
+// at the line start, or -1.
+func (l *sourceLocator) recordLine(start int, rec *models.RecordConfig) int {
+	if i := l.find(regexp.MustCompile(`\b`+regexp.QuoteMeta(rec.Type)+`\(\s*`+quoted(rec.GetLabel(), "")), start, true); i >= 0 {
+		return i
+	}
+	return l.find(regexp.MustCompile(quoted(rec.GetLabel(), "")), start, true)
+}
+
+// locate returns the line of the record of domain, or else of domain,
+// which msg mentions, or 0.
+func (l *sourceLocator) locate(domain *models.DomainConfig, msg string) int {
+	start := l.domainLine(domain)
+	if start < 0 {
+		return 0
+	}
+
+	var rec *models.RecordConfig
+	for _, r := range domain.Records {
+		label := r.GetLabel()
+		if strings.Contains(msg, label+"."+domain.Name) && (rec == nil || len(label) > len(rec.GetLabel())) {
+			rec = r
+		}
+	}
+	if rec != nil {
+		if i := l.recordLine(start, rec); i >= 0 {
+			return i + 1
+		}
+	}
+	return start + 1
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckSARIF(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "dnsconfig.js")
+	config := `var REG = NewRegistrar("none");
+D("example.org", REG,
+  A("www", "192.0.2.1")
+);
+D("example.com", REG,
+  A("www", "192.0.2.1"),
+  A("w_x", "192.0.2.2"),
+  CNAME("@", "foo.example.net.")
+);
+`
+	if err := os.WriteFile(file, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	args := CheckArgs{Format: "sarif"}
+	args.JSFile = file
+	if err := CheckSARIF(args, &buf); err == nil {
+		t.Errorf("CheckSARIF() = nil, want the validation errors")
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF %s: %s", buf.String(), err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("SARIF = %s", buf.String())
+	}
+	type finding struct {
+		level string
+		line  int
+	}
+	var got []finding
+	for _, r := range log.Runs[0].Results {
+		if r.RuleID != "dnscontrol/validation" || len(r.Locations) != 1 {
+			t.Fatalf("result = %+v", r)
+		}
+		got = append(got, finding{r.Level, r.Locations[0].PhysicalLocation.Region.StartLine})
+	}
+	want := []finding{{"warning", 7}, {"error", 8}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestCheckJUnit(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "dnsconfig.js")
+	config := `var REG = NewRegistrar("none");
+D("example.org", REG,
+  A("www", "192.0.2.1")
+);
+D("example.com", REG,
+  A("w_x", "192.0.2.2"),
+  CNAME("@", "foo.example.net.")
+);
+`
+	if err := os.WriteFile(file, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	args := CheckArgs{Format: "junit"}
+	args.JSFile = file
+	if err := CheckJUnit(args, &buf); err == nil {
+		t.Errorf("CheckJUnit() = nil, want the validation errors")
+	}
+	for _, want := range []string{
+		`<testsuites name="dnscontrol check" tests="2" failures="1" errors="0"`,
+		`<testcase classname="check" name="example.org" time="0.000"></testcase>`,
+		`<failure message="in CNAME @.example.com: cannot create CNAME record for bare domain">`,
+		`<system-out>WARNING: label w_x.example.com contains`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,9 +9,11 @@ import (
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/auditlog"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/js"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tracing"
 	"github.com/urfave/cli/v2"
 
 	"github.com/fatih/color"
@@ -40,6 +43,13 @@ var _ = cmd(catDebug, &cli.Command{
 	},
 })
 
+// logFormat and logLevel are the format and the level of the logs
+// (--log-format and --log-level).
+var logFormat, logLevel string
+
+// otelFlag exports the traces (--otel).
+var otelFlag bool
+
 // Run will execute the CLI
 func Run(v string) int {
 	version = v
@@ -74,6 +84,34 @@ func Run(v string) int {
 			Usage:       "Disables update reordering",
 			Destination: &diff2.DisableOrdering,
 		},
+		&cli.BoolFlag{
+			Name:        "read-only",
+			Usage:       "Refuse to change any provider, even with push",
+			Destination: &readOnly,
+		},
+		&cli.StringFlag{
+			Name:  "audit-log",
+			Usage: "Append the requests to the APIs of the providers to this file, as JSON lines",
+			Action: func(ctx *cli.Context, filename string) error {
+				return auditlog.Open(filename)
+			},
+		},
+		&cli.BoolFlag{
+			Name:        "otel",
+			Usage:       "Export OpenTelemetry traces with OTLP, to $OTEL_EXPORTER_OTLP_ENDPOINT",
+			Destination: &otelFlag,
+		},
+		&cli.StringFlag{
+			Name:        "log-format",
+			Usage:       "Format of the logs: console, or text or json on stderr",
+			Value:       "console",
+			Destination: &logFormat,
+		},
+		&cli.StringFlag{
+			Name:        "log-level",
+			Usage:       "Level of the logs: debug, info, warn or error (default: info, or debug with --debug)",
+			Destination: &logLevel,
+		},
 		&cli.BoolFlag{
 			Name:        "no-colors",
 			Usage:       "Disable colors",
@@ -81,6 +119,26 @@ func Run(v string) int {
 			Value:       false,
 		},
 	}
+	var endTrace func()
+	app.Before = func(ctx *cli.Context) error {
+		if err := printer.ConfigureLogging(logFormat, logLevel, os.Stderr); err != nil {
+			return exit(err)
+		}
+		if otelFlag {
+			end, err := startTrace(ctx)
+			if err != nil {
+				return exit(fmt.Errorf("failed starting OpenTelemetry: %w", err))
+			}
+			endTrace = end
+		}
+		return nil
+	}
+	app.After = func(ctx *cli.Context) error {
+		if endTrace != nil {
+			endTrace()
+		}
+		return nil
+	}
 	sort.Sort(cli.CommandsByName(commands))
 	app.Commands = commands
 	app.EnableBashCompletion = true
@@ -108,6 +166,23 @@ func Run(v string) int {
 	return 0
 }
 
+// startTrace starts the root span of the command, the current span. end
+// ends it and flushes the spans.
+func startTrace(ctx *cli.Context) (end func(), err error) {
+	shutdown, err := tracing.Init(ctx.Context, version)
+	if err != nil {
+		return nil, err
+	}
+	spanCtx, span := tracing.Start(ctx.Context, "dnscontrol "+ctx.Args().First())
+	tracing.SetCurrent(spanCtx)
+	return func() {
+		span.End()
+		if err := shutdown(context.Background()); err != nil {
+			printer.Warnf("OpenTelemetry: %s\n", err)
+		}
+	}, nil
+}
+
 // Shared config types
 
 // GetDNSConfigArgs contains what we need to get a valid dns config.
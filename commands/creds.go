@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var auditArgs CredsAuditArgs
+	return &cli.Command{
+		Name:  "creds",
+		Usage: "stores the secrets of creds.json in the keychain of the OS (stand-alone)",
+		Description: `Store and read the secrets in the keychain of the OS (macOS Keychain,
+Windows Credential Manager, or the Secret Service of libsecret). The values
+"$keyring:NAME" of creds.json are read from the keychain.
+
+Audit the expiry of the credentials of creds.json: the "expires" key of the
+entries, or the expiry reported by the API of the provider with --query.`,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "set",
+				Usage:     "stores a secret, read from the terminal or stdin",
+				UsageText: "dnscontrol creds set name",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						return cli.Exit("Arguments should be: name (Ex: cloudflare/apitoken)", 1)
+					}
+					return exit(credsSet(ctx.Args().Get(0), os.Stdin))
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "prints a secret",
+				UsageText: "dnscontrol creds get name",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						return cli.Exit("Arguments should be: name (Ex: cloudflare/apitoken)", 1)
+					}
+					secret, err := credsfile.GetKeyringSecret(ctx.Args().Get(0))
+					if err != nil {
+						return exit(err)
+					}
+					fmt.Println(secret)
+					return nil
+				},
+			},
+			{
+				Name:      "audit",
+				Usage:     "prints the expiry of the credentials of creds.json",
+				UsageText: "dnscontrol creds audit [command options]",
+				Flags:     auditArgs.flags(),
+				Action: func(ctx *cli.Context) error {
+					return exit(CredsAudit(auditArgs))
+				},
+			},
+		},
+	}
+}())
+
+// credsSet stores the secret read from in, without echo if it is a terminal.
+func credsSet(name string, in *os.File) error {
+	var secret []byte
+	var err error
+	if term.IsTerminal(int(in.Fd())) {
+		fmt.Fprintf(os.Stderr, "Secret %s: ", name)
+		secret, err = term.ReadPassword(int(in.Fd()))
+		fmt.Fprintln(os.Stderr)
+	} else {
+		secret, err = io.ReadAll(in)
+	}
+	if err != nil {
+		return err
+	}
+	value := strings.TrimRight(string(secret), "\r\n")
+	if value == "" {
+		return fmt.Errorf("the secret %s is empty", name)
+	}
+	return credsfile.SetKeyringSecret(name, value)
+}
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/urfave/cli/v2"
+)
+
+// credsExpiry returns the expiry of the credentials of a creds.json entry:
+// its "expires" key, or else the expiry reported by the API of the provider
+// driver, if it reports it. ok is false if the expiry is unknown or if the
+// credentials do not expire.
+func credsExpiry(m map[string]string, driver interface{}) (expiry time.Time, ok bool, err error) {
+	expiry, ok, err = credsfile.Expiry(m)
+	if ok || err != nil {
+		return expiry, ok, err
+	}
+	if expirer, isExpirer := driver.(providers.CredentialsExpirer); isExpirer {
+		expiry, err = expirer.CredentialsExpiry()
+		return expiry, err == nil && !expiry.IsZero(), err
+	}
+	return time.Time{}, false, nil
+}
+
+// daysUntil returns the number of whole days until t, negative if t is
+// past.
+func daysUntil(t time.Time) int {
+	return int(time.Until(t).Hours() / 24)
+}
+
+// warnCredsExpiry warns about the credentials of the providers of cfg which
+// expire within days days.
+func warnCredsExpiry(cfg *models.DNSConfig, providerConfigs map[string]map[string]string, days int) {
+	if days <= 0 {
+		return
+	}
+	drivers := map[string]interface{}{}
+	var names []string
+	add := func(name string, driver interface{}) {
+		if _, ok := drivers[name]; !ok {
+			drivers[name] = driver
+			names = append(names, name)
+		}
+	}
+	for _, d := range cfg.Domains {
+		if d.RegistrarInstance != nil {
+			add(d.RegistrarName, d.RegistrarInstance.Driver)
+		}
+		for _, p := range d.DNSProviderInstances {
+			add(p.Name, p.Driver)
+		}
+	}
+
+	for _, name := range names {
+		expiry, ok, err := credsExpiry(providerConfigs[name], drivers[name])
+		if err != nil {
+			printer.Warnf("creds.json: %s: cannot check the expiry of the credentials: %s\n", name, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if left := daysUntil(expiry); time.Now().After(expiry) {
+			printer.Warnf("creds.json: the credentials of %s expired on %s\n", name, expiry.Format(time.DateOnly))
+		} else if left < days {
+			printer.Warnf("creds.json: the credentials of %s expire on %s, in %d day(s)\n", name, expiry.Format(time.DateOnly), left)
+		}
+	}
+}
+
+// CredsAuditArgs args required for the creds audit subcommand.
+type CredsAuditArgs struct {
+	GetCredentialsArgs
+	Days  int  // Number of days before the expiry when the credentials are reported
+	Query bool // Query the API of the providers for the entries without "expires"
+}
+
+func (args *CredsAuditArgs) flags() []cli.Flag {
+	flags := args.GetCredentialsArgs.flags()
+	flags = append(flags, &cli.IntFlag{
+		Name:        "days",
+		Destination: &args.Days,
+		Value:       30,
+		Usage:       `Report the credentials which expire within this many days`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "query",
+		Destination: &args.Query,
+		Usage:       `Query the API of the providers which report the expiry of the credentials, for the entries without "expires"`,
+	})
+	return flags
+}
+
+// CredsAudit prints the expiry of the credentials of creds.json. It fails if
+// some credentials expire within args.Days days.
+func CredsAudit(args CredsAuditArgs) error {
+	providerConfigs, err := credsfile.LoadProviderConfigs(args.CredsFile)
+	if err != nil {
+		return fmt.Errorf("failed CredsAudit LoadProviderConfigs(%q): %w", args.CredsFile, err)
+	}
+	names := make([]string, 0, len(providerConfigs))
+	for name, m := range providerConfigs {
+		if m[providerTypeFieldName] != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	expiring := 0
+	for _, name := range names {
+		m := providerConfigs[name]
+		if err := credsfile.Resolve(name, m); err != nil {
+			expiring++
+			fmt.Printf("%-20s %-16s ERROR         %s\n", name, m[providerTypeFieldName], err)
+			continue
+		}
+		var driver interface{}
+		if _, ok := m[credsfile.ExpiresKey]; !ok && args.Query {
+			driver, err = createProvider(m)
+			if err != nil {
+				expiring++
+				fmt.Printf("%-20s %-16s ERROR         %s\n", name, m[providerTypeFieldName], err)
+				continue
+			}
+		}
+
+		expiry, ok, err := credsExpiry(m, driver)
+		var status, detail string
+		switch {
+		case err != nil:
+			status, detail = "ERROR", err.Error()
+		case !ok:
+			status = "UNKNOWN"
+		case time.Now().After(expiry):
+			status, detail = "EXPIRED", expiry.Format(time.DateOnly)
+		case daysUntil(expiry) < args.Days:
+			status, detail = "EXPIRES SOON", fmt.Sprintf("%s (in %d day(s))", expiry.Format(time.DateOnly), daysUntil(expiry))
+		default:
+			status, detail = "OK", fmt.Sprintf("%s (in %d day(s))", expiry.Format(time.DateOnly), daysUntil(expiry))
+		}
+		if status == "ERROR" || status == "EXPIRED" || status == "EXPIRES SOON" {
+			expiring++
+		}
+		fmt.Println(strings.TrimSpace(fmt.Sprintf("%-20s %-16s %-13s %s", name, m[providerTypeFieldName], status, detail)))
+	}
+
+	if expiring != 0 {
+		return fmt.Errorf("%d credentials expire within %d days or cannot be checked", expiring, args.Days)
+	}
+	return nil
+}
+
+// createProvider returns the DNS provider, or else the registrar, of a
+// creds.json entry.
+func createProvider(m map[string]string) (interface{}, error) {
+	pType := m[providerTypeFieldName]
+	if _, ok := providers.DNSProviderTypes[pType]; ok {
+		return providers.CreateDNSProvider(pType, m, nil)
+	}
+	return providers.CreateRegistrar(pType, m)
+}
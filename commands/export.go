@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/depgraph"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args ExportArgs
+	return &cli.Command{
+		Name:  "export",
+		Usage: "exports the records of dnsconfig.js, or the graph of their dependencies, without accessing the providers",
+		Description: `Export the records of all the domains of dnsconfig.js as a flat
+inventory (--format=csv), a record per line for each of its DNS providers:
+
+   domain,label,type,ttl,rdata,provider,file,line
+
+The file and the line are where the record is in dnsconfig.js, if it can be
+found there: the line of its D() otherwise.
+
+Export the graph of the dependencies of the records (CNAME, MX, NS, SRV, ...)
+on their targets, in the DOT language of Graphviz (--format=dot) or as JSON
+(--format=graph). With --resolve, the targets outside of the managed zones
+are resolved: the ones which do not exist are dangling, and a takeover risk
+if they are names of a third-party service (GitHub Pages, Heroku, S3, ...).`,
+		Action: func(c *cli.Context) error {
+			return exit(Export(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// ExportArgs are the arguments of the export subcommand.
+type ExportArgs struct {
+	GetDNSConfigArgs
+	Format     string // Format of the export: csv, dot or graph
+	OutputFile string // File where the export is written, stdout if ""
+	Resolve    bool   // Resolve the unmanaged targets of the graph
+}
+
+func (args *ExportArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "format",
+		Destination: &args.Format,
+		Value:       "csv",
+		Usage:       `Format of the export: csv (the records), dot or graph (the graph of their dependencies, in DOT or JSON)`,
+		Action: func(ctx *cli.Context, s string) error {
+			if s != "csv" && s != "dot" && s != "graph" {
+				return fmt.Errorf("invalid value %q for --format, expected csv, dot or graph", s)
+			}
+			return nil
+		},
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "resolve",
+		Destination: &args.Resolve,
+		Usage:       `With --format=dot or graph, resolve the targets outside of the managed zones and report the dangling ones`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "out",
+		Destination: &args.OutputFile,
+		Usage:       `Write the export to this file instead of stdout`,
+	})
+	return flags
+}
+
+// Export writes the records of the configuration.
+func Export(args ExportArgs) error {
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+
+	w := io.Writer(os.Stdout)
+	if args.OutputFile != "" {
+		f, err := os.Create(args.OutputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if args.Format == "dot" || args.Format == "graph" {
+		return exportGraph(args, cfg, w)
+	}
+
+	// The records of an IR file have no source.
+	file := ""
+	if args.JSONFile == "" {
+		file = filepath.ToSlash(args.JSFile)
+	}
+	l := newSourceLocator(file, cfg)
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"domain", "label", "type", "ttl", "rdata", "provider", "file", "line"})
+	for _, d := range cfg.Domains {
+		providers := make([]string, 0, len(d.DNSProviderNames))
+		for name := range d.DNSProviderNames {
+			providers = append(providers, name)
+		}
+		sort.Strings(providers)
+		if len(providers) == 0 {
+			providers = []string{""}
+		}
+
+		start := l.domainLine(d)
+		// used is the last line of each type and label, so that the
+		// records with the same type and label are found on the next
+		// lines.
+		used := map[string]int{}
+		for _, rec := range d.Records {
+			line := ""
+			if start >= 0 {
+				key := rec.Type + " " + rec.GetLabel()
+				i := -1
+				if prev, ok := used[key]; ok {
+					i = l.recordLine(prev+1, rec)
+				}
+				if i < 0 {
+					i = l.recordLine(start, rec)
+				}
+				if i >= 0 {
+					used[key] = i
+				} else {
+					i = start
+				}
+				line = strconv.Itoa(i + 1)
+			}
+
+			rtype := rec.Type
+			if rec.Type == "UNKNOWN" {
+				rtype = rec.UnknownTypeName
+			}
+			for _, p := range providers {
+				cw.Write([]string{d.Name, rec.GetLabel(), rtype, strconv.FormatUint(uint64(rec.TTL), 10),
+					rec.GetTargetCombinedFunc(nil), p, file, line})
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportGraph writes the graph of the dependencies of the records of cfg.
+// The risks found by the resolution of the targets are reported on stderr.
+func exportGraph(args ExportArgs, cfg *models.DNSConfig, w io.Writer) error {
+	g := depgraph.Build(cfg)
+	if args.Resolve {
+		r, err := depgraph.NewDNSResolver()
+		if err != nil {
+			return err
+		}
+		g.Resolve(context.Background(), r)
+	}
+	for _, e := range g.Risks() {
+		n := g.Node(e.To)
+		switch n.Risk {
+		case depgraph.Missing:
+			fmt.Fprintf(os.Stderr, "WARNING: %s %s -> %s: the target has no records in %s\n", e.From, e.Type, e.To, n.Zone)
+		case depgraph.Dangling:
+			fmt.Fprintf(os.Stderr, "WARNING: %s %s -> %s: the target does not exist\n", e.From, e.Type, e.To)
+		case depgraph.Takeover:
+			fmt.Fprintf(os.Stderr, "WARNING: %s %s -> %s: the target does not exist at %s, anyone may register it (takeover risk)\n", e.From, e.Type, e.To, n.Service)
+		}
+	}
+	if args.Format == "dot" {
+		return g.WriteDOT(w)
+	}
+	return g.WriteJSON(w)
+}
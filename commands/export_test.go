@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "dnsconfig.js")
+	config := `var REG = NewRegistrar("none");
+var DSP = NewDnsProvider("bind", "BIND");
+D("example.com", REG, DnsProvider(DSP),
+  A("www", "192.0.2.1"),
+  MX("@", 10, "mx1.example.com."),
+  MX("@", 20, "mx2.example.com."),
+  TXT("@", "v=spf1 mx -all")
+);
+`
+	if err := os.WriteFile(file, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := ExportArgs{Format: "csv", OutputFile: filepath.Join(dir, "records.csv")}
+	args.JSFile = file
+	if err := Export(args); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(args.OutputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := filepath.ToSlash(file)
+	want := `domain,label,type,ttl,rdata,provider,file,line
+example.com,www,A,300,192.0.2.1,bind,` + f + `,4
+example.com,@,MX,300,10 mx1.example.com.,bind,` + f + `,5
+example.com,@,MX,300,20 mx2.example.com.,bind,` + f + `,6
+example.com,@,TXT,300,v=spf1 mx -all,bind,` + f + `,7
+`
+	if string(got) != want {
+		t.Errorf("Export() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -78,7 +78,7 @@ EXAMPLES:
 // is the same as
 // get-zones --format=nameonly foo bar all
 var _ = cmd(catUtils, func() *cli.Command {
-	var args GetZoneArgs
+	var args CheckCredsArgs
 	return &cli.Command{
 		Name:  "check-creds",
 		Usage: "Do a small operation to verify credentials (stand-alone)",
@@ -100,7 +100,10 @@ var _ = cmd(catUtils, func() *cli.Command {
 			args.ProviderName = arg1
 			args.ZoneNames = []string{"all"}
 			args.OutputFormat = "nameonly"
-			return exit(GetZone(args))
+			if args.Deep || args.SandboxZone != "" || args.For != "" {
+				return exit(CheckCreds(args))
+			}
+			return exit(GetZone(args.GetZoneArgs))
 		},
 		Flags:     args.flags(),
 		UsageText: "dnscontrol check-creds [command options] credkey provider",
@@ -109,6 +112,12 @@ var _ = cmd(catUtils, func() *cli.Command {
 If successful, a list of zones will be output. If not, hopefully you
 see verbose error messages.
 
+With --deep, the permissions are probed instead: listing the zones,
+reading the records of a zone and, if the API reports them, the
+permissions of the token. Each probe is reported as OK, FAILED or
+SKIPPED. With --sandbox-zone, a TXT record is created and deleted in
+that zone to verify the permission to write.
+
 ARGUMENTS:
    credkey:  The name used in creds.json (first parameter to NewDnsProvider() in dnsconfig.js)
    provider: The name of the provider (second parameter to NewDnsProvider() in dnsconfig.js)
@@ -116,7 +125,8 @@ ARGUMENTS:
 EXAMPLES:
    dnscontrol check-creds myr53 ROUTE53      # Pre v3.16, or pre-v4.0 for backwards-compatibility
    dnscontrol check-creds myr53
-   dnscontrol check-creds --out=/dev/null myr53 && echo Success`,
+   dnscontrol check-creds --out=/dev/null myr53 && echo Success
+   dnscontrol check-creds --deep --sandbox-zone=sandbox.example.com cloudflare`,
 	}
 }())
 
@@ -162,6 +172,9 @@ func GetZone(args GetZoneArgs) error {
 	if err != nil {
 		return fmt.Errorf("failed GetZone LoadProviderConfigs(%q): %w", args.CredsFile, err)
 	}
+	if err := credsfile.Resolve(args.CredName, providerConfigs[args.CredName]); err != nil {
+		return err
+	}
 	provider, err := providers.CreateDNSProvider(args.ProviderName, providerConfigs[args.CredName], nil)
 	if err != nil {
 		return fmt.Errorf("failed GetZone CDP: %w", err)
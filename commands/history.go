@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+// historyFile is the push history, set by the --history flag of push.
+var historyFile = "dnscontrol-history.json"
+
+var historyMutex sync.Mutex
+
+// HistoryEntry is a snapshot taken by push before changing a zone.
+type HistoryEntry struct {
+	Time     time.Time `json:"time"`
+	Domain   string    `json:"domain"`
+	Provider string    `json:"provider"`
+	Snapshot string    `json:"snapshot"`
+}
+
+func historyFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:        "history",
+		Destination: &historyFile,
+		Value:       historyFile,
+		Usage:       `Push history, where the IDs of the snapshots of the zones are recorded`,
+	}
+}
+
+// readHistory reads the push history, one JSON entry per line.
+func readHistory(file string) ([]HistoryEntry, error) {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("can't parse the history %s: %w", file, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// appendHistory adds an entry to the push history.
+func appendHistory(file string, entry HistoryEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// latestSnapshot returns the latest snapshot of the domain at the provider.
+func latestSnapshot(entries []HistoryEntry, domain, provider string) (HistoryEntry, bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Domain == domain && entries[i].Provider == provider {
+			return entries[i], true
+		}
+	}
+	return HistoryEntry{}, false
+}
+
+// snapshotCorrections takes a snapshot of the zone before the corrections
+// change it, if the provider can, and records it in the push history. It is
+// only done by push. If the snapshot fails, the other corrections fail
+// without changing the zone, as there would be no restore point.
+func snapshotCorrections(domain string, provider *models.DNSProviderInstance, corrections []*models.Correction, push bool) []*models.Correction {
+	snapshotter, ok := provider.Driver.(providers.Snapshotter)
+	if !push || !ok || !hasChanges(corrections) {
+		return corrections
+	}
+	var snapshotErr error
+	snapshot := &models.Correction{
+		Msg: color.GreenString("+ SNAPSHOT %s before the changes", domain),
+		F: func() error {
+			id, err := snapshotter.CreateSnapshot(domain)
+			if err == nil {
+				err = appendHistory(historyFile, HistoryEntry{
+					Time:     time.Now().UTC(),
+					Domain:   domain,
+					Provider: provider.Name,
+					Snapshot: id,
+				})
+			}
+			snapshotErr = err
+			return err
+		},
+	}
+	guarded := []*models.Correction{snapshot}
+	for _, c := range corrections {
+		if c.F != nil {
+			f := c.F
+			c.F = func() error {
+				if snapshotErr != nil {
+					return fmt.Errorf("not done, the snapshot of %s failed: %w", domain, snapshotErr)
+				}
+				return f()
+			}
+		}
+		guarded = append(guarded, c)
+	}
+	return guarded
+}
+
+// hasChanges reports whether one of the corrections changes the zone.
+func hasChanges(corrections []*models.Correction) bool {
+	for _, c := range corrections {
+		if c.F != nil {
+			return true
+		}
+	}
+	return false
+}
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args RollbackArgs
+	return &cli.Command{
+		Name:  "rollback",
+		Usage: "restores a zone from a snapshot taken by push (stand-alone)",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 2 {
+				return cli.Exit("Arguments should be: credskey zone (Ex: gandi example.com)", 1)
+			}
+			args.CredName = ctx.Args().Get(0)
+			args.ZoneName = ctx.Args().Get(1)
+			return exit(Rollback(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol rollback [command options] credkey zone",
+		Description: `Restore a zone from the snapshot push took before changing it.  This is a stand-alone utility.
+
+Before changing the zone of a provider which can take snapshots, push takes
+a snapshot of the zone and records its ID in the push history. By default the
+latest snapshot of the zone is restored.
+
+ARGUMENTS:
+   credkey:  The name used in creds.json (first parameter to NewDnsProvider() in dnsconfig.js)
+   zone:     The zone (domain) to restore
+
+EXAMPLES:
+   dnscontrol rollback gandi example.com
+   dnscontrol rollback --snapshot=2b3c4d5e-6f70-4182-93a4-b5c6d7e8f901 gandi example.com`,
+	}
+}())
+
+// RollbackArgs args required for the rollback subcommand.
+type RollbackArgs struct {
+	GetCredentialsArgs        // Args related to creds.json
+	CredName           string // key in creds.json
+	ZoneName           string // The zone to restore
+	Snapshot           string // The ID of the snapshot ("" means the latest one)
+}
+
+func (args *RollbackArgs) flags() []cli.Flag {
+	flags := args.GetCredentialsArgs.flags()
+	flags = append(flags, historyFlag())
+	flags = append(flags, &cli.StringFlag{
+		Name:        "snapshot",
+		Destination: &args.Snapshot,
+		Usage:       `ID of the snapshot to restore, instead of the latest one of the history`,
+	})
+	return flags
+}
+
+// Rollback contains all data/flags needed to run rollback, independently of CLI.
+func Rollback(args RollbackArgs) error {
+	providerConfigs, err := credsfile.LoadProviderConfigs(args.CredsFile)
+	if err != nil {
+		return fmt.Errorf("failed Rollback LoadProviderConfigs(%q): %w", args.CredsFile, err)
+	}
+	if err := credsfile.Resolve(args.CredName, providerConfigs[args.CredName]); err != nil {
+		return err
+	}
+	provider, err := providers.CreateDNSProvider("-", providerConfigs[args.CredName], nil)
+	if err != nil {
+		return fmt.Errorf("failed Rollback CDP: %w", err)
+	}
+	snapshotter, ok := provider.(providers.Snapshotter)
+	if !ok {
+		return fmt.Errorf("provider %s cannot restore snapshots", args.CredName)
+	}
+	if isReadOnly(providerConfigs[args.CredName]) {
+		return (&models.ProviderBase{Name: args.CredName, ReadOnly: true}).ReadOnlyError()
+	}
+
+	id := args.Snapshot
+	if id == "" {
+		entries, err := readHistory(historyFile)
+		if err != nil {
+			return err
+		}
+		entry, ok := latestSnapshot(entries, args.ZoneName, args.CredName)
+		if !ok {
+			return fmt.Errorf("no snapshot of %s at %s in the history %s", args.ZoneName, args.CredName, historyFile)
+		}
+		id = entry.Snapshot
+		fmt.Printf("Restoring the snapshot %s of %s taken on %s\n", id, args.ZoneName, entry.Time.Format(time.RFC3339))
+	} else {
+		fmt.Printf("Restoring the snapshot %s of %s\n", id, args.ZoneName)
+	}
+	return snapshotter.RestoreSnapshot(args.ZoneName, id)
+}
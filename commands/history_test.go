@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestHistory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "history.json")
+
+	entries, err := readHistory(file)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Expected an empty history, got %v, %v", entries, err)
+	}
+
+	when := time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)
+	for _, entry := range []HistoryEntry{
+		{Time: when, Domain: "example.com", Provider: "gandi", Snapshot: "first"},
+		{Time: when, Domain: "example.org", Provider: "gandi", Snapshot: "other"},
+		{Time: when.Add(time.Hour), Domain: "example.com", Provider: "gandi", Snapshot: "second"},
+	} {
+		if err := appendHistory(file, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err = readHistory(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || !entries[0].Time.Equal(when) {
+		t.Fatalf("Unexpected history %v", entries)
+	}
+	if entry, ok := latestSnapshot(entries, "example.com", "gandi"); !ok || entry.Snapshot != "second" {
+		t.Errorf("Expected the snapshot second, got %v", entry)
+	}
+	if _, ok := latestSnapshot(entries, "example.com", "other"); ok {
+		t.Error("Expected no snapshot for another provider")
+	}
+}
+
+type fakeSnapshotter struct {
+	models.DNSProvider
+	err error
+}
+
+func (s fakeSnapshotter) CreateSnapshot(string) (string, error) { return "id", s.err }
+func (fakeSnapshotter) RestoreSnapshot(string, string) error    { return nil }
+
+func TestSnapshotCorrections(t *testing.T) {
+	defer func(file string) { historyFile = file }(historyFile)
+	historyFile = filepath.Join(t.TempDir(), "history.json")
+	provider := &models.DNSProviderInstance{Driver: fakeSnapshotter{}}
+	provider.Name = "gandi"
+
+	report := []*models.Correction{{Msg: "no changes"}}
+	if got := snapshotCorrections("example.com", provider, report, true); len(got) != 1 {
+		t.Errorf("Expected no snapshot without changes, got %d corrections", len(got))
+	}
+
+	changed := 0
+	change := func() []*models.Correction {
+		return []*models.Correction{{Msg: "change", F: func() error { changed++; return nil }}}
+	}
+	if got := snapshotCorrections("example.com", provider, change(), false); len(got) != 1 {
+		t.Errorf("Expected no snapshot in preview, got %d corrections", len(got))
+	}
+
+	got := snapshotCorrections("example.com", provider, change(), true)
+	if len(got) != 2 || got[1].Msg != "change" {
+		t.Fatalf("Expected the snapshot before the change, got %v", got)
+	}
+	if err := got[0].F(); err != nil {
+		t.Fatal(err)
+	}
+	if err := got[1].F(); err != nil || changed != 1 {
+		t.Errorf("Expected the change to be done after the snapshot, got %v", err)
+	}
+	entries, err := readHistory(historyFile)
+	if err != nil || len(entries) != 1 || entries[0].Snapshot != "id" || entries[0].Provider != "gandi" {
+		t.Errorf("Unexpected history %v, %v", entries, err)
+	}
+}
+
+func TestSnapshotCorrectionsFailed(t *testing.T) {
+	defer func(file string) { historyFile = file }(historyFile)
+	historyFile = filepath.Join(t.TempDir(), "history.json")
+	provider := &models.DNSProviderInstance{Driver: fakeSnapshotter{err: errors.New("quota exceeded")}}
+	provider.Name = "gandi"
+
+	changed := false
+	got := snapshotCorrections("example.com", provider, []*models.Correction{{Msg: "change", F: func() error { changed = true; return nil }}}, true)
+	if err := got[0].F(); err == nil {
+		t.Fatal("Expected the snapshot to fail")
+	}
+	if err := got[1].F(); err == nil || changed {
+		t.Errorf("Expected the change to be refused without a snapshot, got %v", err)
+	}
+}
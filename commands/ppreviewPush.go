@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/auditlog"
 	"github.com/StackExchange/dnscontrol/v4/pkg/bindserial"
 	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
 	"github.com/StackExchange/dnscontrol/v4/pkg/nameservers"
@@ -16,6 +17,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/pkg/notifications"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rfc4183"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tracing"
 	"github.com/StackExchange/dnscontrol/v4/pkg/zonerecs"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/urfave/cli/v2"
@@ -51,6 +53,9 @@ type PPreviewArgs struct {
 	NoPopulate  bool
 	DePopulate  bool
 	Full        bool
+	// CredsExpiryDays is the number of days before the expiry of the credentials
+	// when preview and push warn about it.
+	CredsExpiryDays int
 }
 
 // ReportItem is a record of corrections for a particular domain/provider/registrar.
@@ -102,6 +107,12 @@ func (args *PPreviewArgs) flags() []cli.Flag {
 		Destination: &args.Full,
 		Usage:       `Add headings, providers names, notifications of no changes, etc`,
 	})
+	flags = append(flags, &cli.IntFlag{
+		Name:        "creds-expiry-days",
+		Destination: &args.CredsExpiryDays,
+		Value:       30,
+		Usage:       `Warn about the credentials which expire within this many days (0 disables the warnings)`,
+	})
 	flags = append(flags, &cli.IntFlag{
 		Name:   "reportmax",
 		Hidden: true,
@@ -150,6 +161,7 @@ func (args *PPushArgs) flags() []cli.Flag {
 		Destination: &args.Report,
 		Usage:       `Generate a machine-parseable report of performed corrections.`,
 	})
+	flags = append(flags, historyFlag())
 	return flags
 }
 
@@ -193,6 +205,7 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 	if err != nil {
 		return err
 	}
+	warnCredsExpiry(cfg, providerConfigs, args.CredsExpiryDays)
 
 	out.PrintfIf(fullMode, "Normalizing and validating 'desired'..\n")
 	errs := normalize.ValidateAndNormalizeConfig(cfg)
@@ -213,13 +226,13 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 		out.PrintfIf(fullMode, "Concurrently gathering: %q\n", zone.Name)
 		go func(zone *models.DomainConfig, args PPreviewArgs, zcache *zoneCache) {
 			defer wg.Done()
-			oneZone(zone, args, zcache)
+			oneZone(zone, args, zcache, push)
 		}(zone, args, zcache)
 	}
 	out.Printf("SERIALLY gathering %d zone(s)\n", len(zonesSerial))
 	for _, zone := range zonesSerial {
 		out.Printf("Serially Gathering: %q\n", zone.Name)
-		oneZone(zone, args, zcache)
+		oneZone(zone, args, zcache, push)
 	}
 	out.PrintfIf(len(zonesConcurrent) > 0, "Waiting for concurrent gathering(s) to complete...")
 	wg.Wait()
@@ -239,7 +252,7 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 			skip := skipProvider(provider.Name, providersToProcess)
 			out.StartDNSProvider(provider.Name, skip)
 			if !skip {
-				corrections := zone.GetCorrections(provider.Name)
+				corrections := provider.GuardCorrections(zone.GetCorrections(provider.Name))
 				numActions := countActions(corrections)
 				totalCorrections += numActions
 				out.EndProvider2(provider.Name, numActions)
@@ -252,7 +265,7 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 		skip := skipProvider(zone.RegistrarInstance.Name, providersToProcess)
 		out.StartRegistrar(zone.RegistrarName, !skip)
 		if skip {
-			corrections := zone.GetCorrections(zone.RegistrarInstance.Name)
+			corrections := zone.RegistrarInstance.GuardCorrections(zone.GetCorrections(zone.RegistrarInstance.Name))
 			numActions := countActions(corrections)
 			out.EndProvider2(zone.RegistrarName, numActions)
 			totalCorrections += numActions
@@ -265,7 +278,9 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 	if os.Getenv("TEAMCITY_VERSION") != "" {
 		fmt.Fprintf(os.Stderr, "##teamcity[buildStatus status='SUCCESS' text='%d corrections']", totalCorrections)
 	}
-	rfc4183.PrintWarning()
+	if w := rfc4183.Warning(); w != "" {
+		out.Warnf("%s\n", w)
+	}
 	notifier.Done()
 	out.Printf("Done. %d corrections.\n", totalCorrections)
 	err = writeReport(report, reportItems)
@@ -361,10 +376,10 @@ func optimizeOrder(zones []*models.DomainConfig) []*models.DomainConfig {
 	return zones
 }
 
-func oneZone(zone *models.DomainConfig, args PPreviewArgs, zc *zoneCache) {
+func oneZone(zone *models.DomainConfig, args PPreviewArgs, zc *zoneCache, push bool) {
 	// Fix the parent zone's delegation: (if able/needed)
 	//zone.NameserversMutex.Lock()
-	delegationCorrections := generateDelegationCorrections(zone, zone.DNSProviderInstances, zone.RegistrarInstance)
+	delegationCorrections := generateNameserverRecords(zone, zone.DNSProviderInstances)
 	//zone.NameserversMutex.Unlock()
 
 	// Loop over the (selected) providers configured for that zone:
@@ -373,17 +388,23 @@ func oneZone(zone *models.DomainConfig, args PPreviewArgs, zc *zoneCache) {
 
 		// Populate the zones at the provider (if desired/needed/able):
 		if !args.NoPopulate {
-			populateCorrections := generatePopulateCorrections(provider, zone.Name, zc)
+			populateCorrections := generatePopulateCorrections(provider, zone.Name, zone.Metadata, zc)
 			zone.StoreCorrections(provider.Name, populateCorrections)
 		}
 
 		// Update the zone's records at the provider:
 		zoneCor, rep := generateZoneCorrections(zone, provider)
+		zoneCor = snapshotCorrections(zone.Name, provider, zoneCor, push)
 		zone.StoreCorrections(provider.Name, rep)
 		zone.StoreCorrections(provider.Name, zoneCor)
 	}
 
-	// Do the delegation corrections after the zones are updated.
+	// Do the delegation corrections after the zones are updated. They are
+	// generated after the zone corrections too, as the DS records reported
+	// by the DNS providers may depend on them.
+	if delegationCorrections == nil {
+		delegationCorrections = generateDelegationCorrections(zone)
+	}
 	zone.StoreCorrections(zone.RegistrarInstance.Name, delegationCorrections)
 }
 
@@ -470,9 +491,12 @@ func pprintOrRunCorrections(zoneName string, providerName string, corrections []
 			// If it is an action (not an informational message), notify and execute.
 			if correction.F != nil {
 				notifier.Notify(zoneName, providerName, correction.Msg, err, false)
-				err = correction.F()
+				auditlog.SetCorrection(auditlog.CorrectionID(zoneName, providerName, cc))
+				err = tracing.Run("correction", correction.F, tracing.Domain(zoneName), tracing.Provider(providerName), tracing.Correction(correction.Msg))
+				auditlog.SetCorrection("")
 				out.EndCorrection(err)
 				if err != nil {
+					printer.LogError("failed running the correction", err, "provider", providerName, "domain", zoneName, "correction", correction.Msg)
 					anyErrors = true
 				}
 			}
@@ -504,7 +528,7 @@ func writeReport(report string, reportItems []*ReportItem) error {
 	return nil
 }
 
-func generatePopulateCorrections(provider *models.DNSProviderInstance, zoneName string, zcache *zoneCache) []*models.Correction {
+func generatePopulateCorrections(provider *models.DNSProviderInstance, zoneName string, metadata map[string]string, zcache *zoneCache) []*models.Correction {
 
 	lister, ok := provider.Driver.(providers.ZoneLister)
 	if !ok {
@@ -529,20 +553,26 @@ func generatePopulateCorrections(provider *models.DNSProviderInstance, zoneName
 
 	return []*models.Correction{{
 		Msg: fmt.Sprintf("Create zone '%s' in the '%s' profile", aceZoneName, provider.Name),
-		F:   func() error { return creator.EnsureZoneExists(aceZoneName) },
+		F:   func() error { return providers.EnsureZoneExists(creator, aceZoneName, metadata) },
 	}}
 }
 
 func generateZoneCorrections(zone *models.DomainConfig, provider *models.DNSProviderInstance) ([]*models.Correction, []*models.Correction) {
+	// The zones are gathered concurrently: the span is not the current one.
+	_, span := tracing.StartCurrent("zone corrections", tracing.Domain(zone.Name), tracing.Provider(provider.Name))
 	reports, zoneCorrections, err := zonerecs.CorrectZoneRecords(provider.Driver, zone)
+	tracing.End(span, err)
 	if err != nil {
+		printer.LogError("failed getting the corrections", err, "provider", provider.Name, "domain", zone.Name)
 		return []*models.Correction{{Msg: fmt.Sprintf("Domain %q provider %s Error: %s", zone.Name, provider.Name, err)}}, nil
 	}
 	return zoneCorrections, reports
 }
 
-func generateDelegationCorrections(zone *models.DomainConfig, providers []*models.DNSProviderInstance, _ *models.RegistrarInstance) []*models.Correction {
-	//fmt.Printf("DEBUG: generateDelegationCorrections start zone=%q nsList = %v\n", zone.Name, zone.Nameservers)
+// generateNameserverRecords sets the nameservers of the zone and adds its
+// NS records. It returns the messages that skip the registrar, if any.
+func generateNameserverRecords(zone *models.DomainConfig, providers []*models.DNSProviderInstance) []*models.Correction {
+	//fmt.Printf("DEBUG: generateNameserverRecords start zone=%q nsList = %v\n", zone.Name, zone.Nameservers)
 	nsList, err := nameservers.DetermineNameserversForProviders(zone, providers, true)
 	if err != nil {
 		return msg(fmt.Sprintf("DtermineNS: zone %q; Error: %s", zone.Name, err))
@@ -553,9 +583,20 @@ func generateDelegationCorrections(zone *models.DomainConfig, providers []*model
 	if len(zone.Nameservers) == 0 && zone.Metadata["no_ns"] != "true" {
 		return []*models.Correction{{Msg: fmt.Sprintf("No nameservers declared for domain %q; skipping registrar. Add {no_ns:'true'} to force", zone.Name)}}
 	}
+	return nil
+}
 
+// generateDelegationCorrections returns the corrections of the registrar of
+// the zone, once its nameservers are set.
+func generateDelegationCorrections(zone *models.DomainConfig) []*models.Correction {
+	if err := setRegistrarDS(zone); err != nil {
+		return msg(fmt.Sprintf("zone %q; DS; Error: %s", zone.Name, err))
+	}
+	_, span := tracing.StartCurrent("registrar corrections", tracing.Domain(zone.Name), tracing.Provider(zone.RegistrarInstance.Name))
 	corrections, err := zone.RegistrarInstance.Driver.GetRegistrarCorrections(zone)
+	tracing.End(span, err)
 	if err != nil {
+		printer.LogError("failed getting the corrections", err, "registrar", zone.RegistrarInstance.Name, "domain", zone.Name)
 		return msg(fmt.Sprintf("zone %q; Rprovider %q; Error: %s", zone.Name, zone.RegistrarInstance.Name, err))
 	}
 	return corrections
@@ -567,12 +608,15 @@ func msg(s string) []*models.Correction {
 
 // PInitializeProviders takes (fully processed) configuration and instantiates all providers and returns them.
 func PInitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[string]string, notifyFlag bool) (notify notifications.Notifier, err error) {
-	var notificationCfg map[string]string
+	var notificationCfgs map[string]map[string]string
 	defer func() {
-		notify = notifications.Init(notificationCfg)
+		if rerr := resolveNotificationCreds(notificationCfgs); rerr != nil && err == nil {
+			err = rerr
+		}
+		notify = notifications.InitRoutes(notificationCfgs, notificationTags(cfg))
 	}()
 	if notifyFlag {
-		notificationCfg = providerConfigs["notifications"]
+		notificationCfgs = providerConfigs
 	}
 	isNonDefault := map[string]bool{}
 	for name, vals := range providerConfigs {
@@ -597,6 +641,9 @@ func PInitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[
 	for _, d := range cfg.Domains {
 		if registrars[d.RegistrarName] == nil {
 			rCfg := cfg.RegistrarsByName[d.RegistrarName]
+			if err := credsfile.Resolve(d.RegistrarName, providerConfigs[d.RegistrarName]); err != nil {
+				return nil, err
+			}
 			r, err := providers.CreateRegistrar(rCfg.Type, providerConfigs[d.RegistrarName])
 			if err != nil {
 				return nil, err
@@ -605,9 +652,13 @@ func PInitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[
 		}
 		d.RegistrarInstance.Driver = registrars[d.RegistrarName]
 		d.RegistrarInstance.IsDefault = !isNonDefault[d.RegistrarName]
+		d.RegistrarInstance.ReadOnly = isReadOnly(providerConfigs[d.RegistrarName])
 		for _, pInst := range d.DNSProviderInstances {
 			if dnsProviders[pInst.Name] == nil {
 				dCfg := cfg.DNSProvidersByName[pInst.Name]
+				if err := credsfile.Resolve(dCfg.Name, providerConfigs[dCfg.Name]); err != nil {
+					return nil, err
+				}
 				prov, err := providers.CreateDNSProvider(dCfg.Type, providerConfigs[dCfg.Name], dCfg.Metadata)
 				if err != nil {
 					return nil, err
@@ -616,6 +667,7 @@ func PInitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[
 			}
 			pInst.Driver = dnsProviders[pInst.Name]
 			pInst.IsDefault = !isNonDefault[pInst.Name]
+			pInst.ReadOnly = isReadOnly(providerConfigs[pInst.Name])
 		}
 	}
 	return
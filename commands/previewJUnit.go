@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/junit"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/v4/pkg/notifications"
+)
+
+var ansiColors = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// junitRun collects the results of preview and push as a JUnit report,
+// with a test case per domain. It is the notifier of the corrections, and
+// notifies them to the notifier of creds.json. The methods do nothing if
+// the report is disabled (nil).
+type junitRun struct {
+	notifications.Notifier
+	command string
+	suite   junit.Suite
+	index   map[string]int
+	pending map[string]int
+}
+
+func newJUnitRun(command string) *junitRun {
+	return &junitRun{
+		command: command,
+		suite:   junit.Suite{Name: command, Timestamp: time.Now()},
+		index:   map[string]int{},
+		pending: map[string]int{},
+	}
+}
+
+// get returns the test case of the domain.
+func (r *junitRun) get(domain string) *junit.Case {
+	i, ok := r.index[domain]
+	if !ok {
+		i = len(r.suite.Cases)
+		r.index[domain] = i
+		r.suite.Cases = append(r.suite.Cases, junit.Case{ClassName: r.command, Name: domain})
+	}
+	return &r.suite.Cases[i]
+}
+
+// start starts the test case of the domain, and returns the function which
+// ends it.
+func (r *junitRun) start(domain string) func() {
+	if r == nil {
+		return func() {}
+	}
+	started := time.Now()
+	r.get(domain)
+	return func() {
+		r.get(domain).Duration = time.Since(started)
+	}
+}
+
+// fail records the error of the provider for the domain.
+func (r *junitRun) fail(domain, provider string, err error) {
+	if r == nil {
+		return
+	}
+	c := r.get(domain)
+	c.Error = appendLine(c.Error, fmt.Sprintf("%s: %s", provider, err))
+}
+
+// corrections records the number of corrections of the provider for the
+// domain.
+func (r *junitRun) corrections(domain string, n int) {
+	if r == nil {
+		return
+	}
+	r.pending[domain] += n
+}
+
+// Notify implements notifications.Notifier.
+func (r *junitRun) Notify(domain, provider string, message string, err error, preview bool) {
+	r.Notifier.Notify(domain, provider, message, err, preview)
+	c := r.get(domain)
+	line := fmt.Sprintf("%s: %s", provider, ansiColors.ReplaceAllString(message, ""))
+	if err != nil {
+		c.Error = appendLine(c.Error, fmt.Sprintf("%s: %s", line, err))
+	} else {
+		c.Output = appendLine(c.Output, line)
+	}
+}
+
+// abort writes to w the report of a run stopped by err before the domains,
+// with the test case "configuration" in error, and returns err. The errors
+// of errs which are not warnings, e.g. the validation errors, are the text
+// of the error.
+func (r *junitRun) abort(w io.Writer, err error, errs ...error) error {
+	if r == nil {
+		return err
+	}
+	c := r.get("configuration")
+	c.Error = err.Error()
+	for _, e := range errs {
+		if _, ok := e.(normalize.Warning); !ok {
+			c.Error = appendLine(c.Error, e.Error())
+		}
+	}
+	if werr := junit.Write(w, "dnscontrol "+r.command, r.suite); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// write writes the report to w. If expectNoChanges is true, the domains
+// with corrections fail.
+func (r *junitRun) write(w io.Writer, expectNoChanges bool) error {
+	if r == nil {
+		return nil
+	}
+	if expectNoChanges {
+		for domain, n := range r.pending {
+			if n != 0 {
+				r.get(domain).Failure = fmt.Sprintf("%d pending correction(s)", n)
+			}
+		}
+	}
+	return junit.Write(w, "dnscontrol "+r.command, r.suite)
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type nopNotifier struct{ n int }
+
+func (n *nopNotifier) Notify(domain, provider string, message string, err error, preview bool) {
+	n.n++
+}
+
+func (n *nopNotifier) Done() {}
+
+func TestJUnitRun(t *testing.T) {
+	var disabled *junitRun
+	disabled.start("example.com")()
+	disabled.fail("example.com", "bind", errors.New("failed"))
+	if err := disabled.write(nil, true); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier := &nopNotifier{}
+	r := newJUnitRun("preview")
+	r.Notifier = notifier
+	r.start("example.com")()
+	r.Notify("example.com", "bind", "\x1b[32m+ CREATE www.example.com A 192.0.2.1\x1b[0m", nil, true)
+	r.corrections("example.com", 1)
+	r.start("example.net")()
+	r.fail("example.net", "cloudflare", errors.New("403 Forbidden"))
+	r.start("example.org")()
+	if notifier.n != 1 {
+		t.Errorf("notified %d corrections, want 1", notifier.n)
+	}
+
+	var buf bytes.Buffer
+	if err := r.write(&buf, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<testsuites name="dnscontrol preview" tests="3" failures="1" errors="1"`,
+		`<failure message="1 pending correction(s)">`,
+		`<system-out>bind: + CREATE www.example.com A 192.0.2.1</system-out>`,
+		`<error message="cloudflare: 403 Forbidden">`,
+		`<testcase classname="preview" name="example.org"`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestJUnitRunAbort(t *testing.T) {
+	var disabled *junitRun
+	if err := disabled.abort(nil, errors.New("failed")); err == nil || err.Error() != "failed" {
+		t.Errorf("abort() = %v, want the error", err)
+	}
+
+	var buf bytes.Buffer
+	r := newJUnitRun("push")
+	err := r.abort(&buf, errors.New("exiting due to validation errors"), errors.New("example.com: invalid record"))
+	if err == nil || err.Error() != "exiting due to validation errors" {
+		t.Errorf("abort() = %v, want the error", err)
+	}
+	for _, want := range []string{
+		`<testsuites name="dnscontrol push" tests="1" failures="0" errors="1"`,
+		`<testcase classname="push" name="configuration"`,
+		`<error message="exiting due to validation errors">exiting due to validation errors&#xA;example.com: invalid record</error>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -10,13 +10,16 @@ import (
 	"golang.org/x/net/idna"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/auditlog"
 	"github.com/StackExchange/dnscontrol/v4/pkg/bindserial"
 	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
 	"github.com/StackExchange/dnscontrol/v4/pkg/nameservers"
 	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/v4/pkg/notifications"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/pushstats"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rfc4183"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tracing"
 	"github.com/StackExchange/dnscontrol/v4/pkg/zonerecs"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/urfave/cli/v2"
@@ -44,6 +47,12 @@ type PreviewArgs struct {
 	WarnChanges bool
 	NoPopulate  bool
 	Full        bool
+	// CredsExpiryDays is the number of days before the expiry of the credentials
+	// when preview and push warn about it.
+	CredsExpiryDays int
+	Stats           bool   // Print the statistics of the providers at the end
+	StatsJSON       string // Write the statistics of the providers to this file
+	Format          string // Format of the results: text or junit
 }
 
 // ReportItem is a record of corrections for a particular domain/provider/registrar.
@@ -78,6 +87,34 @@ func (args *PreviewArgs) flags() []cli.Flag {
 		Destination: &args.Full,
 		Usage:       `Add headings, providers names, notifications of no changes, etc`,
 	})
+	flags = append(flags, &cli.IntFlag{
+		Name:        "creds-expiry-days",
+		Destination: &args.CredsExpiryDays,
+		Value:       30,
+		Usage:       `Warn about the credentials which expire within this many days (0 disables the warnings)`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "stats",
+		Destination: &args.Stats,
+		Usage:       `Print a summary per provider: records fetched, corrections, API calls, retries and duration`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "stats-json",
+		Destination: &args.StatsJSON,
+		Usage:       `Write the summary per provider to this file, as JSON`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "format",
+		Destination: &args.Format,
+		Value:       "text",
+		Usage:       `Format of the results: text, or junit to print a JUnit report for the test reports of CI (the rest of the output goes to stderr)`,
+		Action: func(ctx *cli.Context, s string) error {
+			if s != "text" && s != "junit" {
+				return fmt.Errorf("invalid value %q for --format, expected text or junit", s)
+			}
+			return nil
+		},
+	})
 	flags = append(flags, &cli.IntFlag{
 		Name:   "reportmax",
 		Hidden: true,
@@ -126,6 +163,7 @@ func (args *PushArgs) flags() []cli.Flag {
 		Destination: &args.Report,
 		Usage:       `Generate a machine-parseable report of performed corrections.`,
 	})
+	flags = append(flags, historyFlag())
 	return flags
 }
 
@@ -152,22 +190,43 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 		printer.Println("WARNING: Please remove obsolete --diff2 flag. This will be an error in v5 or later. See https://github.com/StackExchange/dnscontrol/issues/2262")
 	}
 
+	if args.Stats || args.StatsJSON != "" {
+		pushstats.Enable()
+	}
+	var tests *junitRun
+	if args.Format == "junit" {
+		// The report is printed to stdout, the rest of the output to stderr.
+		if cp, ok := out.(*printer.ConsolePrinter); ok {
+			cp.Writer = os.Stderr
+		}
+		command := "preview"
+		if push {
+			command = "push"
+		}
+		tests = newJUnitRun(command)
+	}
+
 	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
 	if err != nil {
-		return err
+		return tests.abort(os.Stdout, err)
 	}
 	providerConfigs, err := credsfile.LoadProviderConfigs(args.CredsFile)
 	if err != nil {
-		return err
+		return tests.abort(os.Stdout, err)
 	}
 	notifier, err := InitializeProviders(cfg, providerConfigs, args.Notify)
 	if err != nil {
-		return err
+		return tests.abort(os.Stdout, err)
+	}
+	if tests != nil {
+		tests.Notifier = notifier
+		notifier = tests
 	}
+	warnCredsExpiry(cfg, providerConfigs, args.CredsExpiryDays)
 
 	errs := normalize.ValidateAndNormalizeConfig(cfg)
 	if PrintValidationErrors(errs) {
-		return fmt.Errorf("exiting due to validation errors")
+		return tests.abort(os.Stdout, fmt.Errorf("exiting due to validation errors"), errs...)
 	}
 	anyErrors := false
 	totalCorrections := 0
@@ -193,6 +252,7 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 			if err != nil {
 				return
 			}
+			defer tests.start(domain.Name)()
 
 			// Correct the domain...
 
@@ -200,12 +260,17 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 			var providersWithExistingZone []*models.DNSProviderInstance
 			/// For each DSP...
 			for _, provider := range domain.DNSProviderInstances {
+				stopStats := pushstats.Start(provider.Name)
 				if !args.NoPopulate {
 					// preview run: check if zone is already there, if not print a warning
 					if lister, ok := provider.Driver.(providers.ZoneLister); ok && !push {
 						zones, err := lister.ListZones()
 						if err != nil {
-							out.Errorf("ERROR: %s\n", err.Error())
+							stopStats()
+							if !printer.LogError("failed listing the zones", err, "provider", provider.Name, "domain", domain.Name) {
+								out.Errorf("%s\n", err.Error())
+							}
+							tests.fail(domain.Name, provider.Name, err)
 							return
 						}
 						aceZoneName, _ := idna.ToASCII(domain.Name)
@@ -215,17 +280,26 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 							//out.Warnf("DEBUG: Name: %v\n", domain.Name)
 
 							out.Warnf("Zone '%s' does not exist in the '%s' profile and will be added automatically.\n", domain.Name, provider.Name)
+							stopStats()
 							continue // continue with next provider, as we can not determine corrections without an existing zone
 						}
 					} else if creator, ok := provider.Driver.(providers.ZoneCreator); ok && push {
 						// this is the actual push, ensure domain exists at DSP
-						if err := creator.EnsureZoneExists(domain.Name); err != nil {
+						err := provider.ReadOnlyError()
+						if !provider.ReadOnly {
+							err = providers.EnsureZoneExists(creator, domain.Name, domain.Metadata)
+						}
+						if err != nil {
+							stopStats()
+							printer.LogError("failed creating the zone", err, "provider", provider.Name, "domain", domain.Name)
 							out.Warnf("Error creating domain: %s\n", err)
+							tests.fail(domain.Name, provider.Name, err)
 							anyErrors = true
 							continue // continue with next provider, as we couldn't create this one
 						}
 					}
 				}
+				stopStats()
 				providersWithExistingZone = append(providersWithExistingZone, provider)
 			}
 
@@ -233,7 +307,10 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 
 			nsList, err := nameservers.DetermineNameserversForProviders(domain, providersWithExistingZone, false)
 			if err != nil {
-				out.Errorf("ERROR: %s\n", err.Error())
+				if !printer.LogError("failed getting the nameservers", err, "domain", domain.Name) {
+					out.Errorf("%s\n", err.Error())
+				}
+				tests.fail(domain.Name, "nameservers", err)
 				return
 			}
 			domain.Nameservers = nsList
@@ -247,20 +324,32 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 					continue
 				}
 
-				reports, corrections, err := zonerecs.CorrectZoneRecords(provider.Driver, domain)
+				stopStats := pushstats.Start(provider.Name)
+				var reports, corrections []*models.Correction
+				err := tracing.Run("zone corrections", func() (err error) {
+					reports, corrections, err = zonerecs.CorrectZoneRecords(pushstats.Driver(provider.Name, provider.Driver), domain)
+					return err
+				}, tracing.Domain(domain.Name), tracing.Provider(provider.Name))
 				out.EndProvider(provider.Name, len(corrections), err)
 				if err != nil {
+					stopStats()
+					printer.LogError("failed getting the corrections", err, "provider", provider.Name, "domain", domain.Name)
+					tests.fail(domain.Name, provider.Name, err)
 					anyErrors = true
 					return
 				}
 				totalCorrections += len(corrections)
+				tests.corrections(domain.Name, len(corrections))
 				printReports(domain.Name, provider.Name, reports, out, push, notifier)
 				reportItems = append(reportItems, ReportItem{
 					Domain:      domain.Name,
 					Corrections: len(corrections),
 					Provider:    provider.Name,
 				})
+				corrections = snapshotCorrections(domain.Name, provider, corrections, push)
+				corrections = provider.GuardCorrections(corrections)
 				anyErrors = printOrRunCorrections(domain.Name, provider.Name, corrections, out, push, interactive, notifier) || anyErrors
+				stopStats()
 			}
 
 			//
@@ -274,18 +363,30 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 				return
 			}
 
-			corrections, err := domain.RegistrarInstance.Driver.GetRegistrarCorrections(domain)
+			defer pushstats.Start(domain.RegistrarName)()
+			err = setRegistrarDS(domain)
+			var corrections []*models.Correction
+			if err == nil {
+				err = tracing.Run("registrar corrections", func() (err error) {
+					corrections, err = domain.RegistrarInstance.Driver.GetRegistrarCorrections(domain)
+					return err
+				}, tracing.Domain(domain.Name), tracing.Provider(domain.RegistrarName))
+			}
 			out.EndProvider(domain.RegistrarName, len(corrections), err)
 			if err != nil {
+				printer.LogError("failed getting the corrections", err, "registrar", domain.RegistrarName, "domain", domain.Name)
+				tests.fail(domain.Name, domain.RegistrarName, err)
 				anyErrors = true
 				return
 			}
 			totalCorrections += len(corrections)
+			tests.corrections(domain.Name, len(corrections))
 			reportItems = append(reportItems, ReportItem{
 				Domain:      domain.Name,
 				Corrections: len(corrections),
 				Registrar:   domain.RegistrarName,
 			})
+			corrections = domain.RegistrarInstance.GuardCorrections(corrections)
 			anyErrors = printOrRunCorrections(domain.Name, domain.RegistrarName, corrections, out, push, interactive, notifier) || anyErrors
 		}(domain)
 	}
@@ -294,9 +395,17 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 	if os.Getenv("TEAMCITY_VERSION") != "" {
 		fmt.Fprintf(os.Stderr, "##teamcity[buildStatus status='SUCCESS' text='%d corrections']", totalCorrections)
 	}
-	rfc4183.PrintWarning()
+	if w := rfc4183.Warning(); w != "" {
+		out.Warnf("%s\n", w)
+	}
 	notifier.Done()
 	out.Printf("Done. %d corrections.\n", totalCorrections)
+	if err := writeStats(args, out); err != nil {
+		return err
+	}
+	if err := tests.write(os.Stdout, args.WarnChanges); err != nil {
+		return err
+	}
 	if anyErrors {
 		return fmt.Errorf("completed with errors")
 	}
@@ -320,14 +429,44 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 	return nil
 }
 
+// notificationTags returns the tags of the domains for the routes of the
+// notifications: the comma-separated list of their metadata "notify".
+func notificationTags(cfg *models.DNSConfig) map[string][]string {
+	tags := map[string][]string{}
+	for _, d := range cfg.Domains {
+		for _, t := range strings.Split(d.Metadata["notify"], ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags[d.Name] = append(tags[d.Name], t)
+			}
+		}
+	}
+	return tags
+}
+
+// resolveNotificationCreds resolves the secrets of the entries of the
+// notifications in creds.json.
+func resolveNotificationCreds(configs map[string]map[string]string) error {
+	for name, keys := range configs {
+		if name == "notifications" || strings.HasPrefix(name, notifications.RoutePrefix) {
+			if err := credsfile.Resolve(name, keys); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // InitializeProviders takes (fully processed) configuration and instantiates all providers and returns them.
 func InitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[string]string, notifyFlag bool) (notify notifications.Notifier, err error) {
-	var notificationCfg map[string]string
+	var notificationCfgs map[string]map[string]string
 	defer func() {
-		notify = notifications.Init(notificationCfg)
+		if rerr := resolveNotificationCreds(notificationCfgs); rerr != nil && err == nil {
+			err = rerr
+		}
+		notify = notifications.InitRoutes(notificationCfgs, notificationTags(cfg))
 	}()
 	if notifyFlag {
-		notificationCfg = providerConfigs["notifications"]
+		notificationCfgs = providerConfigs
 	}
 	isNonDefault := map[string]bool{}
 	for name, vals := range providerConfigs {
@@ -352,6 +491,9 @@ func InitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[s
 	for _, d := range cfg.Domains {
 		if registrars[d.RegistrarName] == nil {
 			rCfg := cfg.RegistrarsByName[d.RegistrarName]
+			if err := credsfile.Resolve(d.RegistrarName, providerConfigs[d.RegistrarName]); err != nil {
+				return nil, err
+			}
 			r, err := providers.CreateRegistrar(rCfg.Type, providerConfigs[d.RegistrarName])
 			if err != nil {
 				return nil, err
@@ -360,9 +502,13 @@ func InitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[s
 		}
 		d.RegistrarInstance.Driver = registrars[d.RegistrarName]
 		d.RegistrarInstance.IsDefault = !isNonDefault[d.RegistrarName]
+		d.RegistrarInstance.ReadOnly = isReadOnly(providerConfigs[d.RegistrarName])
 		for _, pInst := range d.DNSProviderInstances {
 			if dnsProviders[pInst.Name] == nil {
 				dCfg := cfg.DNSProvidersByName[pInst.Name]
+				if err := credsfile.Resolve(dCfg.Name, providerConfigs[dCfg.Name]); err != nil {
+					return nil, err
+				}
 				prov, err := providers.CreateDNSProvider(dCfg.Type, providerConfigs[dCfg.Name], dCfg.Metadata)
 				if err != nil {
 					return nil, err
@@ -371,6 +517,7 @@ func InitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[s
 			}
 			pInst.Driver = dnsProviders[pInst.Name]
 			pInst.IsDefault = !isNonDefault[pInst.Name]
+			pInst.ReadOnly = isReadOnly(providerConfigs[pInst.Name])
 		}
 	}
 	return
@@ -583,18 +730,47 @@ func printOrRunCorrections(domain string, provider string, corrections []*models
 				continue
 			}
 			if correction.F != nil {
-				err = correction.F()
+				auditlog.SetCorrection(auditlog.CorrectionID(domain, provider, i+1))
+				err = tracing.Run("correction", correction.F, tracing.Domain(domain), tracing.Provider(provider), tracing.Correction(correction.Msg))
+				auditlog.SetCorrection("")
 				out.EndCorrection(err)
 				if err != nil {
+					printer.LogError("failed running the correction", err, "provider", provider, "domain", domain, "correction", correction.Msg)
 					anyErrors = true
 				}
 			}
 		}
+		pushstats.AddCorrection(provider, correction.Msg, err)
 		notifier.Notify(domain, provider, correction.Msg, err, !push)
 	}
 	return anyErrors
 }
 
+// writeStats prints the statistics of the providers, and writes them to
+// the file of --stats-json.
+func writeStats(args PreviewArgs, out printer.CLI) error {
+	if !args.Stats && args.StatsJSON == "" {
+		return nil
+	}
+	summary := pushstats.Summary()
+	if args.Stats {
+		var b strings.Builder
+		if err := pushstats.WriteTable(&b, summary); err != nil {
+			return err
+		}
+		out.Printf("%s", b.String())
+	}
+	if args.StatsJSON != "" {
+		f, err := os.Create(args.StatsJSON)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return pushstats.WriteJSON(f, summary)
+	}
+	return nil
+}
+
 func printReports(domain string, provider string, reports []*models.Correction, out printer.CLI, push bool, notifier notifications.Notifier) (anyErrors bool) {
 	anyErrors = false
 	if len(reports) == 0 {
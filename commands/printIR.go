@@ -12,7 +12,9 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rfc4183"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rtypes"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tracing"
 	"github.com/urfave/cli/v2"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var _ = cmd(catDebug, func() *cli.Command {
@@ -30,6 +32,24 @@ var _ = cmd(catDebug, func() *cli.Command {
 // CheckArgs encapsulates the flags/arguments for the check command.
 type CheckArgs struct {
 	GetDNSConfigArgs
+	Format string // Format of the errors: text or sarif
+}
+
+func (args *CheckArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "format",
+		Destination: &args.Format,
+		Value:       "text",
+		Usage:       `Format of the errors: text, sarif for GitHub code scanning, or junit for the test reports of CI`,
+		Action: func(ctx *cli.Context, s string) error {
+			if s != "text" && s != "sarif" && s != "junit" {
+				return fmt.Errorf("invalid value %q for --format, expected text, sarif or junit", s)
+			}
+			return nil
+		},
+	})
+	return flags
 }
 
 var _ = cmd(catDebug, func() *cli.Command {
@@ -42,6 +62,12 @@ var _ = cmd(catDebug, func() *cli.Command {
 		Name:  "check",
 		Usage: "Check and validate dnsconfig.js. Output to stdout.  Do not access providers.",
 		Action: func(c *cli.Context) error {
+			switch args.Format {
+			case "sarif":
+				return exit(CheckSARIF(args, os.Stdout))
+			case "junit":
+				return exit(CheckJUnit(args, os.Stdout))
+			}
 
 			// Create a PrintIRArgs struct and copy our args to the
 			// appropriate fields.
@@ -125,7 +151,9 @@ func ExecuteDSL(args ExecuteDSLArgs) (*models.DNSConfig, error) {
 		return nil, fmt.Errorf("no config specified")
 	}
 
+	_, span := tracing.StartCurrent("evaluate configuration", attribute.String("dnscontrol.file", args.JSFile))
 	dnsConfig, err := js.ExecuteJavaScript(args.JSFile, args.DevMode, stringSliceToMap(args.Variable))
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("executing %s: %w", args.JSFile, err)
 	}
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"strconv"
+)
+
+// readOnly forbids the changes of all the providers (--read-only).
+var readOnly bool
+
+// readOnlyFieldName is the name of the field in creds.json that forbids the
+// changes of a provider, e.g. for shared "viewer" credentials.
+const readOnlyFieldName = "readonly"
+
+// isReadOnly reports whether the provider of the creds.json entry m may not
+// be changed.
+func isReadOnly(m map[string]string) bool {
+	if readOnly {
+		return true
+	}
+	ro, _ := strconv.ParseBool(m[readOnlyFieldName])
+	return ro
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// setRegistrarDS sets the DS records that the registrar publishes for a
+// domain with AUTODNSSEC_ON to those reported by its DNS providers, unless
+// the DS records are in dnsconfig.js. The DS records with a SHA-256 digest
+// are preferred, as some registrars accept a single DS record.
+//
+// dc.RegistrarDS stays empty if no DNS provider is a DSReporter, or if they
+// report no DS records: the registrars then leave the DS records untouched,
+// e.g. those published by hand for a zone signed by Cloudflare.
+func setRegistrarDS(dc *models.DomainConfig) error {
+	if dc.AutoDNSSEC != "on" || len(dc.RegistrarDS) != 0 || dc.RegistrarInstance == nil ||
+		!providers.ProviderHasCapability(dc.RegistrarInstance.ProviderType, providers.CanUseDSAtRegistrar) {
+		return nil
+	}
+
+	var all, sha256 models.Records
+	seen := map[string]bool{}
+	for _, dsp := range dc.DNSProviderInstances {
+		reporter, ok := dsp.Driver.(providers.DSReporter)
+		if !ok {
+			continue
+		}
+		records, err := reporter.GetDSRecords(dc.Name)
+		if err != nil {
+			return err
+		}
+		for _, rc := range records {
+			if seen[rc.ToComparableNoTTL()] {
+				continue
+			}
+			seen[rc.ToComparableNoTTL()] = true
+			all = append(all, rc)
+			if rc.DsDigestType == 2 {
+				sha256 = append(sha256, rc)
+			}
+		}
+	}
+	if len(sha256) != 0 {
+		dc.RegistrarDS = sha256
+	} else {
+		dc.RegistrarDS = all
+	}
+	return nil
+}
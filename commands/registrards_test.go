@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/_all"
+)
+
+// dsReporter is a DNS provider which reports DS records.
+type dsReporter struct {
+	providers.None
+	ds []string
+}
+
+func (r dsReporter) GetDSRecords(domain string) (models.Records, error) {
+	var records models.Records
+	for _, ds := range r.ds {
+		rc := &models.RecordConfig{Type: "DS"}
+		rc.SetLabel("@", domain)
+		if err := rc.SetTargetDSString(ds); err != nil {
+			return nil, err
+		}
+		records = append(records, rc)
+	}
+	return records, nil
+}
+
+func TestSetRegistrarDS(t *testing.T) {
+	const (
+		sha256 = "12345 13 2 2BB183AF5F22588179A53B0A98631FAD1A292118"
+		sha384 = "12345 13 4 3BB183AF5F22588179A53B0A98631FAD1A292118"
+	)
+	domain := func(registrar, autoDNSSEC string, ds ...string) *models.DomainConfig {
+		return &models.DomainConfig{
+			Name:              "example.com",
+			AutoDNSSEC:        autoDNSSEC,
+			RegistrarInstance: &models.RegistrarInstance{ProviderBase: models.ProviderBase{ProviderType: registrar}},
+			DNSProviderInstances: []*models.DNSProviderInstance{
+				{Driver: providers.None{}},
+				{Driver: dsReporter{ds: ds}},
+			},
+		}
+	}
+
+	for _, test := range []struct {
+		name     string
+		dc       *models.DomainConfig
+		expected []string
+	}{
+		{"sha256 preferred", domain("DYNADOT", "on", sha384, sha256, sha256), []string{sha256}},
+		{"no sha256", domain("DYNADOT", "on", sha384), []string{sha384}},
+		{"no AUTODNSSEC_ON", domain("DYNADOT", "", sha256), nil},
+		{"registrar without DS", domain("NONE", "on", sha256), nil},
+		{"no DS reported", domain("DYNADOT", "on"), nil},
+		{"no DSReporter", &models.DomainConfig{
+			Name:                 "example.com",
+			AutoDNSSEC:           "on",
+			RegistrarInstance:    &models.RegistrarInstance{ProviderBase: models.ProviderBase{ProviderType: "DYNADOT"}},
+			DNSProviderInstances: []*models.DNSProviderInstance{{Driver: providers.None{}}},
+		}, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := setRegistrarDS(test.dc); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, rc := range test.dc.RegistrarDS {
+				got = append(got, rc.GetTargetCombined())
+			}
+			if len(got) != len(test.expected) {
+				t.Fatalf("Expected %v, got %v", test.expected, got)
+			}
+			for i := range got {
+				if got[i] != test.expected[i] {
+					t.Errorf("Expected %v, got %v", test.expected, got)
+				}
+			}
+		})
+	}
+
+	// The DS records of dnsconfig.js are kept.
+	dc := domain("DYNADOT", "on", sha256)
+	declared := &models.RecordConfig{Type: "DS"}
+	dc.RegistrarDS = models.Records{declared}
+	if err := setRegistrarDS(dc); err != nil {
+		t.Fatal(err)
+	}
+	if len(dc.RegistrarDS) != 1 || dc.RegistrarDS[0] != declared {
+		t.Errorf("Expected the declared DS records to be kept, got %v", dc.RegistrarDS)
+	}
+}
@@ -483,6 +483,71 @@ declare function CAA(name: string, tag: "issue" | "issuemail" | "issuewild" | "i
  */
 declare function CAA_BUILDER(opts: { label?: string; iodef: string; iodef_critical?: boolean; issue: string[]; issue_critical?: boolean; issuemail: string[]; issuemail_critical?: boolean; issuewild: string[]; issuewild_critical?: boolean; ttl?: Duration }): DomainModifier;
 
+/**
+ * CATALOG_ZONE adds the domain to an [RFC 9432](https://www.rfc-editor.org/rfc/rfc9432) catalog zone.
+ * Secondary servers that pull the catalog zone (BIND, PowerDNS, NSD, Knot DNS) automatically
+ * serve the member zones it lists, so the zones created by DNSControl don't have to be
+ * configured on each secondary.
+ *
+ * The catalog zone must also be declared with [`D`](../top-level-functions/D.md), usually with the
+ * same DNS providers as its members. DNSControl generates its records:
+ *
+ * * `version` `TXT "2"`, the version of the catalog zone schema (unless it is already declared).
+ * * One `PTR` record per member zone. The label of a member is the SHA-1 hash of its name, the convention of BIND: `<hash>.zones`.
+ *
+ * When a domain is removed from `dnsconfig.js`, or its `CATALOG_ZONE` is removed, its record is
+ * removed from the catalog zone by the next `dnscontrol push`, and the secondaries stop serving it.
+ *
+ * ```javascript
+ * D("catalog.example.com", REG_NONE, DnsProvider(DSP_BIND),
+ *   NAMESERVER("invalid."),
+ * END);
+ *
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_BIND),
+ *   CATALOG_ZONE("catalog.example.com"),
+ *   A("@", "1.2.3.4"),
+ * END);
+ *
+ * D("example.org", REG_MY_PROVIDER, DnsProvider(DSP_BIND),
+ *   CATALOG_ZONE("catalog.example.com"),
+ *   A("@", "5.6.7.8"),
+ * END);
+ * ```
+ *
+ * RFC 9432 requires a single NS record `invalid.` in the catalog zone, as in the example.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/catalog_zone
+ */
+declare function CATALOG_ZONE(name: string): DomainModifier;
+
+/**
+ * `CF_CUSTOM_HOSTNAME` declares a [custom hostname](https://developers.cloudflare.com/cloudflare-for-platforms/cloudflare-for-saas/)
+ * of the zone (Cloudflare for SaaS): the hostname of a customer, usually in another domain, which is served by the zone.
+ * It needs `manage_custom_hostnames: true` in the [provider metadata](../../provider/cloudflareapi.md).
+ *
+ * With `manage_custom_hostnames`, DNSControl manages _all_ the custom hostnames of the zone: the custom hostnames
+ * which aren't declared are deleted, including those created outside of DNSControl.
+ *
+ * The config of a custom hostname has these fields:
+ *
+ * * `ssl_method`: the method which validates the certificate of the hostname: `http` (the default), `txt` or `email`
+ * * `custom_origin_server`: the origin of the hostname, instead of the fallback origin of the zone
+ * * `custom_origin_sni`: the SNI sent to the custom origin server
+ *
+ * The API can't remove the custom origin of a custom hostname: the custom hostname is deleted and created again,
+ * and its certificate is validated again.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_CLOUDFLARE),
+ *     CF_CUSTOM_HOSTNAME("shop.customer.com", {ssl_method: "txt"}),
+ *     CF_CUSTOM_HOSTNAME("www.client.net", {custom_origin_server: "eu.example.com"}),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/service-provider-specific/cloudflare-dns/cf_custom_hostname
+ */
+declare function CF_CUSTOM_HOSTNAME(hostname: string, config: { ssl_method?: 'http' | 'txt' | 'email', custom_origin_server?: string, custom_origin_sni?: string }): DomainModifier;
+
 /**
  * WARNING: Cloudflare is removing this feature and replacing it with a new
  * feature called "Dynamic Single Redirect". DNSControl will automatically
@@ -1002,6 +1067,10 @@ declare function DOMAIN_ELSEWHERE_AUTO(name: string, domain: string, registrar:
  *
  * Digest must be a string.
  *
+ * If the registrar of the domain can publish DS records (for example
+ * [`DYNADOT`](../../provider/dynadot.md)), the DS records at the root of the
+ * zone (`@`) are sent to the registrar instead of the DNS providers.
+ *
  * ```javascript
  * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
  *   DS("example.com", 2371, 13, 2, "ABCDEF"),
@@ -1920,6 +1989,26 @@ declare function M365_BUILDER(opts: { label?: string; mx?: boolean; autodiscover
  */
 declare function MX(name: string, priority: number, target: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `NAMECHEAP_EMAILFWD` is a Namecheap-specific feature that maps to Namecheap's email forwarding: the mails sent to the mailbox of the domain are forwarded to the address.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *     NAMECHEAP_EMAILFWD("info", "someone@example.org"),
+ *     NAMECHEAP_EMAILFWD("*", "catchall@example.org"),
+ * );
+ * ```
+ *
+ * The fields are:
+ * * mailbox: the mailbox of the domain, `*` forwards all the mailboxes which aren't forwarded otherwise
+ * * address: where you'd like to forward the mails to
+ *
+ * The email forwarding of Namecheap replaces the MX records: a domain can't have both.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/service-provider-specific//namecheap_emailfwd
+ */
+declare function NAMECHEAP_EMAILFWD(mailbox: string, address: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * `NAMESERVER()` instructs DNSControl to inform the domain"s registrar where to find this zone.
  * For some registrars this will also add NS records to the zone itself.
@@ -2505,6 +2594,30 @@ declare function OPENPGPKEY(opts: { local: string; digest: string; ...modifiers:
  */
 declare function PORKBUN_URLFWD(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `POWERDNS_LUA` is a PowerDNS-specific feature that maps to PowerDNS's [LUA record](https://doc.powerdns.com/authoritative/lua-records/index.html),
+ * whose answers are computed by a Lua snippet when the record is queried.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   {powerdns_enable_lua_records: "1"},
+ *   POWERDNS_LUA("www", "A", "ifportup(443, {'192.0.2.1', '192.0.2.2'})"),
+ *   POWERDNS_LUA("pool", "AAAA", "pickrandom({'2001:db8::1', '2001:db8::2'})", TTL(60)),
+ * );
+ * ```
+ *
+ * The fields are:
+ * * name: the record name
+ * * rtype: the type of the records returned by the snippet, e.g. `A`, `AAAA`, `CNAME` or `TXT`
+ * * snippet: the Lua snippet, it is quoted by dnscontrol
+ *
+ * The LUA records must be enabled in PowerDNS, either with the `enable-lua-records` setting
+ * or with the `ENABLE-LUA-RECORDS` metadata of the zone (`powerdns_enable_lua_records`, see the [PowerDNS provider](../../provider/powerdns.md)).
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/service-provider-specific/powerdns/powerdns_lua
+ */
+declare function POWERDNS_LUA(name: string, rtype: string, snippet: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * PTR adds a PTR record to the domain.
  *
@@ -2703,6 +2816,42 @@ declare function R53_ALIAS(name: string, target: string, zone_idModifier: Domain
  */
 declare function R53_EVALUATE_TARGET_HEALTH(enabled: boolean): RecordModifier;
 
+/**
+ * `R53_HEALTH_CHECK` declares a Route53 [health check](https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/dns-failover.html)
+ * for the domain. The records with a [routing policy](../../provider/route53.md#metadata) refer to it by name with the
+ * `route53_health_check` metadata, usually a failover record.
+ *
+ * The health checks are created, updated and deleted by `dnscontrol push`: those created for the domain
+ * that are not declared anymore are deleted, once the records don't refer to them. The health checks of
+ * the domains that don't use `R53_HEALTH_CHECK` are left untouched, as well as the health checks that
+ * were not created by DNSControl.
+ *
+ * The config of a health check has these fields:
+ *
+ * * `type`: `HTTP`, `HTTPS`, `HTTP_STR_MATCH`, `HTTPS_STR_MATCH` or `TCP`
+ * * `ip` and/or `fqdn`: the endpoint that is checked
+ * * `port`: the port of the endpoint (default: 80 for HTTP, 443 for HTTPS)
+ * * `path`: the path of the HTTP request, e.g. `/health`
+ * * `search_string`: the string to find in the response, for the `_STR_MATCH` types
+ * * `request_interval`: 10 or 30 seconds (default: 30)
+ * * `failure_threshold`: the number of failed checks before the endpoint is unhealthy (default: 3)
+ * * `inverted`: `true` inverts the status of the health check
+ * * `enable_sni`: sends the host name in the TLS handshake (default: `true` for HTTPS)
+ *
+ * The type and the request interval of a health check can't be changed, rename the health check to replace it.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider("ROUTE53"),
+ *   R53_HEALTH_CHECK("api", {type: "HTTPS", fqdn: "api-primary.example.com", path: "/health", failure_threshold: 2}),
+ *   A("api", "192.0.2.10", {route53_set_identifier: "primary", route53_failover: "PRIMARY", route53_health_check: "api"}),
+ *   A("api", "192.0.2.20", {route53_set_identifier: "secondary", route53_failover: "SECONDARY"}),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/service-provider-specific/amazon-route-53/r53_health_check
+ */
+declare function R53_HEALTH_CHECK(name: string, config: { type: 'HTTP' | 'HTTPS' | 'HTTP_STR_MATCH' | 'HTTPS_STR_MATCH' | 'TCP', ip?: string, fqdn?: string, port?: number, path?: string, search_string?: string, request_interval?: 10 | 30, failure_threshold?: number, inverted?: boolean, enable_sni?: boolean }): DomainModifier;
+
 /**
  * `R53_ZONE` lets you specify the AWS Zone ID for an entire domain ([`D()`](../top-level-functions/D.md)) or a specific [`R53_ALIAS()`](../domain-modifiers/R53_ALIAS.md) record.
  *
@@ -84,6 +84,9 @@ func getProvider(t *testing.T) (providers.DNSServiceProvider, string, map[string
 			metadata = []byte(`{ ` + strings.Join(items, `, `) + ` }`)
 		}
 
+		if err := credsfile.Resolve(name, cfg); err != nil {
+			t.Fatal(err)
+		}
 		provider, err := providers.CreateDNSProvider(name, cfg, metadata)
 		if err != nil {
 			t.Fatal(err)
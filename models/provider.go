@@ -1,5 +1,7 @@
 package models
 
+import "fmt"
+
 // DNSProvider is an interface for DNS Provider plug-ins.
 type DNSProvider interface {
 	GetNameservers(domain string) ([]*Nameserver, error)
@@ -17,6 +19,30 @@ type ProviderBase struct {
 	Name         string
 	IsDefault    bool
 	ProviderType string
+	// ReadOnly forbids the corrections of the provider, with --read-only
+	// or "readonly" in creds.json.
+	ReadOnly bool
+}
+
+// GuardCorrections returns the corrections, which fail instead of changing
+// the provider if it is read-only.
+func (p *ProviderBase) GuardCorrections(corrections []*Correction) []*Correction {
+	if !p.ReadOnly {
+		return corrections
+	}
+	guarded := make([]*Correction, len(corrections))
+	for i, c := range corrections {
+		guarded[i] = &Correction{Msg: c.Msg}
+		if c.F != nil {
+			guarded[i].F = func() error { return p.ReadOnlyError() }
+		}
+	}
+	return guarded
+}
+
+// ReadOnlyError is the error of the changes of a read-only provider.
+func (p *ProviderBase) ReadOnlyError() error {
+	return fmt.Errorf("%s is read-only (--read-only or \"readonly\" in creds.json), refusing to change it", p.Name)
 }
 
 // RegistrarInstance is a single registrar.
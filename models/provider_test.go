@@ -0,0 +1,29 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuardCorrections(t *testing.T) {
+	ran := false
+	corrections := []*Correction{
+		{Msg: "+ CREATE www.example.com A 192.0.2.1", F: func() error { ran = true; return nil }},
+		{Msg: "INFO: a report"},
+	}
+
+	p := &ProviderBase{Name: "bind"}
+	if got := p.GuardCorrections(corrections); got[0].F() != nil || !ran {
+		t.Errorf("the correction of a provider which is not read-only did not run")
+	}
+
+	ran = false
+	p.ReadOnly = true
+	guarded := p.GuardCorrections(corrections)
+	if guarded[0].Msg != corrections[0].Msg || guarded[1].F != nil {
+		t.Errorf("got %+v, want the messages and the reports unchanged", guarded)
+	}
+	if err := guarded[0].F(); err == nil || !strings.Contains(err.Error(), "read-only") || ran {
+		t.Errorf("the correction of a read-only provider ran, or failed with %v", err)
+	}
+}
@@ -44,11 +44,13 @@ import (
 //	  CLOUDNS_WR
 //	  FRAME
 //	  IMPORT_TRANSFORM
+//	  NAMECHEAP_EMAILFWD
 //	  NAMESERVER
 //	  NO_PURGE
 //	  NS1_URLFWD
 //	  PAGE_RULE
 //	  PORKBUN_URLFWD
+//	  POWERDNS_LUA
 //	  PURGE
 //	  URL
 //	  URL301
@@ -15,6 +15,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/nameservers"
 	"github.com/StackExchange/dnscontrol/v4/pkg/notifications"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v4/pkg/zonerecs"
 	"github.com/go-acme/lego/certcrypto"
 	"github.com/go-acme/lego/certificate"
@@ -106,7 +107,7 @@ func (c *certManager) IssueOrRenewCert(cfg *CertConfig, renewUnder int, verbose
 	}
 	defer c.finalCleanUp()
 
-	log.Printf("Checking certificate [%s]", cfg.CertName)
+	printer.Printf("Checking certificate [%s]\n", cfg.CertName)
 	existing, err := c.storage.GetCertificate(cfg.CertName)
 	if err != nil {
 		return false, err
@@ -123,23 +124,23 @@ func (c *certManager) IssueOrRenewCert(cfg *CertConfig, renewUnder int, verbose
 	}
 
 	if existing == nil {
-		log.Println("No existing cert found. Issuing new...")
+		printer.Printf("No existing cert found. Issuing new...\n")
 	} else {
 		names, daysLeft, err := getCertInfo(existing.Certificate)
 		if err != nil {
 			return false, err
 		}
-		log.Printf("Found existing cert. %0.2f days remaining.", daysLeft)
+		printer.Printf("Found existing cert. %0.2f days remaining.\n", daysLeft)
 		namesOK := dnsNamesEqual(cfg.Names, names)
 		if daysLeft >= float64(renewUnder) && namesOK {
-			log.Println("Nothing to do")
+			printer.Printf("Nothing to do\n")
 			//nothing to do
 			return false, nil
 		}
 		if !namesOK {
-			log.Println("DNS Names don't match expected set. Reissuing.")
+			printer.Printf("DNS Names don't match expected set. Reissuing.\n")
 		} else {
-			log.Println("Renewing cert")
+			printer.Printf("Renewing cert\n")
 			action = func() (*certificate.Resource, error) {
 				return client.Certificate.Renew(*existing, true, cfg.MustStaple)
 			}
@@ -165,7 +166,7 @@ func (c *certManager) IssueOrRenewCert(cfg *CertConfig, renewUnder int, verbose
 	if err != nil {
 		return false, err
 	}
-	fmt.Printf("Obtained certificate for %s\n", cfg.CertName)
+	printer.Printf("Obtained certificate for %s\n", cfg.CertName)
 	if err = c.storage.StoreCertificate(cfg.CertName, certResource); err != nil {
 		return true, err
 	}
@@ -256,7 +257,7 @@ func (c *certManager) ensureNoPendingCorrections(d *models.DomainConfig) error {
 	if len(corrections) != 0 {
 		// TODO: maybe allow forcing through this check.
 		for _, c := range corrections {
-			fmt.Println(c.Msg)
+			printer.Printf("%s\n", c.Msg)
 		}
 		return fmt.Errorf("found %d pending corrections for %s. Not going to proceed issuing certificates", len(corrections), d.Name)
 	}
@@ -280,6 +281,7 @@ func (c *certManager) getCorrections(d *models.DomainConfig) ([]*models.Correcti
 		if err != nil {
 			return nil, err
 		}
+		corrections = p.GuardCorrections(corrections)
 		for _, c := range reports {
 			c.Msg = fmt.Sprintf("INFO[%s] %s", p.Name, strings.TrimSpace(c.Msg))
 		}
@@ -296,9 +298,9 @@ func (c *certManager) getAndRunCorrections(d *models.DomainConfig) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%d corrections\n", len(cs))
+	printer.Printf("%d corrections\n", len(cs))
 	for _, corr := range cs {
-		fmt.Printf("Running [%s]\n", corr.Msg)
+		printer.Printf("Running [%s]\n", corr.Msg)
 		err = corr.F()
 		c.notifier.Notify(d.Name, "certs", corr.Msg, err, false)
 		if err != nil {
@@ -314,11 +316,11 @@ func (c *certManager) CleanUp(domain, token, keyAuth string) error {
 }
 
 func (c *certManager) finalCleanUp() error {
-	log.Println("Cleaning up all records we made")
+	printer.Printf("Cleaning up all records we made\n")
 	var lastError error
 	for _, d := range c.originalDomains {
 		if err := c.getAndRunCorrections(d); err != nil {
-			log.Printf("ERROR cleaning up: %s", err)
+			printer.Printf("ERROR cleaning up: %s\n", err)
 			lastError = err
 		}
 	}
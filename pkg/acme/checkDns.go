@@ -1,9 +1,9 @@
 package acme
 
 import (
-	"log"
 	"time"
 
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/go-acme/lego/challenge/dns01"
 )
 
@@ -17,11 +17,11 @@ func (c *certManager) preCheckDNS(domain, fqdn, value string, native dns01.PreCh
 		return v, err
 	}
 	if !c.waitedOnce {
-		log.Printf("DNS ok. Waiting another 60s to ensure stability.")
+		printer.Printf("DNS ok. Waiting another 60s to ensure stability.\n")
 		time.Sleep(60 * time.Second)
 		c.waitedOnce = true
 	}
-	log.Printf("DNS records seem to exist. Proceeding to request validation")
+	printer.Printf("DNS records seem to exist. Proceeding to request validation\n")
 	return v, err
 }
 
@@ -0,0 +1,162 @@
+// Package auditlog writes the requests to the APIs of the providers in an
+// audit log, as JSON lines: method, URL, headers, status, latency and the
+// correction being run. The credentials in the headers and in the URLs are
+// redacted.
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces the values of the credentials.
+const redacted = "REDACTED"
+
+// Entry is a line of the audit log.
+type Entry struct {
+	Time           time.Time         `json:"time"`
+	Method         string            `json:"method"`
+	URL            string            `json:"url"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	Status         int               `json:"status,omitempty"`
+	ResponseLength int64             `json:"response_length,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	LatencyMS      float64           `json:"latency_ms"`
+	Correction     string            `json:"correction,omitempty"`
+}
+
+var (
+	mu  sync.Mutex
+	enc *json.Encoder
+	// correction is the ID of the correction which runs, for all the
+	// requests: the corrections must run serially.
+	correction string
+)
+
+// Open appends the audit log to the file filename.
+func Open(filename string) error {
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	Start(f)
+	return nil
+}
+
+// Start writes the audit log to w, and logs the requests of the default
+// transport of HTTP.
+func Start(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	enc = json.NewEncoder(w)
+	if _, ok := http.DefaultTransport.(*transport); !ok {
+		http.DefaultTransport = Wrap(http.DefaultTransport)
+	}
+}
+
+// SetCorrection sets the ID of the correction which sends the next
+// requests, or "" after the correction. The ID is shared by all the
+// requests: it is only right while the corrections run one at a time, as
+// push and ppush do.
+func SetCorrection(id string) {
+	mu.Lock()
+	defer mu.Unlock()
+	correction = id
+}
+
+// Wrap returns a transport which logs the requests of rt, if the audit log
+// is started.
+func Wrap(rt http.RoundTripper) http.RoundTripper {
+	return &transport{base: rt}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mu.Lock()
+	started := enc != nil
+	mu.Unlock()
+	if !started {
+		return t.base.RoundTrip(req)
+	}
+
+	entry := Entry{
+		Time:           time.Now().UTC(),
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+	resp, err := t.base.RoundTrip(req)
+	entry.LatencyMS = float64(time.Since(entry.Time).Microseconds()) / 1000
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		entry.ResponseLength = resp.ContentLength
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	entry.Correction = correction
+	enc.Encode(entry)
+	return resp, err
+}
+
+// isSecret reports whether the header or the query parameter name holds
+// credentials, e.g. the keys X-Ovh-Application and X-Ovh-Consumer of OVH.
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range []string{"auth", "token", "key", "secret", "password", "passwd", "signature", "cookie", "session", "credential", "consumer", "application"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(h))
+	for name, values := range h {
+		if isSecret(name) {
+			m[name] = redacted
+		} else {
+			m[name] = strings.Join(values, ", ")
+		}
+	}
+	return m
+}
+
+func redactURL(u *url.URL) string {
+	r := *u
+	if r.User != nil {
+		r.User = url.User(redacted)
+	}
+	if r.RawQuery != "" {
+		q := r.Query()
+		for name := range q {
+			if isSecret(name) {
+				q[name] = []string{redacted}
+			}
+		}
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
+
+// CorrectionID returns the ID of the correction n (from 1) of the zone for
+// the provider, e.g. "example.com/cloudflare#2".
+func CorrectionID(zone, provider string, n int) string {
+	return fmt.Sprintf("%s/%s#%d", zone, provider, n)
+}
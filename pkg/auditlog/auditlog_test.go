@@ -0,0 +1,57 @@
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	Start(&buf)
+	defer func() { enc = nil }()
+
+	SetCorrection(CorrectionID("example.com", "bind", 2))
+	req, err := http.NewRequest("DELETE", srv.URL+"/zones/example.com?api_key=s3cr3t&page=2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	req.Header.Set("X-Auth-Key", "s3cr3t")
+	req.Header.Set("X-Ovh-Application", "s3cr3t")
+	req.Header.Set("X-Ovh-Consumer", "s3cr3t")
+	req.Header.Set("X-Ovh-Timestamp", "1700000000")
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	SetCorrection("")
+
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Errorf("the audit log has the secret: %s", buf.String())
+	}
+	var entry Entry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Method != "DELETE" || entry.Status != http.StatusTeapot || entry.Correction != "example.com/bind#2" {
+		t.Errorf("got %+v", entry)
+	}
+	if want := srv.URL + "/zones/example.com?api_key=REDACTED&page=2"; entry.URL != want {
+		t.Errorf("got URL %q, want %q", entry.URL, want)
+	}
+	if entry.RequestHeaders["Authorization"] != redacted || entry.RequestHeaders["X-Ovh-Consumer"] != redacted ||
+		entry.RequestHeaders["Accept"] != "application/json" || entry.RequestHeaders["X-Ovh-Timestamp"] != "1700000000" {
+		t.Errorf("got headers %v", entry.RequestHeaders)
+	}
+}
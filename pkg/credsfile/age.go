@@ -0,0 +1,116 @@
+package credsfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/agessh"
+	"filippo.io/age/armor"
+)
+
+// ageSuffix is the suffix of the creds files encrypted with age. When the
+// creds file does not exist, the same file with this suffix is used, e.g.
+// "creds.json.age" for "creds.json".
+const ageSuffix = ".age"
+
+// ageIdentityEnv is the environment variable of the identity files which
+// decrypt the creds files: age identity files or SSH private keys, separated
+// like the PATH. The default is the SSH keys ~/.ssh/id_ed25519 and
+// ~/.ssh/id_rsa.
+const ageIdentityEnv = "DNSCONTROL_AGE_IDENTITY"
+
+// ageCredsFile returns the creds file encrypted with age to read instead of
+// filename, or "" if there is none.
+func ageCredsFile(filename string) string {
+	if strings.HasSuffix(filename, ageSuffix) {
+		return filename
+	}
+	if fileExists(filename) || !fileExists(filename+ageSuffix) {
+		return ""
+	}
+	return filename + ageSuffix
+}
+
+// ageIdentities returns the identities of the identity files. The default
+// SSH keys which can't be used, e.g. the passphrase-protected ones, are
+// skipped: the credentials are decrypted with the others.
+func ageIdentities() ([]age.Identity, error) {
+	var paths []string
+	defaults := false
+	if v := os.Getenv(ageIdentityEnv); v != "" {
+		paths = filepath.SplitList(v)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		defaults = true
+		for _, name := range []string{"id_ed25519", "id_rsa"} {
+			if path := filepath.Join(home, ".ssh", name); fileExists(path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no identity file to decrypt the credentials, set %s", ageIdentityEnv)
+	}
+
+	var identities []age.Identity
+	var skipped []string
+	for _, path := range paths {
+		dat, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading identity file %v: %v", path, err)
+		}
+		if bytes.Contains(dat, []byte("AGE-SECRET-KEY-")) {
+			ids, err := age.ParseIdentities(bytes.NewReader(dat))
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing identity file %v: %v", path, err)
+			}
+			identities = append(identities, ids...)
+			continue
+		}
+		id, err := agessh.ParseIdentity(dat)
+		if err != nil {
+			err = fmt.Errorf("failed parsing SSH key %v (passphrase-protected keys are not supported): %v", path, err)
+			if !defaults {
+				return nil, err
+			}
+			skipped = append(skipped, err.Error())
+			continue
+		}
+		identities = append(identities, id)
+	}
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("no usable identity file to decrypt the credentials, set %s: %s", ageIdentityEnv, strings.Join(skipped, "; "))
+	}
+	return identities, nil
+}
+
+// decryptAgeFile returns the creds file decrypted with age. The file may be
+// armored ("age --armor").
+func decryptAgeFile(filename string) ([]byte, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading provider credentials file %v: %v", filename, err)
+	}
+	defer f.Close()
+
+	identities, err := ageIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	in := bufio.NewReader(f)
+	var r io.Reader = in
+	if start, _ := in.Peek(len(armor.Header)); string(start) == armor.Header {
+		r = armor.NewReader(in)
+	}
+	dec, err := age.Decrypt(r, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting provider credentials file %v: %v", filename, err)
+	}
+	return io.ReadAll(dec)
+}
@@ -0,0 +1,132 @@
+package credsfile
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/agessh"
+	"filippo.io/age/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestLoadProviderConfigsAge(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	identityFile := filepath.Join(dir, "keys.txt")
+	if err := os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ageIdentityEnv, identityFile)
+
+	for _, armored := range []bool{false, true} {
+		var b bytes.Buffer
+		var dst io.Writer = &b
+		var aw io.WriteCloser
+		if armored {
+			aw = armor.NewWriter(&b)
+			dst = aw
+		}
+		w, err := age.Encrypt(dst, identity.Recipient())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(`{"bind": {"TYPE": "BIND", "directory": "zones"}}`)); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+		if aw != nil {
+			aw.Close()
+		}
+		if err := os.WriteFile(filepath.Join(dir, "creds.json.age"), b.Bytes(), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		// creds.json does not exist, creds.json.age is read instead.
+		configs, err := LoadProviderConfigs(filepath.Join(dir, "creds.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if configs["bind"]["directory"] != "zones" {
+			t.Errorf("armored=%t: LoadProviderConfigs() = %v", armored, configs)
+		}
+	}
+}
+
+func TestAgeIdentitiesDefaultKeys(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ageIdentityEnv, "")
+	if err := os.Mkdir(filepath.Join(home, ".ssh"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeKey := func(name string, passphrase []byte) ssh.PublicKey {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var block *pem.Block
+		if passphrase != nil {
+			block, err = ssh.MarshalPrivateKeyWithPassphrase(priv, "", passphrase)
+		} else {
+			block, err = ssh.MarshalPrivateKey(priv, "")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(home, ".ssh", name), pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		sshPub, err := ssh.NewPublicKey(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sshPub
+	}
+
+	// The passphrase-protected key is the only one.
+	writeKey("id_ed25519", []byte("passphrase"))
+	if _, err := ageIdentities(); err == nil {
+		t.Error("expected an error without a usable key, got none")
+	}
+
+	// It is skipped, the other key decrypts the credentials.
+	pub := writeKey("id_rsa", nil)
+	recipient, err := agessh.NewEd25519Recipient(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	w, err := age.Encrypt(&b, recipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(`{"bind": {"TYPE": "BIND"}}`))
+	w.Close()
+	creds := filepath.Join(t.TempDir(), "creds.json.age")
+	if err := os.WriteFile(creds, b.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	configs, err := LoadProviderConfigs(creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configs["bind"]["TYPE"] != "BIND" {
+		t.Errorf("LoadProviderConfigs() = %v", configs)
+	}
+
+	// An identity file which is set explicitly must be usable.
+	t.Setenv(ageIdentityEnv, filepath.Join(home, ".ssh", "id_ed25519"))
+	if _, err := ageIdentities(); err == nil {
+		t.Error("expected an error for a passphrase-protected key, got none")
+	}
+}
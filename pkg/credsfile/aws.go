@@ -0,0 +1,115 @@
+package credsfile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// The prefixes of the values read from AWS, with the ambient identity of
+// the AWS SDK (environment, shared config, role of the instance or the task):
+//   - "$aws-sm:SECRET" or "$aws-sm:SECRET#FIELD": a secret of Secrets Manager,
+//     by name or ARN. The field is read from a secret which is a JSON object,
+//     and defaults to the subkey.
+//   - "$aws-ssm:PARAMETER": a parameter of the SSM Parameter Store, by name or
+//     ARN. The SecureString parameters are decrypted.
+//
+// The region is the one of the ARN, or the region of the AWS configuration.
+const (
+	awsSecretsManagerPrefix = "$aws-sm:"
+	awsSSMPrefix            = "$aws-ssm:"
+)
+
+var awsSecrets struct {
+	sync.Mutex
+	config  *aws.Config
+	secrets map[string]string // The secret strings by ID, read once.
+}
+
+// awsConfig returns the AWS configuration for the resource, in the region
+// of its ARN if it is one.
+func awsConfig(resource string) (aws.Config, error) {
+	if awsSecrets.config == nil {
+		cfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return aws.Config{}, fmt.Errorf("aws: %w", err)
+		}
+		awsSecrets.config = &cfg
+		awsSecrets.secrets = map[string]string{}
+	}
+	cfg := awsSecrets.config.Copy()
+	if a, err := arn.Parse(resource); err == nil && a.Region != "" {
+		cfg.Region = a.Region
+	}
+	return cfg, nil
+}
+
+func resolveAWSSecretsManager(ref, subkey string) (string, error) {
+	id, field := ref, ""
+	if i := strings.LastIndex(ref, "#"); i >= 0 {
+		id, field = ref[:i], ref[i+1:]
+	}
+
+	awsSecrets.Lock()
+	defer awsSecrets.Unlock()
+	value, ok := awsSecrets.secrets[id]
+	if !ok {
+		cfg, err := awsConfig(id)
+		if err != nil {
+			return "", err
+		}
+		out, err := secretsmanager.NewFromConfig(cfg).GetSecretValue(context.Background(), &secretsmanager.GetSecretValueInput{SecretId: aws.String(id)})
+		if err != nil {
+			return "", fmt.Errorf("aws: %w", err)
+		}
+		if out.SecretString == nil {
+			return "", fmt.Errorf("aws: the secret %s is binary", id)
+		}
+		value = *out.SecretString
+		awsSecrets.secrets[id] = value
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		if field != "" {
+			return "", fmt.Errorf("aws: the secret %s is not a JSON object", id)
+		}
+		return value, nil
+	}
+	if field == "" {
+		field = subkey
+	}
+	s, ok := fields[field].(string)
+	if !ok {
+		return "", fmt.Errorf("aws: the secret %s has no string field %q", id, field)
+	}
+	return s, nil
+}
+
+func resolveAWSSSM(ref, _ string) (string, error) {
+	awsSecrets.Lock()
+	defer awsSecrets.Unlock()
+	cfg, err := awsConfig(ref)
+	if err != nil {
+		return "", err
+	}
+	out, err := ssm.NewFromConfig(cfg).GetParameter(context.Background(), &ssm.GetParameterInput{
+		Name:           aws.String(ref),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("aws: %w", err)
+	}
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return "", fmt.Errorf("aws: the parameter %s has no value", ref)
+	}
+	return *out.Parameter.Value, nil
+}
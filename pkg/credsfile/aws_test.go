@@ -0,0 +1,69 @@
+package credsfile
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveAWS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&in)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			secrets := map[string]string{
+				"dns/linode": `{"token": "LINODE"}`,
+				"dns/plain":  "PLAIN",
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"Name": in["SecretId"], "SecretString": secrets[in["SecretId"].(string)]})
+		case "AmazonSSM.GetParameter":
+			if in["Name"] == "/dns/empty" {
+				json.NewEncoder(w).Encode(map[string]interface{}{"Parameter": map[string]interface{}{"Name": in["Name"]}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"Parameter": map[string]interface{}{"Name": in["Name"], "Value": "SSM" + in["Name"].(string)}})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", t.TempDir()+"/credentials")
+	awsSecrets.config, awsSecrets.secrets = nil, nil
+
+	m := map[string]map[string]string{
+		"linode": {
+			"token":  "$aws-sm:dns/linode",
+			"plain":  "$aws-sm:dns/plain",
+			"param":  "$aws-ssm:/dns/param",
+			"static": "value",
+		},
+	}
+	if err := resolveAll(m); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"token": "LINODE", "plain": "PLAIN", "param": "SSM/dns/param", "static": "value"}
+	for k, v := range want {
+		if m["linode"][k] != v {
+			t.Errorf("%s = %q, want %q", k, m["linode"][k], v)
+		}
+	}
+
+	m = map[string]map[string]string{"linode": {"token": "$aws-sm:dns/plain#token"}}
+	if err := resolveAll(m); err == nil {
+		t.Error("expected an error for a field of a string secret, got none")
+	}
+
+	m = map[string]map[string]string{"linode": {"token": "$aws-ssm:/dns/empty"}}
+	if err := resolveAll(m); err == nil {
+		t.Error("expected an error for a parameter without a value, got none")
+	}
+}
@@ -0,0 +1,37 @@
+package credsfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/shlex"
+)
+
+// execPrefix is the prefix of the values read from the output of a command:
+// "$exec:COMMAND ARGS...". The command is split like a shell command, but
+// is not run by a shell. It gets the name of the value in the environment
+// variable DNSCONTROL_CREDS_KEY and prints the secret on stdout.
+const execPrefix = "$exec:"
+
+func resolveExec(ref, subkey string) (string, error) {
+	args, err := shlex.Split(ref)
+	if err != nil {
+		return "", fmt.Errorf("exec: %w", err)
+	}
+	if len(args) == 0 {
+		return "", errors.New("exec: no command")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = append(os.Environ(), "DNSCONTROL_CREDS_KEY="+subkey)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("exec: %s: %v: %s", args[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
@@ -0,0 +1,54 @@
+package credsfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake command is a shell script")
+	}
+	// A fake secret manager, which prints its argument and the name of the value.
+	helper := filepath.Join(t.TempDir(), "get-secret")
+	script := "#!/bin/sh\nprintf '%s/%s\\n' \"$1\" \"$DNSCONTROL_CREDS_KEY\"\n"
+	if err := os.WriteFile(helper, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	m := map[string]map[string]string{"linode": {"token": "$exec:" + helper + " 'dns linode'"}}
+	if err := resolveAll(m); err != nil {
+		t.Fatal(err)
+	}
+	if want := "dns linode/token"; m["linode"]["token"] != want {
+		t.Errorf("token = %q, want %q", m["linode"]["token"], want)
+	}
+
+	m = map[string]map[string]string{"linode": {"token": "$exec:false"}}
+	if err := resolveAll(m); err == nil {
+		t.Error("expected an error for a failed command, got none")
+	}
+}
+
+type testResolver struct{}
+
+func (testResolver) Prefix() string { return "$test:" }
+
+func (testResolver) Resolve(ref, subkey string) (string, error) {
+	return ref + "-" + subkey, nil
+}
+
+func TestRegisterSecretResolver(t *testing.T) {
+	defer func(r []SecretResolver) { secretResolvers = r }(secretResolvers)
+	RegisterSecretResolver(testResolver{})
+
+	m := map[string]map[string]string{"linode": {"token": "$test:abc"}}
+	if err := resolveAll(m); err != nil {
+		t.Fatal(err)
+	}
+	if want := "abc-token"; m["linode"]["token"] != want {
+		t.Errorf("token = %q, want %q", m["linode"]["token"], want)
+	}
+}
@@ -0,0 +1,28 @@
+package credsfile
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExpiresKey is the key of the creds.json entries with the expiry date of
+// their credentials, e.g. "2025-12-31" or "2025-12-31T23:59:59Z".
+const ExpiresKey = "expires"
+
+// Expiry returns the expiry date of the credentials of the creds.json entry
+// m, or false if it has none.
+func Expiry(m map[string]string) (time.Time, bool, error) {
+	v := m[ExpiresKey]
+	if v == "" {
+		return time.Time{}, false, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s %q, expected a date like 2025-12-31", ExpiresKey, v)
+	}
+	// The credentials are valid during the whole day.
+	return t.Add(24*time.Hour - time.Second), true, nil
+}
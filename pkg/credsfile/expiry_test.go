@@ -0,0 +1,29 @@
+package credsfile
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiry(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Time
+		ok     bool
+		hasErr bool
+	}{
+		{"", time.Time{}, false, false},
+		{"2025-12-31", time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC), true, false},
+		{"2025-12-31T12:00:00Z", time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC), true, false},
+		{"next year", time.Time{}, false, true},
+	}
+	for _, tst := range tests {
+		got, ok, err := Expiry(map[string]string{ExpiresKey: tst.value})
+		if (err != nil) != tst.hasErr {
+			t.Errorf("%q: got error %v", tst.value, err)
+		}
+		if ok != tst.ok || !got.Equal(tst.want) {
+			t.Errorf("%q: got %v, %v, want %v, %v", tst.value, got, ok, tst.want, tst.ok)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package credsfile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringPrefix is the prefix of the values read from the keychain of the
+// OS (macOS Keychain, Windows Credential Manager, or the Secret Service of
+// libsecret): "$keyring:NAME", where NAME is the name of the secret set by
+// "dnscontrol creds set NAME".
+const keyringPrefix = "$keyring:"
+
+// keyringService is the service of the secrets of DNSControl in the keychain.
+const keyringService = "dnscontrol"
+
+// SetKeyringSecret stores the secret in the keychain of the OS.
+func SetKeyringSecret(name, secret string) error {
+	if err := keyring.Set(keyringService, name, secret); err != nil {
+		return fmt.Errorf("keyring: %w", err)
+	}
+	return nil
+}
+
+// GetKeyringSecret returns the secret from the keychain of the OS.
+func GetKeyringSecret(name string) (string, error) {
+	secret, err := keyring.Get(keyringService, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("keyring: no secret %q, set it with: dnscontrol creds set %s", name, name)
+	} else if err != nil {
+		return "", fmt.Errorf("keyring: %w", err)
+	}
+	return secret, nil
+}
+
+func resolveKeyring(ref, _ string) (string, error) {
+	return GetKeyringSecret(ref)
+}
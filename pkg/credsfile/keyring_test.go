@@ -0,0 +1,27 @@
+package credsfile
+
+import (
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestResolveKeyring(t *testing.T) {
+	keyring.MockInit()
+	if err := SetKeyringSecret("cloudflare/apitoken", "TOKEN"); err != nil {
+		t.Fatal(err)
+	}
+
+	m := map[string]map[string]string{"cloudflare": {"apitoken": "$keyring:cloudflare/apitoken"}}
+	if err := resolveAll(m); err != nil {
+		t.Fatal(err)
+	}
+	if m["cloudflare"]["apitoken"] != "TOKEN" {
+		t.Errorf("apitoken = %q, want TOKEN", m["cloudflare"]["apitoken"])
+	}
+
+	m = map[string]map[string]string{"cloudflare": {"apitoken": "$keyring:cloudflare/missing"}}
+	if err := resolveAll(m); err == nil {
+		t.Error("expected an error for a missing secret, got none")
+	}
+}
@@ -0,0 +1,31 @@
+package credsfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// onePasswordPrefix is the prefix of the secret references of 1Password,
+// "op://VAULT/ITEM/FIELD", which are read by the 1Password CLI. The CLI
+// signs in as usual, e.g. with the service account of
+// OP_SERVICE_ACCOUNT_TOKEN.
+const onePasswordPrefix = "op://"
+
+// opCommand is the command of the 1Password CLI.
+var opCommand = "op"
+
+func resolveOnePassword(ref, _ string) (string, error) {
+	cmd := exec.Command(opCommand, "read", "--no-newline", onePasswordPrefix+ref)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("1password: the 1Password CLI is not installed: %w", err)
+		}
+		return "", fmt.Errorf("1password: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,30 @@
+package credsfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveOnePassword(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake op command is a shell script")
+	}
+	// A fake op command, which prints the reference it reads.
+	op := filepath.Join(t.TempDir(), "op")
+	script := "#!/bin/sh\n[ \"$1 $2\" = \"read --no-newline\" ] || exit 1\nprintf 'secret of %s' \"$3\"\n"
+	if err := os.WriteFile(op, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	defer func(cmd string) { opCommand = cmd }(opCommand)
+	opCommand = op
+
+	m := map[string]map[string]string{"linode": {"token": "op://Secrets/Linode DNSControl/credential"}}
+	if err := resolveAll(m); err != nil {
+		t.Fatal(err)
+	}
+	if want := "secret of op://Secrets/Linode DNSControl/credential"; m["linode"]["token"] != want {
+		t.Errorf("token = %q, want %q", m["linode"]["token"], want)
+	}
+}
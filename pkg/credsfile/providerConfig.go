@@ -3,6 +3,9 @@
 // their environment variable equivalents. To reference an environment variable in your json file, simply use values in this format:
 //
 //	"key"="$ENV_VAR_NAME"
+//
+// The values can also be read from a secret store, e.g. "$vault:PATH#FIELD" for HashiCorp Vault, or from a file with
+// "file://PATH". The references "${...}" compose the values, e.g. "https://${host}:${port}".
 package credsfile
 
 import (
@@ -15,11 +18,13 @@ import (
 	"strings"
 
 	"github.com/DisposaBoy/JsonConfigReader"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/TomOnTime/utfutil"
 	"github.com/google/shlex"
 )
 
 // LoadProviderConfigs will open or execute the specified file name, and parse its contents. It will replace environment variables it finds if any value matches $[A-Za-z_-0-9]+
+// The secrets of an entry are read from their stores by Resolve, before the provider of the entry is created.
 func LoadProviderConfigs(fname string) (map[string]map[string]string, error) {
 	var results = map[string]map[string]string{}
 
@@ -27,7 +32,13 @@ func LoadProviderConfigs(fname string) (map[string]map[string]string, error) {
 	var err error
 	filesIsExecutable := strings.HasPrefix(fname, "!") || isExecutable(fname)
 
-	if filesIsExecutable && !strings.HasSuffix(fname, ".json") {
+	if ageFile := ageCredsFile(fname); ageFile != "" {
+		// file is encrypted with age
+		dat, err = decryptAgeFile(ageFile)
+		if err != nil {
+			return nil, err
+		}
+	} else if filesIsExecutable && !strings.HasSuffix(fname, ".json") {
 		// file is executable and is not a .json (needed because in Windows WSL all files are executable).
 		dat, err = executeCredsFile(strings.TrimPrefix(fname, "!"))
 		if err != nil {
@@ -39,6 +50,12 @@ func LoadProviderConfigs(fname string) (map[string]map[string]string, error) {
 		if err != nil {
 			return nil, err
 		}
+		if isSOPSFile(dat) {
+			dat, err = decryptSOPSFile(fname)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	s := string(dat)
@@ -47,9 +64,16 @@ func LoadProviderConfigs(fname string) (map[string]map[string]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing provider credentials file %v: %v", fname, err)
 	}
-	if err = replaceEnvVars(results); err != nil {
-		return nil, err
+	// The environment variables are replaced now, the secret stores and the
+	// references ${...} are resolved when the entry is used, by Resolve.
+	pendingMu.Lock()
+	pending = map[string]map[string]bool{}
+	for name, keys := range results {
+		final := map[string]bool{}
+		replaceEnvVars(keys, final)
+		pending[name] = final
 	}
+	pendingMu.Unlock()
 
 	// For backwards compatibility, insert NONE and BIND entries if
 	// they do not exist. These are the only providers that previously
@@ -78,7 +102,7 @@ func readCredsFile(filename string) ([]byte, error) {
 	if err != nil {
 		// no creds file is ok. Bind requires nothing for example. Individual providers will error if things not found.
 		if os.IsNotExist(err) {
-			fmt.Printf("INFO: Config file %q does not exist. Skipping.\n", filename)
+			printer.Printf("INFO: Config file %q does not exist. Skipping.\n", filename)
 			return []byte{}, nil
 		}
 		return nil, fmt.Errorf("failed reading provider credentials file %v: %v", filename, err)
@@ -106,15 +130,14 @@ func fileExists(filename string) bool {
 	return !errors.Is(err, os.ErrNotExist)
 }
 
-func replaceEnvVars(m map[string]map[string]string) error {
-	for _, keys := range m {
-		for k, v := range keys {
-			if strings.HasPrefix(v, "$") {
-				env := v[1:]
-				newVal := os.Getenv(env)
-				keys[k] = newVal
-			}
+// replaceEnvVars replaces the values "$NAME" of an entry with the
+// environment variable NAME, and adds their keys to final: the value of the
+// variable is not a reference to a secret store nor to other values.
+func replaceEnvVars(keys map[string]string, final map[string]bool) {
+	for k, v := range keys {
+		if strings.HasPrefix(v, "$") && !strings.Contains(v, "${") && !isSecretRef(v) {
+			keys[k] = os.Getenv(v[1:])
+			final[k] = true
 		}
 	}
-	return nil
 }
@@ -0,0 +1,116 @@
+package credsfile
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SecretResolver resolves the values of creds.json which start with its
+// prefix from a secret store.
+type SecretResolver interface {
+	// Prefix is the prefix of the values of the secret store, e.g. "$vault:".
+	Prefix() string
+	// Resolve returns the secret of ref, the value without the prefix.
+	// subkey is the name of the value, e.g. "apikey".
+	Resolve(ref, subkey string) (string, error)
+}
+
+// secretSource is a SecretResolver of a function.
+type secretSource struct {
+	prefix  string
+	resolve func(ref, subkey string) (string, error)
+}
+
+func (s secretSource) Prefix() string { return s.prefix }
+
+func (s secretSource) Resolve(ref, subkey string) (string, error) {
+	return s.resolve(ref, subkey)
+}
+
+// secretResolvers are the secret stores of creds.json values.
+var secretResolvers = []SecretResolver{
+	secretSource{prefix: vaultPrefix, resolve: resolveVault},
+	secretSource{prefix: awsSecretsManagerPrefix, resolve: resolveAWSSecretsManager},
+	secretSource{prefix: awsSSMPrefix, resolve: resolveAWSSSM},
+	secretSource{prefix: onePasswordPrefix, resolve: resolveOnePassword},
+	secretSource{prefix: keyringPrefix, resolve: resolveKeyring},
+	secretSource{prefix: execPrefix, resolve: resolveExec},
+	secretSource{prefix: filePrefix, resolve: resolveFile},
+}
+
+// RegisterSecretResolver adds a secret store of creds.json values. It must
+// be called before the creds file is loaded, e.g. in an init function.
+func RegisterSecretResolver(r SecretResolver) {
+	secretResolvers = append(secretResolvers, r)
+}
+
+// pending are the entries of the creds file loaded last which are not
+// resolved yet, by name, with their keys whose values are final.
+var (
+	pendingMu sync.Mutex
+	pending   = map[string]map[string]bool{}
+)
+
+// Resolve replaces the values of the entry name of the creds file which
+// refer to a secret store with the secrets, then the references ${...}. It
+// must be called before the provider of the entry is created, and does
+// nothing the next times: the secret stores are only used by the entries
+// of the providers which are used.
+func Resolve(name string, keys map[string]string) error {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	final, ok := pending[name]
+	if !ok {
+		return nil
+	}
+	delete(pending, name)
+	if err := resolveSecrets(name, keys, final); err != nil {
+		return err
+	}
+	return expandTemplates(name, keys, final)
+}
+
+// isSecretRef reports whether the value v refers to a secret store.
+func isSecretRef(v string) bool {
+	for _, r := range secretResolvers {
+		if strings.HasPrefix(v, r.Prefix()) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSecrets replaces the values of the entry name that refer to a
+// secret store with the secrets, and adds their keys to final: a secret is
+// never expanded, whatever it contains. The stores are only used if a value
+// refers to them.
+func resolveSecrets(name string, keys map[string]string, final map[string]bool) error {
+	for k, v := range keys {
+		if final[k] {
+			continue
+		}
+		for _, r := range secretResolvers {
+			if !strings.HasPrefix(v, r.Prefix()) {
+				continue
+			}
+			secret, err := r.Resolve(strings.TrimPrefix(v, r.Prefix()), k)
+			if err != nil {
+				return fmt.Errorf("creds.json: %s: %s: %w", name, k, err)
+			}
+			keys[k] = secret
+			final[k] = true
+			break
+		}
+	}
+	return nil
+}
+
+// splitField splits a reference "PATH#FIELD" of a secret. The field
+// defaults to the subkey.
+func splitField(ref, subkey string) (string, string) {
+	if i := strings.LastIndex(ref, "#"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, subkey
+}
@@ -0,0 +1,42 @@
+package credsfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// sopsCommand is the command that decrypts the files encrypted with SOPS,
+// with their age, KMS or PGP keys.
+var sopsCommand = "sops"
+
+// isSOPSFile reports whether the creds file is a JSON file encrypted with
+// SOPS, which has its metadata in the "sops" key.
+func isSOPSFile(dat []byte) bool {
+	var file struct {
+		SOPS *struct {
+			MAC string `json:"mac"`
+		} `json:"sops"`
+	}
+	if err := json.Unmarshal(dat, &file); err != nil {
+		return false
+	}
+	return file.SOPS != nil && file.SOPS.MAC != ""
+}
+
+// decryptSOPSFile returns the creds file, decrypted by SOPS.
+func decryptSOPSFile(filename string) ([]byte, error) {
+	cmd := exec.Command(sopsCommand, "--decrypt", "--input-type", "json", "--output-type", "json", filename)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	dat, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%v is encrypted with SOPS, which is not installed: %w", filename, err)
+		}
+		return nil, fmt.Errorf("failed decrypting provider credentials file %v with SOPS: %v: %s", filename, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return dat, nil
+}
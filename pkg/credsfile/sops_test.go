@@ -0,0 +1,43 @@
+package credsfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestLoadProviderConfigsSOPS(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake sops command is a shell script")
+	}
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "creds.json")
+	encrypted := `{
+	"bind": {"TYPE": "ENC[AES256_GCM,data:abc,type:str]"},
+	"sops": {"mac": "ENC[AES256_GCM,data:mac,type:str]", "version": "3.9.0"}
+}`
+	if err := os.WriteFile(fname, []byte(encrypted), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if !isSOPSFile([]byte(encrypted)) || isSOPSFile([]byte(`{"bind": {"TYPE": "BIND"}}`)) {
+		t.Fatal("isSOPSFile() doesn't detect the SOPS files")
+	}
+
+	// A fake sops command, which prints the decrypted file.
+	sops := filepath.Join(dir, "sops")
+	script := "#!/bin/sh\necho '{\"bind\": {\"TYPE\": \"BIND\", \"directory\": \"zones\"}}'\n"
+	if err := os.WriteFile(sops, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	defer func(cmd string) { sopsCommand = cmd }(sopsCommand)
+	sopsCommand = sops
+
+	configs, err := LoadProviderConfigs(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if configs["bind"]["directory"] != "zones" {
+		t.Errorf("LoadProviderConfigs() = %v", configs)
+	}
+}
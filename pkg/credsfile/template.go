@@ -0,0 +1,160 @@
+package credsfile
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// filePrefix is the prefix of the values read from a file: "file://PATH".
+const filePrefix = "file://"
+
+// resolveFile returns the content of the file, without the trailing
+// newline.
+func resolveFile(path, _ string) (string, error) {
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(dat), "\r\n"), nil
+}
+
+// expandTemplates replaces the references "${...}" in the values of the
+// entries:
+//
+//	${KEY}       the value of the key KEY of the same entry
+//	${env:NAME}  the environment variable NAME
+//	${file:PATH} the content of the file PATH
+//
+// A filter decodes the value of a reference: "${env:NAME|base64}". "$${" is
+// a literal "${", and so is any "${" which does not start one of these
+// references, e.g. in a password. The values of the keys in final, the
+// secrets, are not expanded, nor the values the references are replaced with.
+func expandTemplates(name string, keys map[string]string, final map[string]bool) error {
+	state := map[string]int{}
+	for k := range final {
+		state[k] = expanded
+	}
+	t := &templater{keys: keys, state: state}
+	for k := range keys {
+		if _, err := t.expand(k); err != nil {
+			return fmt.Errorf("creds.json: %s: %s: %w", name, k, err)
+		}
+	}
+	return nil
+}
+
+// The states of the keys of templater.
+const (
+	unexpanded = iota
+	expanding
+	expanded
+)
+
+// templater expands the keys of an entry, and the keys they refer to.
+type templater struct {
+	keys  map[string]string
+	state map[string]int
+}
+
+func (t *templater) expand(k string) (string, error) {
+	switch t.state[k] {
+	case expanded:
+		return t.keys[k], nil
+	case expanding:
+		return "", fmt.Errorf("the reference ${%s} is circular", k)
+	}
+	t.state[k] = expanding
+	v, err := t.expandString(t.keys[k])
+	if err != nil {
+		return "", err
+	}
+	t.keys[k] = v
+	t.state[k] = expanded
+	return v, nil
+}
+
+func (t *templater) expandString(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		if i > 0 && s[i-1] == '$' {
+			b.WriteString(s[:i])
+			b.WriteString("{")
+			s = s[i+2:]
+			continue
+		}
+		j := strings.Index(s[i:], "}")
+		if j < 0 || !t.isReference(s[i+2:i+j]) {
+			b.WriteString(s[:i+2])
+			s = s[i+2:]
+			continue
+		}
+		v, err := t.reference(s[i+2 : i+j])
+		if err != nil {
+			return "", fmt.Errorf("${%s}: %w", s[i+2:i+j], err)
+		}
+		b.WriteString(s[:i])
+		b.WriteString(v)
+		s = s[i+j+1:]
+	}
+}
+
+// isReference reports whether expr, the text between "${" and "}", is one of
+// the references: a key of the entry, "env:NAME" or "file:PATH", and the
+// filters.
+func (t *templater) isReference(expr string) bool {
+	ref := strings.TrimSpace(strings.Split(expr, "|")[0])
+	if name, ok := strings.CutPrefix(ref, "env:"); ok {
+		return name != ""
+	}
+	if path, ok := strings.CutPrefix(ref, "file:"); ok {
+		return path != ""
+	}
+	_, ok := t.keys[ref]
+	return ok
+}
+
+// reference returns the value of the reference expr, the text between
+// "${" and "}".
+func (t *templater) reference(expr string) (string, error) {
+	filters := strings.Split(expr, "|")
+	ref := strings.TrimSpace(filters[0])
+
+	var v string
+	var err error
+	if name, ok := strings.CutPrefix(ref, "env:"); ok {
+		var set bool
+		if v, set = os.LookupEnv(name); !set {
+			return "", fmt.Errorf("the environment variable %s is not set", name)
+		}
+	} else if path, ok := strings.CutPrefix(ref, "file:"); ok {
+		if v, err = resolveFile(path, ""); err != nil {
+			return "", err
+		}
+	} else if v, err = t.expand(ref); err != nil {
+		return "", err
+	}
+
+	for _, f := range filters[1:] {
+		switch f = strings.TrimSpace(f); f {
+		case "base64":
+			dat, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v))
+			if err != nil {
+				return "", fmt.Errorf("base64: %w", err)
+			}
+			v = string(dat)
+		default:
+			return "", fmt.Errorf("unknown filter %q, expected base64", f)
+		}
+	}
+	return v, nil
+}
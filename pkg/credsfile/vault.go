@@ -0,0 +1,119 @@
+package credsfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// vaultPrefix is the prefix of the values read from HashiCorp Vault:
+// "$vault:PATH" or "$vault:PATH#FIELD", e.g.
+// "$vault:secret/data/dns/cloudflare#apitoken". The field defaults to the
+// subkey.
+//
+// The address of Vault is VAULT_ADDR. The client logs in with, in order:
+//   - a token: VAULT_TOKEN, or the file ~/.vault-token of "vault login"
+//   - AppRole: VAULT_ROLE_ID and VAULT_SECRET_ID (mount VAULT_APPROLE_MOUNT, default "approle")
+//   - Kubernetes: VAULT_K8S_ROLE and the token of the service account
+//     (VAULT_K8S_TOKEN_PATH, mount VAULT_K8S_MOUNT, default "kubernetes")
+const vaultPrefix = "$vault:"
+
+const defaultK8sTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+var vault struct {
+	sync.Mutex
+	client  *api.Logical
+	secrets map[string]map[string]interface{} // The secrets by path, read once.
+}
+
+// newVaultClient returns a client of Vault, logged in.
+func newVaultClient() (*api.Client, error) {
+	client, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+	if client.Token() != "" {
+		return client, nil
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if token, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+			client.SetToken(strings.TrimSpace(string(token)))
+			return client, nil
+		}
+	}
+
+	var mount string
+	var login map[string]interface{}
+	if roleID := os.Getenv("VAULT_ROLE_ID"); roleID != "" {
+		mount = envOr("VAULT_APPROLE_MOUNT", "approle")
+		login = map[string]interface{}{"role_id": roleID, "secret_id": os.Getenv("VAULT_SECRET_ID")}
+	} else if role := os.Getenv("VAULT_K8S_ROLE"); role != "" {
+		jwt, err := os.ReadFile(envOr("VAULT_K8S_TOKEN_PATH", defaultK8sTokenPath))
+		if err != nil {
+			return nil, fmt.Errorf("vault: can't read the token of the service account: %w", err)
+		}
+		mount = envOr("VAULT_K8S_MOUNT", "kubernetes")
+		login = map[string]interface{}{"role": role, "jwt": strings.TrimSpace(string(jwt))}
+	} else {
+		return nil, fmt.Errorf("vault: no token (VAULT_TOKEN), AppRole (VAULT_ROLE_ID) or Kubernetes role (VAULT_K8S_ROLE)")
+	}
+
+	secret, err := client.Logical().Write("auth/"+mount+"/login", login)
+	if err != nil {
+		return nil, fmt.Errorf("vault: login with auth/%s failed: %w", mount, err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("vault: login with auth/%s returned no token", mount)
+	}
+	client.SetToken(secret.Auth.ClientToken)
+	return client, nil
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// resolveVault returns the field of the secret at the path of ref. The
+// secrets of the KV version 2 engine are read from their "data".
+func resolveVault(ref, subkey string) (string, error) {
+	path, field := splitField(ref, subkey)
+
+	vault.Lock()
+	defer vault.Unlock()
+	data, ok := vault.secrets[path]
+	if !ok {
+		if vault.client == nil {
+			client, err := newVaultClient()
+			if err != nil {
+				return "", err
+			}
+			vault.client = client.Logical()
+			vault.secrets = map[string]map[string]interface{}{}
+		}
+		secret, err := vault.client.Read(path)
+		if err != nil {
+			return "", fmt.Errorf("vault: %w", err)
+		}
+		if secret == nil {
+			return "", fmt.Errorf("vault: no secret at %s", path)
+		}
+		data = secret.Data
+		if kv2, ok := data["data"].(map[string]interface{}); ok && data["metadata"] != nil {
+			data = kv2
+		}
+		vault.secrets[path] = data
+	}
+
+	value, ok := data[field].(string)
+	if !ok {
+		return "", fmt.Errorf("vault: the secret at %s has no string field %q", path, field)
+	}
+	return value, nil
+}
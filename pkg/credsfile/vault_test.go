@@ -0,0 +1,55 @@
+package credsfile
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveVault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"auth": map[string]interface{}{"client_token": "s.approle"}})
+		case "/v1/secret/data/dns/cloudflare":
+			if r.Header.Get("X-Vault-Token") != "s.approle" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"data":     map[string]interface{}{"apitoken": "TOKEN", "account": "ACCOUNT"},
+				"metadata": map[string]interface{}{"version": 1},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("VAULT_ROLE_ID", "role")
+	t.Setenv("VAULT_SECRET_ID", "secret")
+	vault.client, vault.secrets = nil, nil
+
+	m := map[string]map[string]string{
+		"cloudflare": {
+			"TYPE":      "CLOUDFLAREAPI",
+			"apitoken":  "$vault:secret/data/dns/cloudflare",
+			"accountid": "$vault:secret/data/dns/cloudflare#account",
+		},
+	}
+	if err := resolveAll(m); err != nil {
+		t.Fatal(err)
+	}
+	if m["cloudflare"]["apitoken"] != "TOKEN" || m["cloudflare"]["accountid"] != "ACCOUNT" || m["cloudflare"]["TYPE"] != "CLOUDFLAREAPI" {
+		t.Errorf("resolveSecrets() = %v", m)
+	}
+
+	m = map[string]map[string]string{"cloudflare": {"apikey": "$vault:secret/data/dns/cloudflare"}}
+	if err := resolveAll(m); err == nil {
+		t.Error("expected an error for a missing field, got none")
+	}
+}
@@ -0,0 +1,267 @@
+// Package depgraph builds the graph of the dependencies of the records of
+// the configuration: the CNAME, MX, NS, SRV, ... records and their targets.
+// The targets outside of the managed zones can be resolved, to find the
+// dependencies which point at unmanaged or third-party infrastructure which
+// does not exist anymore, and could be taken over.
+package depgraph
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+)
+
+// The statuses of the resolution of the nodes.
+const (
+	Resolves = "resolves" // The name exists, with or without addresses
+	NXDomain = "nxdomain" // The name does not exist
+	Failed   = "failed"   // The resolution failed, e.g. a timeout
+)
+
+// The risks of the dependencies.
+const (
+	// Missing is a target in a managed zone which has no records.
+	Missing = "missing"
+	// Dangling is an unmanaged target which does not exist.
+	Dangling = "dangling"
+	// Takeover is a target of a third-party service which does not exist:
+	// it may be registered at the service by someone else.
+	Takeover = "takeover"
+)
+
+// Node is a name of the graph: a label of a managed zone, or a target.
+type Node struct {
+	Name string `json:"name"`
+	// Zone is the managed zone of the name, "" if it is not managed.
+	Zone string `json:"zone,omitempty"`
+	// Service is the third-party service of the name, e.g. "GitHub Pages".
+	Service string `json:"service,omitempty"`
+	// Status is the resolution of the unmanaged names, if they are
+	// resolved.
+	Status string `json:"status,omitempty"`
+	// Risk is the risk of the dependencies on the name, if any.
+	Risk string `json:"risk,omitempty"`
+}
+
+// Edge is a dependency of a record of From on To.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// Graph is the graph of the dependencies.
+type Graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []Edge  `json:"edges"`
+
+	byName map[string]*Node
+}
+
+// node returns the node of name, created if it does not exist.
+func (g *Graph) node(name string) *Node {
+	n, ok := g.byName[name]
+	if !ok {
+		n = &Node{Name: name}
+		g.byName[name] = n
+		g.Nodes = append(g.Nodes, n)
+	}
+	return n
+}
+
+// Node returns the node of name, or nil.
+func (g *Graph) Node(name string) *Node {
+	return g.byName[name]
+}
+
+// Build returns the graph of the dependencies of the records of the
+// domains of cfg. The managed targets without records are Missing.
+func Build(cfg *models.DNSConfig) *Graph {
+	g := &Graph{byName: map[string]*Node{}}
+	names := map[string]bool{}
+	for _, d := range cfg.Domains {
+		for _, rec := range d.Records {
+			names[rec.GetLabelFQDN()] = true
+		}
+	}
+
+	zoneOf := func(name string) string {
+		zone := ""
+		for _, d := range cfg.Domains {
+			if (name == d.Name || strings.HasSuffix(name, "."+d.Name)) && len(d.Name) > len(zone) {
+				zone = d.Name
+			}
+		}
+		return zone
+	}
+
+	for _, d := range cfg.Domains {
+		for _, rec := range d.Records {
+			if rec.Type == "AZURE_ALIAS" || rec.Type == "R53_ALIAS" {
+				// The targets are resources of the cloud, an ID or a
+				// name which is only resolved by its DNS service.
+				continue
+			}
+			for _, target := range rec.GetDependencies() {
+				target = strings.ToLower(strings.TrimSuffix(target, "."))
+				if !isHostname(target) {
+					// e.g. the null MX "."
+					continue
+				}
+				from := g.node(rec.GetLabelFQDN())
+				from.Zone = d.Name
+				to := g.node(target)
+				to.Zone = zoneOf(target)
+				if to.Zone != "" && !names[target] {
+					to.Risk = Missing
+				}
+				if to.Zone == "" {
+					to.Service = thirdPartyService(target)
+				}
+				g.Edges = append(g.Edges, Edge{From: from.Name, To: target, Type: rec.Type})
+			}
+		}
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].Name < g.Nodes[j].Name })
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g
+}
+
+// isHostname reports whether name is a host name.
+func isHostname(name string) bool {
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Resolver resolves the names. DNSResolver is a Resolver.
+type Resolver interface {
+	// Rcode returns the rcode of the answer to a query of the name, e.g.
+	// dns.RcodeNameError if it does not exist.
+	Rcode(ctx context.Context, name string) (int, error)
+}
+
+// DNSResolver is the Resolver of the DNS servers of the system.
+type DNSResolver struct {
+	Servers []string // The addresses of the servers, "host:port"
+}
+
+// NewDNSResolver returns the Resolver of the servers of /etc/resolv.conf.
+func NewDNSResolver() (*DNSResolver, error) {
+	conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the DNS servers of the system: %w", err)
+	}
+	r := &DNSResolver{}
+	for _, s := range conf.Servers {
+		r.Servers = append(r.Servers, net.JoinHostPort(s, conf.Port))
+	}
+	return r, nil
+}
+
+// Rcode implements Resolver. The servers are tried in turn until one of
+// them answers NOERROR or NXDOMAIN: unlike the resolver of Go, a name
+// without addresses (NODATA) is not NXDOMAIN.
+func (r *DNSResolver) Rcode(ctx context.Context, name string) (int, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), dns.TypeA)
+	c := new(dns.Client)
+	err := fmt.Errorf("no DNS server")
+	for _, s := range r.Servers {
+		in, _, xerr := c.ExchangeContext(ctx, m, s)
+		switch {
+		case xerr != nil:
+			err = xerr
+		case in.Rcode == dns.RcodeSuccess || in.Rcode == dns.RcodeNameError:
+			return in.Rcode, nil
+		default:
+			err = fmt.Errorf("%s answered %s", s, dns.RcodeToString[in.Rcode])
+		}
+	}
+	return dns.RcodeServerFailure, err
+}
+
+// resolveTimeout is the timeout of the resolution of a name.
+const resolveTimeout = 5 * time.Second
+
+// resolveWorkers is the number of names resolved at once.
+const resolveWorkers = 8
+
+// Resolve resolves the unmanaged nodes with r. The ones which do not exist
+// are Dangling, or Takeover if they are names of a third-party service.
+func (g *Graph) Resolve(ctx context.Context, r Resolver) {
+	var external []*Node
+	for _, n := range g.Nodes {
+		if n.Zone == "" {
+			external = append(external, n)
+		}
+	}
+
+	nodes := make(chan *Node)
+	var wg sync.WaitGroup
+	for i := 0; i < resolveWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range nodes {
+				lctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+				rcode, err := r.Rcode(lctx, n.Name)
+				cancel()
+				switch {
+				case err != nil:
+					n.Status = Failed
+				case rcode == dns.RcodeSuccess:
+					n.Status = Resolves
+				case rcode == dns.RcodeNameError:
+					n.Status = NXDomain
+					n.Risk = Dangling
+					if n.Service != "" {
+						n.Risk = Takeover
+					}
+				default:
+					n.Status = Failed
+				}
+			}
+		}()
+	}
+	for _, n := range external {
+		nodes <- n
+	}
+	close(nodes)
+	wg.Wait()
+}
+
+// Risks returns the edges of the dependencies on the nodes with a risk.
+func (g *Graph) Risks() []Edge {
+	var risks []Edge
+	for _, e := range g.Edges {
+		if g.byName[e.To].Risk != "" {
+			risks = append(risks, e)
+		}
+	}
+	return risks
+}
@@ -0,0 +1,127 @@
+package depgraph
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+)
+
+func record(rtype, label, domain, target string) *models.RecordConfig {
+	rc := &models.RecordConfig{Type: rtype, TTL: 300}
+	rc.SetLabel(label, domain)
+	rc.SetTarget(target)
+	return rc
+}
+
+// fakeResolver answers NXDOMAIN for the names which are not in it.
+type fakeResolver map[string]int
+
+func (r fakeResolver) Rcode(ctx context.Context, name string) (int, error) {
+	if rcode, ok := r[name]; ok {
+		return rcode, nil
+	}
+	return dns.RcodeNameError, nil
+}
+
+func TestGraph(t *testing.T) {
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "example.com", Records: models.Records{
+			record("A", "www", "example.com", "192.0.2.1"),
+			record("CNAME", "ok", "example.com", "www.example.com."),
+			record("CNAME", "gone", "example.com", "nothing.example.com."),
+			record("CNAME", "docs", "example.com", "old-site.github.io."),
+			record("CNAME", "ext", "example.com", "gone.example.net."),
+			record("MX", "@", "example.com", "mx.example.net."),
+			record("AZURE_ALIAS", "az", "example.com", "/subscriptions/1234/resourceGroups/dns/providers/Microsoft.Network/trafficManagerProfiles/tm"),
+			record("R53_ALIAS", "r53", "example.com", "d1234.cloudfront.net."),
+			record("MX", "null", "example.com", "."),
+		}},
+	}}
+
+	g := Build(cfg)
+	if len(g.Edges) != 5 {
+		t.Fatalf("edges = %+v, want 5", g.Edges)
+	}
+	if n := g.Node("www.example.com"); n == nil || n.Zone != "example.com" || n.Risk != "" {
+		t.Errorf("www.example.com = %+v", n)
+	}
+	if n := g.Node("nothing.example.com"); n == nil || n.Risk != Missing {
+		t.Errorf("nothing.example.com = %+v, want missing", n)
+	}
+	if n := g.Node("old-site.github.io"); n == nil || n.Zone != "" || n.Service != "GitHub Pages" || n.Risk != "" {
+		t.Errorf("old-site.github.io = %+v", n)
+	}
+
+	g.Resolve(context.Background(), fakeResolver{"mx.example.net": dns.RcodeSuccess})
+	for name, want := range map[string]struct{ status, risk string }{
+		"mx.example.net":     {Resolves, ""},
+		"gone.example.net":   {NXDomain, Dangling},
+		"old-site.github.io": {NXDomain, Takeover},
+	} {
+		if n := g.Node(name); n.Status != want.status || n.Risk != want.risk {
+			t.Errorf("%s = %+v, want %s %s", name, n, want.status, want.risk)
+		}
+	}
+	if risks := g.Risks(); len(risks) != 3 {
+		t.Errorf("Risks() = %+v, want 3", risks)
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"docs.example.com" -> "old-site.github.io" [label="CNAME", color=red];`,
+		`"ok.example.com" -> "www.example.com" [label="CNAME"];`,
+		`"old-site.github.io" [label="old-site.github.io\nGitHub Pages\nTAKEOVER", style=dashed, color=red, fontcolor=red];`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("DOT does not contain %s:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestDNSResolver(t *testing.T) {
+	mux := dns.NewServeMux()
+	mux.HandleFunc(".", func(w dns.ResponseWriter, req *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		switch req.Question[0].Name {
+		case "www.example.net.":
+			m.Answer = append(m.Answer, &dns.A{Hdr: dns.RR_Header{Name: "www.example.net.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 300}, A: net.ParseIP("192.0.2.1")})
+		case "v6only.example.net.":
+			// NODATA: the name exists, without A records.
+		case "broken.example.net.":
+			m.Rcode = dns.RcodeServerFailure
+		default:
+			m.Rcode = dns.RcodeNameError
+		}
+		w.WriteMsg(m)
+	})
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	srv := &dns.Server{PacketConn: pc, Handler: mux}
+	go srv.ActivateAndServe()
+	defer srv.Shutdown()
+
+	r := &DNSResolver{Servers: []string{pc.LocalAddr().String()}}
+	for name, want := range map[string]int{
+		"www.example.net":    dns.RcodeSuccess,
+		"v6only.example.net": dns.RcodeSuccess,
+		"gone.example.net":   dns.RcodeNameError,
+	} {
+		if rcode, err := r.Rcode(context.Background(), name); err != nil || rcode != want {
+			t.Errorf("Rcode(%s) = %d, %v, want %d", name, rcode, err, want)
+		}
+	}
+	if _, err := r.Rcode(context.Background(), "broken.example.net"); err == nil {
+		t.Error("expected an error for SERVFAIL, got none")
+	}
+}
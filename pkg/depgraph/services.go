@@ -0,0 +1,57 @@
+package depgraph
+
+import "strings"
+
+// services are the suffixes of the names of third-party services where a
+// name which does not exist anymore can be registered again, by anyone.
+// A CNAME to such a name lets whoever registers it serve the content of
+// the managed name.
+var services = []struct {
+	suffix  string
+	service string
+}{
+	{"github.io", "GitHub Pages"},
+	{"herokuapp.com", "Heroku"},
+	{"herokudns.com", "Heroku"},
+	{"azurewebsites.net", "Azure App Service"},
+	{"cloudapp.net", "Azure Cloud Services"},
+	{"cloudapp.azure.com", "Azure Virtual Machines"},
+	{"trafficmanager.net", "Azure Traffic Manager"},
+	{"blob.core.windows.net", "Azure Blob Storage"},
+	{"azureedge.net", "Azure CDN"},
+	{"s3.amazonaws.com", "Amazon S3"},
+	{"amazonaws.com", "AWS"},
+	{"cloudfront.net", "Amazon CloudFront"},
+	{"elasticbeanstalk.com", "AWS Elastic Beanstalk"},
+	{"storage.googleapis.com", "Google Cloud Storage"},
+	{"appspot.com", "Google App Engine"},
+	{"firebaseapp.com", "Firebase Hosting"},
+	{"web.app", "Firebase Hosting"},
+	{"netlify.app", "Netlify"},
+	{"netlify.com", "Netlify"},
+	{"vercel.app", "Vercel"},
+	{"pages.dev", "Cloudflare Pages"},
+	{"fastly.net", "Fastly"},
+	{"myshopify.com", "Shopify"},
+	{"zendesk.com", "Zendesk"},
+	{"wpengine.com", "WP Engine"},
+	{"pantheonsite.io", "Pantheon"},
+	{"ghost.io", "Ghost"},
+	{"readthedocs.io", "Read the Docs"},
+	{"bitbucket.io", "Bitbucket"},
+	{"surge.sh", "Surge"},
+	{"fly.dev", "Fly.io"},
+	{"statuspage.io", "Statuspage"},
+	{"helpscoutdocs.com", "Help Scout"},
+	{"unbouncepages.com", "Unbounce"},
+}
+
+// thirdPartyService returns the third-party service of name, or "".
+func thirdPartyService(name string) string {
+	for _, s := range services {
+		if name == s.suffix || strings.HasSuffix(name, "."+s.suffix) {
+			return s.service
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package depgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteJSON writes the graph as JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes the graph in the DOT language of Graphviz. The managed
+// names are in the cluster of their zone, the unmanaged ones are dashed,
+// and the ones with a risk are red.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph dnscontrol {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"sans-serif\"];\n")
+
+	var zones []string
+	byZone := map[string][]*Node{}
+	for _, n := range g.Nodes {
+		if _, ok := byZone[n.Zone]; !ok && n.Zone != "" {
+			zones = append(zones, n.Zone)
+		}
+		byZone[n.Zone] = append(byZone[n.Zone], n)
+	}
+	for i, zone := range zones {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", strconv.Quote(zone))
+		for _, n := range byZone[zone] {
+			b.WriteString("    " + dotNode(n) + "\n")
+		}
+		b.WriteString("  }\n")
+	}
+	for _, n := range byZone[""] {
+		b.WriteString("  " + dotNode(n) + "\n")
+	}
+	for _, e := range g.Edges {
+		attrs := ""
+		if g.byName[e.To].Risk != "" {
+			attrs = ", color=red"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(e.Type), attrs)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotNode returns the statement of the node n.
+func dotNode(n *Node) string {
+	label := n.Name
+	var attrs []string
+	if n.Service != "" {
+		label += "\n" + n.Service
+	}
+	if n.Zone == "" {
+		attrs = append(attrs, "style=dashed")
+	}
+	if n.Risk != "" {
+		label += "\n" + strings.ToUpper(n.Risk)
+		attrs = append(attrs, "color=red", "fontcolor=red")
+	}
+	attrs = append([]string{"label=" + strconv.Quote(label)}, attrs...)
+	return fmt.Sprintf("%s [%s];", strconv.Quote(n.Name), strings.Join(attrs, ", "))
+}
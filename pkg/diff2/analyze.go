@@ -234,7 +234,11 @@ func humanDiff(a, b targetConfig) string {
 	// For example if only the MX priority changes, show just that.
 
 	if a.comparableNoTTL != b.comparableNoTTL {
-		// The recorddata is different:
+		// The recorddata is different. Show the words which changed in the
+		// long TXT records (SPF, DKIM, ...).
+		if useWordDiff(b.rec.Type, a.comparableFull, b.comparableFull) {
+			return wordDiff(a.comparableFull, b.comparableFull)
+		}
 		return fmt.Sprintf("(%s) -> (%s)", a.comparableFull, b.comparableFull)
 	}
 
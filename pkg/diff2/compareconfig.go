@@ -6,6 +6,7 @@ import (
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/prettyzone"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 )
 
 /*
@@ -155,13 +156,13 @@ func (cc *CompareConfig) verifyCNAMEAssertions() {
 
 				if len(td.existingTargets) != 0 {
 					if j != 0 {
-						fmt.Println("WARNING: should not happen: (CNAME not in FIRST position)")
+						printer.Warnf("should not happen: (CNAME not in FIRST position)\n")
 					}
 				}
 
 				if len(td.desiredTargets) != 0 {
 					if j != highest(ld.tdata) {
-						fmt.Println("WARNING: should not happen: (CNAME not in last position). There are multiple records on a label with a CNAME.")
+						printer.Warnf("should not happen: (CNAME not in last position). There are multiple records on a label with a CNAME.\n")
 					}
 				}
 			}
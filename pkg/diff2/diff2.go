@@ -7,10 +7,13 @@ package diff2
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/dnsgraph"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tracing"
 )
 
 // Verb indicates the Change's type (create, delete, etc.)
@@ -215,13 +218,19 @@ func ByZone(existing models.Records, dc *models.DomainConfig, compFunc Comparabl
 			changes = true
 		}
 	}
-	return justMsgs(instructions), changes, err
+	// The whole zone is updated at once, so the changes are shown grouped
+	// by label rather than in the order of their dependencies.
+	byLabel := slices.Clone(instructions)
+	sort.SliceStable(byLabel, func(i, j int) bool { return byLabel[i].Key.NameFQDN < byLabel[j].Key.NameFQDN })
+	return justMsgs(byLabel), changes, err
 }
 
 //
 
 // byHelper does 90% of the work for the By*() calls.
 func byHelper(fn func(cc *CompareConfig) ChangeList, existing models.Records, dc *models.DomainConfig, compFunc ComparableFunc) (ChangeList, error) {
+	_, span := tracing.StartCurrent("diff2", tracing.Domain(dc.Name))
+	defer span.End()
 
 	// Process NO_PURGE/ENSURE_ABSENT and IGNORE*().
 	desired, msgs, err := handsoff(
@@ -0,0 +1,158 @@
+package diff2
+
+import (
+	"regexp"
+	"strings"
+)
+
+// wordDiffMinLen is the length of the record data from which humanDiff
+// shows the words which changed, rather than the old and the new data.
+const wordDiffMinLen = 64
+
+// wordDiffContext is the number of unchanged words shown around the
+// changed words. Further unchanged words are elided.
+const wordDiffContext = 2
+
+// wordDiffPrefixLen is the number of characters of the common prefix of
+// a changed word which are shown, e.g. of the key of a DKIM record.
+const wordDiffPrefixLen = 8
+
+// wordToken splits the record data into words, whitespace and quotes, so
+// that the quotes of the TXT strings are not part of their first and last
+// words.
+var wordToken = regexp.MustCompile(`\s+|"|[^\s"]+`)
+
+func isWord(t string) bool {
+	return strings.TrimSpace(t) != "" && t != `"`
+}
+
+// useWordDiff reports whether the change of the record data from a to b
+// is shown as a word diff: the data of the TXT records (SPF, DKIM, ...)
+// which are long.
+func useWordDiff(rtype string, a, b string) bool {
+	return rtype == "TXT" && (len(a) >= wordDiffMinLen || len(b) >= wordDiffMinLen)
+}
+
+// wordDiff returns the words of b and the changes from a, in the format of
+// "git diff --word-diff": "[-removed-]{+added+}". The unchanged words far
+// from the changes are elided with "…".
+func wordDiff(a, b string) string {
+	at := wordToken.FindAllString(a, -1)
+	bt := wordToken.FindAllString(b, -1)
+
+	// The longest common subsequence of the tokens.
+	lcs := make([][]int, len(at)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bt)+1)
+	}
+	for i := len(at) - 1; i >= 0; i-- {
+		for j := len(bt) - 1; j >= 0; j-- {
+			if at[i] == bt[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	var same, removed, added []string
+	first := true
+	flushSame := func(last bool) {
+		out.WriteString(elide(same, first, last))
+		same = nil
+	}
+	flushChange := func() {
+		if len(removed) == 0 && len(added) == 0 {
+			return
+		}
+		flushSame(false)
+		first = false
+		out.WriteString(changedWords(strings.Join(removed, ""), strings.Join(added, "")))
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for i < len(at) || j < len(bt) {
+		switch {
+		case i < len(at) && j < len(bt) && at[i] == bt[j]:
+			flushChange()
+			same = append(same, at[i])
+			i++
+			j++
+		case j < len(bt) && (i == len(at) || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, bt[j])
+			j++
+		default:
+			removed = append(removed, at[i])
+			i++
+		}
+	}
+	flushChange()
+	flushSame(true)
+	return out.String()
+}
+
+// changedWords returns the removed and the added words. If they are a
+// single word each with a long common prefix, e.g. the key of a DKIM
+// record, the prefix is shown once, shortened.
+func changedWords(removed, added string) string {
+	prefix := ""
+	if removed != "" && added != "" && !strings.ContainsAny(removed+added, " \t\"") {
+		n := 0
+		for n < len(removed) && n < len(added) && removed[n] == added[n] {
+			n++
+		}
+		if n > wordDiffPrefixLen {
+			prefix = "…" + removed[n-wordDiffPrefixLen:n]
+			removed, added = removed[n:], added[n:]
+		}
+	}
+	s := prefix
+	if removed != "" {
+		s += "[-" + removed + "-]"
+	}
+	if added != "" {
+		s += "{+" + added + "+}"
+	}
+	return s
+}
+
+// elide returns the unchanged tokens, without the words further than
+// wordDiffContext from the changes: the changes before them if first is
+// false, and after them if last is false.
+func elide(tokens []string, first, last bool) string {
+	words := 0
+	for _, t := range tokens {
+		if isWord(t) {
+			words++
+		}
+	}
+	keepBefore, keepAfter := wordDiffContext, wordDiffContext
+	if first {
+		keepBefore = 0
+	}
+	if last {
+		keepAfter = 0
+	}
+	if first && last || words <= keepBefore+keepAfter+1 {
+		return strings.Join(tokens, "")
+	}
+
+	// Keep the tokens up to the keepBefore-th word, and from the
+	// keepAfter-th word from the end.
+	head, seen := 0, 0
+	for head < len(tokens) && (seen < keepBefore || !isWord(tokens[head]) && seen > 0) {
+		if isWord(tokens[head]) {
+			seen++
+		}
+		head++
+	}
+	tail, seen := len(tokens), 0
+	for tail > 0 && (seen < keepAfter || !isWord(tokens[tail-1]) && seen > 0) {
+		if isWord(tokens[tail-1]) {
+			seen++
+		}
+		tail--
+	}
+	return strings.Join(tokens[:head], "") + "…" + strings.Join(tokens[tail:], "")
+}
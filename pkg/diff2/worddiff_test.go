@@ -0,0 +1,54 @@
+package diff2
+
+import "testing"
+
+func TestWordDiff(t *testing.T) {
+	tests := []struct {
+		name, a, b, want string
+	}{
+		{
+			name: "spf mechanism",
+			a:    `"v=spf1 ip4:192.0.2.0/24 include:_spf.google.com include:mailgun.org include:servers.mcsv.net -all" ttl=300`,
+			b:    `"v=spf1 ip4:192.0.2.0/24 include:_spf.google.com include:mailgun.org include:servers.mcsv.net ~all" ttl=300`,
+			want: `… include:mailgun.org include:servers.mcsv.net [--all-]{+~all+}" ttl=300`,
+		},
+		{
+			name: "spf include added and removed",
+			a:    `"v=spf1 include:a.example include:b.example include:c.example include:d.example include:e.example include:f.example -all"`,
+			b:    `"v=spf1 include:a.example include:x.example include:c.example include:d.example include:e.example include:f.example include:g.example -all"`,
+			want: `"v=spf1 include:a.example [-include:b.example-]{+include:x.example+} include:c.example include:d.example include:e.example include:f.example {+include:g.example +}-all"`,
+		},
+		{
+			name: "dkim key",
+			a:    `"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAvxyz" ttl=300`,
+			b:    `"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAvxyw" ttl=300`,
+			want: `"v=DKIM1; k=rsa; …CAQEAvxy[-z-]{+w+}" ttl=300`,
+		},
+		{
+			name: "ttl",
+			a:    `"v=spf1 -all" ttl=300`,
+			b:    `"v=spf1 -all" ttl=3600`,
+			want: `"v=spf1 -all" [-ttl=300-]{+ttl=3600+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wordDiff(tt.a, tt.b); got != tt.want {
+				t.Errorf("wordDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseWordDiff(t *testing.T) {
+	long := `"v=spf1 include:_spf.google.com include:mailgun.org include:servers.mcsv.net -all" ttl=300`
+	if !useWordDiff("TXT", `"v=spf1 -all" ttl=300`, long) {
+		t.Errorf("useWordDiff(TXT, long) = false")
+	}
+	if useWordDiff("TXT", `"short" ttl=300`, `"shorter" ttl=300`) {
+		t.Errorf("useWordDiff(TXT, short) = true")
+	}
+	if useWordDiff("CNAME", long, long+"x") {
+		t.Errorf("useWordDiff(CNAME) = true")
+	}
+}
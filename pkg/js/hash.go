@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/robertkrimen/otto"
 )
 
@@ -18,13 +19,13 @@ func hashFunc(call otto.FunctionCall) otto.Value {
 	algorithm := call.Argument(0).String() // The algorithm to use for hashing
 	value := call.Argument(1).String()     // The value to hash
 	result := otto.Value{}
-	fmt.Printf("%s\n", value)
+	printer.Debugf("%s\n", value)
 
 	switch algorithm {
 	case "SHA1", "sha1":
 		tmp := sha1.New()
 		tmp.Write([]byte(value))
-		fmt.Printf("%s\n", hex.EncodeToString(tmp.Sum(nil)))
+		printer.Debugf("%s\n", hex.EncodeToString(tmp.Sum(nil)))
 		result, _ = otto.ToValue(hex.EncodeToString(tmp.Sum(nil)))
 	case "SHA256", "sha256":
 		tmp := sha256.New()
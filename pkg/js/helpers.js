@@ -365,6 +365,18 @@ function R53_EVALUATE_TARGET_HEALTH(enabled) {
     };
 }
 
+// R53_HEALTH_CHECK(name, config)
+function R53_HEALTH_CHECK(name, config) {
+    return function (d) {
+        var checks = {};
+        if (_.isString(d.meta.route53_health_checks)) {
+            checks = JSON.parse(d.meta.route53_health_checks);
+        }
+        checks[name] = config;
+        d.meta.route53_health_checks = JSON.stringify(checks);
+    };
+}
+
 function validateR53AliasType(value) {
     if (!_.isString(value)) {
         return false;
@@ -1027,6 +1039,11 @@ function NAMESERVER_TTL(v) {
     return { ns_ttl: v.toString() };
 }
 
+// CATALOG_ZONE(name): Add the domain to the catalog zone name.
+function CATALOG_ZONE(name) {
+    return { catalog_zone: name };
+}
+
 function format_tt(transform_table) {
     // Turn [[low: 1, high: 2, newBase: 3], [low: 4, high: 5, newIP: 6]]
     // into "1 ~ 2 ~ 3 ~; 4 ~ 5 ~  ~ 6"
@@ -1432,12 +1449,39 @@ var CF_WORKER_ROUTE = recordBuilder('CF_WORKER_ROUTE', {
     },
 });
 
+// CF_CUSTOM_HOSTNAME(hostname, config)
+function CF_CUSTOM_HOSTNAME(hostname, config) {
+    return function (d) {
+        var hostnames = {};
+        if (_.isString(d.meta.cloudflare_custom_hostnames)) {
+            hostnames = JSON.parse(d.meta.cloudflare_custom_hostnames);
+        }
+        hostnames[hostname] = config || {};
+        d.meta.cloudflare_custom_hostnames = JSON.stringify(hostnames);
+    };
+}
+
 var URL = recordBuilder('URL');
 var URL301 = recordBuilder('URL301');
 var FRAME = recordBuilder('FRAME');
 var NS1_URLFWD = recordBuilder('NS1_URLFWD');
 var CLOUDNS_WR = recordBuilder('CLOUDNS_WR');
 var PORKBUN_URLFWD = recordBuilder('PORKBUN_URLFWD');
+var NAMECHEAP_EMAILFWD = recordBuilder('NAMECHEAP_EMAILFWD');
+
+// POWERDNS_LUA(name, rtype, snippet, recordModifiers...)
+// The snippet is quoted like PowerDNS expects it: rtype "snippet"
+var POWERDNS_LUA = recordBuilder('POWERDNS_LUA', {
+    args: [
+        ['name', _.isString],
+        ['rtype', _.isString],
+        ['snippet', _.isString],
+    ],
+    transform: function (record, args, modifiers) {
+        record.name = args.name;
+        record.target = args.rtype.toUpperCase() + ' "' + args.snippet.replace(/\\/g, '\\\\').replace(/"/g, '\\"') + '"';
+    },
+});
 
 // LOC_BUILDER_DD takes an object:
 // label: The DNS label for the LOC record. (default: '@')
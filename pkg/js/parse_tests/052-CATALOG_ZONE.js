@@ -0,0 +1,2 @@
+D("foo.com", "none", CATALOG_ZONE("catalog.example"));
+D("catalog.example", "none");
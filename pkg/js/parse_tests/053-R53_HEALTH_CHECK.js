@@ -0,0 +1,5 @@
+D("foo.com", "none",
+    R53_HEALTH_CHECK("api", {type: "HTTPS", fqdn: "api.foo.com", path: "/health"}),
+    R53_HEALTH_CHECK("backup", {type: "TCP", ip: "192.0.2.20", port: 22}),
+    A("api", "192.0.2.10", {route53_set_identifier: "main", route53_failover: "PRIMARY", route53_health_check: "api"})
+);
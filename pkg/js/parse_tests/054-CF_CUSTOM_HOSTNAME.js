@@ -0,0 +1,4 @@
+D("foo.com", "none",
+    CF_CUSTOM_HOSTNAME("shop.customer.com", {ssl_method: "txt", custom_origin_server: "origin.foo.com"}),
+    CF_CUSTOM_HOSTNAME("www.client.net")
+);
@@ -0,0 +1,127 @@
+// Package junit writes the results of dnscontrol as a JUnit XML report,
+// which the CI pipelines display in their test UI.
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Case is a test case, e.g. a domain. It failed if Failure or Error is
+// not "": a failure is a problem of the configuration, an error is a
+// problem of a provider.
+type Case struct {
+	ClassName string
+	Name      string
+	Duration  time.Duration
+	Failure   string
+	Error     string
+	Output    string
+}
+
+// Suite is a suite of test cases, e.g. a run of preview.
+type Suite struct {
+	Name      string
+	Timestamp time.Time
+	Cases     []Case
+}
+
+type xmlSuites struct {
+	XMLName  xml.Name   `xml:"testsuites"`
+	Name     string     `xml:"name,attr"`
+	Tests    int        `xml:"tests,attr"`
+	Failures int        `xml:"failures,attr"`
+	Errors   int        `xml:"errors,attr"`
+	Time     string     `xml:"time,attr"`
+	Suites   []xmlSuite `xml:"testsuite"`
+}
+
+type xmlSuite struct {
+	Name      string    `xml:"name,attr"`
+	Tests     int       `xml:"tests,attr"`
+	Failures  int       `xml:"failures,attr"`
+	Errors    int       `xml:"errors,attr"`
+	Time      string    `xml:"time,attr"`
+	Timestamp string    `xml:"timestamp,attr,omitempty"`
+	Cases     []xmlCase `xml:"testcase"`
+}
+
+type xmlCase struct {
+	ClassName string      `xml:"classname,attr"`
+	Name      string      `xml:"name,attr"`
+	Time      string      `xml:"time,attr"`
+	Failure   *xmlProblem `xml:"failure,omitempty"`
+	Error     *xmlProblem `xml:"error,omitempty"`
+	SystemOut string      `xml:"system-out,omitempty"`
+}
+
+type xmlProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// problem returns the element of a failure or of an error: its first line
+// is the message, and all of it the text.
+func problem(s string) *xmlProblem {
+	if s == "" {
+		return nil
+	}
+	message, _, _ := strings.Cut(s, "\n")
+	return &xmlProblem{Message: message, Text: s}
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// Write writes the suites as a report named name.
+func Write(w io.Writer, name string, suites ...Suite) error {
+	report := xmlSuites{Name: name}
+	var total time.Duration
+	for _, s := range suites {
+		xs := xmlSuite{Name: s.Name, Tests: len(s.Cases)}
+		if !s.Timestamp.IsZero() {
+			xs.Timestamp = s.Timestamp.UTC().Format("2006-01-02T15:04:05")
+		}
+		var d time.Duration
+		for _, c := range s.Cases {
+			xc := xmlCase{
+				ClassName: c.ClassName,
+				Name:      c.Name,
+				Time:      seconds(c.Duration),
+				Failure:   problem(c.Failure),
+				Error:     problem(c.Error),
+				SystemOut: c.Output,
+			}
+			if xc.Failure != nil {
+				xs.Failures++
+			}
+			if xc.Error != nil {
+				xs.Errors++
+			}
+			d += c.Duration
+			xs.Cases = append(xs.Cases, xc)
+		}
+		xs.Time = seconds(d)
+		total += d
+		report.Tests += xs.Tests
+		report.Failures += xs.Failures
+		report.Errors += xs.Errors
+		report.Suites = append(report.Suites, xs)
+	}
+	report.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
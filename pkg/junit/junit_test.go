@@ -0,0 +1,45 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "dnscontrol preview", Suite{
+		Name:      "preview",
+		Timestamp: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Cases: []Case{
+			{ClassName: "preview", Name: "example.com", Duration: 1500 * time.Millisecond, Output: "+ CREATE www.example.com A 192.0.2.1"},
+			{ClassName: "preview", Name: "example.net", Failure: "2 pending corrections\n+ CREATE a.example.net A 192.0.2.1"},
+			{ClassName: "preview", Name: "example.org", Error: "cloudflare: 403 Forbidden"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got xmlSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid XML %s: %s", buf.String(), err)
+	}
+	if got.Tests != 3 || got.Failures != 1 || got.Errors != 1 || got.Time != "1.500" || len(got.Suites) != 1 {
+		t.Fatalf("report = %s", buf.String())
+	}
+	s := got.Suites[0]
+	if s.Timestamp != "2024-05-01T10:00:00" || len(s.Cases) != 3 {
+		t.Fatalf("suite = %+v", s)
+	}
+	if f := s.Cases[1].Failure; f == nil || f.Message != "2 pending corrections" {
+		t.Errorf("failure = %+v", f)
+	}
+	if e := s.Cases[2].Error; e == nil || e.Message != "cloudflare: 403 Forbidden" {
+		t.Errorf("error = %+v", e)
+	}
+	if s.Cases[0].Failure != nil || s.Cases[0].SystemOut == "" {
+		t.Errorf("case = %+v", s.Cases[0])
+	}
+}
@@ -28,7 +28,7 @@ func DetermineNameserversForProviders(dc *models.DomainConfig, providers []*mode
 			continue
 		}
 		if !silent && !printer.SkinnyReport {
-			fmt.Printf("----- Getting nameservers from: %s\n", dnsProvider.Name)
+			printer.Printf("----- Getting nameservers from: %s\n", dnsProvider.Name)
 		}
 
 		nss, err := dnsProvider.Driver.GetNameservers(dc.Name)
@@ -62,7 +62,7 @@ func AddNSRecords(dc *models.DomainConfig) {
 	if ttls, ok := dc.Metadata["ns_ttl"]; ok {
 		t, err := strconv.ParseUint(ttls, 10, 32)
 		if err != nil {
-			fmt.Printf("WARNING: ns_ttl for %s (%s) is not a valid int", dc.Name, ttls)
+			printer.Warnf("ns_ttl for %s (%s) is not a valid int\n", dc.Name, ttls)
 		} else {
 			ttl = uint32(t)
 		}
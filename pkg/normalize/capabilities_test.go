@@ -17,6 +17,8 @@ func TestCapabilitiesAreFiltered(t *testing.T) {
 	// something we can test against.
 	skipCheckCapabilities := make(map[string]struct{})
 	//skipCheckCapabilities["CanUseBlahBlahBlah"] = struct{}{}
+	// A registrar capability, used by moveRegistrarDS.
+	skipCheckCapabilities["CanUseDSAtRegistrar"] = struct{}{}
 
 	fset := token.NewFileSet()
 	pkgs, err := parser.ParseDir(fset, providersImportDir, nil, 0)
@@ -0,0 +1,95 @@
+package normalize
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+)
+
+// catalogMeta is the metadata set by CATALOG_ZONE() on the member zones.
+const catalogMeta = "catalog_zone"
+
+// catalogMemberLabel returns the label of a member zone in a catalog zone.
+// Like BIND, it is the SHA-1 hash of the member zone in wire format.
+func catalogMemberLabel(member string) string {
+	buf := make([]byte, 256)
+	off, err := dns.PackDomainName(dns.Fqdn(member), buf, 0, nil, false)
+	if err != nil {
+		// Member names are validated domain names.
+		panic(err)
+	}
+	sum := sha1.Sum(buf[:off])
+	return hex.EncodeToString(sum[:]) + ".zones"
+}
+
+// addCatalogMembers adds the records of the RFC 9432 catalog zones: the
+// version of the schema, and one PTR record per member zone. The catalog
+// zones must be declared with D() too.
+func addCatalogMembers(config *models.DNSConfig) (errs []error) {
+	members := map[string]map[string]bool{}
+	for _, d := range config.Domains {
+		catalog := strings.ToLower(strings.TrimSuffix(d.Metadata[catalogMeta], "."))
+		if catalog == "" {
+			continue
+		}
+		if catalog == strings.ToLower(d.Name) {
+			errs = append(errs, fmt.Errorf("CATALOG_ZONE(%q) of domain %s: a catalog zone can't be its own member", catalog, d.Name))
+			continue
+		}
+		if members[catalog] == nil {
+			members[catalog] = map[string]bool{}
+		}
+		members[catalog][strings.ToLower(d.Name)] = true
+	}
+
+	catalogs := make([]string, 0, len(members))
+	for catalog := range members {
+		catalogs = append(catalogs, catalog)
+	}
+	sort.Strings(catalogs)
+
+	for _, catalog := range catalogs {
+		names := make([]string, 0, len(members[catalog]))
+		for name := range members[catalog] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		found := false
+		for _, d := range config.Domains {
+			if !strings.EqualFold(d.Name, catalog) {
+				continue
+			}
+			found = true
+
+			hasVersion := false
+			for _, rec := range d.Records {
+				if rec.Type == "TXT" && rec.GetLabel() == "version" {
+					hasVersion = true
+				}
+			}
+			if !hasVersion {
+				rc := &models.RecordConfig{Type: "TXT", Metadata: map[string]string{}}
+				rc.SetLabel("version", d.Name)
+				rc.SetTargetTXT("2")
+				d.Records = append(d.Records, rc)
+			}
+
+			for _, name := range names {
+				rc := &models.RecordConfig{Type: "PTR", Metadata: map[string]string{}}
+				rc.SetLabel(catalogMemberLabel(name), d.Name)
+				rc.SetTarget(name + ".")
+				d.Records = append(d.Records, rc)
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("CATALOG_ZONE(%q) is used by %s but the catalog zone is not declared with D()", catalog, strings.Join(names, ", ")))
+		}
+	}
+	return errs
+}
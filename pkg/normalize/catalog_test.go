@@ -0,0 +1,56 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_catalogMemberLabel(t *testing.T) {
+	// The label of domain.example in the examples of the BIND documentation.
+	if got, want := catalogMemberLabel("domain.example"), "5960775ba382e7a4e09263fc06e7c00569b6a05c.zones"; got != want {
+		t.Errorf("catalogMemberLabel() = %q, want %q", got, want)
+	}
+}
+
+func Test_addCatalogMembers(t *testing.T) {
+	catalog := &models.DomainConfig{Name: "catalog.example"}
+	config := &models.DNSConfig{
+		Domains: []*models.DomainConfig{
+			{Name: "example.com", Metadata: map[string]string{catalogMeta: "catalog.example"}},
+			{Name: "example.org", Metadata: map[string]string{catalogMeta: "Catalog.Example."}},
+			{Name: "example.net"},
+			catalog,
+		},
+	}
+	if errs := addCatalogMembers(config); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	got := map[string]string{}
+	for _, rc := range catalog.Records {
+		got[rc.Type+" "+rc.GetLabel()] = rc.GetTargetCombined()
+	}
+	want := map[string]string{
+		"TXT version": `"2"`,
+		"PTR " + catalogMemberLabel("example.com"): "example.com.",
+		"PTR " + catalogMemberLabel("example.org"): "example.org.",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: got %q, want %q", k, got[k], v)
+		}
+	}
+
+	missing := &models.DNSConfig{
+		Domains: []*models.DomainConfig{
+			{Name: "example.com", Metadata: map[string]string{catalogMeta: "catalog.example"}},
+		},
+	}
+	if errs := addCatalogMembers(missing); len(errs) != 1 {
+		t.Errorf("undeclared catalog zone: got %d errors, want 1", len(errs))
+	}
+}
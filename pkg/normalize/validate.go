@@ -315,6 +315,11 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 		return []error{err}
 	}
 
+	// Add the member zones to their catalog zones.
+	if ers := addCatalogMembers(config); len(ers) > 0 {
+		return ers
+	}
+
 	for _, domain := range config.Domains {
 		pTypes := []string{}
 		for _, provider := range domain.DNSProviderInstances {
@@ -475,6 +480,8 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 	}
 
 	for _, d := range config.Domains {
+		// Hand the DS records of the zone over to the registrar if it can publish them
+		moveRegistrarDS(d)
 		// Check that CNAMES don't have to co-exist with any other records
 		errs = append(errs, checkCNAMEs(d)...)
 		// Check that if any advanced record types are used in a domain, every provider for that domain supports them
@@ -759,6 +766,31 @@ func checkProviderDS(pType string, records models.Records) error {
 	return nil
 }
 
+// moveRegistrarDS moves the DS records at the root of the zone to
+// dc.RegistrarDS if the registrar can publish them in the parent zone.
+//
+// The type of the registrar is "-" if it is only in creds.json, which
+// `dnscontrol check` doesn't read. The DS records are moved as well then,
+// so that check doesn't report them as unsupported by the DNS providers
+// of the zone: preview and push, which know the type, do the full check.
+func moveRegistrarDS(dc *models.DomainConfig) {
+	if dc.RegistrarInstance == nil {
+		return
+	}
+	if pType := dc.RegistrarInstance.ProviderType; pType != "-" && !providers.ProviderHasCapability(pType, providers.CanUseDSAtRegistrar) {
+		return
+	}
+	records := dc.Records[:0]
+	for _, rec := range dc.Records {
+		if rec.Type == "DS" && rec.GetLabel() == "@" {
+			dc.RegistrarDS = append(dc.RegistrarDS, rec)
+			continue
+		}
+		records = append(records, rec)
+	}
+	dc.Records = records
+}
+
 func checkProviderCapabilities(dc *models.DomainConfig) error {
 	// Check if the zone uses a capability that the provider doesn't
 	// support.
@@ -525,6 +525,42 @@ func Test_DSChecks(t *testing.T) {
 	})
 }
 
+const RegistrarDS = "REGISTRAR_DS_SUPPORT"
+
+func init() {
+	providers.RegisterRegistrarType(RegistrarDS, nil, providers.DocumentationNotes{
+		providers.CanUseDSAtRegistrar: providers.Can(),
+	})
+}
+
+func Test_moveRegistrarDS(t *testing.T) {
+	apexDS := &models.RecordConfig{Type: "DS"}
+	apexDS.SetLabel("@", "example.com")
+	childDS := &models.RecordConfig{Type: "DS"}
+	childDS.SetLabel("child", "example.com")
+
+	for _, tt := range []struct {
+		registrar           string
+		wantRecords, wantDS int
+	}{
+		{registrar: RegistrarDS, wantRecords: 1, wantDS: 1},
+		{registrar: ProviderNoDS, wantRecords: 2, wantDS: 0},
+		// The type is only in creds.json, e.g. for check.
+		{registrar: "-", wantRecords: 1, wantDS: 1},
+	} {
+		dc := &models.DomainConfig{
+			Name:              "example.com",
+			Records:           models.Records{apexDS, childDS},
+			RegistrarInstance: &models.RegistrarInstance{ProviderBase: models.ProviderBase{ProviderType: tt.registrar}},
+		}
+		moveRegistrarDS(dc)
+		if len(dc.Records) != tt.wantRecords || len(dc.RegistrarDS) != tt.wantDS {
+			t.Errorf("%s: got %d records and %d registrar DS, want %d and %d",
+				tt.registrar, len(dc.Records), len(dc.RegistrarDS), tt.wantRecords, tt.wantDS)
+		}
+	}
+}
+
 func Test_errorRepeat(t *testing.T) {
 	type args struct {
 		label  string
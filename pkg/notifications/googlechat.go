@@ -0,0 +1,118 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) Notifier {
+		url, ok := cfg["googlechat_url"]
+		if !ok {
+			return nil
+		}
+		notifier := &googleChatNotifier{
+			URL: url,
+		}
+		return notifier
+	})
+}
+
+// googleChatNotifier sends the summary of the corrections of the run to a
+// space of Google Chat, as a card with a section per domain.
+type googleChatNotifier struct {
+	URL string
+
+	mu      sync.Mutex
+	preview bool
+	total   int
+	errors  int
+	domains []string            // in the order of the corrections
+	lines   map[string][]string // the corrections of each domain
+}
+
+type googleChatWidget struct {
+	TextParagraph struct {
+		Text string `json:"text"`
+	} `json:"textParagraph"`
+}
+
+type googleChatSection struct {
+	Header  string             `json:"header"`
+	Widgets []googleChatWidget `json:"widgets"`
+}
+
+func (s *googleChatNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lines == nil {
+		s.lines = map[string][]string{}
+	}
+	if _, ok := s.lines[domain]; !ok {
+		s.domains = append(s.domains, domain)
+	}
+	s.preview = preview
+	s.total++
+
+	line := fmt.Sprintf("<b>%s</b>: %s", html.EscapeString(provider), strings.ReplaceAll(html.EscapeString(msg), "\n", "<br>"))
+	if err != nil {
+		s.errors++
+		line += fmt.Sprintf(`<br><font color="#d93025">Error: %s</font>`, html.EscapeString(err.Error()))
+	}
+	s.lines[domain] = append(s.lines[domain], line)
+}
+
+func (s *googleChatNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.total == 0 {
+		return
+	}
+
+	var title string
+	if s.preview {
+		title = "DNSControl preview"
+	} else if s.errors != 0 {
+		title = "DNSControl ERROR making changes"
+	} else {
+		title = "DNSControl successfully made changes"
+	}
+	subtitle := fmt.Sprintf("%d correction(s) in %d domain(s)", s.total, len(s.domains))
+	if s.errors != 0 {
+		subtitle += fmt.Sprintf(", %d failed", s.errors)
+	}
+
+	var sections []googleChatSection
+	for _, domain := range s.domains {
+		var widget googleChatWidget
+		widget.TextParagraph.Text = strings.Join(s.lines[domain], "<br>")
+		sections = append(sections, googleChatSection{Header: domain, Widgets: []googleChatWidget{widget}})
+	}
+	payload := map[string]interface{}{
+		"cardsV2": []map[string]interface{}{{
+			"cardId": "dnscontrol",
+			"card": map[string]interface{}{
+				"header":   map[string]string{"title": title, "subtitle": subtitle},
+				"sections": sections,
+			},
+		}},
+	}
+	marshaledPayload, _ := json.Marshal(payload)
+
+	resp, err := http.Post(s.URL, "application/json", bytes.NewReader(marshaledPayload))
+	if err != nil {
+		printer.Warnf("notifications: googlechat: %s\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		printer.Warnf("notifications: googlechat: %s\n", resp.Status)
+	}
+}
@@ -0,0 +1,44 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGoogleChatNotifier(t *testing.T) {
+	var payload struct {
+		CardsV2 []struct {
+			Card struct {
+				Header   map[string]string   `json:"header"`
+				Sections []googleChatSection `json:"sections"`
+			} `json:"card"`
+		} `json:"cardsV2"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer srv.Close()
+
+	n := Init(map[string]string{"googlechat_url": srv.URL})
+	n.Notify("example.com", "bind", "CREATE www.example.com A 192.0.2.1", nil, false)
+	n.Notify("example.net", "bind", "CREATE www.example.net A 192.0.2.2", errors.New("failed"), false)
+	n.Notify("example.com", "bind", "DELETE old.example.com A 192.0.2.3", nil, false)
+	n.Done()
+
+	if len(payload.CardsV2) != 1 {
+		t.Fatalf("got %d cards, want 1", len(payload.CardsV2))
+	}
+	card := payload.CardsV2[0].Card
+	if want := "3 correction(s) in 2 domain(s), 1 failed"; card.Header["subtitle"] != want {
+		t.Errorf("subtitle = %q, want %q", card.Header["subtitle"], want)
+	}
+	if len(card.Sections) != 2 || card.Sections[0].Header != "example.com" || card.Sections[1].Header != "example.net" {
+		t.Fatalf("got sections %+v", card.Sections)
+	}
+	if want := "<b>bind</b>: CREATE www.example.com A 192.0.2.1<br><b>bind</b>: DELETE old.example.com A 192.0.2.3"; card.Sections[0].Widgets[0].TextParagraph.Text != want {
+		t.Errorf("text = %q, want %q", card.Sections[0].Widgets[0].TextParagraph.Text, want)
+	}
+}
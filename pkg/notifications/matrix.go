@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) Notifier {
+		homeserver, ok := cfg["matrix_homeserver"]
+		if !ok {
+			return nil
+		}
+		notifier := &matrixNotifier{
+			Homeserver:  strings.TrimSuffix(homeserver, "/"),
+			AccessToken: cfg["matrix_access_token"],
+			RoomID:      cfg["matrix_room_id"],
+		}
+		return notifier
+	})
+}
+
+// matrixNotifier sends the summary of the corrections of the run to a room
+// of Matrix.
+type matrixNotifier struct {
+	Homeserver  string
+	AccessToken string
+	RoomID      string
+
+	mu      sync.Mutex
+	preview bool
+	errors  int
+	plain   []string
+	html    []string
+}
+
+func (s *matrixNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preview = preview
+	line := fmt.Sprintf("%s[%s] - %s", domain, provider, msg)
+	item := fmt.Sprintf("<code>%s[%s]</code> %s", html.EscapeString(domain), html.EscapeString(provider), strings.ReplaceAll(html.EscapeString(msg), "\n", "<br>"))
+	if err != nil {
+		s.errors++
+		line += fmt.Sprintf(" Error: %s", err)
+		item += fmt.Sprintf(" <b>Error:</b> %s", html.EscapeString(err.Error()))
+	}
+	s.plain = append(s.plain, line)
+	s.html = append(s.html, "<li>"+item+"</li>")
+}
+
+func (s *matrixNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.plain) == 0 {
+		return
+	}
+
+	var title string
+	if s.preview {
+		title = fmt.Sprintf("DNSControl preview: %d correction(s)", len(s.plain))
+	} else if s.errors != 0 {
+		title = fmt.Sprintf("DNSControl ERROR: %d of %d correction(s) failed", s.errors, len(s.plain))
+	} else {
+		title = fmt.Sprintf("DNSControl successfully ran %d correction(s)", len(s.plain))
+	}
+	payload := struct {
+		MsgType       string `json:"msgtype"`
+		Body          string `json:"body"`
+		Format        string `json:"format"`
+		FormattedBody string `json:"formatted_body"`
+	}{
+		MsgType:       "m.text",
+		Body:          title + "\n" + strings.Join(s.plain, "\n"),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: "<b>" + html.EscapeString(title) + "</b><ul>" + strings.Join(s.html, "") + "</ul>",
+	}
+	marshaledPayload, _ := json.Marshal(payload)
+
+	// The transaction ID makes the homeserver ignore the retries of a message.
+	u := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/dnscontrol-%d",
+		s.Homeserver, url.PathEscape(s.RoomID), time.Now().UnixNano())
+	req, err := http.NewRequest(http.MethodPut, u, bytes.NewReader(marshaledPayload))
+	if err != nil {
+		printer.Warnf("notifications: matrix: %s\n", err)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+s.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		printer.Warnf("notifications: matrix: %s\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		printer.Warnf("notifications: matrix: %s\n", resp.Status)
+	}
+}
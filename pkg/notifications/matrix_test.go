@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMatrixNotifier(t *testing.T) {
+	var path, auth string
+	var payload map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.EscapedPath(), r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer srv.Close()
+
+	n := Init(map[string]string{
+		"matrix_homeserver":   srv.URL + "/",
+		"matrix_access_token": "t0ken",
+		"matrix_room_id":      "!room:example.com",
+	})
+	n.Notify("example.com", "bind", "CREATE <www>.example.com A 192.0.2.1", nil, true)
+	n.Done()
+
+	if !strings.HasPrefix(path, "/_matrix/client/v3/rooms/%21room:example.com/send/m.room.message/") {
+		t.Errorf("path = %s", path)
+	}
+	if auth != "Bearer t0ken" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if want := "DNSControl preview: 1 correction(s)\nexample.com[bind] - CREATE <www>.example.com A 192.0.2.1"; payload["body"] != want {
+		t.Errorf("body = %q, want %q", payload["body"], want)
+	}
+	if !strings.Contains(payload["formatted_body"], "<li><code>example.com[bind]</code> CREATE &lt;www&gt;.example.com") {
+		t.Errorf("formatted_body = %q", payload["formatted_body"])
+	}
+}
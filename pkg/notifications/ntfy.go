@@ -0,0 +1,71 @@
+package notifications
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) Notifier {
+		topic, ok := cfg["ntfy_topic"]
+		if !ok {
+			return nil
+		}
+		notifier := &ntfyNotifier{
+			Server: strings.TrimSuffix(cfg["ntfy_server"], "/"),
+			Topic:  topic,
+			Token:  cfg["ntfy_token"],
+		}
+		if notifier.Server == "" {
+			notifier.Server = "https://ntfy.sh"
+		}
+		return notifier
+	})
+}
+
+// ntfyNotifier publishes the summary of the corrections of the run to a
+// topic of ntfy.
+type ntfyNotifier struct {
+	Server string
+	Topic  string
+	Token  string
+	textSummary
+}
+
+func (s *ntfyNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.add(domain, provider, msg, err, preview)
+}
+
+func (s *ntfyNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	title := s.title()
+	if title == "" {
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, s.Server+"/"+s.Topic, strings.NewReader(s.text(4000)))
+	if err != nil {
+		printer.Warnf("notifications: ntfy: %s\n", err)
+		return
+	}
+	req.Header.Set("Title", title)
+	req.Header.Set("Tags", "globe_with_meridians")
+	if s.errors != 0 {
+		req.Header.Set("Priority", "high")
+		req.Header.Set("Tags", "warning")
+	}
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		printer.Warnf("notifications: ntfy: %s\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		printer.Warnf("notifications: ntfy: %s\n", resp.Status)
+	}
+}
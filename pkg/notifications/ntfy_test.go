@@ -0,0 +1,41 @@
+package notifications
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNtfyNotifier(t *testing.T) {
+	var path, title, priority, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		path, title, priority, body = r.URL.Path, r.Header.Get("Title"), r.Header.Get("Priority"), string(b)
+	}))
+	defer srv.Close()
+
+	n := Init(map[string]string{"ntfy_server": srv.URL + "/", "ntfy_topic": "dns-alerts"})
+	n.Notify("example.com", "bind", "CREATE www.example.com A 192.0.2.1", errors.New("failed"), false)
+	n.Done()
+
+	if path != "/dns-alerts" || title != "DNSControl ERROR: 1 of 1 correction(s) failed" || priority != "high" {
+		t.Errorf("path = %q, title = %q, priority = %q", path, title, priority)
+	}
+	if want := "example.com[bind] CREATE www.example.com A 192.0.2.1 Error: failed"; body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestTextSummaryTruncated(t *testing.T) {
+	var s textSummary
+	for i := 0; i < 100; i++ {
+		s.add("exämple.com", "bind", "CREATE www.exämple.com A 192.0.2.1", nil, true)
+	}
+	text := s.text(1000)
+	if len(text) > 1000 || !strings.HasSuffix(text, "\n…") || !strings.HasPrefix(text, "exämple.com[bind]") {
+		t.Errorf("got %d bytes: %q", len(text), text)
+	}
+}
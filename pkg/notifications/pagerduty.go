@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// pagerDutyEventsURL is the endpoint of the Events API v2 of PagerDuty.
+var pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) Notifier {
+		routingKey, ok := cfg["pagerduty_routing_key"]
+		if !ok {
+			return nil
+		}
+		notifier := &pagerDutyNotifier{
+			RoutingKey: routingKey,
+			DedupKey:   cfg["pagerduty_dedup_key"],
+			Severity:   cfg["pagerduty_severity"],
+			Drift:      cfg["pagerduty_drift"] == "true",
+		}
+		if notifier.DedupKey == "" {
+			notifier.DedupKey = "dnscontrol"
+		}
+		if notifier.Severity == "" {
+			notifier.Severity = "error"
+		}
+		return notifier
+	})
+}
+
+// pagerDutyNotifier triggers an alert of PagerDuty when corrections of a
+// push fail, or with Drift when a preview has corrections, i.e. the zones
+// were changed outside of DNSControl. The alert is resolved by the next run
+// which has no errors (push) or no corrections (preview).
+type pagerDutyNotifier struct {
+	RoutingKey string
+	DedupKey   string // Prefix of the dedup keys of the alerts of push and of drift
+	Severity   string
+	Drift      bool
+
+	mu      sync.Mutex
+	preview bool
+	total   int
+	details []string // The failed corrections, or the pending ones of a preview
+}
+
+func (s *pagerDutyNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preview = preview
+	s.total++
+	if err != nil {
+		s.details = append(s.details, fmt.Sprintf("%s[%s] - %s Error: %s", domain, provider, msg, err))
+	} else if preview {
+		s.details = append(s.details, fmt.Sprintf("%s[%s] - %s", domain, provider, msg))
+	}
+}
+
+func (s *pagerDutyNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pushKey, driftKey := s.DedupKey+"-push", s.DedupKey+"-drift"
+	switch {
+	case s.preview && s.Drift:
+		s.send("trigger", driftKey, fmt.Sprintf("DNSControl: %d correction(s) pending, the zones drifted from dnsconfig.js", s.total))
+	case s.preview:
+		// The pending corrections are not alerts without Drift.
+	case len(s.details) != 0:
+		s.send("trigger", pushKey, fmt.Sprintf("DNSControl: %d of %d correction(s) failed", len(s.details), s.total))
+	default:
+		// A push without errors, or a run without corrections: the zones
+		// match dnsconfig.js.
+		s.send("resolve", pushKey, "")
+		if s.Drift {
+			s.send("resolve", driftKey, "")
+		}
+	}
+}
+
+func (s *pagerDutyNotifier) send(action, dedupKey, summary string) {
+	event := map[string]interface{}{
+		"routing_key":  s.RoutingKey,
+		"event_action": action,
+		"dedup_key":    dedupKey,
+	}
+	if action == "trigger" {
+		source, _ := os.Hostname()
+		event["payload"] = map[string]interface{}{
+			"summary":        summary,
+			"source":         source,
+			"severity":       s.Severity,
+			"component":      "dnscontrol",
+			"custom_details": map[string][]string{"corrections": s.details},
+		}
+	}
+	marshaledPayload, _ := json.Marshal(event)
+
+	resp, err := http.Post(pagerDutyEventsURL, "application/json", bytes.NewReader(marshaledPayload))
+	if err != nil {
+		printer.Warnf("notifications: pagerduty: %s\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		printer.Warnf("notifications: pagerduty: %s\n", resp.Status)
+	}
+}
@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPagerDutyNotifier(t *testing.T) {
+	var events []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&event)
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+	defer func(u string) { pagerDutyEventsURL = u }(pagerDutyEventsURL)
+	pagerDutyEventsURL = srv.URL
+
+	cfg := map[string]string{"pagerduty_routing_key": "r0uting", "pagerduty_drift": "true"}
+	tests := []struct {
+		name   string
+		notify func(Notifier)
+		want   []string // event_action dedup_key
+	}{
+		{"failed push", func(n Notifier) {
+			n.Notify("example.com", "bind", "CREATE www.example.com A 192.0.2.1", errors.New("failed"), false)
+		}, []string{"trigger dnscontrol-push"}},
+		{"drift", func(n Notifier) {
+			n.Notify("example.com", "bind", "CREATE www.example.com A 192.0.2.1", nil, true)
+		}, []string{"trigger dnscontrol-drift"}},
+		{"successful push", func(n Notifier) {
+			n.Notify("example.com", "bind", "CREATE www.example.com A 192.0.2.1", nil, false)
+		}, []string{"resolve dnscontrol-push", "resolve dnscontrol-drift"}},
+		{"no corrections", func(n Notifier) {}, []string{"resolve dnscontrol-push", "resolve dnscontrol-drift"}},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			events = nil
+			n := Init(cfg)
+			tst.notify(n)
+			n.Done()
+			if len(events) != len(tst.want) {
+				t.Fatalf("got %d events, want %d", len(events), len(tst.want))
+			}
+			for i, e := range events {
+				if got := e["event_action"].(string) + " " + e["dedup_key"].(string); got != tst.want[i] {
+					t.Errorf("event %d = %q, want %q", i, got, tst.want[i])
+				}
+				if e["routing_key"] != "r0uting" {
+					t.Errorf("routing_key = %v", e["routing_key"])
+				}
+			}
+		})
+	}
+}
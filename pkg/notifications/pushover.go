@@ -0,0 +1,64 @@
+package notifications
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// pushoverURL is the endpoint of the messages of the API of Pushover.
+var pushoverURL = "https://api.pushover.net/1/messages.json"
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) Notifier {
+		token, ok := cfg["pushover_token"]
+		if !ok {
+			return nil
+		}
+		notifier := &pushoverNotifier{
+			Token: token,
+			User:  cfg["pushover_user"],
+		}
+		return notifier
+	})
+}
+
+// pushoverNotifier sends the summary of the corrections of the run with
+// Pushover.
+type pushoverNotifier struct {
+	Token string // Token of the application
+	User  string // Key of the user or of the group
+	textSummary
+}
+
+func (s *pushoverNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.add(domain, provider, msg, err, preview)
+}
+
+func (s *pushoverNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	title := s.title()
+	if title == "" {
+		return
+	}
+	form := url.Values{
+		"token":   {s.Token},
+		"user":    {s.User},
+		"title":   {title},
+		"message": {s.text(1000)},
+	}
+	if s.errors != 0 {
+		form.Set("priority", "1")
+	}
+	resp, err := http.PostForm(pushoverURL, form)
+	if err != nil {
+		printer.Warnf("notifications: pushover: %s\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		printer.Warnf("notifications: pushover: %s\n", resp.Status)
+	}
+}
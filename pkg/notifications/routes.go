@@ -0,0 +1,102 @@
+package notifications
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// RoutePrefix is the prefix of the names of the creds.json entries of the
+// notification routes, e.g. "notifications:team-a".
+const RoutePrefix = "notifications:"
+
+// route sends the notifications of some domains to its own destinations.
+type route struct {
+	domains  []string // glob patterns of the domain names
+	tags     []string
+	notifier Notifier
+}
+
+func (r route) matches(domain string, tags []string) bool {
+	for _, pattern := range r.domains {
+		if ok, _ := path.Match(pattern, strings.ToLower(domain)); ok {
+			return true
+		}
+	}
+	for _, t := range tags {
+		for _, rt := range r.tags {
+			if t == rt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// InitRoutes creates the Notifier of the "notifications" entry of the
+// configs of creds.json, and of the routes: the entries
+// "notifications:NAME", with the keys "domains" (glob patterns of the domain
+// names) and "tags". The notifications of a domain are sent to the routes
+// which match its name or one of its tags, or else to "notifications".
+// tags are the tags of the domains, keyed by domain name.
+func InitRoutes(configs map[string]map[string]string, tags map[string][]string) Notifier {
+	var names []string
+	for name := range configs {
+		if strings.HasPrefix(name, RoutePrefix) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return Init(configs["notifications"])
+	}
+	sort.Strings(names)
+
+	r := &router{fallback: Init(configs["notifications"]), tags: tags}
+	for _, name := range names {
+		cfg := configs[name]
+		r.routes = append(r.routes, route{
+			domains:  splitList(strings.ToLower(cfg["domains"])),
+			tags:     splitList(cfg["tags"]),
+			notifier: Init(cfg),
+		})
+	}
+	return r
+}
+
+// splitList splits a comma-separated list.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// router sends the notifications of each domain to its routes.
+type router struct {
+	routes   []route
+	fallback Notifier
+	tags     map[string][]string
+}
+
+func (r *router) Notify(domain, provider string, message string, err error, preview bool) {
+	routed := false
+	for _, rt := range r.routes {
+		if rt.matches(domain, r.tags[domain]) {
+			rt.notifier.Notify(domain, provider, message, err, preview)
+			routed = true
+		}
+	}
+	if !routed {
+		r.fallback.Notify(domain, provider, message, err, preview)
+	}
+}
+
+func (r *router) Done() {
+	for _, rt := range r.routes {
+		rt.notifier.Done()
+	}
+	r.fallback.Done()
+}
@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestInitRoutes(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string][]string{} // path -> domains
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var summary webhookSummary
+		json.NewDecoder(r.Body).Decode(&summary)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, c := range summary.Corrections {
+			received[r.URL.Path] = append(received[r.URL.Path], c.Domain)
+		}
+	}))
+	defer srv.Close()
+
+	n := InitRoutes(map[string]map[string]string{
+		"notifications":        {"webhook_url": srv.URL + "/noc"},
+		"notifications:team-a": {"webhook_url": srv.URL + "/team-a", "domains": "*.team-a.example, team-a.example"},
+		"notifications:corp":   {"webhook_url": srv.URL + "/corp", "tags": "corp"},
+		"cloudflare":           {"TYPE": "CLOUDFLAREAPI"},
+	}, map[string][]string{"example.com": {"corp"}, "www.team-a.example": {"corp"}})
+	for _, domain := range []string{"example.com", "Team-A.example", "www.team-a.example", "example.org"} {
+		n.Notify(domain, "bind", "CREATE www."+domain+" A 192.0.2.1", nil, false)
+	}
+	n.Done()
+
+	for path, domains := range received {
+		sort.Strings(domains)
+		received[path] = domains
+	}
+	want := map[string]string{
+		"/noc":    "example.org",
+		"/team-a": "Team-A.example www.team-a.example",
+		"/corp":   "example.com www.team-a.example",
+	}
+	for path, domains := range want {
+		if got := strings.Join(received[path], " "); got != domains {
+			t.Errorf("%s got %q, want %q", path, got, domains)
+		}
+	}
+}
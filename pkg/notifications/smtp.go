@@ -0,0 +1,208 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// smtpToPrefix is the prefix of the keys of the recipients of a domain,
+// e.g. "smtp_to_example.com".
+const smtpToPrefix = "smtp_to_"
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) Notifier {
+		host, ok := cfg["smtp_host"]
+		if !ok {
+			return nil
+		}
+		notifier := &smtpNotifier{
+			Host:     host,
+			Port:     cfg["smtp_port"],
+			Security: cfg["smtp_security"],
+			Username: cfg["smtp_username"],
+			Password: cfg["smtp_password"],
+			From:     cfg["smtp_from"],
+			To:       splitList(cfg["smtp_to"]),
+			DomainTo: map[string][]string{},
+		}
+		if notifier.Security == "" {
+			notifier.Security = "starttls"
+		}
+		if notifier.Port == "" {
+			notifier.Port = "587"
+			if notifier.Security == "tls" {
+				notifier.Port = "465"
+			}
+		}
+		if notifier.From == "" {
+			notifier.From = notifier.Username
+		}
+		for k, v := range cfg {
+			if domain, ok := strings.CutPrefix(k, smtpToPrefix); ok {
+				notifier.DomainTo[strings.ToLower(domain)] = splitList(v)
+			}
+		}
+		return notifier
+	})
+}
+
+// smtpNotifier sends a digest of the corrections of the run by email. The
+// corrections of a domain are sent to its recipients, or else to To.
+type smtpNotifier struct {
+	Host     string
+	Port     string
+	Security string // starttls, tls or none
+	Username string
+	Password string
+	From     string
+	To       []string
+	DomainTo map[string][]string
+
+	mu      sync.Mutex
+	preview bool
+	errors  int
+	domains []string            // in the order of the corrections
+	lines   map[string][]string // the corrections of each domain
+}
+
+// smtpDigest is an email of the digest.
+type smtpDigest struct {
+	To   []string
+	Body string
+}
+
+func (s *smtpNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lines == nil {
+		s.lines = map[string][]string{}
+	}
+	if _, ok := s.lines[domain]; !ok {
+		s.domains = append(s.domains, domain)
+	}
+	s.preview = preview
+	line := fmt.Sprintf("  [%s] %s", provider, strings.ReplaceAll(msg, "\n", "\n    "))
+	if err != nil {
+		s.errors++
+		line += fmt.Sprintf("\n    Error: %s", err)
+	}
+	s.lines[domain] = append(s.lines[domain], line)
+}
+
+// digests returns the emails of the corrections: one for each set of
+// recipients, with the corrections of their domains.
+func (s *smtpNotifier) digests() []smtpDigest {
+	var digests []smtpDigest
+	index := map[string]int{}
+	for _, domain := range s.domains {
+		to, ok := s.DomainTo[strings.ToLower(domain)]
+		if !ok {
+			to = s.To
+		}
+		if len(to) == 0 {
+			continue
+		}
+		to = append([]string{}, to...)
+		sort.Strings(to)
+		key := strings.Join(to, ",")
+		i, ok := index[key]
+		if !ok {
+			i = len(digests)
+			index[key] = i
+			digests = append(digests, smtpDigest{To: to})
+		}
+		digests[i].Body += fmt.Sprintf("%s:\n%s\n\n", domain, strings.Join(s.lines[domain], "\n"))
+	}
+	return digests
+}
+
+func (s *smtpNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var subject string
+	if s.preview {
+		subject = "DNSControl preview: pending DNS changes"
+	} else if s.errors != 0 {
+		subject = fmt.Sprintf("DNSControl ERROR: %d DNS change(s) failed", s.errors)
+	} else {
+		subject = "DNSControl: DNS changes applied"
+	}
+	for _, d := range s.digests() {
+		if err := s.send(d.To, subject, d.Body); err != nil {
+			printer.Warnf("notifications: smtp: %s\n", err)
+		}
+	}
+}
+
+func (s *smtpNotifier) send(to []string, subject, body string) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	addr := net.JoinHostPort(s.Host, s.Port)
+	config := &tls.Config{ServerName: s.Host}
+	var c *smtp.Client
+	var err error
+	switch s.Security {
+	case "tls":
+		conn, err := tls.Dial("tcp", addr, config)
+		if err != nil {
+			return err
+		}
+		c, err = smtp.NewClient(conn, s.Host)
+		if err != nil {
+			return err
+		}
+	case "starttls", "none":
+		if c, err = smtp.Dial(addr); err != nil {
+			return err
+		}
+		if s.Security == "starttls" {
+			if err := c.StartTLS(config); err != nil {
+				c.Close()
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("invalid smtp_security %q, expected starttls, tls or none", s.Security)
+	}
+	defer c.Close()
+
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.From); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
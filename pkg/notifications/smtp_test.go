@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSMTPDigests(t *testing.T) {
+	n := Init(map[string]string{
+		"smtp_host":             "smtp.example.com",
+		"smtp_to":               "noc@example.com",
+		"smtp_to_example.net":   "team-a@example.com, noc@example.com",
+		"smtp_to_example.org":   "noc@example.com,team-a@example.com",
+		"smtp_to_internal.test": "",
+	}).(multiNotifier)[0].(*smtpNotifier)
+	if n.Port != "587" || n.Security != "starttls" {
+		t.Errorf("Port = %q, Security = %q", n.Port, n.Security)
+	}
+
+	n.Notify("example.com", "bind", "CREATE www.example.com A 192.0.2.1", nil, false)
+	n.Notify("example.net", "bind", "CREATE www.example.net A 192.0.2.2", errors.New("failed"), false)
+	n.Notify("example.org", "bind", "CREATE www.example.org A 192.0.2.3", nil, false)
+	n.Notify("internal.test", "bind", "CREATE www.internal.test A 192.0.2.4", nil, false)
+
+	want := []smtpDigest{
+		{To: []string{"noc@example.com"}, Body: "example.com:\n  [bind] CREATE www.example.com A 192.0.2.1\n\n"},
+		{To: []string{"noc@example.com", "team-a@example.com"}, Body: "example.net:\n  [bind] CREATE www.example.net A 192.0.2.2\n    Error: failed\n\n" +
+			"example.org:\n  [bind] CREATE www.example.org A 192.0.2.3\n\n"},
+	}
+	if got := n.digests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// textSummary collects the corrections of a run, for the notifiers which
+// send a short text at the end of the run.
+type textSummary struct {
+	mu      sync.Mutex
+	preview bool
+	errors  int
+	lines   []string
+}
+
+func (s *textSummary) add(domain, provider, msg string, err error, preview bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.preview = preview
+	line := fmt.Sprintf("%s[%s] %s", domain, provider, msg)
+	if err != nil {
+		s.errors++
+		line += fmt.Sprintf(" Error: %s", err)
+	}
+	s.lines = append(s.lines, line)
+}
+
+// title returns the title of the summary, or "" if there are no
+// corrections.
+func (s *textSummary) title() string {
+	switch {
+	case len(s.lines) == 0:
+		return ""
+	case s.preview:
+		return fmt.Sprintf("DNSControl: %d pending correction(s)", len(s.lines))
+	case s.errors != 0:
+		return fmt.Sprintf("DNSControl ERROR: %d of %d correction(s) failed", s.errors, len(s.lines))
+	default:
+		return fmt.Sprintf("DNSControl: %d correction(s) applied", len(s.lines))
+	}
+}
+
+// text returns the corrections, truncated to max bytes.
+func (s *textSummary) text(max int) string {
+	text := strings.Join(s.lines, "\n")
+	if len(text) > max {
+		n := max - len("\n…")
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		text = text[:n] + "\n…"
+	}
+	return text
+}
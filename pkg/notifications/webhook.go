@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// webhookHeaderPrefix is the prefix of the keys of the headers of the
+// webhook, e.g. "webhook_header_Authorization".
+const webhookHeaderPrefix = "webhook_header_"
+
+// webhookDefaultTemplate is the body of the webhook without
+// webhook_template: the summary as JSON.
+const webhookDefaultTemplate = `{{json .}}`
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) Notifier {
+		url, ok := cfg["webhook_url"]
+		if !ok {
+			return nil
+		}
+		text := cfg["webhook_template"]
+		if text == "" {
+			text = webhookDefaultTemplate
+		}
+		tmpl, err := template.New("webhook").Funcs(template.FuncMap{"json": webhookJSON}).Parse(text)
+		if err != nil {
+			printer.Warnf("notifications: invalid webhook_template: %s\n", err)
+			return nil
+		}
+
+		notifier := &webhookNotifier{
+			URL:      url,
+			Template: tmpl,
+			Headers:  http.Header{"Content-Type": {"application/json"}},
+		}
+		for k, v := range cfg {
+			if name, ok := strings.CutPrefix(k, webhookHeaderPrefix); ok {
+				notifier.Headers.Set(name, v)
+			}
+		}
+		return notifier
+	})
+}
+
+// webhookSummary is the data of the template of the webhook.
+type webhookSummary struct {
+	Preview     bool                `json:"preview"`
+	Errors      int                 `json:"errors"`
+	Corrections []webhookCorrection `json:"corrections"`
+}
+
+// webhookCorrection is a correction of the summary.
+type webhookCorrection struct {
+	Domain   string `json:"domain"`
+	Provider string `json:"provider"`
+	Message  string `json:"message"`
+	Error    string `json:"error,omitempty"`
+}
+
+// webhookNotifier posts the summary of the corrections of the run to a URL,
+// with a body of a template.
+type webhookNotifier struct {
+	URL      string
+	Template *template.Template
+	Headers  http.Header
+
+	mu      sync.Mutex
+	summary webhookSummary
+}
+
+func (s *webhookNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := webhookCorrection{Domain: domain, Provider: provider, Message: msg}
+	if err != nil {
+		c.Error = err.Error()
+		s.summary.Errors++
+	}
+	s.summary.Preview = preview
+	s.summary.Corrections = append(s.summary.Corrections, c)
+}
+
+func (s *webhookNotifier) Done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.summary.Corrections) == 0 {
+		return
+	}
+	var body bytes.Buffer
+	if err := s.Template.Execute(&body, s.summary); err != nil {
+		printer.Warnf("notifications: webhook_template: %s\n", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, s.URL, &body)
+	if err != nil {
+		printer.Warnf("notifications: webhook_url: %s\n", err)
+		return
+	}
+	req.Header = s.Headers
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		printer.Warnf("notifications: webhook: %s\n", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		printer.Warnf("notifications: webhook: %s\n", resp.Status)
+	}
+}
+
+// webhookJSON returns v as JSON, e.g. to quote a string in the template.
+func webhookJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
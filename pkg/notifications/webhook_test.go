@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var body, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body, auth = string(b), r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	n := Init(map[string]string{
+		"webhook_url":                  srv.URL,
+		"webhook_template":             `{"text": {{json (printf "%d change(s), %d error(s)" (len .Corrections) .Errors)}}, "first": {{json (index .Corrections 0).Domain}}}`,
+		"webhook_header_Authorization": "Bearer t0ken",
+	})
+	n.Notify("example.com", "bind", "CREATE www.example.com A 192.0.2.1", nil, false)
+	n.Notify("example.net", "bind", "DELETE www.example.net A 192.0.2.2", errors.New("failed"), false)
+	n.Done()
+
+	if want := `{"text": "2 change(s), 1 error(s)", "first": "example.com"}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+	if auth != "Bearer t0ken" {
+		t.Errorf("Authorization = %q", auth)
+	}
+}
@@ -0,0 +1,78 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Logger is the structured logger of --log-format=text or json, or nil for
+// the console output. The debug messages, the warnings, the errors and the
+// messages of the providers (the functions Debugf, Printf and Warnf of the
+// package) are logged, instead of being printed. The output of the commands
+// (the methods of CLI) is printed as usual.
+var Logger *slog.Logger
+
+// logLevel is the level of --log-level.
+var logLevel = new(slog.LevelVar)
+
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// ConfigureLogging sets the format ("console", "text" or "json") and the
+// level ("debug", "info", "warn" or "error") of the logs, written to w by
+// the structured formats.
+func ConfigureLogging(format, level string, w io.Writer) error {
+	if level == "" {
+		level = "info"
+		if DefaultPrinter.Verbose {
+			level = "debug"
+		}
+	}
+	l, ok := logLevels[level]
+	if !ok {
+		return fmt.Errorf("invalid log level %q, expected debug, info, warn or error", level)
+	}
+	logLevel.Set(l)
+
+	opts := &slog.HandlerOptions{Level: logLevel}
+	switch format {
+	case "", "console":
+		Logger = nil
+		DefaultPrinter.Verbose = l <= slog.LevelDebug
+	case "text":
+		Logger = slog.New(slog.NewTextHandler(w, opts))
+	case "json":
+		Logger = slog.New(slog.NewJSONHandler(w, opts))
+	default:
+		return fmt.Errorf("invalid log format %q, expected console, text or json", format)
+	}
+	return nil
+}
+
+// logf logs the message, without its trailing newline, if the logs are
+// structured. It returns false if they are not.
+func logf(level slog.Level, format string, args ...interface{}) bool {
+	if Logger == nil {
+		return false
+	}
+	Logger.Log(context.Background(), level, strings.TrimRight(fmt.Sprintf(format, args...), "\n"))
+	return true
+}
+
+// LogError logs err with its context, e.g. "provider", name, "domain",
+// domain, if the logs are structured. It returns false if they are not, for
+// the callers which print the error on the console.
+func LogError(msg string, err error, attrs ...any) bool {
+	if Logger == nil {
+		return false
+	}
+	Logger.Error(msg, append(attrs, "error", err.Error())...)
+	return true
+}
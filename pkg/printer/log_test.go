@@ -0,0 +1,45 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestConfigureLogging(t *testing.T) {
+	defer ConfigureLogging("console", "info", nil)
+
+	var buf bytes.Buffer
+	if err := ConfigureLogging("json", "warn", &buf); err != nil {
+		t.Fatal(err)
+	}
+	Printf("not logged at the level warn\n")
+	Warnf("zone %s is not managed\n", "example.com")
+	LogError("failed getting the corrections", errors.New("HTTP 500"), "provider", "bind", "domain", "example.com")
+
+	var lines []map[string]string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var line map[string]string
+		if err := dec.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %v", len(lines), lines)
+	}
+	if lines[0]["level"] != "WARN" || lines[0]["msg"] != "zone example.com is not managed" {
+		t.Errorf("got %v", lines[0])
+	}
+	if lines[1]["level"] != "ERROR" || lines[1]["provider"] != "bind" || lines[1]["domain"] != "example.com" || lines[1]["error"] != "HTTP 500" {
+		t.Errorf("got %v", lines[1])
+	}
+
+	for _, args := range [][2]string{{"xml", "info"}, {"json", "verbose"}} {
+		if err := ConfigureLogging(args[0], args[1], &buf); err == nil {
+			t.Errorf("%v: expected an error, got none", args)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -37,12 +38,16 @@ type Printer interface {
 
 // Debugf is called to print/format debug information.
 func Debugf(fmt string, args ...interface{}) {
-	DefaultPrinter.Debugf(fmt, args...)
+	if !logf(slog.LevelDebug, fmt, args...) {
+		DefaultPrinter.Debugf(fmt, args...)
+	}
 }
 
 // Printf is called to print/format information.
 func Printf(fmt string, args ...interface{}) {
-	DefaultPrinter.Printf(fmt, args...)
+	if !logf(slog.LevelInfo, fmt, args...) {
+		DefaultPrinter.Printf(fmt, args...)
+	}
 }
 
 // Println is called to print/format information.
@@ -52,7 +57,9 @@ func Println(lines ...string) {
 
 // Warnf is called to print/format a warning.
 func Warnf(fmt string, args ...interface{}) {
-	DefaultPrinter.Warnf(fmt, args...)
+	if !logf(slog.LevelWarn, fmt, args...) {
+		DefaultPrinter.Warnf(fmt, args...)
+	}
 }
 
 // Errorf is called to print/format an error.
@@ -185,6 +192,9 @@ func (c ConsolePrinter) EndProvider2(name string, numCorrections int) {
 
 // Debugf is called to print/format debug information.
 func (c ConsolePrinter) Debugf(format string, args ...interface{}) {
+	if logf(slog.LevelDebug, format, args...) {
+		return
+	}
 	if c.Verbose {
 		fmt.Fprintf(c.Writer, format, args...)
 	}
@@ -202,11 +212,17 @@ func (c ConsolePrinter) Println(lines ...string) {
 
 // Warnf is called to print/format a warning.
 func (c ConsolePrinter) Warnf(format string, args ...interface{}) {
+	if logf(slog.LevelWarn, format, args...) || logLevel.Level() > slog.LevelWarn {
+		return
+	}
 	fmt.Fprintf(c.Writer, "WARNING: "+format, args...)
 }
 
 // Errorf is called to print/format an error.
 func (c ConsolePrinter) Errorf(format string, args ...interface{}) {
+	if logf(slog.LevelError, format, args...) {
+		return
+	}
 	fmt.Fprintf(c.Writer, "ERROR: "+format, args...)
 }
 
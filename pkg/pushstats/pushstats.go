@@ -0,0 +1,272 @@
+// Package pushstats collects the statistics of preview and push per
+// provider: the zones and records fetched, the corrections by type, the
+// requests to the API, the retries and the duration.
+package pushstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// The types of corrections.
+const (
+	Create = "create"
+	Modify = "modify"
+	Delete = "delete"
+	Other  = "other"
+)
+
+// Unattributed is the name of the statistics of the requests sent while
+// several providers run at once, which can't be attributed to one of them.
+const Unattributed = "(unattributed)"
+
+// Provider is the statistics of a provider.
+type Provider struct {
+	Name        string         `json:"provider"`
+	Zones       int            `json:"zones"`
+	Records     int            `json:"records_fetched"`
+	Corrections map[string]int `json:"corrections"`
+	Errors      int            `json:"errors"`
+	APICalls    int            `json:"api_calls"`
+	Retries     int            `json:"retries"`
+	DurationMS  float64        `json:"duration_ms"`
+
+	duration time.Duration
+	// active is the number of the runs of the provider in progress, since
+	// activeAt.
+	active   int
+	activeAt time.Time
+	// last is the last request to the API, and if it failed with a
+	// status which is retried.
+	last      string
+	lastRetry bool
+}
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	stats   = map[string]*Provider{}
+	// running are the providers which run.
+	running = map[string]*Provider{}
+)
+
+// Enable starts the collection of the statistics, and counts the requests
+// of the default transport of HTTP.
+func Enable() {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = true
+	if _, ok := http.DefaultTransport.(*transport); !ok {
+		http.DefaultTransport = Wrap(http.DefaultTransport)
+	}
+}
+
+// get returns the statistics of the provider name. mu must be held.
+func get(name string) *Provider {
+	p, ok := stats[name]
+	if !ok {
+		p = &Provider{Name: name, Corrections: map[string]int{}}
+		stats[name] = p
+	}
+	return p
+}
+
+// Start starts a run of the provider name, until the returned function is
+// called. The time the provider runs is its duration. The requests are
+// counted for the provider which runs; the ones sent while several
+// providers run at once are Unattributed, as the transport can't tell
+// which provider sent them.
+func Start(name string) (stop func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return func() {}
+	}
+	p := get(name)
+	if p.active == 0 {
+		p.activeAt = time.Now()
+		running[name] = p
+	}
+	p.active++
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		p.active--
+		if p.active == 0 {
+			p.duration += time.Since(p.activeAt)
+			delete(running, name)
+		}
+	}
+}
+
+// Driver returns driver, which counts the zones and the records it fetches
+// for the provider name.
+func Driver(name string, driver models.DNSProvider) models.DNSProvider {
+	return &countingDriver{DNSProvider: driver, name: name}
+}
+
+type countingDriver struct {
+	models.DNSProvider
+	name string
+}
+
+func (d *countingDriver) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	recs, err := d.DNSProvider.GetZoneRecords(domain, meta)
+	mu.Lock()
+	defer mu.Unlock()
+	if enabled && err == nil {
+		p := get(d.name)
+		p.Zones++
+		p.Records += len(recs)
+	}
+	return recs, err
+}
+
+// AddCorrection counts the correction msg of the provider name, and err if
+// it failed.
+func AddCorrection(name, msg string, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return
+	}
+	p := get(name)
+	for t, n := range CorrectionTypes(msg) {
+		p.Corrections[t] += n
+	}
+	if err != nil {
+		p.Errors++
+	}
+}
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// CorrectionTypes counts the changes of the message of a correction by
+// type. A correction of a zone changes a record per line, e.g.
+// "+ CREATE www.example.com A 192.0.2.1", a correction which changes no
+// record by name is counted as Other.
+func CorrectionTypes(msg string) map[string]int {
+	counts := map[string]int{}
+	for _, line := range strings.Split(ansiEscape.ReplaceAllString(msg, ""), "\n") {
+		verb, _, _ := strings.Cut(strings.TrimLeft(line, "+-±* \t"), " ")
+		verb, _, _ = strings.Cut(strings.ToUpper(verb), "-")
+		switch strings.TrimRight(verb, ":") {
+		case "CREATE", "ADD":
+			counts[Create]++
+		case "MODIFY", "CHANGE", "UPDATE":
+			counts[Modify]++
+		case "DELETE", "REMOVE":
+			counts[Delete]++
+		}
+	}
+	if len(counts) == 0 {
+		counts[Other] = 1
+	}
+	return counts
+}
+
+// runner returns the provider which runs, or Unattributed if several run.
+// mu must be held.
+func runner() *Provider {
+	if len(running) == 1 {
+		for _, p := range running {
+			return p
+		}
+	}
+	return get(Unattributed)
+}
+
+// Wrap returns a transport which counts the requests of rt for the provider
+// which runs, if the statistics are enabled.
+func Wrap(rt http.RoundTripper) http.RoundTripper {
+	return &transport{base: rt}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled || len(running) == 0 {
+		return resp, err
+	}
+	p := runner()
+	p.APICalls++
+	// A request is a retry if it repeats the last one, which failed with
+	// a status the clients of the APIs retry.
+	key := req.Method + " " + req.URL.String()
+	if p.lastRetry && key == p.last {
+		p.Retries++
+	}
+	p.last = key
+	p.lastRetry = err == nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500)
+	return resp, err
+}
+
+// Summary returns the statistics of the providers, sorted by name.
+func Summary() []Provider {
+	mu.Lock()
+	defer mu.Unlock()
+	summary := make([]Provider, 0, len(stats))
+	for _, p := range stats {
+		s := *p
+		s.Corrections = make(map[string]int, len(p.Corrections))
+		for t, n := range p.Corrections {
+			s.Corrections[t] = n
+		}
+		d := p.duration
+		if p.active > 0 {
+			d += time.Since(p.activeAt)
+		}
+		s.DurationMS = float64(d.Microseconds()) / 1000
+		summary = append(summary, s)
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Name < summary[j].Name })
+	return summary
+}
+
+// WriteTable writes the summary as a table.
+func WriteTable(w io.Writer, summary []Provider) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROVIDER\tZONES\tRECORDS\tCREATE\tMODIFY\tDELETE\tOTHER\tERRORS\tAPI CALLS\tRETRIES\tDURATION")
+	for _, p := range summary {
+		d := time.Duration(p.DurationMS * float64(time.Millisecond)).Round(time.Millisecond)
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", p.Name, p.Zones, p.Records,
+			p.Corrections[Create], p.Corrections[Modify], p.Corrections[Delete], p.Corrections[Other],
+			p.Errors, p.APICalls, p.Retries, d)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the summary as JSON.
+func WriteJSON(w io.Writer, summary []Provider) error {
+	b, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// reset forgets the statistics, for the tests.
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = false
+	stats = map[string]*Provider{}
+	running = map[string]*Provider{}
+}
@@ -0,0 +1,129 @@
+package pushstats
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCorrectionTypes(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want map[string]int
+	}{
+		{"+ CREATE www.example.com A 192.0.2.1", map[string]int{Create: 1}},
+		{"\x1b[33m± MODIFY-TTL www.example.com A 192.0.2.1\x1b[0m", map[string]int{Modify: 1}},
+		{"+ CREATE a.example.com A 192.0.2.1\n- DELETE b.example.com A 192.0.2.2\n- DELETE c.example.com A 192.0.2.3", map[string]int{Create: 1, Delete: 2}},
+		{"UPDATE named.conf: zone \"example.com\"", map[string]int{Modify: 1}},
+		{"Update nameservers ns1.example.net -> ns2.example.net", map[string]int{Modify: 1}},
+		{"Enable DNSSEC", map[string]int{Other: 1}},
+	}
+	for _, tt := range tests {
+		if got := CorrectionTypes(tt.msg); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CorrectionTypes(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+type fakeDriver struct {
+	models.DNSProvider
+	recs models.Records
+}
+
+func (d fakeDriver) GetZoneRecords(string, map[string]string) (models.Records, error) {
+	return d.recs, nil
+}
+
+func TestSummary(t *testing.T) {
+	reset()
+	defer reset()
+
+	failures := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/flaky" && failures < 2 {
+			failures++
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: Wrap(http.DefaultTransport)}
+	get := func(path string) {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	get("/") // Not counted: the statistics are not enabled.
+	Enable()
+	get("/") // Not counted: no provider runs.
+
+	stop := Start("cloudflare")
+	driver := Driver("cloudflare", fakeDriver{recs: models.Records{{}, {}, {}}})
+	if _, err := driver.GetZoneRecords("example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	get("/zones")
+	get("/flaky")
+	get("/flaky")
+	get("/flaky")
+	AddCorrection("cloudflare", "+ CREATE www.example.com A 192.0.2.1", nil)
+	AddCorrection("cloudflare", "- DELETE old.example.com A 192.0.2.2", errors.New("failed"))
+
+	stop()
+
+	stop = Start("gandi")
+	get("/domains")
+	AddCorrection("gandi", "Update nameservers", nil)
+	stop()
+	get("/") // Not counted: no provider runs.
+
+	// The requests sent while several providers run can't be attributed.
+	stopCF, stopGandi := Start("cloudflare"), Start("gandi")
+	get("/zones")
+	stopGandi()
+	get("/zones")
+	stopCF()
+
+	summary := Summary()
+	if len(summary) != 3 {
+		t.Fatalf("Summary() = %+v, want 2 providers and the unattributed requests", summary)
+	}
+	unattributed, cf, gandi := summary[0], summary[1], summary[2]
+	if unattributed.Name != Unattributed || unattributed.APICalls != 1 {
+		t.Errorf("unattributed = %+v", unattributed)
+	}
+	if cf.Name != "cloudflare" || cf.Zones != 1 || cf.Records != 3 || cf.APICalls != 5 || cf.Retries != 2 || cf.Errors != 1 {
+		t.Errorf("cloudflare = %+v", cf)
+	}
+	if want := map[string]int{Create: 1, Delete: 1}; !reflect.DeepEqual(cf.Corrections, want) {
+		t.Errorf("cloudflare corrections = %v, want %v", cf.Corrections, want)
+	}
+	if gandi.Name != "gandi" || gandi.APICalls != 1 || gandi.Corrections[Modify] != 1 {
+		t.Errorf("gandi = %+v", gandi)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, summary); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[0], "API CALLS") || !strings.Contains(lines[2], "cloudflare") {
+		t.Errorf("WriteTable() = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, summary); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"records_fetched": 3`) {
+		t.Errorf("WriteJSON() = %s", buf.String())
+	}
+}
@@ -3,6 +3,8 @@ package rfc4183
 import (
 	"fmt"
 	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 )
 
 var newmode bool
@@ -39,14 +41,22 @@ func NeedsWarning() {
 	warningNeeded = true
 }
 
-// PrintWarning prints a warning if a warning related to RFC2317 is needed.
-func PrintWarning() {
+// Warning returns the warning related to RFC2317, or "" if it is not
+// needed.
+func Warning() string {
 	if modeset {
 		// No warnings if REVCOMPAT() was used.
-		return
+		return ""
 	}
 	if !warningNeeded {
-		return
+		return ""
+	}
+	return "REV() breaking change coming in v5.0. See https://docs.dnscontrol.org/functions/REVCOMPAT"
+}
+
+// PrintWarning prints a warning if a warning related to RFC2317 is needed.
+func PrintWarning() {
+	if w := Warning(); w != "" {
+		printer.Warnf("%s\n", w)
 	}
-	fmt.Printf("WARNING: REV() breaking change coming in v5.0. See https://docs.dnscontrol.org/functions/REVCOMPAT\n")
 }
@@ -0,0 +1,126 @@
+// Package sarif writes the problems of the configuration in the Static
+// Analysis Results Interchange Format (SARIF) 2.1.0, which GitHub code
+// scanning and other tools display as annotations of the files.
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	version = "2.1.0"
+)
+
+// The levels of the findings.
+const (
+	Error   = "error"
+	Warning = "warning"
+)
+
+// Rule is a kind of finding.
+type Rule struct {
+	ID          string
+	Description string
+}
+
+// Finding is a problem of the configuration, in the file File at the line
+// Line. Line is 0 if the line is unknown.
+type Finding struct {
+	RuleID  string
+	Level   string
+	Message string
+	File    string
+	Line    int
+	Column  int
+}
+
+type log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool    tool     `json:"tool"`
+	Results []result `json:"results"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []rule `json:"rules,omitempty"`
+}
+
+type rule struct {
+	ID               string  `json:"id"`
+	ShortDescription message `json:"shortDescription"`
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   message    `json:"message"`
+	Locations []location `json:"locations,omitempty"`
+}
+
+type location struct {
+	PhysicalLocation physicalLocation `json:"physicalLocation"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+	Region           *region          `json:"region,omitempty"`
+}
+
+type artifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// Write writes the findings of the tool dnscontrol of this version, with
+// their rules.
+func Write(w io.Writer, toolVersion string, rules []Rule, findings []Finding) error {
+	d := driver{
+		Name:           "dnscontrol",
+		Version:        toolVersion,
+		InformationURI: "https://docs.dnscontrol.org/",
+	}
+	for _, r := range rules {
+		d.Rules = append(d.Rules, rule{ID: r.ID, ShortDescription: message{Text: r.Description}})
+	}
+	results := make([]result, 0, len(findings))
+	for _, f := range findings {
+		r := result{RuleID: f.RuleID, Level: f.Level, Message: message{Text: f.Message}}
+		if f.File != "" {
+			l := location{PhysicalLocation: physicalLocation{ArtifactLocation: artifactLocation{URI: f.File}}}
+			if f.Line > 0 {
+				l.PhysicalLocation.Region = &region{StartLine: f.Line, StartColumn: f.Column}
+			}
+			r.Locations = []location{l}
+		}
+		results = append(results, r)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log{
+		Schema:  schema,
+		Version: version,
+		Runs:    []run{{Tool: tool{Driver: d}, Results: results}},
+	})
+}
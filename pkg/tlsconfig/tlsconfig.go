@@ -0,0 +1,131 @@
+// Package tlsconfig builds the TLS configuration of the HTTP-based providers
+// from the keys of their creds.json entry:
+//
+//   - skip_tls_verify: "true" skips the verification of the certificate of
+//     the server.
+//   - cert: the PEM encoded CA certificates which verify the server.
+//   - tls_ca_file: a file of PEM encoded CA certificates (a CA bundle) which
+//     verify the server.
+//   - tls_client_cert, tls_client_key: the PEM files of the client
+//     certificate and its key (mutual TLS).
+//   - tls_min_version: the minimum version of TLS, "1.0" to "1.3".
+//   - tls_server_name: the name of the server, sent with SNI and verified in
+//     its certificate, if it is not the host of the URL.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/auditlog"
+	"github.com/StackExchange/dnscontrol/v4/pkg/pushstats"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tracing"
+)
+
+// defaultTransport is the default transport of HTTP before --audit-log
+// wraps it.
+var defaultTransport = http.DefaultTransport.(*http.Transport)
+
+var versions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Keys are the creds.json keys of the TLS options, except cert which some
+// providers use for something else.
+var Keys = []string{"skip_tls_verify", "tls_ca_file", "tls_client_cert", "tls_client_key", "tls_min_version", "tls_server_name"}
+
+// Option returns the first TLS option set in the creds.json keys m, or "" if
+// there is none.
+func Option(m map[string]string) string {
+	for _, k := range Keys {
+		if m[k] != "" {
+			return k
+		}
+	}
+	return ""
+}
+
+// New returns the TLS configuration of the creds.json keys m.
+func New(m map[string]string) (*tls.Config, error) {
+	config := &tls.Config{ServerName: m["tls_server_name"]}
+
+	if s := m["skip_tls_verify"]; s != "" {
+		skip, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid skip_tls_verify %q: %w", s, err)
+		}
+		config.InsecureSkipVerify = skip
+	}
+
+	if cert := m["cert"]; cert != "" {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM([]byte(cert)) {
+			return nil, fmt.Errorf("unable to parse the certificate of cert")
+		}
+	}
+	if file := m["tls_ca_file"]; file != "" {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read tls_ca_file: %w", err)
+		}
+		if config.RootCAs == nil {
+			config.RootCAs = x509.NewCertPool()
+		}
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in tls_ca_file (%s)", file)
+		}
+	}
+
+	cert, key := m["tls_client_cert"], m["tls_client_key"]
+	if (cert == "") != (key == "") {
+		return nil, fmt.Errorf("tls_client_cert and tls_client_key must be both set")
+	}
+	if cert != "" {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+
+	if v := m["tls_min_version"]; v != "" {
+		version, ok := versions[v]
+		if !ok {
+			return nil, fmt.Errorf("invalid tls_min_version %q, expected 1.0, 1.1, 1.2 or 1.3", v)
+		}
+		config.MinVersion = version
+	}
+
+	return config, nil
+}
+
+// NewHTTPClient returns an HTTP client with the TLS configuration of the
+// creds.json keys m. It uses the proxy of the environment, like the default
+// client.
+func NewHTTPClient(m map[string]string) (*http.Client, error) {
+	config, err := New(m)
+	if err != nil {
+		return nil, err
+	}
+	transport := defaultTransport.Clone()
+	transport.TLSClientConfig = config
+	return &http.Client{Transport: wrap(transport)}, nil
+}
+
+// NewSDKClient returns an HTTP client for the SDKs which don't use the
+// default transport of HTTP (AWS, Azure, Google Cloud), so that their
+// requests are in the audit log, the statistics and the traces too.
+func NewSDKClient() *http.Client {
+	return &http.Client{Transport: wrap(defaultTransport.Clone())}
+}
+
+func wrap(rt http.RoundTripper) http.RoundTripper {
+	return tracing.Wrap(auditlog.Wrap(pushstats.Wrap(rt)))
+}
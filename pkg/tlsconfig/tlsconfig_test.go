@@ -0,0 +1,81 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, ca, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		m    map[string]string
+		ok   bool
+	}{
+		{"system roots", map[string]string{}, false},
+		{"cert", map[string]string{"cert": string(ca)}, true},
+		{"tls_ca_file", map[string]string{"tls_ca_file": caFile, "tls_min_version": "1.2"}, true},
+		{"skip_tls_verify", map[string]string{"skip_tls_verify": "true"}, true},
+		// The certificate of httptest is valid for *.example.com.
+		{"tls_server_name", map[string]string{"cert": string(ca), "tls_server_name": "dns.example.com"}, true},
+		{"wrong tls_server_name", map[string]string{"cert": string(ca), "tls_server_name": "dns.example.net"}, false},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			client, err := NewHTTPClient(tst.m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Get(srv.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err == nil) != tst.ok {
+				t.Errorf("got error %v, want success %v", err, tst.ok)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	config, err := New(map[string]string{"tls_min_version": "1.3", "tls_server_name": "dns.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.MinVersion != tls.VersionTLS13 || config.ServerName != "dns.example.com" {
+		t.Errorf("got MinVersion %x and ServerName %q", config.MinVersion, config.ServerName)
+	}
+
+	for _, m := range []map[string]string{
+		{"skip_tls_verify": "maybe"},
+		{"cert": "not a certificate"},
+		{"tls_ca_file": "/nonexistent/ca.pem"},
+		{"tls_client_cert": "client.pem"},
+		{"tls_min_version": "1.4"},
+	} {
+		if _, err := New(m); err == nil {
+			t.Errorf("%v: expected an error, got none", m)
+		}
+	}
+}
+
+func TestOption(t *testing.T) {
+	if k := Option(map[string]string{"cert": "PEM", "apikey": "key"}); k != "" {
+		t.Errorf("Option() = %q, want none", k)
+	}
+	if k := Option(map[string]string{"apikey": "key", "tls_ca_file": "/etc/ssl/ca.pem"}); k != "tls_ca_file" {
+		t.Errorf("Option() = %q, want tls_ca_file", k)
+	}
+}
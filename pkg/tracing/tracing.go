@@ -0,0 +1,138 @@
+// Package tracing exports OpenTelemetry traces of the commands with OTLP:
+// the evaluation of the configuration, the corrections of each zone, the
+// diffs, each correction and the requests to the APIs of the providers.
+//
+// The commands run the zones sequentially, so the spans of the requests to
+// the APIs, which have no context, are the children of the current span,
+// set with SetCurrent.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans. It does nothing until Init.
+var tracer = otel.Tracer("github.com/StackExchange/dnscontrol")
+
+var (
+	mu      sync.Mutex
+	current = context.Background()
+)
+
+// Init exports the traces with OTLP over HTTP, configured by the environment
+// variables OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_HEADERS, etc.,
+// and creates the spans of the requests of the default transport of HTTP.
+// shutdown flushes the spans.
+func Init(ctx context.Context, version string) (shutdown func(context.Context) error, err error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("dnscontrol"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tracer = provider.Tracer("github.com/StackExchange/dnscontrol")
+	http.DefaultTransport = Wrap(http.DefaultTransport)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span, the child of the span of ctx. End it with End.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartCurrent starts a span, the child of the current span. End it with
+// End.
+func StartCurrent(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Start(Current(), name, attrs...)
+}
+
+// End ends the span, with the error if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Current returns the context of the current span.
+func Current() context.Context {
+	mu.Lock()
+	defer mu.Unlock()
+	return current
+}
+
+// SetCurrent sets the current span, the parent of the spans started
+// without a context, and returns the previous one to restore.
+func SetCurrent(ctx context.Context) context.Context {
+	mu.Lock()
+	defer mu.Unlock()
+	previous := current
+	current = ctx
+	return previous
+}
+
+// Wrap returns a transport which creates a span for each request of rt, the
+// child of the span of the request or else of the current span.
+func Wrap(rt http.RoundTripper) http.RoundTripper {
+	return parentTransport{otelhttp.NewTransport(rt)}
+}
+
+type parentTransport struct {
+	base http.RoundTripper
+}
+
+func (t parentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		req = req.WithContext(trace.ContextWithSpan(req.Context(), trace.SpanFromContext(Current())))
+	}
+	return t.base.RoundTrip(req)
+}
+
+// Run runs f in a span, the child of the current span, which is the
+// current span while f runs. Only the commands which run sequentially use
+// it.
+func Run(name string, f func() error, attrs ...attribute.KeyValue) error {
+	ctx, span := StartCurrent(name, attrs...)
+	previous := SetCurrent(ctx)
+	err := f()
+	SetCurrent(previous)
+	End(span, err)
+	return err
+}
+
+// Domain is the attribute of the name of the domain of a span.
+func Domain(name string) attribute.KeyValue {
+	return attribute.String("dnscontrol.domain", name)
+}
+
+// Provider is the attribute of the name of the provider of a span.
+func Provider(name string) attribute.KeyValue {
+	return attribute.String("dnscontrol.provider", name)
+}
+
+// Correction is the attribute of the message of the correction of a span.
+func Correction(msg string) attribute.KeyValue {
+	return attribute.String("dnscontrol.correction", msg)
+}
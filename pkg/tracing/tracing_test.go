@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRun(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	tracer = provider.Tracer("test")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	client := &http.Client{Transport: Wrap(http.DefaultTransport)}
+
+	err := Run("correction", func() error {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return errors.New("failed")
+	}, Domain("example.com"), Provider("bind"))
+	if err == nil || err.Error() != "failed" {
+		t.Fatalf("got error %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	request, correction := spans[0], spans[1]
+	if correction.Name != "correction" || correction.Status.Code != codes.Error {
+		t.Errorf("got span %q with status %v", correction.Name, correction.Status)
+	}
+	if request.Parent.SpanID() != correction.SpanContext.SpanID() {
+		t.Errorf("the span of the request %q is not the child of the correction", request.Name)
+	}
+	if trace.SpanContextFromContext(Current()).IsValid() {
+		t.Error("the current span was not restored")
+	}
+}
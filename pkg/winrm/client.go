@@ -0,0 +1,339 @@
+// Package winrm is a minimal WS-Management (WinRM) client that runs
+// PowerShell scripts on a remote Windows host.
+package winrm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	nsShell       = "http://schemas.microsoft.com/wbem/wsman/1/windows/shell"
+	resourceCmd   = nsShell + "/cmd"
+	actionCreate  = "http://schemas.xmlsoap.org/ws/2004/09/transfer/Create"
+	actionDelete  = "http://schemas.xmlsoap.org/ws/2004/09/transfer/Delete"
+	actionCommand = nsShell + "/Command"
+	actionReceive = nsShell + "/Receive"
+	actionSignal  = nsShell + "/Signal"
+	stateDone     = nsShell + "/CommandState/Done"
+	signalTerm    = nsShell + "/signal/terminate"
+)
+
+// Config describes how to connect to a WinRM endpoint.
+type Config struct {
+	// Endpoint is the URL of the WS-Management service, for example
+	// https://dns1.example.com:5986/wsman
+	Endpoint string
+	// Username is either "DOMAIN\user", "user@domain" or a local user.
+	Username string
+	Password string
+	// NTLM selects NTLMv2 authentication. Basic authentication is used otherwise.
+	NTLM bool
+	// TLSConfig is used for HTTPS endpoints.
+	TLSConfig *tls.Config
+	// Timeout bounds each HTTP request. The default is 60 seconds.
+	Timeout time.Duration
+}
+
+// Client runs commands through WinRM.
+type Client struct {
+	cfg  Config
+	http *http.Client
+}
+
+// New returns a client for the endpoint described by cfg.
+func New(cfg Config) *Client {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 60 * time.Second
+	}
+	return &Client{
+		cfg: cfg,
+		http: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: cfg.TLSConfig,
+				// NTLM authenticates a connection, not a request: the
+				// handshake must happen on a single connection.
+				MaxConnsPerHost:     1,
+				MaxIdleConnsPerHost: 1,
+			},
+		},
+	}
+}
+
+// RunPowerShell runs script on the remote host and returns its output.
+// Progress records that PowerShell sends to stderr are removed.
+func (c *Client) RunPowerShell(script string) (stdout, stderr string, exitCode int, err error) {
+	encoded := base64.StdEncoding.EncodeToString(utf16le(script))
+	shellID, err := c.createShell()
+	if err != nil {
+		return "", "", 0, err
+	}
+	defer c.deleteShell(shellID)
+
+	commandID, err := c.command(shellID, "powershell.exe", "-NoProfile", "-NonInteractive", "-EncodedCommand", encoded)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	var out, errOut bytes.Buffer
+	for {
+		done, code, err := c.receive(shellID, commandID, &out, &errOut)
+		if err != nil {
+			c.signal(shellID, commandID)
+			return "", "", 0, err
+		}
+		if done {
+			exitCode = code
+			break
+		}
+	}
+	return out.String(), cleanCLIXML(errOut.String()), exitCode, nil
+}
+
+func (c *Client) createShell() (string, error) {
+	body := `<rsp:Shell><rsp:InputStreams>stdin</rsp:InputStreams><rsp:OutputStreams>stdout stderr</rsp:OutputStreams></rsp:Shell>`
+	options := map[string]string{"WINRS_NOPROFILE": "TRUE", "WINRS_CODEPAGE": "65001"}
+	var resp struct {
+		Selectors []struct {
+			Name  string `xml:"Name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"Body>ResourceCreated>ReferenceParameters>SelectorSet>Selector"`
+	}
+	if err := c.call(actionCreate, "", options, body, &resp); err != nil {
+		return "", fmt.Errorf("winrm: failed creating shell: %w", err)
+	}
+	for _, s := range resp.Selectors {
+		if s.Name == "ShellId" {
+			return s.Value, nil
+		}
+	}
+	return "", errors.New("winrm: no ShellId in response")
+}
+
+func (c *Client) deleteShell(shellID string) {
+	_ = c.call(actionDelete, shellID, nil, "", nil)
+}
+
+func (c *Client) command(shellID, cmd string, args ...string) (string, error) {
+	var b strings.Builder
+	b.WriteString(`<rsp:CommandLine><rsp:Command>`)
+	xml.EscapeText(&b, []byte(cmd))
+	b.WriteString(`</rsp:Command>`)
+	for _, a := range args {
+		b.WriteString(`<rsp:Arguments>`)
+		xml.EscapeText(&b, []byte(a))
+		b.WriteString(`</rsp:Arguments>`)
+	}
+	b.WriteString(`</rsp:CommandLine>`)
+	options := map[string]string{"WINRS_CONSOLEMODE_STDIN": "TRUE", "WINRS_SKIP_CMD_SHELL": "TRUE"}
+	var resp struct {
+		CommandID string `xml:"Body>CommandResponse>CommandId"`
+	}
+	if err := c.call(actionCommand, shellID, options, b.String(), &resp); err != nil {
+		return "", fmt.Errorf("winrm: failed running command: %w", err)
+	}
+	return resp.CommandID, nil
+}
+
+// receive reads the output of a command. It reports when the command is done.
+func (c *Client) receive(shellID, commandID string, stdout, stderr io.Writer) (bool, int, error) {
+	body := fmt.Sprintf(`<rsp:Receive><rsp:DesiredStream CommandId="%s">stdout stderr</rsp:DesiredStream></rsp:Receive>`, commandID)
+	var resp struct {
+		Streams []struct {
+			Name string `xml:"Name,attr"`
+			Data string `xml:",chardata"`
+		} `xml:"Body>ReceiveResponse>Stream"`
+		State struct {
+			State    string `xml:"State,attr"`
+			ExitCode int    `xml:"ExitCode"`
+		} `xml:"Body>ReceiveResponse>CommandState"`
+	}
+	err := c.call(actionReceive, shellID, nil, body, &resp)
+	var f *fault
+	if errors.As(err, &f) && f.timedOut() {
+		// Nothing was written during the operation timeout. Try again.
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, fmt.Errorf("winrm: failed receiving output: %w", err)
+	}
+	for _, s := range resp.Streams {
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s.Data))
+		if err != nil {
+			return false, 0, fmt.Errorf("winrm: invalid %s stream: %w", s.Name, err)
+		}
+		if s.Name == "stderr" {
+			stderr.Write(data)
+		} else {
+			stdout.Write(data)
+		}
+	}
+	return resp.State.State == stateDone, resp.State.ExitCode, nil
+}
+
+func (c *Client) signal(shellID, commandID string) {
+	body := fmt.Sprintf(`<rsp:Signal CommandId="%s"><rsp:Code>%s</rsp:Code></rsp:Signal>`, commandID, signalTerm)
+	_ = c.call(actionSignal, shellID, nil, body, nil)
+}
+
+// fault is a SOAP fault returned by the server.
+type fault struct {
+	Subcode string `xml:"Code>Subcode>Value"`
+	Reason  string `xml:"Reason>Text"`
+}
+
+func (f *fault) Error() string {
+	return fmt.Sprintf("%s (%s)", strings.TrimSpace(f.Reason), f.Subcode)
+}
+
+func (f *fault) timedOut() bool {
+	return strings.HasSuffix(f.Subcode, ":TimedOut")
+}
+
+func messageID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("uuid:%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func (c *Client) envelope(action, shellID string, options map[string]string, body string) string {
+	var b strings.Builder
+	b.WriteString(`<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"` +
+		` xmlns:a="http://schemas.xmlsoap.org/ws/2004/08/addressing"` +
+		` xmlns:w="http://schemas.dmtf.org/wbem/wsman/1/wsman.xsd"` +
+		` xmlns:rsp="` + nsShell + `"><env:Header>`)
+	b.WriteString(`<a:To>`)
+	xml.EscapeText(&b, []byte(c.cfg.Endpoint))
+	b.WriteString(`</a:To>`)
+	b.WriteString(`<a:ReplyTo><a:Address env:mustUnderstand="true">http://schemas.xmlsoap.org/ws/2004/08/addressing/role/anonymous</a:Address></a:ReplyTo>`)
+	fmt.Fprintf(&b, `<a:Action env:mustUnderstand="true">%s</a:Action>`, action)
+	fmt.Fprintf(&b, `<a:MessageID>%s</a:MessageID>`, messageID())
+	fmt.Fprintf(&b, `<w:ResourceURI env:mustUnderstand="true">%s</w:ResourceURI>`, resourceCmd)
+	b.WriteString(`<w:MaxEnvelopeSize env:mustUnderstand="true">153600</w:MaxEnvelopeSize>`)
+	b.WriteString(`<w:Locale xml:lang="en-US" env:mustUnderstand="false"/>`)
+	// The operation timeout must be shorter than the HTTP timeout so
+	// that long-running commands are reported as w:TimedOut faults.
+	fmt.Fprintf(&b, `<w:OperationTimeout>PT%dS</w:OperationTimeout>`, int(c.cfg.Timeout.Seconds()*2/3))
+	if shellID != "" {
+		fmt.Fprintf(&b, `<w:SelectorSet><w:Selector Name="ShellId">%s</w:Selector></w:SelectorSet>`, shellID)
+	}
+	if len(options) > 0 {
+		b.WriteString(`<w:OptionSet>`)
+		for _, k := range []string{"WINRS_NOPROFILE", "WINRS_CODEPAGE", "WINRS_CONSOLEMODE_STDIN", "WINRS_SKIP_CMD_SHELL"} {
+			if v, ok := options[k]; ok {
+				fmt.Fprintf(&b, `<w:Option Name="%s">%s</w:Option>`, k, v)
+			}
+		}
+		b.WriteString(`</w:OptionSet>`)
+	}
+	b.WriteString(`</env:Header><env:Body>`)
+	b.WriteString(body)
+	b.WriteString(`</env:Body></env:Envelope>`)
+	return b.String()
+}
+
+// call sends a SOAP request and decodes the response envelope into target.
+func (c *Client) call(action, shellID string, options map[string]string, body string, target any) error {
+	payload := c.envelope(action, shellID, options, body)
+	resp, err := c.post(payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return errors.New("authentication failed")
+	case resp.StatusCode != http.StatusOK:
+		var env struct {
+			Fault *fault `xml:"Body>Fault"`
+		}
+		if xml.Unmarshal(data, &env) == nil && env.Fault != nil {
+			return env.Fault
+		}
+		return fmt.Errorf("unexpected HTTP status %s: %s", resp.Status, data)
+	}
+
+	if target == nil {
+		return nil
+	}
+	return xml.Unmarshal(data, target)
+}
+
+func (c *Client) newRequest(payload string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodPost, c.cfg.Endpoint, strings.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/soap+xml;charset=UTF-8")
+	return req, nil
+}
+
+// post sends payload, authenticating as configured.
+func (c *Client) post(payload string) (*http.Response, error) {
+	if !c.cfg.NTLM {
+		req, err := c.newRequest(payload)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+		return c.http.Do(req)
+	}
+
+	// NTLM handshake: NEGOTIATE, CHALLENGE, then AUTHENTICATE with the payload.
+	req, err := c.newRequest("")
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(negotiateMessage()))
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil, fmt.Errorf("unexpected HTTP status %s during NTLM negotiation", resp.Status)
+	}
+	var challenge []byte
+	for _, h := range resp.Header.Values("WWW-Authenticate") {
+		if v, ok := strings.CutPrefix(h, "Negotiate "); ok {
+			challenge, err = base64.StdEncoding.DecodeString(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("invalid NTLM challenge: %w", err)
+			}
+		}
+	}
+	if challenge == nil {
+		return nil, errors.New("server did not offer Negotiate authentication")
+	}
+
+	user, domain := splitUser(c.cfg.Username)
+	auth, err := authenticateMessage(challenge, user, c.cfg.Password, domain)
+	if err != nil {
+		return nil, err
+	}
+	req, err = c.newRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Negotiate "+base64.StdEncoding.EncodeToString(auth))
+	return c.http.Do(req)
+}
@@ -0,0 +1,42 @@
+package winrm
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const clixmlHeader = "#< CLIXML"
+
+var clixmlEscape = regexp.MustCompile(`_x([0-9A-Fa-f]{4})_`)
+
+// cleanCLIXML converts the serialized records PowerShell writes to stderr
+// when it isn't attached to a console into plain text. Only error
+// messages are kept; progress and other records are dropped.
+func cleanCLIXML(s string) string {
+	rest, ok := strings.CutPrefix(strings.TrimLeft(s, "\r\n"), clixmlHeader)
+	if !ok {
+		return s
+	}
+	var objs struct {
+		Strings []struct {
+			S    string `xml:"S,attr"`
+			Text string `xml:",chardata"`
+		} `xml:"S"`
+	}
+	if err := xml.Unmarshal([]byte(strings.TrimSpace(rest)), &objs); err != nil {
+		return s
+	}
+	var b strings.Builder
+	for _, str := range objs.Strings {
+		if str.S != "Error" {
+			continue
+		}
+		b.WriteString(clixmlEscape.ReplaceAllStringFunc(str.Text, func(m string) string {
+			r, _ := strconv.ParseUint(m[2:6], 16, 16)
+			return string(rune(r))
+		}))
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package winrm
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4"
+)
+
+// This is a minimal NTLMv2 client (MS-NLMP) for HTTP authentication. It
+// doesn't sign or seal messages, so it must only be used over HTTPS.
+
+const (
+	negotiateUnicode          = 0x00000001
+	requestTarget             = 0x00000004
+	negotiateNTLM             = 0x00000200
+	negotiateAlwaysSign       = 0x00008000
+	negotiateExtendedSecurity = 0x00080000
+	negotiateTargetInfo       = 0x00800000
+	negotiate128              = 0x20000000
+	negotiate56               = 0x80000000
+
+	negotiateFlags = negotiateUnicode | requestTarget | negotiateNTLM | negotiateAlwaysSign |
+		negotiateExtendedSecurity | negotiateTargetInfo | negotiate128 | negotiate56
+
+	avIDEOL       = 0
+	avIDTimestamp = 7
+)
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+func utf16le(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u))
+	for i, r := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], r)
+	}
+	return b
+}
+
+func hmacMD5(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(md5.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// ntowfv2 computes the NTLMv2 response key of a user.
+func ntowfv2(user, password, domain string) []byte {
+	h := md4.New()
+	h.Write(utf16le(password))
+	return hmacMD5(h.Sum(nil), utf16le(strings.ToUpper(user)+domain))
+}
+
+// negotiateMessage returns the NTLM NEGOTIATE_MESSAGE.
+func negotiateMessage() []byte {
+	b := make([]byte, 32)
+	copy(b, ntlmSignature)
+	binary.LittleEndian.PutUint32(b[8:], 1)
+	binary.LittleEndian.PutUint32(b[12:], negotiateFlags)
+	// The domain and workstation fields are empty.
+	binary.LittleEndian.PutUint32(b[20:], 32)
+	binary.LittleEndian.PutUint32(b[28:], 32)
+	return b
+}
+
+type challengeMessage struct {
+	flags           uint32
+	serverChallenge []byte
+	targetInfo      []byte
+}
+
+func parseChallenge(b []byte) (*challengeMessage, error) {
+	if len(b) < 48 || !bytes.Equal(b[:8], ntlmSignature) || binary.LittleEndian.Uint32(b[8:]) != 2 {
+		return nil, errors.New("invalid NTLM challenge message")
+	}
+	c := &challengeMessage{
+		flags:           binary.LittleEndian.Uint32(b[20:]),
+		serverChallenge: b[24:32],
+	}
+	l := int(binary.LittleEndian.Uint16(b[40:]))
+	off := int(binary.LittleEndian.Uint32(b[44:]))
+	if off+l > len(b) {
+		return nil, errors.New("invalid NTLM target info")
+	}
+	c.targetInfo = b[off : off+l]
+	return c, nil
+}
+
+// timestamp returns the MsvAvTimestamp of the target info, if any.
+func (c *challengeMessage) timestamp() []byte {
+	info := c.targetInfo
+	for len(info) >= 4 {
+		id := binary.LittleEndian.Uint16(info)
+		l := int(binary.LittleEndian.Uint16(info[2:]))
+		if id == avIDEOL || 4+l > len(info) {
+			break
+		}
+		if id == avIDTimestamp && l == 8 {
+			return info[4:12]
+		}
+		info = info[4+l:]
+	}
+	return nil
+}
+
+// ntlmv2Response computes the NTLMv2 and LMv2 responses.
+func ntlmv2Response(key, serverChallenge, clientChallenge, timestamp, targetInfo []byte) (nt, lm []byte) {
+	var temp bytes.Buffer
+	temp.Write([]byte{1, 1, 0, 0, 0, 0, 0, 0})
+	temp.Write(timestamp)
+	temp.Write(clientChallenge)
+	temp.Write([]byte{0, 0, 0, 0})
+	temp.Write(targetInfo)
+	temp.Write([]byte{0, 0, 0, 0})
+
+	proof := hmacMD5(key, serverChallenge, temp.Bytes())
+	nt = append(proof, temp.Bytes()...)
+	lm = append(hmacMD5(key, serverChallenge, clientChallenge), clientChallenge...)
+	return nt, lm
+}
+
+// fileTime converts t to a Windows FILETIME.
+func fileTime(t time.Time) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(t.UnixNano()/100)+116444736000000000)
+	return b
+}
+
+// authenticateMessage returns the NTLM AUTHENTICATE_MESSAGE answering challenge.
+func authenticateMessage(challenge []byte, user, password, domain string) ([]byte, error) {
+	c, err := parseChallenge(challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	clientChallenge := make([]byte, 8)
+	if _, err := rand.Read(clientChallenge); err != nil {
+		return nil, err
+	}
+	ts := c.timestamp()
+	if ts == nil {
+		ts = fileTime(time.Now())
+	}
+	nt, lm := ntlmv2Response(ntowfv2(user, password, domain), c.serverChallenge, clientChallenge, ts, c.targetInfo)
+
+	fields := [][]byte{lm, nt, utf16le(domain), utf16le(user), nil, nil}
+	const headerLen = 64
+	b := make([]byte, headerLen)
+	copy(b, ntlmSignature)
+	binary.LittleEndian.PutUint32(b[8:], 3)
+	offset := headerLen
+	for i, f := range fields {
+		pos := 12 + 8*i
+		binary.LittleEndian.PutUint16(b[pos:], uint16(len(f)))
+		binary.LittleEndian.PutUint16(b[pos+2:], uint16(len(f)))
+		binary.LittleEndian.PutUint32(b[pos+4:], uint32(offset))
+		offset += len(f)
+	}
+	binary.LittleEndian.PutUint32(b[60:], c.flags&negotiateFlags)
+	for _, f := range fields {
+		b = append(b, f...)
+	}
+	return b, nil
+}
+
+// splitUser splits "DOMAIN\user" into its parts. User principal names
+// ("user@domain") are passed as is with an empty domain.
+func splitUser(s string) (user, domain string) {
+	if d, u, ok := strings.Cut(s, `\`); ok {
+		return u, d
+	}
+	return s, ""
+}
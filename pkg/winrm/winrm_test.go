@@ -0,0 +1,41 @@
+package winrm
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Test vectors from MS-NLMP section 4.2.4.
+func TestNTLMv2(t *testing.T) {
+	key := ntowfv2("User", "Password", "Domain")
+	if got, want := hex.EncodeToString(key), "0c868a403bfd7a93a3001ef22ef02e3f"; got != want {
+		t.Errorf("ntowfv2() = %s, want %s", got, want)
+	}
+
+	serverChallenge, _ := hex.DecodeString("0123456789abcdef")
+	clientChallenge, _ := hex.DecodeString("aaaaaaaaaaaaaaaa")
+	timestamp := make([]byte, 8)
+	targetInfo, _ := hex.DecodeString("02000c0044006f006d00610069006e0001000c0053006500720076006500720000000000")
+
+	nt, lm := ntlmv2Response(key, serverChallenge, clientChallenge, timestamp, targetInfo)
+	if got, want := hex.EncodeToString(nt[:16]), "68cd0ab851e51c96aabc927bebef6a1c"; got != want {
+		t.Errorf("NTProofStr = %s, want %s", got, want)
+	}
+	if got, want := hex.EncodeToString(lm), "86c35097ac9cec102554764a57cccc19aaaaaaaaaaaaaaaa"; got != want {
+		t.Errorf("LMv2 response = %s, want %s", got, want)
+	}
+}
+
+func TestCleanCLIXML(t *testing.T) {
+	progress := "#< CLIXML\r\n<Objs Version=\"1.1.0.1\" xmlns=\"http://schemas.microsoft.com/powershell/2004/04\"><Obj S=\"progress\" RefId=\"0\"><TN RefId=\"0\"><T>System.Management.Automation.PSCustomObject</T></TN><MS><I64 N=\"SourceId\">1</I64></MS></Obj></Objs>"
+	if got := cleanCLIXML(progress); got != "" {
+		t.Errorf("cleanCLIXML(progress) = %q, want empty", got)
+	}
+	errs := "#< CLIXML\r\n<Objs Version=\"1.1.0.1\" xmlns=\"http://schemas.microsoft.com/powershell/2004/04\"><S S=\"Error\">Zone not found_x000D__x000A_</S></Objs>"
+	if got, want := cleanCLIXML(errs), "Zone not found\r\n"; got != want {
+		t.Errorf("cleanCLIXML(error) = %q, want %q", got, want)
+	}
+	if got := cleanCLIXML("plain"); got != "plain" {
+		t.Errorf("cleanCLIXML(plain) = %q", got)
+	}
+}
@@ -4,32 +4,46 @@ package all
 import (
 	// Define all known providers here. They should each register themselves with the providers package via init function.
 	_ "github.com/StackExchange/dnscontrol/v4/providers/akamaiedgedns"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/alidns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/autodns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/axfrddns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/azuredns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/azureprivatedns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/bind"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/bluecat"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/bunnydns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/cloudflare"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/cloudns"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/constellix"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/coredns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/cscglobal"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/desec"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/digitalocean"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/dnsimple"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/dnsmadeeasy"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/dnsmasq"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/doh"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/domainnameshop"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/dynadot"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/easyname"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/efficientip"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/enom"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/etcd"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/eurodns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/exoscale"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/externaldns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/gandiv5"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/gcloud"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/gcore"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/gransy"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/hedns"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/hetzner"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/hexonet"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/hostingde"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/hover"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/huaweicloud"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/infoblox"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/infomaniak"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/internetbs"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/inwx"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/linode"
@@ -39,20 +53,31 @@ import (
 	_ "github.com/StackExchange/dnscontrol/v4/providers/mythicbeasts"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/namecheap"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/namedotcom"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/namesilo"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/netcup"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/netlify"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/njalla"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/ns1"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/nsd"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/opensrs"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/oracle"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/ovh"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/packetframe"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/porkbun"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/powerdns"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/rcodezero"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/realtimeregister"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/route53"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/rwth"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/sakuracloud"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/softlayer"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/spaceship"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/tencentcloud"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/transip"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/ultradns"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/unbound"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/vercel"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/vultr"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/yandexcloud"
+	_ "github.com/StackExchange/dnscontrol/v4/providers/zonomi"
 )
@@ -18,6 +18,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v4/providers"
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
 )
 
 var features = providers.DocumentationNotes{
@@ -46,6 +47,7 @@ var features = providers.DocumentationNotes{
 type edgeDNSProvider struct {
 	contractID string
 	groupID    string
+	tsigKeys   map[string]*dnsv2.TSIGKey // The TSIG keys of creds.json by name.
 }
 
 func init() {
@@ -88,11 +90,17 @@ func newEdgeDNSDSP(config map[string]string, metadata json.RawMessage) (provider
 		return nil, fmt.Errorf("creds.json: groupID must not be empty")
 	}
 
+	tsigKeys, err := parseTSIGKeys(config["tsig_keys"])
+	if err != nil {
+		return nil, err
+	}
+
 	initialize(clientSecret, host, accessToken, clientToken)
 
 	api := &edgeDNSProvider{
 		contractID: contractID,
 		groupID:    groupID,
+		tsigKeys:   tsigKeys,
 	}
 	return api, nil
 }
@@ -103,11 +111,28 @@ func (a *edgeDNSProvider) EnsureZoneExists(domain string) error {
 		printer.Debugf("Zone %s already exists\n", domain)
 		return nil
 	}
-	return createZone(domain, a.contractID, a.groupID)
+	return createZone(domain, a.contractID, a.groupID, &zoneSettings{Type: primaryZone})
 }
 
 // GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
 func (a *edgeDNSProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	settings, err := a.desiredZoneSettings(dc.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	settingsCorrections, err := a.getZoneSettingsCorrections(dc, settings)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Type == secondaryZone {
+		// The records of a SECONDARY zone are transferred from its masters.
+		autoDNSSecCorrections, err := getAutoDNSSecCorrections(dc)
+		if err != nil {
+			return nil, err
+		}
+		return append(settingsCorrections, autoDNSSecCorrections...), nil
+	}
+
 	keysToUpdate, toReport, err := diff.NewCompat(dc).ChangedGroups(existingRecords)
 	if err != nil {
 		return nil, err
@@ -177,6 +202,22 @@ func (a *edgeDNSProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, exi
 	// Deletes first, then creates and replaces
 	corrections = append(corrections, lastCorrections...)
 
+	autoDNSSecCorrections, err := getAutoDNSSecCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	corrections = append(corrections, autoDNSSecCorrections...)
+
+	// Transfer settings correction
+	corrections = append(corrections, settingsCorrections...)
+
+	return corrections, nil
+}
+
+// getAutoDNSSecCorrections returns the correction enabling or disabling AutoDNSSEC.
+func getAutoDNSSecCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	var corrections []*models.Correction
+
 	// AutoDnsSec correction
 	existingAutoDNSSecEnabled, err := isAutoDNSSecEnabled(dc.Name)
 	if err != nil {
@@ -210,7 +251,7 @@ func (a *edgeDNSProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, exi
 
 // GetNameservers returns the nameservers for a domain.
 func (a *edgeDNSProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
-	authorities, err := getAuthorities(a.contractID)
+	authorities, err := getAuthorities(zoneContract(domain, a.contractID))
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,7 @@ https://github.com/akamai/AkamaiOPEN-edgegrid-golang
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
@@ -40,13 +41,16 @@ func zoneDoesExist(zonename string) bool {
 // createZone create a new zone and creates SOA and NS records for the zone.
 // Akamai assigns a unique set of authoritative nameservers for each contract. These authorities should be
 // used as the NS records on all zones belonging to this contract.
-func createZone(zonename string, contractID string, groupID string) error {
+// A SECONDARY zone gets its records from its masters.
+func createZone(zonename string, contractID string, groupID string, settings *zoneSettings) error {
 	zone := &dnsv2.ZoneCreate{
 		Zone:                  zonename,
-		Type:                  "PRIMARY",
+		Type:                  settings.Type,
+		Masters:               settings.Masters,
 		Comment:               "This zone created by DNSControl (http://dnscontrol.org)",
 		SignAndServe:          false,
 		SignAndServeAlgorithm: "RSA_SHA512",
+		TsigKey:               settings.TsigKey,
 		ContractId:            contractID,
 	}
 
@@ -66,17 +70,25 @@ func createZone(zonename string, contractID string, groupID string) error {
 	}
 
 	// Indirectly create NS and SOA records
-	err = zone.SaveChangelist()
-	if err != nil {
-		return fmt.Errorf("zone initialization failed. SOA and NS records need to be created")
-	}
-	err = zone.SubmitChangelist()
-	if err != nil {
-		return fmt.Errorf("zone create failed. error: %s", err.Error())
+	if zone.Type == primaryZone {
+		err = zone.SaveChangelist()
+		if err != nil {
+			return fmt.Errorf("zone initialization failed. SOA and NS records need to be created")
+		}
+		err = zone.SubmitChangelist()
+		if err != nil {
+			return fmt.Errorf("zone create failed. error: %s", err.Error())
+		}
 	}
 
 	printer.Printf("Created zone: %s\n", zone.Zone)
 	printer.Printf("  Type: %s\n", zone.Type)
+	if len(zone.Masters) != 0 {
+		printer.Printf("  Masters: %s\n", strings.Join(zone.Masters, ", "))
+	}
+	if zone.TsigKey != nil {
+		printer.Printf("  TsigKey: %s\n", zone.TsigKey.Name)
+	}
 	printer.Printf("  Comment: %s\n", zone.Comment)
 	printer.Printf("  SignAndServe: %v\n", zone.SignAndServe)
 	printer.Printf("  SignAndServeAlgorithm: %s\n", zone.SignAndServeAlgorithm)
@@ -162,6 +174,16 @@ func autoDNSSecEnable(enable bool, zonename string) error {
 	return nil
 }
 
+// zoneContract returns the contract of the zone, or contractID if the
+// zone does not exist.
+func zoneContract(zonename string, contractID string) string {
+	zone, err := dnsv2.GetZone(zonename)
+	if err != nil || zone.ContractId == "" {
+		return contractID
+	}
+	return zone.ContractId
+}
+
 // getAuthorities returns the list of authoritative nameservers for the contract.
 // Akamai assigns a unique set of authoritative nameservers for each contract. These authorities should be
 // used as the NS records on all zones belonging to this contract.
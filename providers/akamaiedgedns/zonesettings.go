@@ -0,0 +1,222 @@
+package akamaiedgedns
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/fatih/color"
+)
+
+// The settings of the zone set in the domain metadata.
+const (
+	metaContractID = "akamai_contract_id" // The contract of a new zone, instead of contract_id of creds.json.
+	metaGroupID    = "akamai_group_id"    // The group of a new zone, instead of group_id of creds.json.
+	metaZoneType   = "akamai_zone_type"   // PRIMARY (the default) or SECONDARY.
+	metaMasters    = "akamai_masters"     // The comma-separated IP addresses of the masters of a SECONDARY zone.
+	metaTSIGKey    = "akamai_tsig_key"    // The name of the TSIG key (of tsig_keys of creds.json) of the zone transfers.
+)
+
+const (
+	primaryZone   = "PRIMARY"
+	secondaryZone = "SECONDARY"
+)
+
+// zoneSettings are the transfer settings of a zone. Type is empty, and
+// Masters and TsigKey are nil, when they aren't set in the domain metadata.
+type zoneSettings struct {
+	Type    string
+	Masters []string
+	TsigKey *dnsv2.TSIGKey
+	// HasTsigKey is true when the TSIG key is set in the domain metadata,
+	// TsigKey is nil to remove the key of the zone.
+	HasTsigKey bool
+}
+
+// parseTSIGKeys parses tsig_keys of creds.json, a comma-separated list of
+// name:algorithm:secret.
+func parseTSIGKeys(s string) (map[string]*dnsv2.TSIGKey, error) {
+	keys := map[string]*dnsv2.TSIGKey{}
+	for i, k := range strings.Split(s, ",") {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+		parts := strings.Split(k, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			// Don't print the key, which may contain the secret.
+			return nil, fmt.Errorf("creds.json: tsig_keys: key #%d must be name:algorithm:secret", i+1)
+		}
+		keys[parts[0]] = &dnsv2.TSIGKey{Name: parts[0], Algorithm: parts[1], Secret: parts[2]}
+	}
+	return keys, nil
+}
+
+// desiredZoneSettings returns the settings of the zone in the domain metadata.
+func (a *edgeDNSProvider) desiredZoneSettings(metadata map[string]string) (*zoneSettings, error) {
+	settings := &zoneSettings{}
+	if v := strings.ToUpper(metadata[metaZoneType]); v != "" {
+		if v != primaryZone && v != secondaryZone {
+			return nil, fmt.Errorf("%s (%s) must be %s or %s", metaZoneType, v, primaryZone, secondaryZone)
+		}
+		settings.Type = v
+	}
+
+	if v, ok := metadata[metaMasters]; ok {
+		if settings.Type != secondaryZone {
+			return nil, fmt.Errorf("%s is only for %s zones", metaMasters, secondaryZone)
+		}
+		masters := []string{}
+		for _, ip := range strings.Split(v, ",") {
+			ip = strings.TrimSpace(ip)
+			if ip == "" {
+				continue
+			}
+			if net.ParseIP(ip) == nil {
+				return nil, fmt.Errorf("%s: %q is not an IP address", metaMasters, ip)
+			}
+			masters = append(masters, ip)
+		}
+		sort.Strings(masters)
+		settings.Masters = masters
+	}
+	if settings.Type == secondaryZone && len(settings.Masters) == 0 {
+		return nil, fmt.Errorf("a %s zone needs %s", secondaryZone, metaMasters)
+	}
+
+	if name, ok := metadata[metaTSIGKey]; ok {
+		settings.HasTsigKey = true
+		if name != "" {
+			key, ok := a.tsigKeys[name]
+			if !ok {
+				return nil, fmt.Errorf("%s: the TSIG key %q is not in tsig_keys of creds.json", metaTSIGKey, name)
+			}
+			settings.TsigKey = key
+		}
+	}
+	return settings, nil
+}
+
+// zoneSettingsChanges returns the names of the settings of the zone which
+// differ from the desired ones. The secrets of the TSIG keys are compared
+// only if Akamai returns them.
+func zoneSettingsChanges(existing *dnsv2.ZoneResponse, desired *zoneSettings) ([]string, error) {
+	if desired.Type != "" && !strings.EqualFold(existing.Type, desired.Type) {
+		return nil, fmt.Errorf("zone %s is a %s zone, Edge DNS can't change it to a %s zone", existing.Zone, existing.Type, desired.Type)
+	}
+
+	var changes []string
+	if desired.Masters != nil {
+		current := slices.Clone(existing.Masters)
+		sort.Strings(current)
+		if !slices.Equal(current, desired.Masters) {
+			changes = append(changes, fmt.Sprintf("masters (%s -> %s)", strings.Join(current, ","), strings.Join(desired.Masters, ",")))
+		}
+	}
+
+	if desired.HasTsigKey {
+		var current, wanted string
+		if existing.TsigKey != nil {
+			current = existing.TsigKey.Name + " " + existing.TsigKey.Algorithm
+		}
+		if desired.TsigKey != nil {
+			wanted = desired.TsigKey.Name + " " + desired.TsigKey.Algorithm
+		}
+		secretChanged := existing.TsigKey != nil && desired.TsigKey != nil &&
+			existing.TsigKey.Secret != "" && existing.TsigKey.Secret != desired.TsigKey.Secret
+		if current != wanted {
+			changes = append(changes, fmt.Sprintf("tsig_key (%s -> %s)", current, wanted))
+		} else if secretChanged {
+			changes = append(changes, fmt.Sprintf("tsig_key (secret of %s)", desired.TsigKey.Name))
+		}
+	}
+	return changes, nil
+}
+
+// getZoneSettingsCorrections returns the correction of the transfer
+// settings of the zone, if they differ from the domain metadata.
+func (a *edgeDNSProvider) getZoneSettingsCorrections(dc *models.DomainConfig, desired *zoneSettings) ([]*models.Correction, error) {
+	if desired.Type == "" && desired.Masters == nil && !desired.HasTsigKey {
+		return nil, nil
+	}
+	zone, err := dnsv2.GetZone(dc.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving information for zone %s. error: %s", dc.Name, err.Error())
+	}
+	changes, err := zoneSettingsChanges(zone, desired)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return []*models.Correction{{
+		Msg: color.YellowString("± MODIFY %s transfer settings: %s", dc.Name, strings.Join(changes, ", ")),
+		F: func() error {
+			return updateZoneSettings(zone, desired)
+		},
+	}}, nil
+}
+
+// updateZoneSettings updates the masters and the TSIG key of the zone.
+func updateZoneSettings(zone *dnsv2.ZoneResponse, desired *zoneSettings) error {
+	if desired.Masters != nil {
+		modifiedzone := &dnsv2.ZoneCreate{
+			Zone:                  zone.Zone,
+			Type:                  zone.Type,
+			Masters:               desired.Masters,
+			Comment:               zone.Comment,
+			SignAndServe:          zone.SignAndServe,
+			SignAndServeAlgorithm: zone.SignAndServeAlgorithm,
+			TsigKey:               zone.TsigKey,
+			EndCustomerId:         zone.EndCustomerId,
+			ContractId:            zone.ContractId,
+		}
+		if err := modifiedzone.Update(dnsv2.ZoneQueryString{}); err != nil {
+			return fmt.Errorf("error updating zone %s. error: %s", zone.Zone, err.Error())
+		}
+	}
+
+	if desired.HasTsigKey {
+		if desired.TsigKey == nil {
+			if zone.TsigKey == nil {
+				return nil
+			}
+			if err := dnsv2.DeleteZoneKey(zone.Zone); err != nil {
+				return fmt.Errorf("error deleting the TSIG key of zone %s. error: %s", zone.Zone, err.Error())
+			}
+			return nil
+		}
+		if err := desired.TsigKey.Update(zone.Zone); err != nil {
+			return fmt.Errorf("error updating the TSIG key of zone %s. error: %s", zone.Zone, err.Error())
+		}
+	}
+	return nil
+}
+
+// EnsureZoneExistsWithMetadata creates a zone if it does not exist, in the
+// contract and the group and with the transfer settings of the domain
+// metadata.
+func (a *edgeDNSProvider) EnsureZoneExistsWithMetadata(domain string, metadata map[string]string) error {
+	if zoneDoesExist(domain) {
+		printer.Debugf("Zone %s already exists\n", domain)
+		return nil
+	}
+	settings, err := a.desiredZoneSettings(metadata)
+	if err != nil {
+		return err
+	}
+	contractID, groupID := a.contractID, a.groupID
+	if v := metadata[metaContractID]; v != "" {
+		contractID = v
+	}
+	if v := metadata[metaGroupID]; v != "" {
+		groupID = v
+	}
+	if settings.Type == "" {
+		settings.Type = primaryZone
+	}
+	return createZone(domain, contractID, groupID, settings)
+}
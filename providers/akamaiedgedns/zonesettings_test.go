@@ -0,0 +1,94 @@
+package akamaiedgedns
+
+import (
+	"reflect"
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+)
+
+func TestZoneSettingsChanges(t *testing.T) {
+	keys, err := parseTSIGKeys("xfr:hmac-sha256:c2VjcmV0, other:hmac-sha512:b3RoZXI=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &edgeDNSProvider{tsigKeys: keys}
+
+	existing := &dnsv2.ZoneResponse{
+		Zone:    "example.com",
+		Type:    "SECONDARY",
+		Masters: []string{"192.0.2.2", "192.0.2.1"},
+		TsigKey: &dnsv2.TSIGKey{Name: "xfr", Algorithm: "hmac-sha256"},
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "nothing set",
+		},
+		{
+			name:     "same masters in another order",
+			metadata: map[string]string{metaZoneType: "secondary", metaMasters: "192.0.2.1, 192.0.2.2", metaTSIGKey: "xfr"},
+		},
+		{
+			name:     "new masters",
+			metadata: map[string]string{metaZoneType: "SECONDARY", metaMasters: "192.0.2.1,192.0.2.3"},
+			want:     []string{"masters (192.0.2.1,192.0.2.2 -> 192.0.2.1,192.0.2.3)"},
+		},
+		{
+			name:     "other key",
+			metadata: map[string]string{metaTSIGKey: "other"},
+			want:     []string{"tsig_key (xfr hmac-sha256 -> other hmac-sha512)"},
+		},
+		{
+			name:     "no key",
+			metadata: map[string]string{metaTSIGKey: ""},
+			want:     []string{"tsig_key (xfr hmac-sha256 -> )"},
+		},
+		{
+			name:     "unknown key",
+			metadata: map[string]string{metaTSIGKey: "missing"},
+			wantErr:  true,
+		},
+		{
+			name:     "masters of a primary zone",
+			metadata: map[string]string{metaMasters: "192.0.2.1"},
+			wantErr:  true,
+		},
+		{
+			name:     "secondary zone without masters",
+			metadata: map[string]string{metaZoneType: "SECONDARY"},
+			wantErr:  true,
+		},
+		{
+			name:     "change of type",
+			metadata: map[string]string{metaZoneType: "PRIMARY"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired, err := a.desiredZoneSettings(tt.metadata)
+			var got []string
+			if err == nil {
+				got, err = zoneSettingsChanges(existing, desired)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTSIGKeysInvalid(t *testing.T) {
+	if _, err := parseTSIGKeys("xfr:c2VjcmV0"); err == nil {
+		t.Error("expected an error for a key without algorithm")
+	}
+}
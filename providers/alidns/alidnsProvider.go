@@ -0,0 +1,215 @@
+package alidns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Alibaba Cloud DNS (AliDNS).
+// API Documentation: https://www.alibabacloud.com/help/en/dns/api-alidns-2015-01-09-overview
+
+/*
+AliDNS API DNS provider:
+
+Info required in `creds.json`:
+   - access_key_id
+   - access_key_secret
+   - endpoint (optional, default "https://alidns.aliyuncs.com/")
+
+Record level metadata available:
+   - alidns_line (resolution line, default "default")
+     (https://www.alibabacloud.com/help/en/dns/resolve-line-enumeration)
+
+*/
+
+const (
+	metaLine    = "alidns_line"
+	defaultLine = "default"
+
+	// The free edition requires a TTL of at least 600 seconds. Paid editions
+	// lower the limit; the actual value is read from the API per domain.
+	defaultMinTTL = 600
+)
+
+var defaultNS = []string{
+	"dns1.hichina.com",
+	"dns2.hichina.com",
+}
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Unimplemented("DNSSEC is only available on paid editions and not exposed by the API."),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "ALIDNS"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newAlidns,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newAlidns creates the provider.
+func newAlidns(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &alidnsProvider{
+		accessKeyID:     m["access_key_id"],
+		accessKeySecret: m["access_key_secret"],
+		endpoint:        m["endpoint"],
+		domainInfo:      map[string]*domainInfo{},
+	}
+
+	if c.accessKeyID == "" || c.accessKeySecret == "" {
+		return nil, fmt.Errorf("missing ALIDNS access_key_id or access_key_secret")
+	}
+	if c.endpoint == "" {
+		c.endpoint = defaultEndpoint
+	}
+	if !strings.HasSuffix(c.endpoint, "/") {
+		c.endpoint += "/"
+	}
+
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *alidnsProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	info, err := c.getDomainInfo(domain)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.DNSServers.DNSServer) != 0 {
+		return models.ToNameserversStripTD(info.DNSServers.DNSServer)
+	}
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *alidnsProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		// The apex NS records are managed by AliDNS and can't be modified.
+		if records[i].Type == "NS" && records[i].RR == "@" {
+			continue
+		}
+		rc, err := toRc(domain, &records[i])
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+func genComparable(rec *models.RecordConfig) string {
+	line := rec.Metadata[metaLine]
+	if line == "" {
+		line = defaultLine
+	}
+	return "line=" + line
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *alidnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	info, err := c.getDomainInfo(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	minTTL := info.MinTTL
+	if minTTL == 0 {
+		minTTL = defaultMinTTL
+	}
+
+	for _, rec := range dc.Records {
+		if rec.TTL < minTTL {
+			printer.Warnf("%s %s: TTL %d is below the minimum of the Alibaba Cloud DNS edition, using %d\n", rec.GetLabelFQDN(), rec.Type, rec.TTL, minTTL)
+			rec.TTL = minTTL
+		}
+		if rec.Metadata == nil {
+			rec.Metadata = make(map[string]string)
+		}
+		if rec.Metadata[metaLine] == "" {
+			rec.Metadata[metaLine] = defaultLine
+		}
+	}
+
+	changes, err := diff2.ByRecord(existingRecords, dc, genComparable)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			req, err := toReq(change.New[0])
+			if err != nil {
+				return nil, err
+			}
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(dc.Name, req)
+				},
+			}
+		case diff2.CHANGE:
+			id := change.Old[0].Original.(*domainRecord).RecordID
+			req, err := toReq(change.New[0])
+			if err != nil {
+				return nil, err
+			}
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, alidns ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.modifyRecord(id, req)
+				},
+			}
+		case diff2.DELETE:
+			id := change.Old[0].Original.(*domainRecord).RecordID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, alidns ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.deleteRecord(id)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
@@ -0,0 +1,274 @@
+package alidns
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	defaultEndpoint = "https://alidns.aliyuncs.com/"
+	apiVersion      = "2015-01-09"
+
+	// Maximum page sizes accepted by the API.
+	recordsPageSize = 500
+	domainsPageSize = 100
+)
+
+type alidnsProvider struct {
+	accessKeyID     string
+	accessKeySecret string
+	endpoint        string
+
+	domainInfo map[string]*domainInfo
+}
+
+type requestParams map[string]string
+
+type errorResponse struct {
+	RequestID string `json:"RequestId"`
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+}
+
+type domainRecord struct {
+	RecordID string `json:"RecordId"`
+	RR       string `json:"RR"`
+	Type     string `json:"Type"`
+	Value    string `json:"Value"`
+	TTL      uint32 `json:"TTL"`
+	Priority uint16 `json:"Priority"`
+	Line     string `json:"Line"`
+	Status   string `json:"Status"`
+	Locked   bool   `json:"Locked"`
+}
+
+type describeDomainRecordsResponse struct {
+	TotalCount    int `json:"TotalCount"`
+	PageNumber    int `json:"PageNumber"`
+	PageSize      int `json:"PageSize"`
+	DomainRecords struct {
+		Record []domainRecord `json:"Record"`
+	} `json:"DomainRecords"`
+}
+
+type dnsServers struct {
+	DNSServer []string `json:"DnsServer"`
+}
+
+type domainInfo struct {
+	DomainName  string     `json:"DomainName"`
+	DomainID    string     `json:"DomainId"`
+	DNSServers  dnsServers `json:"DnsServers"`
+	MinTTL      uint32     `json:"MinTtl"`
+	VersionCode string     `json:"VersionCode"`
+	VersionName string     `json:"VersionName"`
+}
+
+type describeDomainsResponse struct {
+	TotalCount int `json:"TotalCount"`
+	PageNumber int `json:"PageNumber"`
+	PageSize   int `json:"PageSize"`
+	Domains    struct {
+		Domain []domainInfo `json:"Domain"`
+	} `json:"Domains"`
+}
+
+type recordIDResponse struct {
+	RecordID string `json:"RecordId"`
+}
+
+// percentEncode implements the URL encoding required by the Alibaba
+// Cloud RPC signature algorithm (RFC 3986 unreserved characters only).
+func percentEncode(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	s = strings.ReplaceAll(s, "%7E", "~")
+	return s
+}
+
+// canonicalQuery returns the sorted, encoded query string the signature is computed over.
+func canonicalQuery(params requestParams) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, percentEncode(k)+"="+percentEncode(params[k]))
+	}
+	return strings.Join(parts, "&")
+}
+
+// sign computes the HMAC-SHA1 signature (signature version 1.0) of a request.
+func sign(method, query, secret string) string {
+	stringToSign := method + "&" + percentEncode("/") + "&" + percentEncode(query)
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func nonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// call invokes an API action and decodes the JSON response into target.
+func (c *alidnsProvider) call(action string, params requestParams, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+retry:
+	query := requestParams{
+		"Action":           action,
+		"Format":           "JSON",
+		"Version":          apiVersion,
+		"AccessKeyId":      c.accessKeyID,
+		"SignatureMethod":  "HMAC-SHA1",
+		"SignatureVersion": "1.0",
+		"SignatureNonce":   nonce(),
+		"Timestamp":        time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	for k, v := range params {
+		query[k] = v
+	}
+	q := canonicalQuery(query)
+	signature := sign(http.MethodGet, q, c.accessKeySecret)
+	reqURL := c.endpoint + "?" + q + "&Signature=" + percentEncode(signature)
+
+	resp, err := http.Get(reqURL)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("alidns API error: %s: %s", resp.Status, string(body))
+		}
+		if strings.HasPrefix(errResp.Code, "Throttling") && retrycnt < maxRetries {
+			retrycnt++
+			printer.Printf("AliDNS rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+			time.Sleep(time.Duration(retrycnt) * time.Second)
+			goto retry
+		}
+		return fmt.Errorf("alidns API error: %s: %s (RequestId: %s)", errResp.Code, errResp.Message, errResp.RequestID)
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(body, target)
+}
+
+func (c *alidnsProvider) getRecords(domain string) ([]domainRecord, error) {
+	var records []domainRecord
+	page := 1
+	for {
+		var resp describeDomainRecordsResponse
+		err := c.call("DescribeDomainRecords", requestParams{
+			"DomainName": domain,
+			"PageNumber": strconv.Itoa(page),
+			"PageSize":   strconv.Itoa(recordsPageSize),
+		}, &resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching record list from alidns: %w", err)
+		}
+		records = append(records, resp.DomainRecords.Record...)
+		if len(records) >= resp.TotalCount || len(resp.DomainRecords.Record) == 0 {
+			break
+		}
+		page++
+	}
+	return records, nil
+}
+
+func (c *alidnsProvider) createRecord(domain string, params requestParams) error {
+	params["DomainName"] = domain
+	if err := c.call("AddDomainRecord", params, &recordIDResponse{}); err != nil {
+		return fmt.Errorf("failed create record (alidns): %w", err)
+	}
+	return nil
+}
+
+func (c *alidnsProvider) modifyRecord(recordID string, params requestParams) error {
+	params["RecordId"] = recordID
+	if err := c.call("UpdateDomainRecord", params, &recordIDResponse{}); err != nil {
+		return fmt.Errorf("failed update record (alidns): %w", err)
+	}
+	return nil
+}
+
+func (c *alidnsProvider) deleteRecord(recordID string) error {
+	if err := c.call("DeleteDomainRecord", requestParams{"RecordId": recordID}, &recordIDResponse{}); err != nil {
+		return fmt.Errorf("failed delete record (alidns): %w", err)
+	}
+	return nil
+}
+
+// getDomainInfo returns the per-domain information, which includes the
+// minimum TTL allowed by the plan (edition) the domain is enrolled in.
+func (c *alidnsProvider) getDomainInfo(domain string) (*domainInfo, error) {
+	if info, ok := c.domainInfo[domain]; ok {
+		return info, nil
+	}
+	var info domainInfo
+	if err := c.call("DescribeDomainInfo", requestParams{"DomainName": domain}, &info); err != nil {
+		return nil, fmt.Errorf("failed fetching domain info from alidns: %w", err)
+	}
+	c.domainInfo[domain] = &info
+	return &info, nil
+}
+
+func (c *alidnsProvider) listAllDomains() ([]string, error) {
+	var domains []string
+	page := 1
+	for {
+		var resp describeDomainsResponse
+		err := c.call("DescribeDomains", requestParams{
+			"PageNumber": strconv.Itoa(page),
+			"PageSize":   strconv.Itoa(domainsPageSize),
+		}, &resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed listing domains from alidns: %w", err)
+		}
+		for _, d := range resp.Domains.Domain {
+			domains = append(domains, d.DomainName)
+		}
+		if len(domains) >= resp.TotalCount || len(resp.Domains.Domain) == 0 {
+			break
+		}
+		page++
+	}
+	sort.Strings(domains)
+	return domains, nil
+}
+
+func (c *alidnsProvider) createDomain(domain string) error {
+	if err := c.call("AddDomain", requestParams{"DomainName": domain}, nil); err != nil {
+		return fmt.Errorf("failed creating domain (alidns): %w", err)
+	}
+	return nil
+}
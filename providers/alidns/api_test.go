@@ -0,0 +1,40 @@
+package alidns
+
+import "testing"
+
+func TestPercentEncode(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"abc", "abc"},
+		{"a b", "a%20b"},
+		{"a*b", "a%2Ab"},
+		{"a~b", "a~b"},
+		{"2016-03-24T16:41:54Z", "2016-03-24T16%3A41%3A54Z"},
+	}
+	for _, tt := range tests {
+		if got := percentEncode(tt.in); got != tt.want {
+			t.Errorf("percentEncode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Example taken from the AliDNS "Request signature" documentation.
+	params := requestParams{
+		"Format":           "XML",
+		"AccessKeyId":      "testid",
+		"Action":           "DescribeDomainRecords",
+		"SignatureMethod":  "HMAC-SHA1",
+		"DomainName":       "example.com",
+		"SignatureNonce":   "f59ed6a9-83fc-473b-9cc6-99c95df3856e",
+		"SignatureVersion": "1.0",
+		"Version":          "2015-01-09",
+		"Timestamp":        "2016-03-24T16:41:54Z",
+	}
+	got := sign("GET", canonicalQuery(params), "testsecret")
+	want := "uRpHwaSEt3J+6KQD//svCh/x+pI="
+	if got != want {
+		t.Errorf("sign() = %q, want %q", got, want)
+	}
+}
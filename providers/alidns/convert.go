@@ -0,0 +1,80 @@
+package alidns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// toRc converts the AliDNS record format into our standard RecordConfig.
+func toRc(domain string, r *domainRecord) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+		Metadata: map[string]string{
+			metaLine: r.Line,
+		},
+	}
+	rc.SetLabel(r.RR, domain)
+
+	var err error
+	switch rtype := r.Type; rtype { // #rtype_variations
+	case "TXT":
+		err = rc.SetTargetTXT(r.Value)
+	case "MX":
+		err = rc.SetTargetMX(r.Priority, fqdn(r.Value))
+	case "CNAME", "NS":
+		err = rc.SetTarget(fqdn(r.Value))
+	case "SRV":
+		// priority weight port target
+		err = rc.SetTargetSRVString(fqdn(r.Value))
+	default:
+		err = rc.PopulateFromString(rtype, r.Value, domain)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unparsable record received from alidns: %w", err)
+	}
+	return rc, nil
+}
+
+// toReq takes a RecordConfig and turns it into the native format used by the API.
+func toReq(rc *models.RecordConfig) (requestParams, error) {
+	req := requestParams{
+		"RR":    rc.GetLabel(),
+		"Type":  rc.Type,
+		"Value": rc.GetTargetField(),
+		"TTL":   strconv.FormatUint(uint64(rc.TTL), 10),
+		"Line":  rc.Metadata[metaLine],
+	}
+
+	switch rc.Type { // #rtype_variations
+	case "A", "AAAA":
+		// Nothing special.
+	case "CNAME", "NS":
+		req["Value"] = strings.TrimSuffix(rc.GetTargetField(), ".")
+	case "TXT":
+		req["Value"] = rc.GetTargetTXTJoined()
+	case "MX":
+		req["Value"] = strings.TrimSuffix(rc.GetTargetField(), ".")
+		req["Priority"] = strconv.Itoa(int(rc.MxPreference))
+	case "SRV":
+		req["Value"] = fmt.Sprintf("%d %d %d %s", rc.SrvPriority, rc.SrvWeight, rc.SrvPort, strings.TrimSuffix(rc.GetTargetField(), "."))
+	case "CAA":
+		req["Value"] = fmt.Sprintf("%d %s \"%s\"", rc.CaaFlag, rc.CaaTag, rc.GetTargetField())
+	default:
+		return nil, fmt.Errorf("alidns.toReq rtype %q unimplemented", rc.Type)
+	}
+
+	return req, nil
+}
+
+// fqdn adds the trailing dot AliDNS omits from hostnames.
+func fqdn(s string) string {
+	if strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
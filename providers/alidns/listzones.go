@@ -0,0 +1,20 @@
+package alidns
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *alidnsProvider) ListZones() ([]string, error) {
+	return c.listAllDomains()
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *alidnsProvider) EnsureZoneExists(domain string) error {
+	domains, err := c.listAllDomains()
+	if err != nil {
+		return err
+	}
+	for _, d := range domains {
+		if d == domain {
+			return nil
+		}
+	}
+	return c.createDomain(domain)
+}
@@ -7,7 +7,8 @@ axfrddns -
   push Dynamic DNS updates (RFC2136) to the same server.
 
   Both the AXFR request and the updates might be authentificated with
-  a TSIG.
+  a TSIG. The updates might instead be authentificated with GSS-TSIG,
+  they are then sent with nsupdate.
 
 */
 
@@ -67,6 +68,8 @@ type axfrddnsProvider struct {
 	nameservers         []*models.Nameserver
 	transferKey         *Key
 	updateKey           *Key
+	gssTSIG             *gssTSIG
+	tlsConfig           *tls.Config
 	hasDnssecRecords    bool
 	serverHasBuggyCNAME bool
 }
@@ -146,6 +149,14 @@ func initAxfrDdns(config map[string]string, providermeta json.RawMessage) (provi
 	if err != nil {
 		return nil, err
 	}
+	api.gssTSIG, err = newGSSTSIG(config)
+	if err != nil {
+		return nil, err
+	}
+	api.tlsConfig, err = newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
 	switch strings.ToLower(strings.TrimSpace(config["buggy-cname"])) {
 	case "yes", "true":
 		api.serverHasBuggyCNAME = true
@@ -161,7 +172,19 @@ func initAxfrDdns(config map[string]string, providermeta json.RawMessage) (provi
 			"transfer-server",
 			"update-mode",
 			"transfer-mode",
+			"buggy-cname",
+			"gss-tsig",
+			"gss-keytab",
+			"gss-principal",
+			"nsupdate-command",
+			"tls-server-name",
+			"tls-ca-file",
+			"tls-client-cert",
+			"tls-client-key",
+			"tls-pin-sha256",
 			"domain",
+			"expires",
+			"readonly",
 			"TYPE":
 			continue
 		default:
@@ -232,7 +255,10 @@ func (c *axfrddnsProvider) getAxfrConnection() (*dns.Transfer, error) {
 	var con net.Conn = nil
 	var err error = nil
 	if c.transferMode == "tcp-tls" {
-		con, err = tls.Dial("tcp", c.transferServer, &tls.Config{})
+		// Zone transfers over TLS (RFC9103) require TLS 1.3.
+		tlsConfig := c.tlsConfig.Clone()
+		tlsConfig.MinVersion = tls.VersionTLS13
+		con, err = tls.Dial("tcp", c.transferServer, tlsConfig)
 	} else {
 		con, err = net.Dial("tcp", c.transferServer)
 	}
@@ -429,6 +455,7 @@ func (c *axfrddnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, fo
 	client := new(dns.Client)
 	client.Net = c.updateMode
 	client.Timeout = dnsTimeout
+	client.TLSConfig = c.tlsConfig
 	if c.updateKey != nil {
 		client.TsigSecret =
 			map[string]string{c.updateKey.id: c.updateKey.secret}
@@ -535,6 +562,10 @@ func (c *axfrddnsProvider) updateZone(client *dns.Client, dc *models.DomainConfi
 
 	f(update)
 
+	if c.gssTSIG != nil {
+		return c.gssTSIG.send(c.master, update)
+	}
+
 	msg, _, err := client.Exchange(update, c.master)
 	if err != nil {
 		return err
@@ -0,0 +1,119 @@
+package axfrddns
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/google/shlex"
+	"github.com/miekg/dns"
+)
+
+// gssTSIG sends the DDNS updates with nsupdate, which authenticates them
+// with GSS-TSIG (RFC3645) and the Kerberos credentials of the system, as
+// required by the Active Directory-integrated zones of Microsoft DNS.
+type gssTSIG struct {
+	command   []string
+	keytab    string
+	principal string
+
+	kinitOnce sync.Once
+	kinitErr  error
+}
+
+func newGSSTSIG(config map[string]string) (*gssTSIG, error) {
+	switch strings.ToLower(strings.TrimSpace(config["gss-tsig"])) {
+	case "yes", "true":
+	case "", "no", "false":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("invalid gss-tsig (%s) in AXFRDDNS: must be yes or no", config["gss-tsig"])
+	}
+	if config["update-key"] != "" {
+		return nil, fmt.Errorf("gss-tsig and update-key are mutually exclusive in AXFRDDNS")
+	}
+	if config["update-mode"] == "tcp-tls" {
+		return nil, fmt.Errorf("gss-tsig doesn't support the update-mode tcp-tls in AXFRDDNS")
+	}
+	command := config["nsupdate-command"]
+	if command == "" {
+		command = "nsupdate -g"
+	}
+	args, err := shlex.Split(command)
+	if err != nil || len(args) == 0 {
+		return nil, fmt.Errorf("invalid nsupdate-command (%s) in AXFRDDNS", command)
+	}
+	g := &gssTSIG{command: args, keytab: config["gss-keytab"], principal: config["gss-principal"]}
+	if (g.keytab == "") != (g.principal == "") {
+		return nil, fmt.Errorf("gss-keytab and gss-principal must be both set in AXFRDDNS")
+	}
+	return g, nil
+}
+
+// nsupdateScript returns the nsupdate commands of a DDNS update.
+func nsupdateScript(server string, update *dns.Msg) (string, error) {
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "server %s %s\n", host, port)
+	fmt.Fprintf(&b, "zone %s\n", update.Question[0].Name)
+	for _, rr := range update.Ns {
+		rr = dns.Copy(rr)
+		switch rr.Header().Class {
+		case dns.ClassNONE:
+			// update.Remove sets the class of the record to NONE.
+			rr.Header().Class = dns.ClassINET
+			fmt.Fprintf(&b, "update delete %s\n", rr)
+		case dns.ClassINET:
+			fmt.Fprintf(&b, "update add %s\n", rr)
+		default:
+			return "", fmt.Errorf("unsupported update of class %s: %s", dns.ClassToString[rr.Header().Class], rr)
+		}
+	}
+	b.WriteString("send\n")
+	return b.String(), nil
+}
+
+// kinit gets a Kerberos ticket with the keytab, if it is set. Otherwise the
+// ticket of the user is used.
+func (g *gssTSIG) kinit() error {
+	g.kinitOnce.Do(func() {
+		if g.keytab == "" {
+			return
+		}
+		printer.Printf("AXFRDDNS: getting a Kerberos ticket for %s\n", g.principal)
+		var stderr bytes.Buffer
+		cmd := exec.Command("kinit", "-k", "-t", g.keytab, g.principal)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			g.kinitErr = fmt.Errorf("[Error] AXFRDDNS: kinit %s: %v: %s", g.principal, err, strings.TrimSpace(stderr.String()))
+		}
+	})
+	return g.kinitErr
+}
+
+// send sends a DDNS update to the server with nsupdate.
+func (g *gssTSIG) send(server string, update *dns.Msg) error {
+	if err := g.kinit(); err != nil {
+		return err
+	}
+	script, err := nsupdateScript(server, update)
+	if err != nil {
+		return err
+	}
+	var output bytes.Buffer
+	cmd := exec.Command(g.command[0], g.command[1:]...)
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("[Error] AXFRDDNS: nameserver refused to update the zone: %v: %s", err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
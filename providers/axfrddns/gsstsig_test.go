@@ -0,0 +1,52 @@
+package axfrddns
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestNsupdateScript(t *testing.T) {
+	before, _ := dns.NewRR("www.example.com. 300 IN A 192.0.2.1")
+	after, _ := dns.NewRR("www.example.com. 300 IN A 192.0.2.2")
+	update := new(dns.Msg)
+	update.SetUpdate("example.com.")
+	update.Remove([]dns.RR{before})
+	update.Insert([]dns.RR{after})
+
+	script, err := nsupdateScript("dc1.example.com:53", update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "server dc1.example.com 53\n" +
+		"zone example.com.\n" +
+		"update delete www.example.com.\t0\tIN\tA\t192.0.2.1\n" +
+		"update add www.example.com.\t300\tIN\tA\t192.0.2.2\n" +
+		"send\n"
+	if script != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, script)
+	}
+}
+
+func TestNewGSSTSIG(t *testing.T) {
+	if g, err := newGSSTSIG(map[string]string{}); g != nil || err != nil {
+		t.Errorf("Expected no GSS-TSIG, got %v, %v", g, err)
+	}
+	g, err := newGSSTSIG(map[string]string{"gss-tsig": "yes", "gss-keytab": "/etc/dnscontrol.keytab", "gss-principal": "dnscontrol@EXAMPLE.COM"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.command) != 2 || g.command[0] != "nsupdate" || g.command[1] != "-g" {
+		t.Errorf("Unexpected command %q", g.command)
+	}
+	for _, config := range []map[string]string{
+		{"gss-tsig": "maybe"},
+		{"gss-tsig": "yes", "update-key": "hmac-sha256:key:c2VjcmV0"},
+		{"gss-tsig": "yes", "update-mode": "tcp-tls"},
+		{"gss-tsig": "yes", "gss-keytab": "/etc/dnscontrol.keytab"},
+	} {
+		if _, err := newGSSTSIG(config); err == nil {
+			t.Errorf("Expected an error for %v", config)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package axfrddns
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// newTLSConfig returns the TLS configuration of the transfers and updates
+// with the mode tcp-tls: the server name, CA, client certificate and SPKI
+// pins of creds.json.
+func newTLSConfig(config map[string]string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: config["tls-server-name"]}
+
+	if file := config["tls-ca-file"]; file != "" {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read tls-ca-file in AXFRDDNS: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in tls-ca-file (%s) in AXFRDDNS", file)
+		}
+	}
+
+	cert, key := config["tls-client-cert"], config["tls-client-key"]
+	if (cert == "") != (key == "") {
+		return nil, fmt.Errorf("tls-client-cert and tls-client-key must be both set in AXFRDDNS")
+	}
+	if cert != "" {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the client certificate in AXFRDDNS: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	if config["tls-pin-sha256"] != "" {
+		var pins [][]byte
+		for _, pin := range strings.Split(config["tls-pin-sha256"], ",") {
+			digest, err := base64.StdEncoding.DecodeString(strings.TrimSpace(pin))
+			if err != nil || len(digest) != sha256.Size {
+				return nil, fmt.Errorf("invalid tls-pin-sha256 (%s) in AXFRDDNS", pin)
+			}
+			pins = append(pins, digest)
+		}
+		// Without a CA, the pin of the certificate of the server
+		// authenticates it, as in the strict privacy profile of
+		// DNS-over-TLS (RFC7858). With a CA, the pin is the one of a
+		// certificate of the verified chain.
+		tlsConfig.InsecureSkipVerify = tlsConfig.RootCAs == nil
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if tlsConfig.InsecureSkipVerify {
+				if len(state.PeerCertificates) == 0 {
+					return fmt.Errorf("[Error] AXFRDDNS: the server sent no certificate")
+				}
+				return verifyPins(state.PeerCertificates[:1], pins)
+			}
+			for _, chain := range state.VerifiedChains {
+				if verifyPins(chain, pins) == nil {
+					return nil
+				}
+			}
+			return verifyPins(state.PeerCertificates, nil)
+		}
+	}
+	return tlsConfig, nil
+}
+
+// spkiPin returns the SHA-256 digest of the public key of a certificate.
+func spkiPin(cert *x509.Certificate) []byte {
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return digest[:]
+}
+
+// verifyPins checks that the public key of one of the certificates is
+// pinned.
+func verifyPins(certs []*x509.Certificate, pins [][]byte) error {
+	for _, cert := range certs {
+		digest := spkiPin(cert)
+		for _, pin := range pins {
+			if bytes.Equal(digest, pin) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("[Error] AXFRDDNS: the certificate of the server doesn't match tls-pin-sha256 (its pin is %s)", base64.StdEncoding.EncodeToString(spkiPin(certs[0])))
+}
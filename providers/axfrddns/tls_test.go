@@ -0,0 +1,104 @@
+package axfrddns
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+func selfSignedCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "primary.example.com"},
+		DNSNames:     []string{"primary.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// handshake returns the error of a TLS handshake with a server with the
+// certificate.
+func handshake(t *testing.T, config *tls.Config, cert tls.Certificate) error {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+	conn, err := net.DialTimeout("tcp", listener.Addr().String(), 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return tls.Client(conn, config).Handshake()
+}
+
+func TestTLSPins(t *testing.T) {
+	cert := selfSignedCertificate(t)
+	pin := base64.StdEncoding.EncodeToString(spkiPin(cert.Leaf))
+	other := base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+	config, err := newTLSConfig(map[string]string{"tls-server-name": "primary.example.com", "tls-pin-sha256": other + "," + pin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(t, config, cert); err != nil {
+		t.Errorf("Expected the pinned certificate to be accepted, got %v", err)
+	}
+
+	config, err = newTLSConfig(map[string]string{"tls-server-name": "primary.example.com", "tls-pin-sha256": other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(t, config, cert); err == nil {
+		t.Error("Expected a certificate which isn't pinned to be refused")
+	}
+
+	// Without pins, the self-signed certificate isn't trusted.
+	config, err = newTLSConfig(map[string]string{"tls-server-name": "primary.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(t, config, cert); err == nil {
+		t.Error("Expected a self-signed certificate to be refused")
+	}
+
+	for _, config := range []map[string]string{
+		{"tls-pin-sha256": "not base64"},
+		{"tls-pin-sha256": base64.StdEncoding.EncodeToString([]byte("short"))},
+		{"tls-client-cert": "client.pem"},
+		{"tls-ca-file": "/nonexistent/ca.pem"},
+	} {
+		if _, err := newTLSConfig(config); err == nil {
+			t.Errorf("Expected an error for %v", config)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package azuredns
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+)
+
+// aliasTargetTypes are the record types of the alias record sets, by type
+// of the Azure resource they point to.
+var aliasTargetTypes = map[string][]string{
+	"microsoft.network/publicipaddresses":      {"A", "AAAA"},
+	"microsoft.network/trafficmanagerprofiles": {"A", "AAAA", "CNAME"},
+	"microsoft.network/frontdoors":             {"A", "AAAA", "CNAME"},
+	"microsoft.cdn/profiles/endpoints":         {"A", "AAAA", "CNAME"},
+	"microsoft.cdn/profiles/afdendpoints":      {"A", "AAAA", "CNAME"},
+}
+
+// aliasTargetType returns the type of the Azure resource of a resource ID,
+// e.g. microsoft.cdn/profiles/endpoints.
+func aliasTargetType(id string) (string, error) {
+	_, provider, ok := strings.Cut(strings.ToLower(id), "/providers/")
+	if !strings.HasPrefix(strings.ToLower(id), "/subscriptions/") || !ok {
+		return "", fmt.Errorf("%q is not an Azure resource ID", id)
+	}
+	parts := strings.Split(strings.Trim(provider, "/"), "/")
+	if len(parts) < 3 || len(parts)%2 == 0 {
+		return "", fmt.Errorf("%q is not an Azure resource ID", id)
+	}
+	rtype := parts[0] + "/" + parts[1]
+	for i := 3; i < len(parts); i += 2 {
+		rtype += "/" + parts[i]
+	}
+	return rtype, nil
+}
+
+// aliasTargetIsInvalid detects AZURE_ALIAS records whose target can't be
+// the target of an alias record set of their type.
+func aliasTargetIsInvalid(rc *models.RecordConfig) error {
+	atype := rc.AzureAlias["type"]
+	rtype, err := aliasTargetType(rc.GetTargetField())
+	if err != nil {
+		return err
+	}
+
+	// The record sets of the zone, .../dnszones/example.com/A/www, are the
+	// target of the alias record sets of the same type.
+	if zoneType, recordType, ok := strings.Cut(rtype, "/dnszones/"); ok && zoneType == "microsoft.network" {
+		if !strings.EqualFold(recordType, atype) {
+			return fmt.Errorf("an alias record set of type %s can't point to a record set of type %s", atype, strings.ToUpper(recordType))
+		}
+		return nil
+	}
+
+	types, ok := aliasTargetTypes[rtype]
+	if !ok {
+		return fmt.Errorf("the resources of type %s can't be the target of an alias record set", rtype)
+	}
+	if !slices.Contains(types, atype) {
+		return fmt.Errorf("an alias record set of type %s can't point to a resource of type %s", atype, rtype)
+	}
+	return nil
+}
+
+// keepAliasTargets sets the target of the AZURE_ALIAS records to the
+// resource ID returned by Azure when they are the same. The resource IDs
+// are case-insensitive, and Azure doesn't preserve their case.
+func keepAliasTargets(dc *models.DomainConfig, existing models.Records) {
+	targets := map[models.RecordKey][]string{}
+	for _, rc := range existing {
+		if rc.Type == "AZURE_ALIAS" {
+			targets[rc.Key()] = append(targets[rc.Key()], rc.GetTargetField())
+		}
+	}
+	for _, rc := range dc.Records {
+		if rc.Type != "AZURE_ALIAS" {
+			continue
+		}
+		for _, target := range targets[rc.Key()] {
+			if target != rc.GetTargetField() && strings.EqualFold(strings.TrimSuffix(target, "/"), strings.TrimSuffix(rc.GetTargetField(), "/")) {
+				_ = rc.SetTarget(target)
+			}
+		}
+	}
+}
+
+// azureRecordSetKey returns the key of the record set of Azure of a change
+// key: an AZURE_ALIAS of type A is in the A record set of its label.
+func azureRecordSetKey(key models.RecordKey) models.RecordKey {
+	key.Type = strings.TrimPrefix(key.Type, "AZURE_ALIAS_")
+	return key
+}
+
+// mergeReplacedDeletes removes the deletions of the record sets which are
+// replaced by an alias record set, or are an alias replaced by records, as
+// they are the same record set in Azure. Their messages are added to those
+// of the replacement.
+func mergeReplacedDeletes(changes diff2.ChangeList) diff2.ChangeList {
+	upserts := map[models.RecordKey]int{}
+	for i, change := range changes {
+		if change.Type == diff2.CREATE || change.Type == diff2.CHANGE {
+			upserts[azureRecordSetKey(change.Key)] = i
+		}
+	}
+
+	replaced := map[int]bool{}
+	for j, change := range changes {
+		if change.Type != diff2.DELETE {
+			continue
+		}
+		if i, ok := upserts[azureRecordSetKey(change.Key)]; ok && changes[i].Key != change.Key {
+			changes[i].Msgs = append(changes[i].Msgs, change.Msgs...)
+			changes[i].MsgsJoined = strings.Join(changes[i].Msgs, "\n")
+			replaced[j] = true
+		}
+	}
+
+	var result diff2.ChangeList
+	for j, change := range changes {
+		if !replaced[j] {
+			result = append(result, change)
+		}
+	}
+	return result
+}
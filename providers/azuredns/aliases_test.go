@@ -0,0 +1,49 @@
+package azuredns
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+)
+
+func TestAliasTargetIsInvalid(t *testing.T) {
+	tests := []struct {
+		atype, target string
+		invalid       bool
+	}{
+		{"A", "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/ip", false},
+		{"CNAME", "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/ip", true},
+		{"CNAME", "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/trafficManagerProfiles/tm", false},
+		{"A", "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Cdn/profiles/cdn/endpoints/www", false},
+		{"CNAME", "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/dnszones/example.com/CNAME/quux.", false},
+		{"A", "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/dnszones/example.com/CNAME/quux.", true},
+		{"A", "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/sa", true},
+		{"A", "myprofile.trafficmanager.net", true},
+	}
+	for _, tst := range tests {
+		rc := &models.RecordConfig{Type: "AZURE_ALIAS", AzureAlias: map[string]string{"type": tst.atype}}
+		_ = rc.SetTarget(tst.target)
+		if err := aliasTargetIsInvalid(rc); (err != nil) != tst.invalid {
+			t.Errorf("%s %s: expected invalid=%v, got %v", tst.atype, tst.target, tst.invalid, err)
+		}
+	}
+}
+
+func TestMergeReplacedDeletes(t *testing.T) {
+	changes := diff2.ChangeList{
+		{Type: diff2.DELETE, Key: models.RecordKey{NameFQDN: "www.example.com", Type: "A"}, Msgs: []string{"- DELETE www A"}},
+		{Type: diff2.CREATE, Key: models.RecordKey{NameFQDN: "www.example.com", Type: "AZURE_ALIAS_A"}, Msgs: []string{"+ CREATE www AZURE_ALIAS"}},
+		{Type: diff2.DELETE, Key: models.RecordKey{NameFQDN: "old.example.com", Type: "A"}, Msgs: []string{"- DELETE old A"}},
+	}
+	result := mergeReplacedDeletes(changes)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(result))
+	}
+	if expected := "+ CREATE www AZURE_ALIAS\n- DELETE www A"; result[0].MsgsJoined != expected {
+		t.Errorf("Expected %q, got %q", expected, result[0].MsgsJoined)
+	}
+	if result[1].Key.NameFQDN != "old.example.com" {
+		t.Errorf("Expected the deletion of old.example.com, got %v", result[1].Key)
+	}
+}
@@ -11,6 +11,8 @@ import (
 func AuditRecords(records []*models.RecordConfig) []error {
 	a := rejectif.Auditor{}
 
+	a.Add("AZURE_ALIAS", aliasTargetIsInvalid) // Last verified 2026-10-14
+
 	a.Add("MX", rejectif.MxNull) // Last verified 2020-12-28
 
 	a.Add("TXT", rejectif.TxtIsEmpty) // Last verified 2023-11-11
@@ -8,12 +8,15 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	aauth "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	adns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
@@ -31,16 +34,16 @@ func newAzureDNSDsp(conf map[string]string, metadata json.RawMessage) (providers
 
 func newAzureDNS(m map[string]string, _ json.RawMessage) (*azurednsProvider, error) {
 	subID, rg := m["SubscriptionID"], m["ResourceGroup"]
-	clientID, clientSecret, tenantID := m["ClientID"], m["ClientSecret"], m["TenantID"]
-	credential, authErr := aauth.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+	credential, authErr := newCredential(m)
 	if authErr != nil {
 		return nil, authErr
 	}
-	zonesClient, zoneErr := adns.NewZonesClient(subID, credential, nil)
+	options := &arm.ClientOptions{ClientOptions: policy.ClientOptions{Transport: tlsconfig.NewSDKClient()}}
+	zonesClient, zoneErr := adns.NewZonesClient(subID, credential, options)
 	if zoneErr != nil {
 		return nil, zoneErr
 	}
-	recordsClient, recordErr := adns.NewRecordSetsClient(subID, credential, nil)
+	recordsClient, recordErr := adns.NewRecordSetsClient(subID, credential, options)
 	if recordErr != nil {
 		return nil, recordErr
 	}
@@ -58,6 +61,49 @@ func newAzureDNS(m map[string]string, _ json.RawMessage) (*azurednsProvider, err
 	return api, nil
 }
 
+// newCredential returns the credential selected by the key AuthMethod:
+// "secret" (the client secret of a service principal), "managed_identity"
+// (the system-assigned identity, or the user-assigned identity ClientID),
+// "workload_identity" (the federated token of a Kubernetes service account
+// or a CI job, in TokenFile or AZURE_FEDERATED_TOKEN_FILE), or "default"
+// (the chain of DefaultAzureCredential). The default is "secret" if there is
+// a ClientSecret, or else "default".
+func newCredential(m map[string]string) (azcore.TokenCredential, error) {
+	clientID, clientSecret, tenantID := m["ClientID"], m["ClientSecret"], m["TenantID"]
+	method := m["AuthMethod"]
+	if method == "" {
+		method = "default"
+		if clientSecret != "" {
+			method = "secret"
+		}
+	}
+	switch method {
+	case "secret":
+		return aauth.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+	case "managed_identity":
+		opts := &aauth.ManagedIdentityCredentialOptions{}
+		if clientID != "" {
+			opts.ID = aauth.ClientID(clientID)
+		}
+		return aauth.NewManagedIdentityCredential(opts)
+	case "workload_identity":
+		return aauth.NewWorkloadIdentityCredential(&aauth.WorkloadIdentityCredentialOptions{
+			ClientID:      clientID,
+			TenantID:      tenantID,
+			TokenFilePath: m["TokenFile"],
+		})
+	case "default":
+		return aauth.NewDefaultAzureCredential(&aauth.DefaultAzureCredentialOptions{TenantID: tenantID})
+	}
+	return nil, fmt.Errorf("unknown AuthMethod %q, expected secret, managed_identity, workload_identity or default", method)
+}
+
+// scopes are the actions of the Azure roles which the provider needs for the records.
+var scopes = providers.RequiredScopes{
+	Read:  []string{"Microsoft.Network/dnszones/read", "Microsoft.Network/dnszones/*/read"},
+	Write: []string{"Microsoft.Network/dnszones/write", "Microsoft.Network/dnszones/*/write", "Microsoft.Network/dnszones/*/delete"},
+}
+
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
@@ -84,7 +130,7 @@ func init() {
 		Initializer:   newAzureDNSDsp,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, scopes)
 	providers.RegisterCustomRecordType("AZURE_ALIAS", providerName, "")
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
@@ -195,10 +241,12 @@ func (a *azurednsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, ex
 
 	// Azure is a "ByRecordSet" API.
 
+	keepAliasTargets(dc, existingRecords)
 	changes, err := diff2.ByRecordSet(existingRecords, dc, nil)
 	if err != nil {
 		return nil, err
 	}
+	changes = mergeReplacedDeletes(changes)
 
 	for _, change := range changes {
 
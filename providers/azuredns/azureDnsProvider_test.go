@@ -0,0 +1,31 @@
+package azuredns
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewCredential(t *testing.T) {
+	tests := []struct {
+		m    map[string]string
+		want string
+	}{
+		{map[string]string{"TenantID": "tenant", "ClientID": "client", "ClientSecret": "secret"}, "*azidentity.ClientSecretCredential"},
+		{map[string]string{"AuthMethod": "managed_identity", "ClientID": "client"}, "*azidentity.ManagedIdentityCredential"},
+		{map[string]string{"AuthMethod": "workload_identity", "TenantID": "tenant", "ClientID": "client", "TokenFile": "/token"}, "*azidentity.WorkloadIdentityCredential"},
+	}
+	for _, tst := range tests {
+		cred, err := newCredential(tst.m)
+		if err != nil {
+			t.Errorf("%v: %v", tst.m, err)
+			continue
+		}
+		if got := fmt.Sprintf("%T", cred); got != tst.want {
+			t.Errorf("%v: got %s, want %s", tst.m, got, tst.want)
+		}
+	}
+
+	if _, err := newCredential(map[string]string{"AuthMethod": "password"}); err == nil {
+		t.Error("expected an error for an unknown AuthMethod, got none")
+	}
+}
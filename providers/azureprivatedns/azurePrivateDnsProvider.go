@@ -8,18 +8,22 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	aauth "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	adns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 type azurednsProvider struct {
 	zonesClient    *adns.PrivateZonesClient
 	recordsClient  *adns.RecordSetsClient
+	linksClient    *adns.VirtualNetworkLinksClient
 	zones          map[string]*adns.PrivateZone
 	resourceGroup  *string
 	subscriptionID *string
@@ -33,23 +37,28 @@ func newAzureDNSDsp(conf map[string]string, metadata json.RawMessage) (providers
 
 func newAzureDNS(m map[string]string, _ json.RawMessage) (*azurednsProvider, error) {
 	subID, rg := m["SubscriptionID"], m["ResourceGroup"]
-	clientID, clientSecret, tenantID := m["ClientID"], m["ClientSecret"], m["TenantID"]
-	credential, authErr := aauth.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+	credential, authErr := newCredential(m)
 	if authErr != nil {
 		return nil, authErr
 	}
-	zonesClient, zoneErr := adns.NewPrivateZonesClient(subID, credential, nil)
+	options := &arm.ClientOptions{ClientOptions: policy.ClientOptions{Transport: tlsconfig.NewSDKClient()}}
+	zonesClient, zoneErr := adns.NewPrivateZonesClient(subID, credential, options)
 	if zoneErr != nil {
 		return nil, zoneErr
 	}
-	recordsClient, recordErr := adns.NewRecordSetsClient(subID, credential, nil)
+	recordsClient, recordErr := adns.NewRecordSetsClient(subID, credential, options)
 	if recordErr != nil {
 		return nil, recordErr
 	}
+	linksClient, linkErr := adns.NewVirtualNetworkLinksClient(subID, credential, options)
+	if linkErr != nil {
+		return nil, linkErr
+	}
 
 	api := &azurednsProvider{
 		zonesClient:    zonesClient,
 		recordsClient:  recordsClient,
+		linksClient:    linksClient,
 		resourceGroup:  to.StringPtr(rg),
 		subscriptionID: to.StringPtr(subID),
 		rawRecords:     map[string][]*adns.RecordSet{},
@@ -62,6 +71,43 @@ func newAzureDNS(m map[string]string, _ json.RawMessage) (*azurednsProvider, err
 	return api, nil
 }
 
+// newCredential returns the credential selected by the key AuthMethod:
+// "secret" (the client secret of a service principal), "managed_identity"
+// (the system-assigned identity, or the user-assigned identity ClientID),
+// "workload_identity" (the federated token of a Kubernetes service account
+// or a CI job, in TokenFile or AZURE_FEDERATED_TOKEN_FILE), or "default"
+// (the chain of DefaultAzureCredential). The default is "secret" if there is
+// a ClientSecret, or else "default".
+func newCredential(m map[string]string) (azcore.TokenCredential, error) {
+	clientID, clientSecret, tenantID := m["ClientID"], m["ClientSecret"], m["TenantID"]
+	method := m["AuthMethod"]
+	if method == "" {
+		method = "default"
+		if clientSecret != "" {
+			method = "secret"
+		}
+	}
+	switch method {
+	case "secret":
+		return aauth.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+	case "managed_identity":
+		opts := &aauth.ManagedIdentityCredentialOptions{}
+		if clientID != "" {
+			opts.ID = aauth.ClientID(clientID)
+		}
+		return aauth.NewManagedIdentityCredential(opts)
+	case "workload_identity":
+		return aauth.NewWorkloadIdentityCredential(&aauth.WorkloadIdentityCredentialOptions{
+			ClientID:      clientID,
+			TenantID:      tenantID,
+			TokenFilePath: m["TokenFile"],
+		})
+	case "default":
+		return aauth.NewDefaultAzureCredential(&aauth.DefaultAzureCredentialOptions{TenantID: tenantID})
+	}
+	return nil, fmt.Errorf("unknown AuthMethod %q, expected secret, managed_identity, workload_identity or default", method)
+}
+
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
@@ -225,7 +271,11 @@ func (a *azurednsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, ex
 		}
 	}
 
-	return corrections, nil
+	linkCorrections, err := a.getLinkCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, linkCorrections...), nil
 }
 
 func (a *azurednsProvider) recordCreate(zoneName string, reckey models.RecordKey, recs models.Records) error {
@@ -533,5 +583,19 @@ func (a *azurednsProvider) EnsureZoneExists(domain string) error {
 	if _, ok := a.zones[domain]; ok {
 		return nil
 	}
+	printer.Printf("Adding private zone for %s to Azure dns account\n", domain)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	defer cancel()
+
+	poller, err := a.zonesClient.BeginCreateOrUpdate(ctx, *a.resourceGroup, domain, adns.PrivateZone{Location: to.StringPtr("global")}, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return err
+	}
+	a.zones[domain] = &resp.PrivateZone
 	return nil
 }
@@ -0,0 +1,184 @@
+package azureprivatedns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	adns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+)
+
+// The virtual network links of a zone are set in the domain metadata, as
+// comma-separated lists of "name=virtual network resource ID". The virtual
+// machines of the networks of azure_vnet_registration_links are registered
+// in the zone. The links are only managed when one of them is set.
+const (
+	metaLinks             = "azure_vnet_links"
+	metaRegistrationLinks = "azure_vnet_registration_links"
+)
+
+// vnetLink is a virtual network link of a zone.
+type vnetLink struct {
+	VirtualNetwork string
+	Registration   bool
+}
+
+func (l vnetLink) String() string {
+	if l.Registration {
+		return l.VirtualNetwork + " (registration)"
+	}
+	return l.VirtualNetwork
+}
+
+// parseLinks returns the virtual network links of the domain metadata, by
+// name.
+func parseLinks(metadata map[string]string) (map[string]vnetLink, error) {
+	links := map[string]vnetLink{}
+	for _, key := range []string{metaLinks, metaRegistrationLinks} {
+		for _, item := range strings.Split(metadata[key], ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			name, vnet, ok := strings.Cut(item, "=")
+			if !ok || name == "" || vnet == "" {
+				return nil, fmt.Errorf("invalid virtual network link %q in %s, expected name=virtual network ID", item, key)
+			}
+			if _, ok := links[name]; ok {
+				return nil, fmt.Errorf("duplicate virtual network link %s", name)
+			}
+			links[name] = vnetLink{VirtualNetwork: vnet, Registration: key == metaRegistrationLinks}
+		}
+	}
+	return links, nil
+}
+
+// sameVirtualNetwork reports whether two virtual network IDs are equal. The
+// resource IDs of Azure are case-insensitive.
+func sameVirtualNetwork(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "/"), strings.TrimSuffix(b, "/"))
+}
+
+func sortedLinkNames(links map[string]vnetLink) []string {
+	names := make([]string, 0, len(links))
+	for name := range links {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getLinkCorrections returns the corrections of the virtual network links
+// of a zone.
+func (a *azurednsProvider) getLinkCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	_, hasLinks := dc.Metadata[metaLinks]
+	_, hasRegistrationLinks := dc.Metadata[metaRegistrationLinks]
+	if !hasLinks && !hasRegistrationLinks {
+		return nil, nil
+	}
+	desired, err := parseLinks(dc.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := a.getLinks(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, name := range sortedLinkNames(desired) {
+		link := desired[name]
+		old, ok := existing[name]
+		switch {
+		case !ok:
+			corrections = append(corrections, &models.Correction{
+				Msg: color.GreenString("+ CREATE virtual network link %s %s", name, link),
+				F:   func() error { return a.linkCreateOrUpdate(dc.Name, name, link) },
+			})
+		case !sameVirtualNetwork(old.VirtualNetwork, link.VirtualNetwork):
+			// The virtual network of a link can't be changed.
+			corrections = append(corrections, &models.Correction{
+				Msg: color.YellowString("± MODIFY virtual network link %s %s -> %s", name, old, link),
+				F: func() error {
+					if err := a.linkDelete(dc.Name, name); err != nil {
+						return err
+					}
+					return a.linkCreateOrUpdate(dc.Name, name, link)
+				},
+			})
+		case old.Registration != link.Registration:
+			corrections = append(corrections, &models.Correction{
+				Msg: color.YellowString("± MODIFY virtual network link %s %s -> %s", name, old, link),
+				F:   func() error { return a.linkCreateOrUpdate(dc.Name, name, link) },
+			})
+		}
+	}
+	for _, name := range sortedLinkNames(existing) {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: color.RedString("- DELETE virtual network link %s %s", name, existing[name]),
+			F:   func() error { return a.linkDelete(dc.Name, name) },
+		})
+	}
+	return corrections, nil
+}
+
+func (a *azurednsProvider) getLinks(zoneName string) (map[string]vnetLink, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	defer cancel()
+	links := map[string]vnetLink{}
+	pager := a.linksClient.NewListPager(*a.resourceGroup, zoneName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range page.Value {
+			if l.Name == nil || l.Properties == nil {
+				continue
+			}
+			var link vnetLink
+			if l.Properties.VirtualNetwork != nil {
+				link.VirtualNetwork = to.String(l.Properties.VirtualNetwork.ID)
+			}
+			link.Registration = to.Bool(l.Properties.RegistrationEnabled)
+			links[*l.Name] = link
+		}
+	}
+	return links, nil
+}
+
+func (a *azurednsProvider) linkCreateOrUpdate(zoneName, name string, link vnetLink) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	defer cancel()
+	poller, err := a.linksClient.BeginCreateOrUpdate(ctx, *a.resourceGroup, zoneName, name, adns.VirtualNetworkLink{
+		Location: to.StringPtr("global"),
+		Properties: &adns.VirtualNetworkLinkProperties{
+			RegistrationEnabled: to.BoolPtr(link.Registration),
+			VirtualNetwork:      &adns.SubResource{ID: to.StringPtr(link.VirtualNetwork)},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
+
+func (a *azurednsProvider) linkDelete(zoneName, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	defer cancel()
+	poller, err := a.linksClient.BeginDelete(ctx, *a.resourceGroup, zoneName, name, nil)
+	if err != nil {
+		return err
+	}
+	_, err = poller.PollUntilDone(ctx, nil)
+	return err
+}
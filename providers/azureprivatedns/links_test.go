@@ -0,0 +1,30 @@
+package azureprivatedns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLinks(t *testing.T) {
+	links, err := parseLinks(map[string]string{
+		metaLinks:             "hub=/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/hub",
+		metaRegistrationLinks: "app=/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/app",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]vnetLink{
+		"hub": {VirtualNetwork: "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/hub"},
+		"app": {VirtualNetwork: "/subscriptions/1/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/app", Registration: true},
+	}
+	if !reflect.DeepEqual(links, expected) {
+		t.Errorf("Expected %v, got %v", expected, links)
+	}
+
+	if _, err := parseLinks(map[string]string{metaLinks: "hub"}); err == nil {
+		t.Errorf("Expected an error for a link without virtual network")
+	}
+	if _, err := parseLinks(map[string]string{metaLinks: "hub=a", metaRegistrationLinks: "hub=b"}); err == nil {
+		t.Errorf("Expected an error for a duplicate link")
+	}
+}
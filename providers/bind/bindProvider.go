@@ -33,7 +33,7 @@ import (
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
-	providers.CanAutoDNSSEC:          providers.Can("Just writes out a comment indicating DNSSEC was requested"),
+	providers.CanAutoDNSSEC:          providers.Can("Signs the zone files with dnssec-signzone, or configures a dnssec-policy, if dnssec_mode is set"),
 	providers.CanGetZones:            providers.Can(),
 	providers.CanConcur:              providers.Cannot(),
 	providers.CanUseCAA:              providers.Can(),
@@ -56,12 +56,16 @@ var features = providers.DocumentationNotes{
 	providers.DocOfficiallySupported: providers.Can(),
 }
 
-func initBind(config map[string]string, providermeta json.RawMessage) (providers.DNSServiceProvider, error) {
+// NewBind creates a BIND provider. It is also used by the providers that
+// write zone files for other DNS servers.
+func NewBind(config map[string]string, providermeta json.RawMessage) (providers.DNSServiceProvider, error) {
 	// config -- the key/values from creds.json
 	// meta -- the json blob from NewReq('name', 'TYPE', meta)
 	api := &bindProvider{
-		directory:      config["directory"],
-		filenameformat: config["filenameformat"],
+		directory:        config["directory"],
+		filenameformat:   config["filenameformat"],
+		namedConf:        config["named_conf"],
+		namedConfZoneDir: config["named_conf_zone_dir"],
 	}
 	if api.directory == "" {
 		api.directory = "zones"
@@ -69,12 +73,19 @@ func initBind(config map[string]string, providermeta json.RawMessage) (providers
 	if api.filenameformat == "" {
 		api.filenameformat = "%U.zone"
 	}
+	var err error
+	if api.dnssec, err = newDNSSECConfig(config, api.directory); err != nil {
+		return nil, err
+	}
 	if len(providermeta) != 0 {
 		err := json.Unmarshal(providermeta, api)
 		if err != nil {
 			return nil, err
 		}
 	}
+	if err := api.checkViews(); err != nil {
+		return nil, err
+	}
 	var nss []string
 	for i, ns := range api.DefaultNS {
 		if ns == "" {
@@ -88,7 +99,6 @@ func initBind(config map[string]string, providermeta json.RawMessage) (providers
 		// name without the trailing dot to indicate a FQDN.
 		nss = append(nss, strings.TrimSuffix(ns, "."))
 	}
-	var err error
 	api.nameservers, err = models.ToNameservers(nss)
 	return api, err
 }
@@ -97,7 +107,7 @@ func init() {
 	const providerName = "BIND"
 	const providerMaintainer = "@tlimoncelli"
 	fns := providers.DspFuncs{
-		Initializer:   initBind,
+		Initializer:   NewBind,
 		RecordAuditor: AuditRecords,
 	}
 	providers.RegisterDomainServiceProviderType(providerName, fns, features)
@@ -122,13 +132,20 @@ func (s SoaDefaults) String() string {
 
 // bindProvider is the provider handle for the bindProvider driver.
 type bindProvider struct {
-	DefaultNS      []string    `json:"default_ns"`
-	DefaultSoa     SoaDefaults `json:"default_soa"`
+	DefaultNS      []string            `json:"default_ns"`
+	DefaultSoa     SoaDefaults         `json:"default_soa"`
+	Views          []View              `json:"views"`
+	ACLs           map[string][]string `json:"acls"`
 	nameservers    []*models.Nameserver
 	directory      string
 	filenameformat string
 	zonefile       string // Where the zone data is e texpected
 	zoneFileFound  bool   // Did the zonefile exist?
+
+	namedConf        string // The named.conf fragment, if it is generated.
+	namedConfZoneDir string // The directory of the zone files in the named.conf fragment.
+
+	dnssec dnssecConfig
 }
 
 // GetNameservers returns the nameservers for a domain.
@@ -253,8 +270,23 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 	if err != nil {
 		return nil, err
 	}
+
+	c.zonefile = filepath.Join(c.directory, ZoneFileName(c.filenameformat, dc))
+	// The signatures are refreshed with a new serial, even if the records
+	// didn't change.
+	if c.dnssec.signed(dc) && !changes && c.dnssec.needsResign(c.zonefile) {
+		msgs = append(msgs, fmt.Sprintf("SIGN %s: the signatures are missing or older than %v", dc.Name, c.dnssec.resign))
+		changes = true
+	}
+	namedConfCorrection, err := c.getNamedConfCorrection(dc, c.zonefile)
+	if err != nil {
+		return nil, err
+	}
 	if !changes {
-		return nil, nil
+		if namedConfCorrection != nil {
+			corrections = append(corrections, namedConfCorrection)
+		}
+		return corrections, nil
 	}
 	msg = strings.Join(msgs, "\n")
 
@@ -270,11 +302,6 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 		comments = append(comments, "Automatic DNSSEC signing requested")
 	}
 
-	c.zonefile = filepath.Join(c.directory,
-		makeFileName(c.filenameformat,
-			dc.Metadata[models.DomainUniqueName], dc.Name, dc.Metadata[models.DomainTag]),
-	)
-
 	// We only change the serial number if there is a change.
 	desiredSoa.SoaSerial = nextSerial
 
@@ -308,10 +335,17 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 				if err != nil {
 					return fmt.Errorf("closing: %w", err)
 				}
+				if c.dnssec.signed(dc) {
+					return c.dnssec.signZone(dc.Name, c.zonefile)
+				}
 				return nil
 			},
 		})
 
+	// The zone file is written before the named.conf fragment.
+	if namedConfCorrection != nil {
+		corrections = append(corrections, namedConfCorrection)
+	}
 	return corrections, nil
 }
 
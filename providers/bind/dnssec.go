@@ -0,0 +1,155 @@
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/google/shlex"
+)
+
+// The DNSSEC modes of the zones with AUTODNSSEC_ON.
+const (
+	dnssecSignzone = "signzone" // The zone files are signed by dnssec-signzone.
+	dnssecPolicy   = "policy"   // BIND signs the zones with a dnssec-policy.
+)
+
+// dnssecConfig is the DNSSEC configuration of the provider.
+type dnssecConfig struct {
+	mode      string
+	keyDir    string
+	algorithm string
+	policy    string
+	resign    time.Duration
+	keygen    []string
+	signzone  []string
+}
+
+var timeNow = time.Now
+
+func newDNSSECConfig(config map[string]string, directory string) (dnssecConfig, error) {
+	d := dnssecConfig{
+		mode:      config["dnssec_mode"],
+		keyDir:    config["dnssec_key_directory"],
+		algorithm: config["dnssec_algorithm"],
+		policy:    config["dnssec_policy"],
+		resign:    7 * 24 * time.Hour,
+	}
+	switch d.mode {
+	case "", dnssecSignzone, dnssecPolicy:
+	default:
+		return d, fmt.Errorf("dnssec_mode (%v) must be %q or %q", d.mode, dnssecSignzone, dnssecPolicy)
+	}
+	if d.keyDir == "" {
+		d.keyDir = filepath.Join(directory, "keys")
+	}
+	if d.algorithm == "" {
+		d.algorithm = "ECDSAP256SHA256"
+	}
+	if d.policy == "" {
+		d.policy = "default"
+	}
+	if v := config["dnssec_resign_interval"]; v != "" {
+		var err error
+		if d.resign, err = time.ParseDuration(v); err != nil {
+			return d, fmt.Errorf("dnssec_resign_interval (%v): %w", v, err)
+		}
+	}
+	for _, cmd := range []struct {
+		key, def string
+		args     *[]string
+	}{
+		{"keygen_command", "dnssec-keygen", &d.keygen},
+		{"signzone_command", "dnssec-signzone", &d.signzone},
+	} {
+		command := config[cmd.key]
+		if command == "" {
+			command = cmd.def
+		}
+		args, err := shlex.Split(command)
+		if err != nil || len(args) == 0 {
+			return d, fmt.Errorf("invalid %s %q: %v", cmd.key, command, err)
+		}
+		*cmd.args = args
+	}
+	return d, nil
+}
+
+// signed reports whether the zone file of a domain is signed by
+// dnssec-signzone.
+func (d dnssecConfig) signed(dc *models.DomainConfig) bool {
+	return d.mode == dnssecSignzone && dc.AutoDNSSEC == "on"
+}
+
+// signedFile returns the signed zone file of a zone file.
+func signedFile(zonefile string) string {
+	return zonefile + ".signed"
+}
+
+// needsResign reports whether the signed zone file is missing or its
+// signatures must be refreshed.
+func (d dnssecConfig) needsResign(zonefile string) bool {
+	st, err := os.Stat(signedFile(zonefile))
+	if err != nil {
+		return true
+	}
+	return timeNow().Sub(st.ModTime()) >= d.resign
+}
+
+func run(command []string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(command[0], append(command[1:], args...)...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s: %v: %s", command[0], strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ensureKeys generates a KSK and a ZSK for the domain if the key directory
+// has no keys for it.
+func (d dnssecConfig) ensureKeys(domain string) error {
+	keys, err := filepath.Glob(filepath.Join(d.keyDir, "K"+domain+".+*.key"))
+	if err != nil || len(keys) != 0 {
+		return err
+	}
+	if err := os.MkdirAll(d.keyDir, 0o700); err != nil {
+		return err
+	}
+	printer.Printf("GENERATING DNSSEC KEYS: %s (%s)\n", domain, d.algorithm)
+	if err := run(d.keygen, "-K", d.keyDir, "-a", d.algorithm, "-f", "KSK", domain); err != nil {
+		return err
+	}
+	return run(d.keygen, "-K", d.keyDir, "-a", d.algorithm, domain)
+}
+
+// signZone signs the zone file with the keys of the key directory. The
+// serial of the zone file is kept, it was bumped when the file was written.
+func (d dnssecConfig) signZone(domain, zonefile string) error {
+	if err := d.ensureKeys(domain); err != nil {
+		return err
+	}
+	printer.Printf("SIGNING ZONEFILE: %v\n", zonefile)
+	return run(d.signzone, "-S", "-K", d.keyDir, "-d", d.keyDir, "-N", "keep", "-o", domain, "-f", signedFile(zonefile), zonefile)
+}
+
+// namedConfOptions returns the options of the zone in the named.conf
+// fragment, and the file BIND loads.
+func (d dnssecConfig) namedConfOptions(dc *models.DomainConfig, zonefile string) (string, string) {
+	if dc.AutoDNSSEC != "on" {
+		return "", zonefile
+	}
+	switch d.mode {
+	case dnssecSignzone:
+		return "", signedFile(zonefile)
+	case dnssecPolicy:
+		return fmt.Sprintf(" dnssec-policy %q; inline-signing yes;", d.policy), zonefile
+	}
+	return "", zonefile
+}
@@ -0,0 +1,96 @@
+package bind
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestNewDNSSECConfig(t *testing.T) {
+	d, err := newDNSSECConfig(map[string]string{"dnssec_mode": "signzone", "signzone_command": "sudo dnssec-signzone"}, "zones")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.keyDir != filepath.Join("zones", "keys") || d.algorithm != "ECDSAP256SHA256" || d.resign != 7*24*time.Hour {
+		t.Errorf("Unexpected defaults %+v", d)
+	}
+	if len(d.signzone) != 2 || d.signzone[0] != "sudo" || d.keygen[0] != "dnssec-keygen" {
+		t.Errorf("Unexpected commands %q, %q", d.signzone, d.keygen)
+	}
+
+	for _, config := range []map[string]string{
+		{"dnssec_mode": "auto"},
+		{"dnssec_resign_interval": "1 week"},
+		{"keygen_command": `"dnssec-keygen`},
+	} {
+		if _, err := newDNSSECConfig(config, "zones"); err == nil {
+			t.Errorf("Expected an error for %v", config)
+		}
+	}
+}
+
+func TestNeedsResign(t *testing.T) {
+	d := dnssecConfig{mode: dnssecSignzone, resign: 24 * time.Hour}
+	zonefile := filepath.Join(t.TempDir(), "example.com.zone")
+	if !d.needsResign(zonefile) {
+		t.Error("Expected a missing signed zone file to be signed")
+	}
+	if err := os.WriteFile(signedFile(zonefile), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if d.needsResign(zonefile) {
+		t.Error("Expected a new signed zone file not to be signed")
+	}
+	old := time.Now().Add(-25 * time.Hour)
+	if err := os.Chtimes(signedFile(zonefile), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if !d.needsResign(zonefile) {
+		t.Error("Expected an old signed zone file to be signed")
+	}
+}
+
+func TestNamedConfDNSSEC(t *testing.T) {
+	for _, test := range []struct {
+		mode, autoDNSSEC, expected string
+	}{
+		{"", "on", `zone "example.com" { type primary; file "/var/lib/bind/example.com.zone"; };`},
+		{dnssecSignzone, "", `zone "example.com" { type primary; file "/var/lib/bind/example.com.zone"; };`},
+		{dnssecSignzone, "on", `zone "example.com" { type primary; file "/var/lib/bind/example.com.zone.signed"; };`},
+		{dnssecPolicy, "on", `zone "example.com" { type primary; file "/var/lib/bind/example.com.zone"; dnssec-policy "default"; inline-signing yes; };`},
+	} {
+		dir := t.TempDir()
+		d, err := newDNSSECConfig(map[string]string{"dnssec_mode": test.mode}, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := &bindProvider{
+			directory:        dir,
+			namedConf:        filepath.Join(dir, "named.conf.dnscontrol"),
+			namedConfZoneDir: "/var/lib/bind",
+			dnssec:           d,
+		}
+		dc := &models.DomainConfig{Name: "example.com", AutoDNSSEC: test.autoDNSSEC}
+		corr, err := c.getNamedConfCorrection(dc, filepath.Join(dir, "example.com.zone"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := corr.F(); err != nil {
+			t.Fatal(err)
+		}
+		content, err := os.ReadFile(c.namedConf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries := parseNamedConf(string(content))
+		if got := c.renderNamedConf(entries); got != string(content) {
+			t.Errorf("Expected the fragment to be parsed back, got:\n%s", got)
+		}
+		if expected := "// generated with dnscontrol, do not edit\n\n" + test.expected + "\n"; string(content) != expected {
+			t.Errorf("%s %s: expected:\n%s\nGot:\n%s", test.mode, test.autoDNSSEC, expected, content)
+		}
+	}
+}
@@ -7,8 +7,17 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
 )
 
+// ZoneFileName returns the filename of the zone file of a domain, as
+// generated with format. It is used by the providers that write zone files
+// with the BIND provider.
+func ZoneFileName(format string, dc *models.DomainConfig) string {
+	return makeFileName(format, dc.Metadata[models.DomainUniqueName], dc.Name, dc.Metadata[models.DomainTag])
+}
+
 // makeFileName uses format to generate a zone's filename.  See the
 func makeFileName(format, uniquename, domain, tag string) string {
 	//fmt.Printf("DEBUG: makeFileName(%q, %q, %q, %q)\n", format, uniquename, domain, tag)
@@ -0,0 +1,202 @@
+package bind
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// View is a view of the named.conf fragment. The zones of a view are the
+// domains whose split horizon tag is the name of the view.
+type View struct {
+	Name              string   `json:"name"`
+	MatchClients      []string `json:"match_clients"`
+	MatchDestinations []string `json:"match_destinations,omitempty"`
+}
+
+// namedConfEntry is a zone of the named.conf fragment.
+type namedConfEntry struct {
+	View    string
+	Zone    string
+	File    string
+	Options string // The options after the file, e.g. the dnssec-policy.
+}
+
+var (
+	namedConfView = regexp.MustCompile(`^view "([^"]+)" \{$`)
+	namedConfZone = regexp.MustCompile(`^\s*zone "([^"]+)" \{ type primary; file "([^"]+)";(.*) \};$`)
+)
+
+// parseNamedConf returns the zones of a named.conf fragment generated by
+// renderNamedConf.
+func parseNamedConf(content string) []namedConfEntry {
+	var entries []namedConfEntry
+	view := ""
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := namedConfView.FindStringSubmatch(line); m != nil {
+			view = m[1]
+		} else if line == "};" {
+			view = ""
+		} else if m := namedConfZone.FindStringSubmatch(line); m != nil {
+			entries = append(entries, namedConfEntry{View: view, Zone: m[1], File: m[2], Options: m[3]})
+		}
+	}
+	return entries
+}
+
+func addressMatchList(items []string) string {
+	var b strings.Builder
+	b.WriteString("{")
+	for _, item := range items {
+		fmt.Fprintf(&b, " %s;", item)
+	}
+	b.WriteString(" }")
+	return b.String()
+}
+
+// renderNamedConf returns the named.conf fragment of the ACLs, views and
+// zones. The entries whose view is not configured are dropped.
+func (c *bindProvider) renderNamedConf(entries []namedConfEntry) string {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].View != entries[j].View {
+			return entries[i].View < entries[j].View
+		}
+		return entries[i].Zone < entries[j].Zone
+	})
+	zone := func(b *strings.Builder, indent string, e namedConfEntry) {
+		fmt.Fprintf(b, "%szone %q { type primary; file %q;%s };\n", indent, e.Zone, e.File, e.Options)
+	}
+
+	var b strings.Builder
+	b.WriteString("// generated with dnscontrol, do not edit\n")
+	acls := make([]string, 0, len(c.ACLs))
+	for name := range c.ACLs {
+		acls = append(acls, name)
+	}
+	sort.Strings(acls)
+	for _, name := range acls {
+		fmt.Fprintf(&b, "\nacl %q %s;\n", name, addressMatchList(c.ACLs[name]))
+	}
+
+	if len(c.Views) == 0 {
+		b.WriteString("\n")
+		for _, e := range entries {
+			zone(&b, "", e)
+		}
+		return b.String()
+	}
+	for _, view := range c.Views {
+		fmt.Fprintf(&b, "\nview %q {\n", view.Name)
+		fmt.Fprintf(&b, "\tmatch-clients %s;\n", addressMatchList(view.MatchClients))
+		if len(view.MatchDestinations) != 0 {
+			fmt.Fprintf(&b, "\tmatch-destinations %s;\n", addressMatchList(view.MatchDestinations))
+		}
+		for _, e := range entries {
+			if e.View == view.Name {
+				zone(&b, "\t", e)
+			}
+		}
+		b.WriteString("};\n")
+	}
+	return b.String()
+}
+
+// checkViews checks the views of the provider metadata.
+func (c *bindProvider) checkViews() error {
+	seen := map[string]bool{}
+	for i, view := range c.Views {
+		if view.Name == "" {
+			return fmt.Errorf("empty name in views[%d]", i)
+		}
+		if seen[view.Name] {
+			return fmt.Errorf("duplicate view %q", view.Name)
+		}
+		seen[view.Name] = true
+		if len(view.MatchClients) == 0 {
+			return fmt.Errorf("view %q has no match_clients", view.Name)
+		}
+	}
+	return nil
+}
+
+// getNamedConfCorrection returns the correction which adds the zone to the
+// named.conf fragment, if it isn't there yet. The zones whose zone file was
+// removed are removed from the fragment.
+func (c *bindProvider) getNamedConfCorrection(dc *models.DomainConfig, zonefile string) (*models.Correction, error) {
+	if c.namedConf == "" {
+		return nil, nil
+	}
+	view := ""
+	if len(c.Views) != 0 {
+		view = dc.Metadata[models.DomainTag]
+		found := false
+		for _, v := range c.Views {
+			found = found || v.Name == view
+		}
+		if !found {
+			return nil, fmt.Errorf("the zone %s is not in a view: its split horizon tag must be one of the views of the BIND provider", dc.GetUniqueName())
+		}
+	}
+
+	var old string
+	content, err := os.ReadFile(c.namedConf)
+	if err == nil {
+		old = string(content)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("can't open %s: %w", c.namedConf, err)
+	}
+
+	// The file of the zone as seen by BIND.
+	options, zonefile := c.dnssec.namedConfOptions(dc, zonefile)
+	file := zonefile
+	if c.namedConfZoneDir != "" {
+		rel, err := filepath.Rel(c.directory, zonefile)
+		if err != nil {
+			return nil, err
+		}
+		file = filepath.ToSlash(filepath.Join(c.namedConfZoneDir, rel))
+	} else if abs, err := filepath.Abs(zonefile); err == nil {
+		file = filepath.ToSlash(abs)
+	}
+
+	current := namedConfEntry{View: view, Zone: dc.Name, File: file, Options: options}
+	entries := []namedConfEntry{current}
+	for _, e := range parseNamedConf(old) {
+		if e.View == current.View && e.Zone == current.Zone {
+			continue
+		}
+		local := filepath.FromSlash(e.File)
+		if c.namedConfZoneDir != "" {
+			local = filepath.Join(c.directory, filepath.FromSlash(strings.TrimPrefix(e.File, strings.TrimSuffix(c.namedConfZoneDir, "/")+"/")))
+		}
+		if _, err := os.Stat(local); os.IsNotExist(err) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	conf := c.renderNamedConf(entries)
+	if conf == old {
+		return nil, nil
+	}
+
+	return &models.Correction{
+		Msg: fmt.Sprintf("UPDATE %s: zone %q", c.namedConf, dc.Name),
+		F: func() error {
+			printer.Printf("WRITING NAMED.CONF: %v\n", c.namedConf)
+			fname, err := preprocessFilename(c.namedConf)
+			if err != nil {
+				return fmt.Errorf("could not create %s: %w", c.namedConf, err)
+			}
+			return os.WriteFile(fname, []byte(conf), 0o644)
+		},
+	}, nil
+}
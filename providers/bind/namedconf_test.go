@@ -0,0 +1,85 @@
+package bind
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestNamedConf(t *testing.T) {
+	dir := t.TempDir()
+	c := &bindProvider{
+		directory:        dir,
+		filenameformat:   "%U.zone",
+		namedConf:        filepath.Join(dir, "named.conf.dnscontrol"),
+		namedConfZoneDir: "/var/lib/bind",
+		ACLs:             map[string][]string{"internal-nets": {"10.0.0.0/8", "192.168.0.0/16"}},
+		Views: []View{
+			{Name: "inside", MatchClients: []string{"internal-nets"}},
+			{Name: "outside", MatchClients: []string{"any"}},
+		},
+	}
+
+	for _, name := range []string{"example.com!inside", "example.com!outside"} {
+		dc := &models.DomainConfig{Name: name}
+		dc.UpdateSplitHorizonNames()
+		zonefile := filepath.Join(dir, ZoneFileName(c.filenameformat, dc))
+		corr, err := c.getNamedConfCorrection(dc, zonefile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if corr == nil {
+			t.Fatalf("Expected a correction for %s", name)
+		}
+		if err := os.WriteFile(zonefile, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := corr.F(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content, err := os.ReadFile(c.namedConf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `// generated with dnscontrol, do not edit
+
+acl "internal-nets" { 10.0.0.0/8; 192.168.0.0/16; };
+
+view "inside" {
+	match-clients { internal-nets; };
+	zone "example.com" { type primary; file "/var/lib/bind/example.com!inside.zone"; };
+};
+
+view "outside" {
+	match-clients { any; };
+	zone "example.com" { type primary; file "/var/lib/bind/example.com!outside.zone"; };
+};
+`
+	if string(content) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, content)
+	}
+	if entries := parseNamedConf(string(content)); !reflect.DeepEqual(entries, []namedConfEntry{
+		{View: "inside", Zone: "example.com", File: "/var/lib/bind/example.com!inside.zone"},
+		{View: "outside", Zone: "example.com", File: "/var/lib/bind/example.com!outside.zone"},
+	}) {
+		t.Errorf("Unexpected entries %v", entries)
+	}
+
+	// The zone is already in the fragment.
+	dc := &models.DomainConfig{Name: "example.com!inside"}
+	dc.UpdateSplitHorizonNames()
+	if corr, err := c.getNamedConfCorrection(dc, filepath.Join(dir, ZoneFileName(c.filenameformat, dc))); err != nil || corr != nil {
+		t.Errorf("Expected no correction, got %v, %v", corr, err)
+	}
+
+	dc = &models.DomainConfig{Name: "example.org"}
+	dc.UpdateSplitHorizonNames()
+	if _, err := c.getNamedConfCorrection(dc, filepath.Join(dir, "example.org.zone")); err == nil {
+		t.Errorf("Expected an error for a zone without view")
+	}
+}
@@ -0,0 +1,298 @@
+package bluecat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const pageSize = 1000
+
+type bluecatProvider struct {
+	client   *http.Client
+	baseURL  string // https://host/api/v2
+	username string
+	password string
+
+	configuration string
+	view          string
+	deploy        string
+
+	credentials string // from the session
+	viewID      int
+	zones       map[string]*zone
+}
+
+type collection struct {
+	Count int             `json:"count"`
+	Data  json.RawMessage `json:"data"`
+}
+
+type entity struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type zone struct {
+	ID           int    `json:"id,omitempty"`
+	Type         string `json:"type"`
+	AbsoluteName string `json:"absoluteName"`
+	Deployable   bool   `json:"deployable"`
+}
+
+type address struct {
+	Address string `json:"address"`
+}
+
+type linkedRecord struct {
+	Type         string `json:"type,omitempty"`
+	AbsoluteName string `json:"absoluteName"`
+}
+
+// resourceRecord holds the fields of the supported resource record types.
+type resourceRecord struct {
+	ID           int    `json:"id,omitempty"`
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	AbsoluteName string `json:"absoluteName,omitempty"`
+	TTL          *int   `json:"ttl"`
+
+	Addresses    []address     `json:"addresses,omitempty"`    // HostRecord
+	LinkedRecord *linkedRecord `json:"linkedRecord,omitempty"` // AliasRecord, MXRecord, SRVRecord
+	Priority     *uint16       `json:"priority,omitempty"`     // MXRecord, SRVRecord
+	Weight       *uint16       `json:"weight,omitempty"`       // SRVRecord
+	Port         *uint16       `json:"port,omitempty"`         // SRVRecord
+	Text         string        `json:"text,omitempty"`         // TXTRecord
+	RecordType   string        `json:"recordType,omitempty"`   // GenericRecord
+	RData        string        `json:"rdata,omitempty"`        // GenericRecord
+}
+
+type apiError struct {
+	Status  int    `json:"status"`
+	Reason  string `json:"reason"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (c *bluecatProvider) login() error {
+	body, err := json.Marshal(map[string]string{"username": c.username, "password": c.password})
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Post(c.baseURL+"/sessions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("BLUECAT login failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("BLUECAT login failed: %s: %s", resp.Status, string(data))
+	}
+	var session struct {
+		Credentials string `json:"basicAuthenticationCredentials"`
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return fmt.Errorf("BLUECAT login failed: %w", err)
+	}
+	c.credentials = session.Credentials
+	return nil
+}
+
+// request sends an API request and decodes the JSON response into target.
+func (c *bluecatProvider) request(method, path string, query url.Values, body any, target any) error {
+	if c.credentials == "" {
+		if err := c.login(); err != nil {
+			return err
+		}
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Basic "+c.credentials)
+	req.Header.Set("Accept", "application/hal+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/hal+json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		var ae apiError
+		if json.Unmarshal(data, &ae) == nil && ae.Message != "" {
+			return fmt.Errorf("BLUECAT API error: %s: %s", resp.Status, ae.Message)
+		}
+		return fmt.Errorf("BLUECAT API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// getAll fetches all pages of a collection.
+func getAll[T any](c *bluecatProvider, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", fmt.Sprint(pageSize))
+
+	var items []T
+	for offset := 0; ; offset += pageSize {
+		query.Set("offset", fmt.Sprint(offset))
+		var resp collection
+		if err := c.request(http.MethodGet, path, query, nil, &resp); err != nil {
+			return nil, err
+		}
+		var batch []T
+		if err := json.Unmarshal(resp.Data, &batch); err != nil {
+			return nil, err
+		}
+		items = append(items, batch...)
+		if len(batch) < pageSize {
+			break
+		}
+	}
+	return items, nil
+}
+
+func nameFilter(field, value string) url.Values {
+	return url.Values{"filter": {fmt.Sprintf("%s:eq('%s')", field, strings.ReplaceAll(value, "'", "\\'"))}}
+}
+
+// findView resolves the configured configuration and view to the view ID.
+func (c *bluecatProvider) findView() (int, error) {
+	if c.viewID != 0 {
+		return c.viewID, nil
+	}
+	configs, err := getAll[entity](c, "/configurations", nameFilter("name", c.configuration))
+	if err != nil {
+		return 0, fmt.Errorf("failed fetching BLUECAT configuration: %w", err)
+	}
+	if len(configs) != 1 {
+		return 0, fmt.Errorf("BLUECAT configuration %q not found", c.configuration)
+	}
+	views, err := getAll[entity](c, fmt.Sprintf("/configurations/%d/views", configs[0].ID), nameFilter("name", c.view))
+	if err != nil {
+		return 0, fmt.Errorf("failed fetching BLUECAT view: %w", err)
+	}
+	if len(views) != 1 {
+		return 0, fmt.Errorf("BLUECAT view %q not found in configuration %q", c.view, c.configuration)
+	}
+	c.viewID = views[0].ID
+	return c.viewID, nil
+}
+
+func (c *bluecatProvider) loadZones() error {
+	if c.zones != nil {
+		return nil
+	}
+	viewID, err := c.findView()
+	if err != nil {
+		return err
+	}
+	zones, err := getAll[zone](c, fmt.Sprintf("/views/%d/zones", viewID), url.Values{"filter": {"deployable:eq(true)"}})
+	if err != nil {
+		return fmt.Errorf("failed listing BLUECAT zones: %w", err)
+	}
+	c.zones = make(map[string]*zone, len(zones))
+	for i := range zones {
+		c.zones[strings.ToLower(zones[i].AbsoluteName)] = &zones[i]
+	}
+	return nil
+}
+
+func (c *bluecatProvider) findZone(domain string) (*zone, error) {
+	if err := c.loadZones(); err != nil {
+		return nil, err
+	}
+	z, ok := c.zones[domain]
+	if !ok {
+		return nil, fmt.Errorf("zone %q not found in BLUECAT view %q", domain, c.view)
+	}
+	return z, nil
+}
+
+func (c *bluecatProvider) createZone(domain string) error {
+	viewID, err := c.findView()
+	if err != nil {
+		return err
+	}
+	z := &zone{Type: "Zone", AbsoluteName: domain, Deployable: true}
+	if err := c.request(http.MethodPost, fmt.Sprintf("/views/%d/zones", viewID), nil, z, nil); err != nil {
+		return fmt.Errorf("failed creating zone (BLUECAT): %w", err)
+	}
+	c.zones = nil
+	return nil
+}
+
+func (c *bluecatProvider) getRecords(zoneID int) ([]*resourceRecord, error) {
+	records, err := getAll[*resourceRecord](c, fmt.Sprintf("/zones/%d/resourceRecords", zoneID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching BLUECAT resource records: %w", err)
+	}
+	return records, nil
+}
+
+func (c *bluecatProvider) createRecord(zoneID int, rec *resourceRecord) error {
+	if err := c.request(http.MethodPost, fmt.Sprintf("/zones/%d/resourceRecords", zoneID), nil, rec, nil); err != nil {
+		return fmt.Errorf("failed create record (BLUECAT): %w", err)
+	}
+	return nil
+}
+
+func (c *bluecatProvider) updateRecord(rec *resourceRecord) error {
+	if err := c.request(http.MethodPut, fmt.Sprintf("/resourceRecords/%d", rec.ID), nil, rec, nil); err != nil {
+		return fmt.Errorf("failed update record (BLUECAT): %w", err)
+	}
+	return nil
+}
+
+func (c *bluecatProvider) deleteRecord(id int) error {
+	if err := c.request(http.MethodDelete, fmt.Sprintf("/resourceRecords/%d", id), nil, nil, nil); err != nil {
+		return fmt.Errorf("failed delete record (BLUECAT): %w", err)
+	}
+	return nil
+}
+
+// deployZone deploys the pending changes of a zone to its DNS servers.
+func (c *bluecatProvider) deployZone(zoneID int) error {
+	body := map[string]string{"type": "QuickDeployment"}
+	if c.deploy == deployFull {
+		body["type"] = "FullDeployment"
+	}
+	if err := c.request(http.MethodPost, fmt.Sprintf("/zones/%d/deployments", zoneID), nil, body, nil); err != nil {
+		return fmt.Errorf("failed deploying zone (BLUECAT): %w", err)
+	}
+	return nil
+}
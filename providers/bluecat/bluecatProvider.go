@@ -0,0 +1,252 @@
+package bluecat
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for BlueCat Address Manager through the RESTful v2 API.
+// API Documentation: https://docs.bluecatnetworks.com/r/Address-Manager-RESTful-v2-API-Guide
+
+/*
+BlueCat Address Manager provider:
+
+Info required in `creds.json`:
+   - host
+   - username
+   - password
+   - configuration
+   - view
+   - deploy (optional, "quick", "full" or "none", default "quick")
+   - skip_tls_verify (optional)
+   - cert (optional, PEM CA certificate of Address Manager)
+
+*/
+
+const (
+	deployQuick = "quick"
+	deployFull  = "full"
+	deployNone  = "none"
+)
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Unimplemented(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Unimplemented(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Can(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "BLUECAT"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newBluecat,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+	providers.RegisterTLSOptions(providerName)
+}
+
+// newBluecat creates the provider.
+func newBluecat(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &bluecatProvider{
+		baseURL:       fmt.Sprintf("https://%s/api/v2", m["host"]),
+		username:      m["username"],
+		password:      m["password"],
+		configuration: m["configuration"],
+		view:          m["view"],
+		deploy:        m["deploy"],
+	}
+	if m["host"] == "" || c.username == "" || c.password == "" {
+		return nil, fmt.Errorf("missing BLUECAT host, username or password")
+	}
+	if c.configuration == "" || c.view == "" {
+		return nil, fmt.Errorf("missing BLUECAT configuration or view")
+	}
+	switch c.deploy {
+	case "":
+		c.deploy = deployQuick
+	case deployQuick, deployFull, deployNone:
+	default:
+		return nil, fmt.Errorf("BLUECAT deploy must be %q, %q or %q, got %q", deployQuick, deployFull, deployNone, c.deploy)
+	}
+
+	client, err := tlsconfig.NewHTTPClient(m)
+	if err != nil {
+		return nil, fmt.Errorf("BLUECAT: %w", err)
+	}
+	c.client = client
+
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+// The NS records are generated from the deployment roles of the zone.
+func (c *bluecatProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *bluecatProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	z, err := c.findZone(domain)
+	if err != nil {
+		return nil, err
+	}
+	records, err := c.getRecords(z.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for _, rec := range records {
+		rcs, err := toRecordConfigs(domain, rec)
+		if err != nil {
+			return nil, err
+		}
+		for _, rc := range rcs {
+			if rc.Type == "NS" && rc.GetLabel() == "@" {
+				continue
+			}
+			existingRecords = append(existingRecords, rc)
+		}
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *bluecatProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	z, err := c.findZone(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	modified := false
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			rec := toRecord(change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(z.ID, rec)
+				},
+			}
+		case diff2.CHANGE:
+			old := change.Old[0].Original.(*resourceRecord)
+			var rec *resourceRecord
+			if old.Type == "HostRecord" {
+				// Host records hold several addresses. The RecordConfigs
+				// of a host record share it, so the changes accumulate.
+				from, to, ttl := change.Old[0].GetTargetField(), change.New[0].GetTargetField(), int(change.New[0].TTL)
+				rec = old
+				corr = &models.Correction{
+					Msg: fmt.Sprintf("%s, BLUECAT ID: %d", change.Msgs[0], old.ID),
+					F: func() error {
+						replaceAddress(rec, from, to)
+						rec.TTL = &ttl
+						return c.updateRecord(rec)
+					},
+				}
+				break
+			}
+			rec = toRecord(change.New[0])
+			rec.ID = old.ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, BLUECAT ID: %d", change.Msgs[0], old.ID),
+				F: func() error {
+					return c.updateRecord(rec)
+				},
+			}
+		case diff2.DELETE:
+			old := change.Old[0].Original.(*resourceRecord)
+			from := change.Old[0].GetTargetField()
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, BLUECAT ID: %d", change.Msgs[0], old.ID),
+				F: func() error {
+					if old.Type == "HostRecord" {
+						replaceAddress(old, from, "")
+						if len(old.Addresses) != 0 {
+							return c.updateRecord(old)
+						}
+					}
+					return c.deleteRecord(old.ID)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		if corr.F != nil {
+			modified = true
+		}
+		corrections = append(corrections, corr)
+	}
+
+	if modified && c.deploy != deployNone {
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Deploy zone %s (%s deployment)", dc.Name, c.deploy),
+			F: func() error {
+				return c.deployZone(z.ID)
+			},
+		})
+	}
+
+	return corrections, nil
+}
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *bluecatProvider) ListZones() ([]string, error) {
+	if err := c.loadZones(); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(c.zones))
+	for name := range c.zones {
+		zones = append(zones, name)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *bluecatProvider) EnsureZoneExists(domain string) error {
+	if err := c.loadZones(); err != nil {
+		return err
+	}
+	if _, ok := c.zones[strings.ToLower(domain)]; ok {
+		return nil
+	}
+	return c.createZone(domain)
+}
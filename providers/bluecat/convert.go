@@ -0,0 +1,139 @@
+package bluecat
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// defaultTTL is used for records that inherit the TTL of the zone.
+const defaultTTL = 3600
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func linkedTarget(rec *resourceRecord) string {
+	if rec.LinkedRecord == nil {
+		return ""
+	}
+	return strings.TrimSuffix(rec.LinkedRecord.AbsoluteName, ".") + "."
+}
+
+// toRecordConfigs converts a resource record into RecordConfigs. Host
+// records produce one RecordConfig per address. Unsupported types return nil.
+func toRecordConfigs(domain string, rec *resourceRecord) ([]*models.RecordConfig, error) {
+	newRc := func(rtype string) *models.RecordConfig {
+		rc := &models.RecordConfig{
+			Type:     rtype,
+			TTL:      defaultTTL,
+			Original: rec,
+		}
+		if rec.TTL != nil && *rec.TTL >= 0 {
+			rc.TTL = uint32(*rec.TTL)
+		}
+		rc.SetLabelFromFQDN(rec.AbsoluteName, domain)
+		return rc
+	}
+
+	var rcs []*models.RecordConfig
+	var err error
+	switch rec.Type {
+	case "HostRecord":
+		for _, a := range rec.Addresses {
+			rtype := "A"
+			if strings.Contains(a.Address, ":") {
+				rtype = "AAAA"
+			}
+			rc := newRc(rtype)
+			if err := rc.SetTarget(a.Address); err != nil {
+				return nil, err
+			}
+			rcs = append(rcs, rc)
+		}
+		return rcs, nil
+	case "AliasRecord":
+		rc := newRc("CNAME")
+		err = rc.SetTarget(linkedTarget(rec))
+		rcs = append(rcs, rc)
+	case "MXRecord":
+		rc := newRc("MX")
+		err = rc.SetTargetMX(deref(rec.Priority), linkedTarget(rec))
+		rcs = append(rcs, rc)
+	case "SRVRecord":
+		rc := newRc("SRV")
+		err = rc.SetTargetSRV(deref(rec.Priority), deref(rec.Weight), deref(rec.Port), linkedTarget(rec))
+		rcs = append(rcs, rc)
+	case "TXTRecord":
+		rc := newRc("TXT")
+		err = rc.SetTargetTXT(rec.Text)
+		rcs = append(rcs, rc)
+	case "GenericRecord":
+		rc := newRc(rec.RecordType)
+		err = rc.PopulateFromString(rec.RecordType, rec.RData, domain)
+		rcs = append(rcs, rc)
+	default:
+		// ExternalHostRecord, HINFORecord, NAPTRRecord, ...
+		return nil, nil
+	}
+	return rcs, err
+}
+
+// toRecord converts a RecordConfig into a new resource record.
+func toRecord(rc *models.RecordConfig) *resourceRecord {
+	name := rc.GetLabel()
+	if name == "@" {
+		name = ""
+	}
+	rec := &resourceRecord{
+		Name: name,
+		TTL:  ptr(int(rc.TTL)),
+	}
+	linked := &linkedRecord{AbsoluteName: strings.TrimSuffix(rc.GetTargetField(), ".")}
+
+	switch rc.Type {
+	case "CNAME":
+		rec.Type = "AliasRecord"
+		rec.LinkedRecord = linked
+	case "MX":
+		rec.Type = "MXRecord"
+		rec.LinkedRecord = linked
+		rec.Priority = ptr(rc.MxPreference)
+	case "SRV":
+		rec.Type = "SRVRecord"
+		rec.LinkedRecord = linked
+		rec.Priority = ptr(rc.SrvPriority)
+		rec.Weight = ptr(rc.SrvWeight)
+		rec.Port = ptr(rc.SrvPort)
+	case "TXT":
+		rec.Type = "TXTRecord"
+		rec.Text = rc.GetTargetTXTJoined()
+	default:
+		rec.Type = "GenericRecord"
+		rec.RecordType = rc.Type
+		rec.RData = rc.GetTargetCombined()
+	}
+	return rec
+}
+
+// replaceAddress replaces or, if to is empty, removes an address of a host record.
+func replaceAddress(rec *resourceRecord, from, to string) {
+	i := slices.IndexFunc(rec.Addresses, func(a address) bool { return a.Address == from })
+	if i < 0 {
+		return
+	}
+	if to == "" {
+		rec.Addresses = slices.Delete(rec.Addresses, i, i+1)
+	} else {
+		rec.Addresses[i].Address = to
+	}
+}
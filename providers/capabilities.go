@@ -54,6 +54,11 @@ const (
 	// only for children records, not at the root of the zone.
 	CanUseDSForChildren
 
+	// CanUseDSAtRegistrar indicates the registrar can publish DS records in
+	// the parent zone. DS records at the root of the zone are then sent to
+	// the registrar instead of the DNS providers.
+	CanUseDSAtRegistrar
+
 	// CanUseHTTPS indicates the provider can handle HTTPS records
 	CanUseHTTPS
 
@@ -127,6 +132,27 @@ type ProviderMetadata interface{}
 // Notes is a collection of all documentation notes, keyed by provider type
 var Notes = map[string]DocumentationNotes{}
 
+// RequiredScopes lists the minimal permissions of the API that the
+// credentials of a provider need, with the names of the API of the
+// provider. Read is needed by preview, and Write by push in addition to
+// Read.
+type RequiredScopes struct {
+	Read  []string
+	Write []string
+}
+
+// For returns the permissions needed by push, or else by preview.
+func (s RequiredScopes) For(push bool) []string {
+	scopes := append([]string{}, s.Read...)
+	if push {
+		scopes = append(scopes, s.Write...)
+	}
+	return scopes
+}
+
+// Scopes is a collection of the required scopes, keyed by provider type
+var Scopes = map[string]RequiredScopes{}
+
 func unwrapProviderCapabilities(pName string, meta []ProviderMetadata) {
 	if providerCapabilities[pName] == nil {
 		providerCapabilities[pName] = map[Capability]bool{}
@@ -143,6 +169,8 @@ func unwrapProviderCapabilities(pName string, meta []ProviderMetadata) {
 				Notes[pName][k] = v
 				providerCapabilities[pName][k] = v.HasFeature
 			}
+		case RequiredScopes:
+			Scopes[pName] = x
 		default:
 			log.Fatalf("Unrecognized ProviderMetadata type: %T", pm)
 		}
@@ -19,26 +19,27 @@ func _() {
 	_ = x[CanUseDNAME-8]
 	_ = x[CanUseDS-9]
 	_ = x[CanUseDSForChildren-10]
-	_ = x[CanUseHTTPS-11]
-	_ = x[CanUseLOC-12]
-	_ = x[CanUseNAPTR-13]
-	_ = x[CanUseOPENPGPKEY-14]
-	_ = x[CanUsePTR-15]
-	_ = x[CanUseRoute53Alias-16]
-	_ = x[CanUseSOA-17]
-	_ = x[CanUseSRV-18]
-	_ = x[CanUseSSHFP-19]
-	_ = x[CanUseSVCB-20]
-	_ = x[CanUseTLSA-21]
-	_ = x[CanUseDNSKEY-22]
-	_ = x[DocCreateDomains-23]
-	_ = x[DocDualHost-24]
-	_ = x[DocOfficiallySupported-25]
+	_ = x[CanUseDSAtRegistrar-11]
+	_ = x[CanUseHTTPS-12]
+	_ = x[CanUseLOC-13]
+	_ = x[CanUseNAPTR-14]
+	_ = x[CanUseOPENPGPKEY-15]
+	_ = x[CanUsePTR-16]
+	_ = x[CanUseRoute53Alias-17]
+	_ = x[CanUseSOA-18]
+	_ = x[CanUseSRV-19]
+	_ = x[CanUseSSHFP-20]
+	_ = x[CanUseSVCB-21]
+	_ = x[CanUseTLSA-22]
+	_ = x[CanUseDNSKEY-23]
+	_ = x[DocCreateDomains-24]
+	_ = x[DocDualHost-25]
+	_ = x[DocOfficiallySupported-26]
 }
 
-const _Capability_name = "CanAutoDNSSECCanConcurCanGetZonesCanUseAKAMAICDNCanUseAliasCanUseAzureAliasCanUseCAACanUseDHCIDCanUseDNAMECanUseDSCanUseDSForChildrenCanUseHTTPSCanUseLOCCanUseNAPTRCanUseOPENPGPKEYCanUsePTRCanUseRoute53AliasCanUseSOACanUseSRVCanUseSSHFPCanUseSVCBCanUseTLSACanUseDNSKEYDocCreateDomainsDocDualHostDocOfficiallySupported"
+const _Capability_name = "CanAutoDNSSECCanConcurCanGetZonesCanUseAKAMAICDNCanUseAliasCanUseAzureAliasCanUseCAACanUseDHCIDCanUseDNAMECanUseDSCanUseDSForChildrenCanUseDSAtRegistrarCanUseHTTPSCanUseLOCCanUseNAPTRCanUseOPENPGPKEYCanUsePTRCanUseRoute53AliasCanUseSOACanUseSRVCanUseSSHFPCanUseSVCBCanUseTLSACanUseDNSKEYDocCreateDomainsDocDualHostDocOfficiallySupported"
 
-var _Capability_index = [...]uint16{0, 13, 22, 33, 48, 59, 75, 84, 95, 106, 114, 133, 144, 153, 164, 180, 189, 207, 216, 225, 236, 246, 256, 268, 284, 295, 317}
+var _Capability_index = [...]uint16{0, 13, 22, 33, 48, 59, 75, 84, 95, 106, 114, 133, 152, 163, 172, 183, 199, 208, 226, 235, 244, 255, 265, 275, 287, 303, 314, 336}
 
 func (i Capability) String() string {
 	if i >= Capability(len(_Capability_index)-1) {
@@ -0,0 +1,69 @@
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// scopes are the permission groups of the API tokens which the provider
+// needs for the records.
+var scopes = providers.RequiredScopes{
+	Read:  []string{"Zone Read", "DNS Read"},
+	Write: []string{"DNS Write"},
+}
+
+// CheckCredentials verifies the API token and returns the permissions of
+// required it lacks. The policies of the token are only known if it has the permission
+// "API Tokens Read". The global API key has all the permissions of the user.
+func (c *cloudflareProvider) CheckCredentials(required []string) ([]string, bool, error) {
+	if c.cfClient.APIToken == "" {
+		return nil, false, nil
+	}
+	ctx := context.Background()
+	verified, err := c.cfClient.VerifyAPIToken(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed verifying the API token: %w", err)
+	}
+	if verified.Status != "active" {
+		return nil, false, fmt.Errorf("the API token is %s", verified.Status)
+	}
+	token, err := c.cfClient.GetAPIToken(ctx, verified.ID)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	granted := map[string]bool{}
+	for _, policy := range token.Policies {
+		if policy.Effect != "allow" {
+			continue
+		}
+		for _, group := range policy.PermissionGroups {
+			granted[group.Name] = true
+		}
+	}
+	var missing []string
+	for _, name := range required {
+		// The Write permission includes the Read permission.
+		if !granted[name] && !granted[strings.Replace(name, " Read", " Write", 1)] {
+			missing = append(missing, name)
+		}
+	}
+	return missing, true, nil
+}
+
+// CredentialsExpiry returns the expiry of the API token. The global API key
+// does not expire.
+func (c *cloudflareProvider) CredentialsExpiry() (time.Time, error) {
+	if c.cfClient.APIToken == "" {
+		return time.Time{}, nil
+	}
+	verified, err := c.cfClient.VerifyAPIToken(context.Background())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed verifying the API token: %w", err)
+	}
+	return verified.ExpiresOn, nil
+}
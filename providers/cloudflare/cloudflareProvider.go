@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,9 +35,17 @@ Info required in `creds.json`:
 
 Record level metadata available:
    - cloudflare_proxy ("on", "off", or "full")
+   - cloudflare_comment
+   - cloudflare_tags (comma-separated "name:value" tags)
+   - cloudflare_region (the region key of the hostname, with manage_regional_hostnames)
 
 Domain level metadata available:
    - cloudflare_proxy_default ("on", "off", or "full")
+   - cloudflare_universalssl ("on" or "off")
+   - cloudflare_cname_flattening ("flatten_at_root" or "flatten_all")
+   - cloudflare_always_use_https ("on" or "off")
+   - cloudflare_ssl ("off", "flexible", "full" or "strict")
+   - cloudflare_custom_hostnames (set by CF_CUSTOM_HOSTNAME, with manage_custom_hostnames)
 
  Provider level metadata available:
    - ip_conversions
@@ -44,6 +54,7 @@ Domain level metadata available:
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Can(),
 	providers.CanGetZones:            providers.Can(),
 	providers.CanConcur:              providers.Can(),
 	providers.CanUseAlias:            providers.Can("CF automatically flattens CNAME records into A records dynamically"),
@@ -70,7 +81,7 @@ func init() {
 		Initializer:   newCloudflare,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, scopes)
 	providers.RegisterCustomRecordType("CF_REDIRECT", providerName, "")
 	providers.RegisterCustomRecordType("CF_TEMP_REDIRECT", providerName, "")
 	providers.RegisterCustomRecordType("CF_WORKER_ROUTE", providerName, "")
@@ -83,10 +94,13 @@ type cloudflareProvider struct {
 	ignoredLabels   []string
 	manageRedirects bool // Old "Page Rule"-style redirects.
 	manageWorkers   bool
+	manageRegions   bool // Regional hostnames (Data Localization).
+	manageHostnames bool // Custom hostnames (Cloudflare for SaaS).
 	accountID       string
 	cfClient        *cloudflare.API
 	//
 	manageSingleRedirects bool // New "Single Redirects"-style redirects.
+	deletePageRules       bool // Delete the Page Rules redirects, once migrated.
 	//
 	// Used by
 	tcLogFilename string   // Transcode Log file name
@@ -156,7 +170,7 @@ func (c *cloudflareProvider) GetZoneRecords(domain string, meta map[string]strin
 		}
 	}
 
-	if c.manageRedirects { // if old
+	if c.manageRedirects || c.deletePageRules { // if old, or migrated
 		prs, err := c.getPageRules(domainID, domain)
 		if err != nil {
 			return nil, err
@@ -234,47 +248,35 @@ func (c *cloudflareProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 
 	checkNSModifications(dc)
 
-	var corrections []*models.Correction
+	keepRecordComments(dc, records)
 
 	// Cloudflare is a "ByRecord" API.
 	instructions, err := diff2.ByRecord(records, dc, genComparable)
 	if err != nil {
 		return nil, err
 	}
+	corrections := c.recordCorrections(instructions, domainID)
 
-	for _, inst := range instructions {
-
-		addToFront := false
-		var corrs []*models.Correction
-
-		domainID := domainID
-		msg := inst.Msgs[0]
+	settingCorrections, err := c.getZoneSettingCorrections(dc, domainID)
+	if err != nil {
+		return nil, err
+	}
+	corrections = append(corrections, settingCorrections...)
 
-		switch inst.Type {
-		case diff2.CREATE:
-			createRec := inst.New[0]
-			corrs = c.mkCreateCorrection(createRec, domainID, msg)
-			// DS records must always have a corresponding NS record.
-			// Therefore, we create NS records before any DS records.
-			addToFront = (createRec.Type == "NS")
-		case diff2.CHANGE:
-			newrec := inst.New[0]
-			oldrec := inst.Old[0]
-			corrs = c.mkChangeCorrection(oldrec, newrec, domainID, msg)
-		case diff2.DELETE:
-			deleteRec := inst.Old[0]
-			deleteRecType := deleteRec.Type
-			corrs = c.mkDeleteCorrection(deleteRecType, deleteRec, domainID, msg)
-			// DS records must always have a corresponding NS record.
-			// Therefore, we remove DS records before any NS records.
-			addToFront = (deleteRecType == "DS")
+	if c.manageRegions {
+		regionCorrections, err := c.getRegionalHostnameCorrections(dc, domainID)
+		if err != nil {
+			return nil, err
 		}
+		corrections = append(corrections, regionCorrections...)
+	}
 
-		if addToFront {
-			corrections = append(corrs, corrections...)
-		} else {
-			corrections = append(corrections, corrs...)
+	if c.manageHostnames {
+		hostnameCorrections, err := c.getCustomHostnameCorrections(dc, domainID)
+		if err != nil {
+			return nil, err
 		}
+		corrections = append(corrections, hostnameCorrections...)
 	}
 
 	// Add universalSSL change when needed
@@ -295,6 +297,7 @@ func (c *cloudflareProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 }
 
 func genComparable(rec *models.RecordConfig) string {
+	var parts []string
 	if rec.Type == "A" || rec.Type == "AAAA" || rec.Type == "CNAME" {
 		proxy := rec.Metadata[metaProxy]
 		if proxy != "" {
@@ -304,10 +307,102 @@ func genComparable(rec *models.RecordConfig) string {
 			if proxy == "off" {
 				proxy = "false"
 			}
-			return "proxy=" + proxy
+			parts = append(parts, "proxy="+proxy)
+		}
+	}
+	if comment := rec.Metadata[metaComment]; comment != "" {
+		parts = append(parts, fmt.Sprintf("comment=%q", comment))
+	}
+	if tags := recordTags(rec); len(tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(tags, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// recordTags returns the sorted tags of a record, never nil.
+func recordTags(rec *models.RecordConfig) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(rec.Metadata[metaTags], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// keepRecordComments copies the comment and the tags of the existing records
+// to the desired records that don't set them, so they are left untouched.
+func keepRecordComments(dc *models.DomainConfig, existing models.Records) {
+	key := func(rec *models.RecordConfig) string {
+		return rec.GetLabelFQDN() + " " + rec.Type + " " + rec.ToComparableNoTTL()
+	}
+	found := map[string]*models.RecordConfig{}
+	for _, rec := range existing {
+		if _, ok := rec.Original.(cloudflare.DNSRecord); ok {
+			found[key(rec)] = rec
+		}
+	}
+	for _, rec := range dc.Records {
+		old, ok := found[key(rec)]
+		if !ok {
+			continue
+		}
+		for _, meta := range []string{metaComment, metaTags} {
+			if _, ok := rec.Metadata[meta]; !ok && old.Metadata[meta] != "" {
+				rec.Metadata[meta] = old.Metadata[meta]
+			}
 		}
 	}
-	return ""
+}
+
+// recordCorrections returns the corrections of the instructions of diff2.
+// The Page Rules are deleted once the Single Redirects replacing them are
+// created, so the redirects keep working during the migration.
+func (c *cloudflareProvider) recordCorrections(instructions diff2.ChangeList, domainID string) []*models.Correction {
+	var corrections []*models.Correction
+	var pageRuleDeletions []*models.Correction
+
+	for _, inst := range instructions {
+
+		addToFront := false
+		var corrs []*models.Correction
+
+		msg := inst.Msgs[0]
+
+		switch inst.Type {
+		case diff2.CREATE:
+			createRec := inst.New[0]
+			corrs = c.mkCreateCorrection(createRec, domainID, msg)
+			// DS records must always have a corresponding NS record.
+			// Therefore, we create NS records before any DS records.
+			addToFront = (createRec.Type == "NS")
+		case diff2.CHANGE:
+			newrec := inst.New[0]
+			oldrec := inst.Old[0]
+			corrs = c.mkChangeCorrection(oldrec, newrec, domainID, msg)
+		case diff2.DELETE:
+			deleteRec := inst.Old[0]
+			deleteRecType := deleteRec.Type
+			corrs = c.mkDeleteCorrection(deleteRecType, deleteRec, domainID, msg)
+			// DS records must always have a corresponding NS record.
+			// Therefore, we remove DS records before any NS records.
+			addToFront = (deleteRecType == "DS")
+			if deleteRecType == "PAGE_RULE" {
+				pageRuleDeletions = append(pageRuleDeletions, corrs...)
+				continue
+			}
+		}
+
+		if addToFront {
+			corrections = append(corrs, corrections...)
+		} else {
+			corrections = append(corrections, corrs...)
+		}
+	}
+
+	corrections = append(corrections, pageRuleDeletions...)
+	return corrections
 }
 
 func (c *cloudflareProvider) mkCreateCorrection(newrec *models.RecordConfig, domainID, msg string) []*models.Correction {
@@ -415,6 +510,116 @@ func (c *cloudflareProvider) mkDeleteCorrection(recType string, origRec *models.
 	return []*models.Correction{correction}
 }
 
+// zoneSettings are the zone settings managed by domain metadata, with their
+// values.
+var zoneSettings = []struct {
+	meta, name string
+	values     []string
+}{
+	{metaCNAMEFlattening, "cname_flattening", []string{"flatten_at_root", "flatten_all"}},
+	{metaAlwaysUseHTTPS, "always_use_https", []string{"on", "off"}},
+	{metaSSL, "ssl", []string{"off", "flexible", "full", "strict"}},
+}
+
+// getZoneSettingCorrections returns the corrections of the zone settings set
+// by the domain metadata, and of the DNSSEC state set by AUTODNSSEC_ON/OFF.
+func (c *cloudflareProvider) getZoneSettingCorrections(dc *models.DomainConfig, domainID string) ([]*models.Correction, error) {
+	var corrections []*models.Correction
+	for _, zs := range zoneSettings {
+		expected := strings.ToLower(dc.Metadata[zs.meta])
+		if expected == "" {
+			continue
+		}
+		actual, err := c.getZoneSetting(domainID, zs.name)
+		if err != nil {
+			return nil, err
+		}
+		if actual != expected {
+			name := zs.name
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Zone setting %s will be %s (was %s) for this domain.", name, expected, actual),
+				F:   func() error { return c.changeZoneSetting(domainID, name, expected) },
+			})
+		}
+	}
+
+	if dc.AutoDNSSEC != "" {
+		actual, err := c.getDNSSEC(domainID)
+		if err != nil {
+			return nil, fmt.Errorf("error receiving dnssec state: %w", err)
+		}
+		if expected := dc.AutoDNSSEC == "on"; actual != expected {
+			state := "disabled"
+			if expected {
+				state = "enabled"
+			}
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("DNSSEC will be %s for this domain.", state),
+				F:   func() error { return c.changeDNSSEC(domainID, expected) },
+			})
+		}
+	}
+	return corrections, nil
+}
+
+// getRegionalHostnameCorrections returns the corrections that set the region
+// keys of the hostnames to their cloudflare_region metadata. The regional
+// hostnames of the zone without records that set it are deleted.
+func (c *cloudflareProvider) getRegionalHostnameCorrections(dc *models.DomainConfig, domainID string) ([]*models.Correction, error) {
+	desired := map[string]string{}
+	for _, rec := range dc.Records {
+		region := rec.Metadata[metaRegion]
+		if region == "" {
+			continue
+		}
+		hostname := rec.GetLabelFQDN()
+		if r, ok := desired[hostname]; ok && r != region {
+			return nil, fmt.Errorf("conflicting cloudflare_region for %s: '%s' and '%s'", hostname, r, region)
+		}
+		desired[hostname] = region
+	}
+
+	existing, err := c.getRegionalHostnames(domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := make([]string, 0, len(desired)+len(existing))
+	for hostname := range desired {
+		hostnames = append(hostnames, hostname)
+	}
+	for hostname := range existing {
+		if _, ok := desired[hostname]; !ok {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	sort.Strings(hostnames)
+
+	var corrections []*models.Correction
+	for _, hostname := range hostnames {
+		hostname, region, old := hostname, desired[hostname], existing[hostname]
+		switch {
+		case region == old:
+		case old == "":
+			corrections = append(corrections, &models.Correction{
+				Msg: color.GreenString("+ CREATE regional hostname %s region=%s", hostname, region),
+				F:   func() error { return c.createRegionalHostname(domainID, hostname, region) },
+			})
+		case region == "":
+			corrections = append(corrections, &models.Correction{
+				Msg: color.RedString("- DELETE regional hostname %s region=%s", hostname, old),
+				F:   func() error { return c.deleteRegionalHostname(domainID, hostname) },
+			})
+		default:
+			corrections = append(corrections, &models.Correction{
+				Msg: color.YellowString("± MODIFY regional hostname %s region=%s (was %s)", hostname, region, old),
+				F:   func() error { return c.updateRegionalHostname(domainID, hostname, region) },
+			})
+		}
+	}
+	return corrections, nil
+}
+
 func checkNSModifications(dc *models.DomainConfig) {
 	newList := make([]*models.RecordConfig, 0, len(dc.Records))
 
@@ -458,11 +663,18 @@ func (c *cloudflareProvider) checkUniversalSSL(dc *models.DomainConfig, id strin
 }
 
 const (
-	metaProxy         = "cloudflare_proxy"
-	metaProxyDefault  = metaProxy + "_default"
-	metaOriginalIP    = "original_ip" // TODO(tlim): Unclear what this means.
-	metaUniversalSSL  = "cloudflare_universalssl"
-	metaIPConversions = "ip_conversions" // TODO(tlim): Rename to obscure_rules.
+	metaProxy        = "cloudflare_proxy"
+	metaProxyDefault = metaProxy + "_default"
+	metaOriginalIP   = "original_ip" // TODO(tlim): Unclear what this means.
+	metaUniversalSSL = "cloudflare_universalssl"
+	metaComment      = "cloudflare_comment"
+	metaTags         = "cloudflare_tags"
+	metaRegion       = "cloudflare_region"
+	// Zone settings.
+	metaCNAMEFlattening = "cloudflare_cname_flattening"
+	metaAlwaysUseHTTPS  = "cloudflare_always_use_https"
+	metaSSL             = "cloudflare_ssl"
+	metaIPConversions   = "ip_conversions" // TODO(tlim): Rename to obscure_rules.
 )
 
 func checkProxyVal(v string) (string, error) {
@@ -495,6 +707,20 @@ func (c *cloudflareProvider) preprocessConfig(dc *models.DomainConfig) error {
 		}
 	}
 
+	if dc.Metadata[metaCustomHostnames] != "" && !c.manageHostnames {
+		return fmt.Errorf("you must add 'manage_custom_hostnames: true' metadata to cloudflare provider to use CF_CUSTOM_HOSTNAME")
+	}
+	if _, err := parseCustomHostnames(dc.Metadata); err != nil {
+		return err
+	}
+
+	// Check the zone settings
+	for _, zs := range zoneSettings {
+		if v := strings.ToLower(dc.Metadata[zs.meta]); v != "" && !slices.Contains(zs.values, v) {
+			return fmt.Errorf("bad metadata value for %s: '%s'. Use %s", zs.meta, v, strings.Join(zs.values, "/"))
+		}
+	}
+
 	// Normalize the proxy setting for each record.
 	// A and CNAMEs: Validate. If null, set to default.
 	// else: Make sure it wasn't set.  Set to default.
@@ -587,6 +813,9 @@ func (c *cloudflareProvider) preprocessConfig(dc *models.DomainConfig) error {
 				return fmt.Errorf("you must add 'manage_single_redirects: true' metadata to cloudflare provider to use CF_SINGLE__REDIRECT records")
 			}
 
+		} else if rec.Metadata[metaRegion] != "" && !c.manageRegions {
+			return fmt.Errorf("you must add 'manage_regional_hostnames: true' metadata to cloudflare provider to use cloudflare_region")
+
 		} else if rec.Type == "CF_WORKER_ROUTE" {
 			// CF_WORKER_ROUTE record types. Encode target as $PATTERN,$SCRIPT
 			parts := strings.Split(rec.GetTargetField(), ",")
@@ -697,8 +926,11 @@ func newCloudflare(m map[string]string, metadata json.RawMessage) (providers.DNS
 			IgnoredLabels   []string `json:"ignored_labels"`
 			ManageRedirects bool     `json:"manage_redirects"` // Old-style PAGE_RULE-based redirects
 			ManageWorkers   bool     `json:"manage_workers"`
+			ManageRegions   bool     `json:"manage_regional_hostnames"` // Regional hostnames (Data Localization)
+			ManageHostnames bool     `json:"manage_custom_hostnames"`   // Custom hostnames (Cloudflare for SaaS)
 			//
 			ManageSingleRedirects bool   `json:"manage_single_redirects"` // New-style Dynamic "Single Redirects"
+			DeletePageRules       bool   `json:"delete_page_rules"`       // Delete the PAGE_RULE-based redirects.
 			TranscodeLogFilename  string `json:"transcode_log"`           // Log the PAGE_RULE conversions.
 		}{}
 		err := json.Unmarshal([]byte(metadata), parsedMeta)
@@ -707,8 +939,14 @@ func newCloudflare(m map[string]string, metadata json.RawMessage) (providers.DNS
 		}
 		api.manageSingleRedirects = parsedMeta.ManageSingleRedirects
 		api.manageRedirects = parsedMeta.ManageRedirects
+		api.deletePageRules = parsedMeta.DeletePageRules
+		if api.deletePageRules && (api.manageRedirects || !api.manageSingleRedirects) {
+			return nil, fmt.Errorf("cloudflare 'delete_page_rules' requires 'manage_single_redirects: true' and 'manage_redirects: false'")
+		}
 		api.tcLogFilename = parsedMeta.TranscodeLogFilename
 		api.manageWorkers = parsedMeta.ManageWorkers
+		api.manageRegions = parsedMeta.ManageRegions
+		api.manageHostnames = parsedMeta.ManageHostnames
 		// ignored_labels:
 		api.ignoredLabels = append(api.ignoredLabels, parsedMeta.IgnoredLabels...)
 		if len(api.ignoredLabels) > 0 {
@@ -852,6 +1090,12 @@ func (c *cloudflareProvider) nativeToRecord(domain string, cr cloudflare.DNSReco
 		Metadata: map[string]string{},
 	}
 	rc.SetLabelFromFQDN(cr.Name, domain)
+	if cr.Comment != "" {
+		rc.Metadata[metaComment] = cr.Comment
+	}
+	if len(cr.Tags) > 0 {
+		rc.Metadata[metaTags] = strings.Join(cr.Tags, ",")
+	}
 
 	// workaround for https://github.com/StackExchange/dnscontrol/issues/446
 	if cr.Type == "SPF" {
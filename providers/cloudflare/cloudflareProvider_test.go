@@ -0,0 +1,74 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers/cloudflare/rtypes/cfsingleredirect"
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestNewCloudflare_DeletePageRules(t *testing.T) {
+	creds := map[string]string{"apitoken": "token"}
+	for _, test := range []struct {
+		metadata string
+		ok       bool
+	}{
+		{`{"manage_single_redirects": true, "delete_page_rules": true}`, true},
+		{`{"manage_single_redirects": true, "manage_redirects": false, "delete_page_rules": true}`, true},
+		{`{"delete_page_rules": true}`, false},
+		{`{"manage_single_redirects": false, "delete_page_rules": true}`, false},
+		{`{"manage_single_redirects": true, "manage_redirects": true, "delete_page_rules": true}`, false},
+		{`{"manage_redirects": true, "delete_page_rules": true}`, false},
+	} {
+		t.Run(test.metadata, func(t *testing.T) {
+			p, err := newCloudflare(creds, json.RawMessage(test.metadata))
+			if test.ok {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !p.(*cloudflareProvider).deletePageRules {
+					t.Error("delete_page_rules is not set")
+				}
+			} else if err == nil {
+				t.Error("Expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestRecordCorrections_PageRulesDeletedLast(t *testing.T) {
+	label := func(rc *models.RecordConfig, name string) *models.RecordConfig {
+		rc.SetLabel(name, "example.com")
+		return rc
+	}
+	pageRule := label(&models.RecordConfig{Type: "PAGE_RULE", Original: cloudflare.PageRule{ID: "pr1"}}, "@")
+	redirect := label(&models.RecordConfig{Type: cfsingleredirect.SINGLEREDIRECT, CloudflareRedirect: &models.CloudflareSingleRedirectConfig{}}, "@")
+	a := label(&models.RecordConfig{Type: "A"}, "www")
+	a.SetTarget("192.0.2.1")
+
+	instructions := diff2.ChangeList{
+		{Type: diff2.DELETE, Old: models.Records{pageRule}, Msgs: []string{"delete page rule"}},
+		{Type: diff2.CREATE, New: models.Records{redirect}, Msgs: []string{"create single redirect"}},
+		{Type: diff2.CREATE, New: models.Records{a}, Msgs: []string{"create a"}},
+	}
+	c := &cloudflareProvider{deletePageRules: true, manageSingleRedirects: true}
+	corrections := c.recordCorrections(instructions, "zone")
+
+	var got []string
+	for _, corr := range corrections {
+		got = append(got, corr.Msg)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got the corrections %q, want 3", got)
+	}
+	if got[0] != "create single redirect" || got[1] != "create a" {
+		t.Errorf("got the corrections %q, want the creations first", got)
+	}
+	if !strings.HasPrefix(got[2], "delete page rule") {
+		t.Errorf("got the corrections %q, want the deletion of the Page Rule last", got)
+	}
+}
@@ -0,0 +1,138 @@
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+)
+
+// metaCustomHostnames is the domain metadata set by CF_CUSTOM_HOSTNAME(), a
+// JSON object of the custom hostnames (Cloudflare for SaaS) by hostname.
+const metaCustomHostnames = "cloudflare_custom_hostnames"
+
+// The methods which validate the certificate of a custom hostname.
+var customHostnameMethods = []string{"http", "txt", "email"}
+
+// customHostnameConfig is a custom hostname declared by CF_CUSTOM_HOSTNAME().
+type customHostnameConfig struct {
+	SSLMethod          string `json:"ssl_method,omitempty"`
+	CustomOriginServer string `json:"custom_origin_server,omitempty"`
+	CustomOriginSNI    string `json:"custom_origin_sni,omitempty"`
+}
+
+func (c customHostnameConfig) String() string {
+	s := "ssl_method=" + c.SSLMethod
+	if c.CustomOriginServer != "" {
+		s += " origin=" + c.CustomOriginServer
+	}
+	if c.CustomOriginSNI != "" {
+		s += " sni=" + c.CustomOriginSNI
+	}
+	return s
+}
+
+// customHostname is a custom hostname of the zone.
+type customHostname struct {
+	ID string
+	customHostnameConfig
+}
+
+// parseCustomHostnames returns the custom hostnames of the domain metadata.
+func parseCustomHostnames(metadata map[string]string) (map[string]customHostnameConfig, error) {
+	desired := map[string]customHostnameConfig{}
+	v := metadata[metaCustomHostnames]
+	if v == "" {
+		return desired, nil
+	}
+	var configs map[string]customHostnameConfig
+	if err := json.Unmarshal([]byte(v), &configs); err != nil {
+		return nil, fmt.Errorf("invalid CF_CUSTOM_HOSTNAME: %w", err)
+	}
+	for hostname, config := range configs {
+		hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+		if hostname == "" {
+			return nil, fmt.Errorf("CF_CUSTOM_HOSTNAME needs a hostname")
+		}
+		config.SSLMethod = strings.ToLower(config.SSLMethod)
+		if config.SSLMethod == "" {
+			config.SSLMethod = "http"
+		}
+		if !slices.Contains(customHostnameMethods, config.SSLMethod) {
+			return nil, fmt.Errorf("CF_CUSTOM_HOSTNAME %s: ssl_method (%s) must be one of %s", hostname, config.SSLMethod, strings.Join(customHostnameMethods, ", "))
+		}
+		if config.CustomOriginSNI != "" && config.CustomOriginServer == "" {
+			return nil, fmt.Errorf("CF_CUSTOM_HOSTNAME %s: custom_origin_sni needs custom_origin_server", hostname)
+		}
+		desired[hostname] = config
+	}
+	return desired, nil
+}
+
+// getCustomHostnameCorrections returns the corrections that create, update
+// and delete the custom hostnames of the zone, as declared by
+// CF_CUSTOM_HOSTNAME(). The custom hostnames that aren't declared are
+// deleted.
+func (c *cloudflareProvider) getCustomHostnameCorrections(dc *models.DomainConfig, domainID string) ([]*models.Correction, error) {
+	desired, err := parseCustomHostnames(dc.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := c.getCustomHostnames(domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := make([]string, 0, len(desired)+len(existing))
+	for hostname := range desired {
+		hostnames = append(hostnames, hostname)
+	}
+	for hostname := range existing {
+		if _, ok := desired[hostname]; !ok {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	sort.Strings(hostnames)
+
+	var corrections []*models.Correction
+	for _, hostname := range hostnames {
+		config, wanted := desired[hostname]
+		old, found := existing[hostname]
+		switch {
+		case !found:
+			corrections = append(corrections, &models.Correction{
+				Msg: color.GreenString("+ CREATE custom hostname %s %s", hostname, config),
+				F:   func() error { return c.createCustomHostname(domainID, hostname, config) },
+			})
+		case !wanted:
+			corrections = append(corrections, &models.Correction{
+				Msg: color.RedString("- DELETE custom hostname %s %s", hostname, old.customHostnameConfig),
+				F:   func() error { return c.deleteCustomHostname(domainID, old.ID) },
+			})
+		case config == old.customHostnameConfig:
+		case old.CustomOriginServer != "" && config.CustomOriginServer == "",
+			old.CustomOriginSNI != "" && config.CustomOriginSNI == "":
+			// The API can't remove the custom origin of a custom hostname,
+			// which is created again.
+			corrections = append(corrections, &models.Correction{
+				Msg: color.YellowString("± MODIFY custom hostname %s %s (was %s), recreated", hostname, config, old.customHostnameConfig),
+				F: func() error {
+					if err := c.deleteCustomHostname(domainID, old.ID); err != nil {
+						return err
+					}
+					return c.createCustomHostname(domainID, hostname, config)
+				},
+			})
+		default:
+			corrections = append(corrections, &models.Correction{
+				Msg: color.YellowString("± MODIFY custom hostname %s %s (was %s)", hostname, config, old.customHostnameConfig),
+				F:   func() error { return c.updateCustomHostname(domainID, old.ID, config) },
+			})
+		}
+	}
+	return corrections, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/transform"
+	"github.com/cloudflare/cloudflare-go"
 )
 
 func newDomainConfig() *models.DomainConfig {
@@ -126,3 +127,62 @@ func TestIpRewriting(t *testing.T) {
 		}
 	}
 }
+
+func TestKeepRecordComments(t *testing.T) {
+	existing := makeRCmeta(map[string]string{metaComment: "owned by ops", metaTags: "team:ops,env:prod"})
+	existing.Original = cloudflare.DNSRecord{}
+
+	domain := newDomainConfig()
+	kept := makeRCmeta(map[string]string{})
+	cleared := makeRCmeta(map[string]string{metaComment: ""})
+	domain.Records = append(domain.Records, kept, cleared)
+	keepRecordComments(domain, models.Records{existing})
+
+	if genComparable(kept) != genComparable(existing) {
+		t.Fatalf("expected %q but found %q", genComparable(existing), genComparable(kept))
+	}
+	if expected := "tags=env:prod,team:ops"; genComparable(existing) != `comment="owned by ops" `+expected || genComparable(cleared) != expected {
+		t.Fatalf("unexpected comparable %q", genComparable(cleared))
+	}
+}
+
+func TestPreprocess_ZoneSettings(t *testing.T) {
+	cf := &cloudflareProvider{}
+	domain := newDomainConfig()
+	domain.Metadata[metaSSL] = "Strict"
+	domain.Metadata[metaCNAMEFlattening] = "flatten_all"
+	if err := cf.preprocessConfig(domain); err != nil {
+		t.Fatal(err)
+	}
+	domain.Metadata[metaAlwaysUseHTTPS] = "true"
+	if err := cf.preprocessConfig(domain); err == nil {
+		t.Fatal("Expected validation error, but got none")
+	}
+}
+
+func TestPreprocess_CustomHostnames(t *testing.T) {
+	cf := &cloudflareProvider{}
+	domain := newDomainConfig()
+	domain.Metadata[metaCustomHostnames] = `{"Shop.Customer.com.":{"custom_origin_server":"origin.test.com"}}`
+	if err := cf.preprocessConfig(domain); err == nil {
+		t.Fatal("Expected an error without manage_custom_hostnames, but got none")
+	}
+
+	cf.manageHostnames = true
+	if err := cf.preprocessConfig(domain); err != nil {
+		t.Fatal(err)
+	}
+	hostnames, err := parseCustomHostnames(domain.Metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := customHostnameConfig{SSLMethod: "http", CustomOriginServer: "origin.test.com"}
+	if hostnames["shop.customer.com"] != expected {
+		t.Fatalf("expected %v but found %v", expected, hostnames)
+	}
+
+	domain.Metadata[metaCustomHostnames] = `{"shop.customer.com":{"ssl_method":"cname"}}`
+	if err := cf.preprocessConfig(domain); err == nil {
+		t.Fatal("Expected validation error, but got none")
+	}
+}
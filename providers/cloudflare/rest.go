@@ -9,6 +9,7 @@ import (
 	"golang.org/x/net/idna"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v4/providers/cloudflare/rtypes/cfsingleredirect"
 	"github.com/cloudflare/cloudflare-go"
 )
@@ -31,13 +32,13 @@ func (c *cloudflareProvider) cacheDomainList() error {
 	for _, zone := range zones {
 		if encoded, err := idna.ToASCII(zone.Name); err == nil && encoded != zone.Name {
 			if _, ok := c.domainIndex[encoded]; ok {
-				fmt.Printf("WARNING: Zone %q appears twice in this cloudflare account\n", encoded)
+				printer.Warnf("Zone %q appears twice in this cloudflare account\n", encoded)
 			}
 			c.domainIndex[encoded] = zone.ID
 			c.nameservers[encoded] = zone.NameServers
 		}
 		if _, ok := c.domainIndex[zone.Name]; ok {
-			fmt.Printf("WARNING: Zone %q appears twice in this cloudflare account\n", zone.Name)
+			printer.Warnf("Zone %q appears twice in this cloudflare account\n", zone.Name)
 		}
 		c.domainIndex[zone.Name] = zone.ID
 		c.nameservers[zone.Name] = zone.NameServers
@@ -179,6 +180,8 @@ func (c *cloudflareProvider) createRecDiff2(rec *models.RecordConfig, domainID s
 				TTL:      int(rec.TTL),
 				Content:  content,
 				Priority: &rec.MxPreference,
+				Comment:  rec.Metadata[metaComment],
+				Tags:     recordTags(rec),
 			}
 			if rec.Type == "SRV" {
 				cf.Data = cfSrvData(rec)
@@ -224,6 +227,7 @@ func (c *cloudflareProvider) modifyRecord(domainID, recID string, proxied bool,
 		return fmt.Errorf("cannot modify record if domain or record id are empty")
 	}
 
+	comment := rec.Metadata[metaComment]
 	r := cloudflare.UpdateDNSRecordParams{
 		ID:       recID,
 		Proxied:  &proxied,
@@ -232,6 +236,8 @@ func (c *cloudflareProvider) modifyRecord(domainID, recID string, proxied bool,
 		Content:  rec.GetTargetField(),
 		Priority: &rec.MxPreference,
 		TTL:      int(rec.TTL),
+		Comment:  &comment,
+		Tags:     recordTags(rec),
 	}
 	if rec.Type == "TXT" {
 		r.Content = rec.GetTargetTXTJoined()
@@ -277,6 +283,113 @@ func (c *cloudflareProvider) getUniversalSSL(domainID string) (bool, error) {
 	return result.Enabled, err
 }
 
+// get the value of a zone setting
+func (c *cloudflareProvider) getZoneSetting(domainID, name string) (string, error) {
+	setting, err := c.cfClient.GetZoneSetting(context.Background(), cloudflare.ZoneIdentifier(domainID), cloudflare.GetZoneSettingParams{Name: name})
+	if err != nil {
+		return "", fmt.Errorf("failed fetching zone setting %s from cloudflare: %w", name, err)
+	}
+	return fmt.Sprint(setting.Value), nil
+}
+
+// change the value of a zone setting
+func (c *cloudflareProvider) changeZoneSetting(domainID, name, value string) error {
+	_, err := c.cfClient.UpdateZoneSetting(context.Background(), cloudflare.ZoneIdentifier(domainID), cloudflare.UpdateZoneSettingParams{Name: name, Value: value})
+	return err
+}
+
+// get dnssec state
+func (c *cloudflareProvider) getDNSSEC(domainID string) (bool, error) {
+	result, err := c.cfClient.ZoneDNSSECSetting(context.Background(), domainID)
+	return result.Status == "active" || result.Status == "pending", err
+}
+
+// change dnssec state
+func (c *cloudflareProvider) changeDNSSEC(domainID string, state bool) error {
+	status := "disabled"
+	if state {
+		status = "active"
+	}
+	_, err := c.cfClient.UpdateZoneDNSSEC(context.Background(), domainID, cloudflare.ZoneDNSSECUpdateOptions{Status: status})
+	return err
+}
+
+// get the regional hostnames of a zone, by hostname
+func (c *cloudflareProvider) getRegionalHostnames(domainID string) (map[string]string, error) {
+	hostnames, err := c.cfClient.ListDataLocalizationRegionalHostnames(context.Background(), cloudflare.ZoneIdentifier(domainID), cloudflare.ListDataLocalizationRegionalHostnamesParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching regional hostnames from cloudflare: %w", err)
+	}
+	regions := map[string]string{}
+	for _, h := range hostnames {
+		regions[h.Hostname] = h.RegionKey
+	}
+	return regions, nil
+}
+
+func (c *cloudflareProvider) createRegionalHostname(domainID, hostname, region string) error {
+	_, err := c.cfClient.CreateDataLocalizationRegionalHostname(context.Background(), cloudflare.ZoneIdentifier(domainID), cloudflare.CreateDataLocalizationRegionalHostnameParams{Hostname: hostname, RegionKey: region})
+	return err
+}
+
+func (c *cloudflareProvider) updateRegionalHostname(domainID, hostname, region string) error {
+	_, err := c.cfClient.UpdateDataLocalizationRegionalHostname(context.Background(), cloudflare.ZoneIdentifier(domainID), cloudflare.UpdateDataLocalizationRegionalHostnameParams{Hostname: hostname, RegionKey: region})
+	return err
+}
+
+func (c *cloudflareProvider) deleteRegionalHostname(domainID, hostname string) error {
+	return c.cfClient.DeleteDataLocalizationRegionalHostname(context.Background(), cloudflare.ZoneIdentifier(domainID), hostname)
+}
+
+// get the custom hostnames of a zone, by hostname
+func (c *cloudflareProvider) getCustomHostnames(domainID string) (map[string]*customHostname, error) {
+	hostnames := map[string]*customHostname{}
+	for page := 1; ; page++ {
+		list, info, err := c.cfClient.CustomHostnames(context.Background(), domainID, page, cloudflare.CustomHostname{})
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching custom hostnames from cloudflare: %w", err)
+		}
+		for _, ch := range list {
+			h := &customHostname{ID: ch.ID}
+			h.CustomOriginServer = ch.CustomOriginServer
+			h.CustomOriginSNI = ch.CustomOriginSNI
+			if ch.SSL != nil {
+				h.SSLMethod = ch.SSL.Method
+			}
+			hostnames[strings.ToLower(ch.Hostname)] = h
+		}
+		if page >= info.TotalPages {
+			return hostnames, nil
+		}
+	}
+}
+
+func nativeCustomHostname(hostname string, config customHostnameConfig) cloudflare.CustomHostname {
+	return cloudflare.CustomHostname{
+		Hostname:           hostname,
+		CustomOriginServer: config.CustomOriginServer,
+		CustomOriginSNI:    config.CustomOriginSNI,
+		SSL: &cloudflare.CustomHostnameSSL{
+			Method: config.SSLMethod,
+			Type:   "dv",
+		},
+	}
+}
+
+func (c *cloudflareProvider) createCustomHostname(domainID, hostname string, config customHostnameConfig) error {
+	_, err := c.cfClient.CreateCustomHostname(context.Background(), domainID, nativeCustomHostname(hostname, config))
+	return err
+}
+
+func (c *cloudflareProvider) updateCustomHostname(domainID, id string, config customHostnameConfig) error {
+	_, err := c.cfClient.UpdateCustomHostname(context.Background(), domainID, id, nativeCustomHostname("", config))
+	return err
+}
+
+func (c *cloudflareProvider) deleteCustomHostname(domainID, id string) error {
+	return c.cfClient.DeleteCustomHostname(context.Background(), domainID, id)
+}
+
 func (c *cloudflareProvider) getSingleRedirects(id string, domain string) ([]*models.RecordConfig, error) {
 	rules, err := c.cfClient.GetEntrypointRuleset(context.Background(), cloudflare.ZoneIdentifier(id), "http_request_dynamic_redirect")
 	if err != nil {
@@ -0,0 +1,263 @@
+package constellix
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	baseURL        = "https://api.dns.constellix.com/v4"
+	perPage        = 100
+	initialBackoff = time.Second * 5 // First backoff delay duration
+	maxBackoff     = time.Minute     // Maximum backoff delay
+)
+
+type constellixProvider struct {
+	apiKey    string
+	secretKey string
+	baseURL   string
+
+	domains map[string]*domain
+}
+
+type domain struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Status      string   `json:"status"`
+	Nameservers []string `json:"nameservers"`
+}
+
+// idRef is a reference to another resource, such as an IP filter. The API
+// accepts a bare ID but returns an object with the ID and links.
+type idRef int
+
+func (r *idRef) UnmarshalJSON(b []byte) error {
+	var obj struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(b, &obj); err == nil {
+		*r = idRef(obj.ID)
+		return nil
+	}
+	var id int
+	if err := json.Unmarshal(b, &id); err != nil {
+		return err
+	}
+	*r = idRef(id)
+	return nil
+}
+
+type record struct {
+	ID           int             `json:"id,omitempty"`
+	Name         string          `json:"name"`
+	Type         string          `json:"type"`
+	TTL          uint32          `json:"ttl"`
+	Mode         string          `json:"mode"`
+	Region       string          `json:"region"`
+	IPFilter     *idRef          `json:"ipfilter"`
+	IPFilterDrop bool            `json:"ipfilterDrop"`
+	GeoProximity *idRef          `json:"geoproximity"`
+	Enabled      bool            `json:"enabled"`
+	Value        json.RawMessage `json:"value"`
+}
+
+// recordValue is one value of a record. Which fields are used depends on
+// the record type.
+type recordValue struct {
+	Value    string  `json:"value,omitempty"`
+	Host     string  `json:"host,omitempty"`   // NS, SRV
+	Server   string  `json:"server,omitempty"` // MX
+	Priority *uint16 `json:"priority,omitempty"`
+	Weight   *uint16 `json:"weight,omitempty"`
+	Port     *uint16 `json:"port,omitempty"`
+	Flags    *uint8  `json:"flags,omitempty"`
+	Tag      string  `json:"tag,omitempty"`
+	Data     string  `json:"data,omitempty"`
+	Enabled  bool    `json:"enabled"`
+
+	// Failover mode only.
+	Order        int `json:"order,omitempty"`
+	SonarCheckID int `json:"sonarCheckId,omitempty"`
+}
+
+// failoverValue is the value of a record in "failover" mode.
+type failoverValue struct {
+	Mode    string        `json:"mode"`
+	Enabled bool          `json:"enabled"`
+	Values  []recordValue `json:"values"`
+}
+
+type pagination struct {
+	CurrentPage int `json:"currentPage"`
+	TotalPages  int `json:"totalPages"`
+}
+
+type listResponse struct {
+	Data json.RawMessage `json:"data"`
+	Meta struct {
+		Pagination pagination `json:"pagination"`
+	} `json:"meta"`
+}
+
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// securityToken computes the value of the Authorization header.
+// https://api.dns.constellix.com/v4/docs#section/Authentication
+func securityToken(apiKey, secretKey string, now time.Time) string {
+	timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+	mac := hmac.New(sha1.New, []byte(secretKey))
+	mac.Write([]byte(timestamp))
+	return apiKey + ":" + base64.StdEncoding.EncodeToString(mac.Sum(nil)) + ":" + timestamp
+}
+
+func (c *constellixProvider) request(method, endpoint string, body any, target any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	backoff := initialBackoff
+retry:
+	req, err := http.NewRequest(method, c.baseURL+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+securityToken(c.apiKey, c.secretKey, time.Now()))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		printer.Printf("pausing CONSTELLIX due to ratelimit: %v\n", backoff)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxBackoff)
+		goto retry
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		var er errorResponse
+		if json.Unmarshal(data, &er) == nil && len(er.Errors) != 0 {
+			return fmt.Errorf("CONSTELLIX API error: %s: %s", resp.Status, strings.Join(er.Errors, " "))
+		}
+		return fmt.Errorf("CONSTELLIX API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// list fetches all pages of a list endpoint.
+func list[T any](c *constellixProvider, endpoint string) ([]T, error) {
+	var items []T
+	for page := 1; ; page++ {
+		var resp listResponse
+		if err := c.request(http.MethodGet, fmt.Sprintf("%s?page=%d&perPage=%d", endpoint, page, perPage), nil, &resp); err != nil {
+			return nil, err
+		}
+		var batch []T
+		if err := json.Unmarshal(resp.Data, &batch); err != nil {
+			return nil, err
+		}
+		items = append(items, batch...)
+		if page >= resp.Meta.Pagination.TotalPages {
+			break
+		}
+	}
+	return items, nil
+}
+
+func (c *constellixProvider) loadDomains() error {
+	if c.domains != nil {
+		return nil
+	}
+	domains, err := list[domain](c, "/domains")
+	if err != nil {
+		return fmt.Errorf("failed listing domains from CONSTELLIX: %w", err)
+	}
+	c.domains = make(map[string]*domain, len(domains))
+	for i := range domains {
+		c.domains[domains[i].Name] = &domains[i]
+	}
+	return nil
+}
+
+func (c *constellixProvider) findDomain(name string) (*domain, error) {
+	if err := c.loadDomains(); err != nil {
+		return nil, err
+	}
+	d, ok := c.domains[name]
+	if !ok {
+		return nil, fmt.Errorf("domain %q not found in CONSTELLIX account", name)
+	}
+	return d, nil
+}
+
+func (c *constellixProvider) createDomain(name string) error {
+	var resp struct {
+		Data domain `json:"data"`
+	}
+	if err := c.request(http.MethodPost, "/domains", map[string]string{"name": name}, &resp); err != nil {
+		return fmt.Errorf("failed creating domain (CONSTELLIX): %w", err)
+	}
+	if c.domains != nil {
+		c.domains[name] = &resp.Data
+	}
+	return nil
+}
+
+func (c *constellixProvider) getRecords(domainID int) ([]record, error) {
+	records, err := list[record](c, fmt.Sprintf("/domains/%d/records", domainID))
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching records from CONSTELLIX: %w", err)
+	}
+	return records, nil
+}
+
+func (c *constellixProvider) createRecord(domainID int, rec *record) error {
+	if err := c.request(http.MethodPost, fmt.Sprintf("/domains/%d/records", domainID), rec, nil); err != nil {
+		return fmt.Errorf("failed create record (CONSTELLIX): %w", err)
+	}
+	return nil
+}
+
+func (c *constellixProvider) updateRecord(domainID int, rec *record) error {
+	if err := c.request(http.MethodPut, fmt.Sprintf("/domains/%d/records/%d", domainID, rec.ID), rec, nil); err != nil {
+		return fmt.Errorf("failed update record (CONSTELLIX): %w", err)
+	}
+	return nil
+}
+
+func (c *constellixProvider) deleteRecord(domainID, recordID int) error {
+	if err := c.request(http.MethodDelete, fmt.Sprintf("/domains/%d/records/%d", domainID, recordID), nil, nil); err != nil {
+		return fmt.Errorf("failed delete record (CONSTELLIX): %w", err)
+	}
+	return nil
+}
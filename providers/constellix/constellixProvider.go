@@ -0,0 +1,254 @@
+package constellix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for the Constellix DNS API v4, which also serves DNS Made Easy
+// accounts that were migrated to the Constellix platform.
+// API Documentation: https://api.dns.constellix.com/v4/docs
+
+/*
+Constellix DNS provider:
+
+Info required in `creds.json`:
+   - api_key
+   - secret_key
+   - base_url (optional)
+
+Record level metadata available:
+   - constellix_ipfilter (IP filter ID, GeoIP)
+   - constellix_ipfilter_drop ("true" to drop queries not matching the filter)
+   - constellix_geoproximity (geo proximity location ID)
+   - constellix_sonar_check (Sonar check ID, enables failover)
+   - constellix_failover_order (position in the failover list)
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Can(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "CONSTELLIX"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newConstellix,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newConstellix creates the provider.
+func newConstellix(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &constellixProvider{
+		apiKey:    m["api_key"],
+		secretKey: m["secret_key"],
+		baseURL:   strings.TrimSuffix(m["base_url"], "/"),
+	}
+	if c.apiKey == "" || c.secretKey == "" {
+		return nil, fmt.Errorf("missing CONSTELLIX api_key or secret_key")
+	}
+	if c.baseURL == "" {
+		c.baseURL = baseURL
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *constellixProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	d, err := c.findDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	return models.ToNameserversStripTD(d.Nameservers)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *constellixProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	d, err := c.findDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	records, err := c.getRecords(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		rec := &records[i]
+		// Records in other regions or modes (e.g. pools) are managed in the portal.
+		if rec.Region != "" && rec.Region != "default" {
+			printer.Warnf("CONSTELLIX: ignoring %s %s in region %q\n", rec.Name, rec.Type, rec.Region)
+			continue
+		}
+		if rec.Mode != "" && rec.Mode != "standard" && rec.Mode != "failover" {
+			printer.Warnf("CONSTELLIX: ignoring %s %s in %q mode\n", rec.Name, rec.Type, rec.Mode)
+			continue
+		}
+		if rec.Type == "HTTP" || rec.Type == "SPF" {
+			continue
+		}
+		rcs, err := toRecordConfigs(domain, rec)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rcs...)
+	}
+	return existingRecords, nil
+}
+
+// normalizeMetadata numbers the values of failover records in the order
+// they are declared, unless constellix_failover_order is set, and clears
+// the metadata that has no effect so that it doesn't produce corrections.
+func normalizeMetadata(records models.Records) {
+	sets := map[setKey]models.Records{}
+	var keys []setKey
+	for _, rc := range records {
+		if rc.Metadata == nil {
+			rc.Metadata = map[string]string{}
+		}
+		if rc.Metadata[metaIPFilter] == "" || rc.Metadata[metaIPFilterDrop] != "true" {
+			delete(rc.Metadata, metaIPFilterDrop)
+		}
+		k := keyFor(rc)
+		if _, ok := sets[k]; !ok {
+			keys = append(keys, k)
+		}
+		sets[k] = append(sets[k], rc)
+	}
+	for _, k := range keys {
+		failover := false
+		for _, rc := range sets[k] {
+			if rc.Metadata[metaSonarCheck] != "" {
+				failover = true
+			}
+		}
+		for i, rc := range sets[k] {
+			if !failover {
+				delete(rc.Metadata, metaFailoverOrder)
+			} else if rc.Metadata[metaFailoverOrder] == "" {
+				rc.Metadata[metaFailoverOrder] = strconv.Itoa(i + 1)
+			}
+		}
+	}
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *constellixProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	normalizeMetadata(dc.Records)
+
+	changes, err := diff2.ByRecord(existingRecords, dc, genComparable)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	d, err := c.findDomain(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Constellix stores all values of a name, type and filter in one
+	// record, so the per-record changes are regrouped by setKey.
+	var corrections []*models.Correction
+	msgs := map[setKey][]string{}
+	var keys []setKey
+	for _, change := range changes {
+		var k setKey
+		switch change.Type {
+		case diff2.REPORT:
+			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
+			continue
+		case diff2.CREATE:
+			k = keyFor(change.New[0])
+		case diff2.CHANGE, diff2.DELETE:
+			k = keyFor(change.Old[0])
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		if _, ok := msgs[k]; !ok {
+			keys = append(keys, k)
+		}
+		msgs[k] = append(msgs[k], change.Msgs...)
+		// A change may move a record to another filter.
+		if change.Type == diff2.CHANGE {
+			if nk := keyFor(change.New[0]); nk != k {
+				if _, ok := msgs[nk]; !ok {
+					keys = append(keys, nk)
+					msgs[nk] = nil
+				}
+			}
+		}
+	}
+
+	desired := map[setKey]models.Records{}
+	for _, rc := range dc.Records {
+		k := keyFor(rc)
+		desired[k] = append(desired[k], rc)
+	}
+	existing := map[setKey]*record{}
+	for _, rc := range existingRecords {
+		existing[keyFor(rc)] = rc.Original.(*record)
+	}
+
+	for _, k := range keys {
+		msg := strings.Join(msgs[k], "\n")
+		old := existing[k]
+		if len(desired[k]) == 0 {
+			id := old.ID
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("%s, CONSTELLIX ID: %d", msg, id),
+				F:   func() error { return c.deleteRecord(d.ID, id) },
+			})
+			continue
+		}
+		rec, err := toRecord(desired[k])
+		if err != nil {
+			return nil, err
+		}
+		if old == nil {
+			corrections = append(corrections, &models.Correction{
+				Msg: msg,
+				F:   func() error { return c.createRecord(d.ID, rec) },
+			})
+			continue
+		}
+		rec.ID = old.ID
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("%s, CONSTELLIX ID: %d", msg, rec.ID),
+			F:   func() error { return c.updateRecord(d.ID, rec) },
+		})
+	}
+
+	return corrections, nil
+}
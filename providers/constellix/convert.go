@@ -0,0 +1,259 @@
+package constellix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+const (
+	metaIPFilter      = "constellix_ipfilter"
+	metaIPFilterDrop  = "constellix_ipfilter_drop"
+	metaGeoProximity  = "constellix_geoproximity"
+	metaSonarCheck    = "constellix_sonar_check"
+	metaFailoverOrder = "constellix_failover_order"
+)
+
+// setKey identifies the native record a RecordConfig belongs to. Constellix
+// allows several records with the same name and type as long as they are
+// attached to different IP filters or geo proximity locations.
+type setKey struct {
+	Name         string
+	Type         string
+	IPFilter     string
+	GeoProximity string
+}
+
+func (k setKey) String() string {
+	s := k.Name + " " + k.Type
+	if k.IPFilter != "" {
+		s += " ipfilter=" + k.IPFilter
+	}
+	if k.GeoProximity != "" {
+		s += " geoproximity=" + k.GeoProximity
+	}
+	return s
+}
+
+func keyFor(rc *models.RecordConfig) setKey {
+	return setKey{
+		Name:         rc.GetLabel(),
+		Type:         rc.Type,
+		IPFilter:     rc.Metadata[metaIPFilter],
+		GeoProximity: rc.Metadata[metaGeoProximity],
+	}
+}
+
+func genComparable(rc *models.RecordConfig) string {
+	var parts []string
+	for _, k := range []string{metaIPFilter, metaIPFilterDrop, metaGeoProximity, metaSonarCheck, metaFailoverOrder} {
+		if v := rc.Metadata[k]; v != "" {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func idString(r *idRef) string {
+	if r == nil || *r == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(*r))
+}
+
+func parseID(rc *models.RecordConfig, key string) (*idRef, error) {
+	v := rc.Metadata[key]
+	if v == "" {
+		return nil, nil
+	}
+	id, err := strconv.Atoi(v)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("%s: invalid %s %q", rc.GetLabelFQDN(), key, v)
+	}
+	r := idRef(id)
+	return &r, nil
+}
+
+func ensureDot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRecordConfigs converts a native record into RecordConfigs, one per value.
+func toRecordConfigs(domain string, rec *record) ([]*models.RecordConfig, error) {
+	var values []recordValue
+	switch rec.Mode {
+	case "", "standard":
+		if err := json.Unmarshal(rec.Value, &values); err != nil {
+			return nil, fmt.Errorf("parsing value of %s %s: %w", rec.Name, rec.Type, err)
+		}
+	case "failover":
+		var fv failoverValue
+		if err := json.Unmarshal(rec.Value, &fv); err != nil {
+			return nil, fmt.Errorf("parsing failover value of %s %s: %w", rec.Name, rec.Type, err)
+		}
+		values = fv.Values
+	default:
+		return nil, fmt.Errorf("unsupported mode %q", rec.Mode)
+	}
+
+	rcs := make([]*models.RecordConfig, 0, len(values))
+	for _, v := range values {
+		rc := &models.RecordConfig{
+			Type:     rec.Type,
+			TTL:      rec.TTL,
+			Metadata: map[string]string{},
+			Original: rec,
+		}
+		rc.SetLabel(rec.Name, domain)
+		if s := idString(rec.IPFilter); s != "" {
+			rc.Metadata[metaIPFilter] = s
+			if rec.IPFilterDrop {
+				rc.Metadata[metaIPFilterDrop] = "true"
+			}
+		}
+		if s := idString(rec.GeoProximity); s != "" {
+			rc.Metadata[metaGeoProximity] = s
+		}
+		if rec.Mode == "failover" {
+			rc.Metadata[metaFailoverOrder] = strconv.Itoa(v.Order)
+			if v.SonarCheckID != 0 {
+				rc.Metadata[metaSonarCheck] = strconv.Itoa(v.SonarCheckID)
+			}
+		}
+
+		var err error
+		switch rec.Type {
+		case "ANAME":
+			rc.Type = "ALIAS"
+			err = rc.SetTarget(ensureDot(v.Value))
+		case "CNAME", "PTR":
+			err = rc.SetTarget(ensureDot(v.Value))
+		case "NS":
+			err = rc.SetTarget(ensureDot(v.Host))
+		case "MX":
+			err = rc.SetTargetMX(deref(v.Priority), ensureDot(v.Server))
+		case "SRV":
+			err = rc.SetTargetSRV(deref(v.Priority), deref(v.Weight), deref(v.Port), ensureDot(v.Host))
+		case "CAA":
+			var flags uint8
+			if v.Flags != nil {
+				flags = *v.Flags
+			}
+			err = rc.SetTargetCAA(flags, v.Tag, v.Data)
+		case "TXT":
+			err = rc.SetTargetTXT(v.Value)
+		default:
+			err = rc.PopulateFromString(rec.Type, v.Value, domain)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rcs = append(rcs, rc)
+	}
+	return rcs, nil
+}
+
+func deref(p *uint16) uint16 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// toRecord converts the RecordConfigs of one setKey into a native record.
+func toRecord(records models.Records) (*record, error) {
+	first := records[0]
+	name := first.GetLabel()
+	if name == "@" {
+		name = ""
+	}
+	rec := &record{
+		Name:    name,
+		Type:    first.Type,
+		TTL:     first.TTL,
+		Mode:    "standard",
+		Region:  "default",
+		Enabled: true,
+	}
+	if rec.Type == "ALIAS" {
+		rec.Type = "ANAME"
+	}
+
+	var err error
+	if rec.IPFilter, err = parseID(first, metaIPFilter); err != nil {
+		return nil, err
+	}
+	if rec.GeoProximity, err = parseID(first, metaGeoProximity); err != nil {
+		return nil, err
+	}
+	rec.IPFilterDrop = first.Metadata[metaIPFilterDrop] == "true"
+
+	failover := false
+	values := make([]recordValue, 0, len(records))
+	for _, rc := range records {
+		if (rc.Metadata[metaIPFilterDrop] == "true") != rec.IPFilterDrop {
+			return nil, fmt.Errorf("%s: all %s records must use the same %s", rc.GetLabelFQDN(), rc.Type, metaIPFilterDrop)
+		}
+
+		v := recordValue{Enabled: true}
+		switch rec.Type {
+		case "A", "AAAA", "ANAME", "CNAME", "PTR":
+			v.Value = rc.GetTargetField()
+		case "NS":
+			v.Host = rc.GetTargetField()
+		case "MX":
+			v.Server = rc.GetTargetField()
+			v.Priority = ptr(rc.MxPreference)
+		case "SRV":
+			v.Host = rc.GetTargetField()
+			v.Priority = ptr(rc.SrvPriority)
+			v.Weight = ptr(rc.SrvWeight)
+			v.Port = ptr(rc.SrvPort)
+		case "CAA":
+			v.Flags = ptr(rc.CaaFlag)
+			v.Tag = rc.CaaTag
+			v.Data = rc.GetTargetField()
+		case "TXT":
+			v.Value = rc.GetTargetTXTJoined()
+		default:
+			v.Value = rc.GetTargetCombined()
+		}
+
+		if s := rc.Metadata[metaSonarCheck]; s != "" {
+			failover = true
+			if v.SonarCheckID, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("%s: invalid %s %q", rc.GetLabelFQDN(), metaSonarCheck, s)
+			}
+		}
+		if s := rc.Metadata[metaFailoverOrder]; s != "" {
+			if v.Order, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("%s: invalid %s %q", rc.GetLabelFQDN(), metaFailoverOrder, s)
+			}
+		}
+		values = append(values, v)
+	}
+
+	if !failover {
+		for i := range values {
+			values[i].Order = 0
+		}
+		rec.Value, err = json.Marshal(values)
+		return rec, err
+	}
+
+	rec.Mode = "failover"
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Order < values[j].Order })
+	rec.Value, err = json.Marshal(failoverValue{Mode: "normal", Enabled: true, Values: values})
+	return rec, err
+}
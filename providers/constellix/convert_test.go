@@ -0,0 +1,71 @@
+package constellix
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestSecurityToken(t *testing.T) {
+	got := securityToken("key", "secret", time.UnixMilli(1700000000000))
+	want := "key:GkxmUVZIPEAQC5SikuOBv4kZAhc=:1700000000000"
+	if got != want {
+		t.Errorf("securityToken() = %q, want %q", got, want)
+	}
+}
+
+func TestFailoverRoundTrip(t *testing.T) {
+	var records models.Records
+	for i, ip := range []string{"192.0.2.1", "192.0.2.2"} {
+		rc := &models.RecordConfig{Type: "A", TTL: 300, Metadata: map[string]string{
+			metaSonarCheck: []string{"11", "12"}[i],
+			metaIPFilter:   "5",
+		}}
+		rc.SetLabel("www", "example.com")
+		if err := rc.SetTarget(ip); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rc)
+	}
+	normalizeMetadata(records)
+
+	rec, err := toRecord(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Mode != "failover" || rec.Name != "www" || rec.IPFilter == nil || *rec.IPFilter != 5 {
+		t.Fatalf("unexpected record %+v", rec)
+	}
+
+	// Simulate the API returning the filter as an object.
+	data, _ := json.Marshal(rec)
+	var m map[string]any
+	_ = json.Unmarshal(data, &m)
+	m["ipfilter"] = map[string]any{"id": 5, "links": map[string]string{}}
+	data, _ = json.Marshal(m)
+	var back record
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+
+	rcs, err := toRecordConfigs("example.com", &back)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rcs) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(rcs))
+	}
+	for i := range rcs {
+		if rcs[i].GetTargetField() != records[i].GetTargetField() {
+			t.Errorf("target %d: got %q, want %q", i, rcs[i].GetTargetField(), records[i].GetTargetField())
+		}
+		if got, want := genComparable(rcs[i]), genComparable(records[i]); got != want {
+			t.Errorf("comparable %d: got %q, want %q", i, got, want)
+		}
+		if keyFor(rcs[i]) != keyFor(records[i]) {
+			t.Errorf("key %d: got %v, want %v", i, keyFor(rcs[i]), keyFor(records[i]))
+		}
+	}
+}
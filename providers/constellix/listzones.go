@@ -0,0 +1,27 @@
+package constellix
+
+import "sort"
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *constellixProvider) ListZones() ([]string, error) {
+	if err := c.loadDomains(); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(c.domains))
+	for name := range c.domains {
+		zones = append(zones, name)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *constellixProvider) EnsureZoneExists(domain string) error {
+	if err := c.loadDomains(); err != nil {
+		return err
+	}
+	if _, ok := c.domains[domain]; ok {
+		return nil
+	}
+	return c.createDomain(domain)
+}
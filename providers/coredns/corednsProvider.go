@@ -0,0 +1,122 @@
+package coredns
+
+/*
+
+coredns -
+  Generate zone files for the CoreDNS file and auto plugins.
+
+	The zone files are written by the BIND provider, one file per zone
+	in the directory, named so that the auto plugin finds them with its
+	default pattern (db.example.com).
+
+	If the directory is in a git work tree, the zone files are committed
+	after being written, and pushed if a remote is configured.
+
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/StackExchange/dnscontrol/v4/providers/bind"
+)
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot("Use the dnssec plugin of CoreDNS"),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDHCID:            providers.Can(),
+	providers.CanUseDNAME:            providers.Can(),
+	providers.CanUseDS:               providers.Can(),
+	providers.CanUseDNSKEY:           providers.Can(),
+	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseLOC:              providers.Can(),
+	providers.CanUseNAPTR:            providers.Can(),
+	providers.CanUseOPENPGPKEY:       providers.Can(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Can(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Can(),
+	providers.CanUseSVCB:             providers.Can(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Can("Driver just maintains list of zone files. It should automatically add missing ones."),
+	providers.DocDualHost:            providers.Can(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "COREDNS"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newCoreDNS,
+		RecordAuditor: bind.AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// corednsProvider writes the zone files with the BIND provider.
+type corednsProvider struct {
+	providers.DNSServiceProvider
+	git *gitRepo
+}
+
+func newCoreDNS(config map[string]string, providermeta json.RawMessage) (providers.DNSServiceProvider, error) {
+	bindConfig := map[string]string{
+		"directory":      config["directory"],
+		"filenameformat": config["filenameformat"],
+	}
+	if bindConfig["filenameformat"] == "" {
+		bindConfig["filenameformat"] = "db.%D"
+	}
+	dsp, err := bind.NewBind(bindConfig, providermeta)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &corednsProvider{DNSServiceProvider: dsp}
+	if config["git"] == "true" || config["git_remote"] != "" {
+		c.git = &gitRepo{
+			dir:    config["directory"],
+			remote: config["git_remote"],
+			branch: config["git_branch"],
+			author: config["git_author"],
+		}
+		if c.git.dir == "" {
+			c.git.dir = "zones"
+		}
+	}
+	return c, nil
+}
+
+// ListZones returns all the zones in the directory.
+func (c *corednsProvider) ListZones() ([]string, error) {
+	return c.DNSServiceProvider.(providers.ZoneLister).ListZones()
+}
+
+// GetZoneRecordsCorrections returns the corrections that write the zone file,
+// then commit it.
+func (c *corednsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundRecords models.Records) ([]*models.Correction, error) {
+	corrections, err := c.DNSServiceProvider.GetZoneRecordsCorrections(dc, foundRecords)
+	if err != nil || c.git == nil {
+		return corrections, err
+	}
+	for _, corr := range corrections {
+		if corr.F == nil {
+			continue
+		}
+		write, msg := corr.F, corr.Msg
+		corr.F = func() error {
+			if err := write(); err != nil {
+				return err
+			}
+			return c.git.commit(fmt.Sprintf("dnscontrol: update %s\n\n%s", dc.Name, msg))
+		}
+	}
+	return corrections, nil
+}
@@ -0,0 +1,67 @@
+package coredns
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// gitRepo commits the zone files to the git repository that contains the
+// zone directory.
+type gitRepo struct {
+	dir    string // The zone directory, inside the work tree.
+	remote string // Pushed after each commit, if set.
+	branch string // The branch pushed, default is the current branch.
+	author string // The author of the commits, default is the git configuration.
+}
+
+func (g *gitRepo) run(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", g.dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// commit commits the changes of the zone directory, then pushes them if a
+// remote is configured. Nothing is committed if the files are unchanged.
+func (g *gitRepo) commit(msg string) error {
+	if _, err := g.run("add", "-A", "--", "."); err != nil {
+		return err
+	}
+
+	// "diff --cached --quiet" exits with 1 if there are staged changes.
+	_, err := g.run("diff", "--cached", "--quiet", "--", ".")
+	var exitErr *exec.ExitError
+	if err == nil {
+		return nil
+	} else if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		return err
+	}
+
+	args := []string{"commit", "--quiet", "-m", msg}
+	if g.author != "" {
+		args = append(args, "--author", g.author)
+	}
+	if _, err := g.run(append(args, "--", ".")...); err != nil {
+		return err
+	}
+
+	if g.remote == "" {
+		return nil
+	}
+	branch := g.branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+	printer.Printf("PUSHING TO %s %s\n", g.remote, branch)
+	_, err = g.run("push", "--quiet", g.remote, branch)
+	return err
+}
@@ -127,6 +127,32 @@ func (c *desecProvider) EnsureZoneExists(domain string) error {
 	return c.createDomain(domain)
 }
 
+// GetDSRecords returns the DS records of the keys of the zone, to be
+// published by the registrar. A zone which doesn't exist yet has none.
+func (c *desecProvider) GetDSRecords(domain string) (models.Records, error) {
+	_, ok, err := c.searchDomainIndex(domain)
+	if err != nil || !ok {
+		return nil, err
+	}
+	dm, err := c.getDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var records models.Records
+	for _, key := range dm.Keys {
+		for _, ds := range key.Ds {
+			rc := &models.RecordConfig{Type: "DS", TTL: models.DefaultTTL}
+			rc.SetLabel("@", domain)
+			if err := rc.SetTargetDSString(ds); err != nil {
+				return nil, fmt.Errorf("invalid DS record %q of %s (deSEC): %w", ds, domain, err)
+			}
+			records = append(records, rc)
+		}
+	}
+	return records, nil
+}
+
 // PrepDesiredRecords munges any records to best suit this provider.
 func PrepDesiredRecords(dc *models.DomainConfig, minTTL uint32) {
 	// Sort through the dc.Records, eliminate any that can't be
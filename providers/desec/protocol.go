@@ -245,6 +245,19 @@ func generateRRSETfromResponse(bodyString []byte) ([]resourceRecord, error) {
 	return rrsNew, nil
 }
 
+func (c *desecProvider) getDomain(domain string) (*domainObject, error) {
+	endpoint := fmt.Sprintf("/domains/%s/", domain)
+	bodyString, _, err := c.get(endpoint, "GET")
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching domain (deSEC): %v", err)
+	}
+	dm := &domainObject{}
+	if err := json.Unmarshal(bodyString, dm); err != nil {
+		return nil, err
+	}
+	return dm, nil
+}
+
 func (c *desecProvider) createDomain(domain string) error {
 	endpoint := "/domains/"
 	pl := domainObject{Name: domain}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/digitalocean/godo"
 	"github.com/miekg/dns/dnsutil"
@@ -36,7 +36,9 @@ var defaultNameServerNames = []string{
 	"ns3.digitalocean.com",
 }
 
-const perPageSize = 100
+// perPageSize is the maximum of the API: the large zones are read with
+// fewer requests.
+const perPageSize = 200
 
 // NewDo creates a DO-specific DNS provider.
 func NewDo(m map[string]string, metadata json.RawMessage) (providers.DNSServiceProvider, error) {
@@ -69,6 +71,12 @@ retry:
 	return api, nil
 }
 
+// scopes are the scopes of the API tokens which the provider needs for the records.
+var scopes = providers.RequiredScopes{
+	Read:  []string{"domain:read"},
+	Write: []string{"domain:create", "domain:update", "domain:delete"},
+}
+
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
@@ -88,12 +96,19 @@ func init() {
 		Initializer:   NewDo,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, scopes)
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
 // EnsureZoneExists creates a zone if it does not exist
 func (api *digitaloceanProvider) EnsureZoneExists(domain string) error {
+	_, err := api.ensureZoneExists(domain)
+	return err
+}
+
+// ensureZoneExists creates a zone if it does not exist, and reports
+// whether it was created.
+func (api *digitaloceanProvider) ensureZoneExists(domain string) (bool, error) {
 retry:
 	ctx := context.Background()
 	_, resp, err := api.client.Domains.Get(ctx, domain)
@@ -108,9 +123,9 @@ retry:
 			Name:      domain,
 			IPAddress: "",
 		})
-		return err
+		return err == nil, err
 	}
-	return err
+	return false, err
 }
 
 // ListZones returns the list of zones (domains) in this account.
@@ -359,7 +374,7 @@ func pauseAndRetry(resp *godo.Response) bool {
 	}
 
 	// a simple exponential back-off with a 3-minute max.
-	log.Printf("Delaying %v due to ratelimit\n", backoff)
+	printer.Printf("Delaying %v due to ratelimit\n", backoff)
 	time.Sleep(backoff)
 	backoff = backoff + (backoff / 2)
 	if backoff > maxBackoff {
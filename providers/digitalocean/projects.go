@@ -0,0 +1,73 @@
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+)
+
+// metaProject is the domain metadata of the project (name or ID) a new
+// domain is assigned to. Otherwise it is in the default project.
+const metaProject = "digitalocean_project"
+
+// EnsureZoneExistsWithMetadata creates a zone if it does not exist, and
+// assigns it to its project.
+func (api *digitaloceanProvider) EnsureZoneExistsWithMetadata(domain string, metadata map[string]string) error {
+	created, err := api.ensureZoneExists(domain)
+	if err != nil || !created || metadata[metaProject] == "" {
+		return err
+	}
+	return api.assignProject(domain, metadata[metaProject])
+}
+
+// findProject returns the ID of a project from its name or ID.
+func (api *digitaloceanProvider) findProject(ctx context.Context, project string) (string, error) {
+	opt := &godo.ListOptions{PerPage: perPageSize}
+retry:
+	for {
+		result, resp, err := api.client.Projects.List(ctx, opt)
+		if err != nil {
+			if pauseAndRetry(resp) {
+				goto retry
+			}
+			return "", err
+		}
+
+		for _, p := range result {
+			if p.ID == project || p.Name == project {
+				return p.ID, nil
+			}
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return "", err
+		}
+
+		opt.Page = page + 1
+	}
+	return "", fmt.Errorf("DigitalOcean project %q not found", project)
+}
+
+// assignProject assigns the domain to the project.
+func (api *digitaloceanProvider) assignProject(domain, project string) error {
+	ctx := context.Background()
+	id, err := api.findProject(ctx, project)
+	if err != nil {
+		return err
+	}
+retry:
+	_, resp, err := api.client.Projects.AssignResources(ctx, id, godo.Domain{Name: domain})
+	if err != nil {
+		if pauseAndRetry(resp) {
+			goto retry
+		}
+		return fmt.Errorf("can't assign %s to the DigitalOcean project %q: %w", domain, project, err)
+	}
+	return nil
+}
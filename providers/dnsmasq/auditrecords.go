@@ -0,0 +1,25 @@
+package dnsmasq
+
+import (
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/rejectif"
+)
+
+// AuditRecords returns a list of errors corresponding to the records
+// that aren't supported by this provider.  If all records are
+// supported, an empty list is returned.
+func AuditRecords(records []*models.RecordConfig) []error {
+	a := rejectif.Auditor{}
+
+	a.Add("MX", rejectif.MxNull) // Last verified 2026-10-14
+
+	a.Add("SRV", rejectif.SrvHasNullTarget) // Last verified 2026-10-14
+
+	a.Add("TXT", rejectif.TxtHasBackslash) // Last verified 2026-10-14
+
+	a.Add("TXT", rejectif.TxtHasDoubleQuotes) // Last verified 2026-10-14
+
+	a.Add("TXT", rejectif.TxtIsEmpty) // Last verified 2026-10-14
+
+	return a.Audit(records)
+}
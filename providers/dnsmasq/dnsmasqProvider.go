@@ -0,0 +1,208 @@
+package dnsmasq
+
+/*
+
+dnsmasq -
+  Generate the configuration of dnsmasq for the zones.
+
+	Each zone is written in a configuration file for --conf-dir, and a hosts
+	file for --hostsdir (or --addn-hosts). dnsmasq answers with its local-ttl,
+	so the TTLs of the records are ignored.
+
+	After the files are written, dnsmasq can be sent a SIGHUP to clear its
+	cache and to read the hosts files again.
+
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Cannot(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can("Driver just maintains list of configuration files. It should automatically add missing ones."),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+// ttl is the TTL of the records, dnsmasq answers with its local-ttl instead.
+const ttl = 300
+
+func init() {
+	const providerName = "DNSMASQ"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newDnsmasq,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// dnsmasqProvider is the provider handle for the dnsmasq driver.
+type dnsmasqProvider struct {
+	directory string // The configuration files.
+	hostsdir  string // The hosts files.
+	pidfile   string // The pid file of dnsmasq, sent a SIGHUP if set.
+}
+
+func newDnsmasq(config map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &dnsmasqProvider{
+		directory: config["directory"],
+		hostsdir:  config["hostsdir"],
+		pidfile:   config["pidfile"],
+	}
+	if c.directory == "" {
+		c.directory = "dnsmasq.d"
+	}
+	if c.hostsdir == "" {
+		c.hostsdir = filepath.Join(c.directory, "hosts")
+	}
+	return c, nil
+}
+
+func (c *dnsmasqProvider) confFile(domain string) string {
+	return filepath.Join(c.directory, domain+".conf")
+}
+
+func (c *dnsmasqProvider) hostsFile(domain string) string {
+	return filepath.Join(c.hostsdir, domain+".hosts")
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *dnsmasqProvider) GetNameservers(string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+// ListZones returns the zones of the configuration files.
+func (c *dnsmasqProvider) ListZones() ([]string, error) {
+	filenames, err := filepath.Glob(filepath.Join(c.directory, "*.conf"))
+	if err != nil {
+		return nil, err
+	}
+	var zones []string
+	for _, filename := range filenames {
+		zones = append(zones, strings.TrimSuffix(filepath.Base(filename), ".conf"))
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *dnsmasqProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	var records models.Records
+	for _, file := range []struct {
+		name  string
+		parse func(io.Reader, string) (models.Records, error)
+	}{
+		{c.confFile(domain), parseConf},
+		{c.hostsFile(domain), parseHosts},
+	} {
+		f, err := os.Open(file.name)
+		if os.IsNotExist(err) {
+			// If the file doesn't exist, that's not an error. Just informational.
+			fmt.Fprintf(os.Stderr, "File does not yet exist: %q (will create)\n", file.name)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("can't open %s: %w", file.name, err)
+		}
+		found, err := file.parse(f, domain)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing %s: %w", file.name, err)
+		}
+		records = append(records, found...)
+	}
+	return records, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *dnsmasqProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundRecords models.Records) ([]*models.Correction, error) {
+	// dnsmasq ignores the TTLs.
+	for _, rc := range dc.Records {
+		rc.TTL = ttl
+	}
+
+	msgs, changes, err := diff2.ByZone(foundRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !changes {
+		return nil, nil
+	}
+
+	comment := fmt.Sprintf("generated with dnscontrol %s", time.Now().Format(time.RFC3339))
+	conf, hosts := formatFiles(dc.Records, comment)
+	return []*models.Correction{
+		{
+			Msg: strings.Join(msgs, "\n"),
+			F: func() error {
+				for _, file := range []struct{ name, content string }{
+					{c.confFile(dc.Name), conf},
+					{c.hostsFile(dc.Name), hosts},
+				} {
+					printer.Printf("WRITING FILE: %v\n", file.name)
+					if err := os.MkdirAll(filepath.Dir(file.name), 0o750); err != nil {
+						return fmt.Errorf("could not create directory: %w", err)
+					}
+					if err := os.WriteFile(file.name, []byte(file.content), 0o644); err != nil {
+						return fmt.Errorf("could not write file: %w", err)
+					}
+				}
+				return c.reload()
+			},
+		},
+	}, nil
+}
+
+// reload sends a SIGHUP to dnsmasq, if its pid file is configured.
+func (c *dnsmasqProvider) reload() error {
+	if c.pidfile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.pidfile)
+	if err != nil {
+		return fmt.Errorf("could not read the pid of dnsmasq: %w", err)
+	}
+	pid, err := parsePid(data)
+	if err != nil {
+		return fmt.Errorf("invalid pid file %s: %w", c.pidfile, err)
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	printer.Printf("SENDING SIGHUP TO DNSMASQ (pid %d)\n", pid)
+	if err := p.Signal(syscall.SIGHUP); err != nil {
+		return fmt.Errorf("could not signal dnsmasq: %w", err)
+	}
+	return nil
+}
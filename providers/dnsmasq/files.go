@@ -0,0 +1,188 @@
+package dnsmasq
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// The records of a zone are written in two files:
+//   - <directory>/<zone>.conf: the configuration options (cname, mx-host,
+//     srv-host, txt-record, ptr-record), read by dnsmasq when it starts.
+//   - <hostsdir>/<zone>.hosts: a hosts file with the A and AAAA records, read
+//     by dnsmasq each time it changes (--hostsdir) or on SIGHUP (--addn-hosts).
+
+// splitFields splits the value of an option on the commas that are not quoted.
+func splitFields(s string) []string {
+	var fields []string
+	var b strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			fields = append(fields, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(fields, b.String())
+}
+
+// parseConf parses the options of a configuration file into records.
+func parseConf(r io.Reader, domain string) (models.Records, error) {
+	var records models.Records
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		option, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid dnsmasq option: %q", line)
+		}
+		f := splitFields(value)
+
+		rc := &models.RecordConfig{TTL: ttl}
+		rc.SetLabelFromFQDN(f[0], domain)
+		var err error
+		switch option {
+		case "cname":
+			if len(f) < 2 {
+				return nil, fmt.Errorf("invalid dnsmasq option: %q", line)
+			}
+			rc.Type = "CNAME"
+			err = rc.SetTarget(dot(f[1]))
+		case "mx-host":
+			rc.Type = "MX"
+			target, pref := "", "1"
+			if len(f) > 1 {
+				target = f[1]
+			}
+			if len(f) > 2 {
+				pref = f[2]
+			}
+			err = rc.SetTargetMXStrings(pref, dot(target))
+		case "srv-host":
+			if len(f) < 5 {
+				return nil, fmt.Errorf("unsupported dnsmasq option (dnsmasq defaults): %q", line)
+			}
+			rc.Type = "SRV"
+			err = rc.SetTargetSRVStrings(f[3], f[4], f[2], dot(f[1]))
+		case "txt-record":
+			rc.Type = "TXT"
+			err = rc.SetTargetTXTs(f[1:])
+		case "ptr-record":
+			if len(f) < 2 {
+				return nil, fmt.Errorf("invalid dnsmasq option: %q", line)
+			}
+			rc.Type = "PTR"
+			err = rc.SetTarget(dot(f[1]))
+		default:
+			return nil, fmt.Errorf("unsupported dnsmasq option: %q", line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid dnsmasq option %q: %w", line, err)
+		}
+		records = append(records, rc)
+	}
+	return records, scanner.Err()
+}
+
+// parseHosts parses a hosts file into A and AAAA records.
+func parseHosts(r io.Reader, domain string) (models.Records, error) {
+	var records models.Records
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		f := strings.Fields(line)
+		if len(f) == 0 {
+			continue
+		}
+		for _, name := range f[1:] {
+			rc := &models.RecordConfig{Type: "A", TTL: ttl}
+			if strings.Contains(f[0], ":") {
+				rc.Type = "AAAA"
+			}
+			rc.SetLabelFromFQDN(name, domain)
+			if err := rc.SetTarget(f[0]); err != nil {
+				return nil, err
+			}
+			records = append(records, rc)
+		}
+	}
+	return records, scanner.Err()
+}
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+func quote(s string) string {
+	return `"` + s + `"`
+}
+
+// formatConf returns the option of a record of the configuration file.
+func formatConf(rc *models.RecordConfig) string {
+	name := rc.GetLabelFQDN()
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "CNAME":
+		return fmt.Sprintf("cname=%s,%s", name, target)
+	case "MX":
+		return fmt.Sprintf("mx-host=%s,%s,%d", name, target, rc.MxPreference)
+	case "SRV":
+		return fmt.Sprintf("srv-host=%s,%s,%d,%d,%d", name, target, rc.SrvPort, rc.SrvPriority, rc.SrvWeight)
+	case "TXT":
+		fields := []string{"txt-record=" + name}
+		for _, txt := range rc.GetTargetTXTSegmented() {
+			fields = append(fields, quote(txt))
+		}
+		return strings.Join(fields, ",")
+	case "PTR":
+		return fmt.Sprintf("ptr-record=%s,%s", name, target)
+	}
+	return ""
+}
+
+// formatFiles returns the content of the configuration file and of the hosts
+// file of the records.
+func formatFiles(records models.Records, comment string) (string, string) {
+	var conf, hosts []string
+	for _, rc := range records {
+		switch rc.Type {
+		case "A", "AAAA":
+			hosts = append(hosts, rc.GetTargetField()+" "+rc.GetLabelFQDN())
+		default:
+			conf = append(conf, formatConf(rc))
+		}
+	}
+	sort.Strings(conf)
+	sort.Strings(hosts)
+
+	header := "# " + comment + "\n"
+	return header + joinLines(conf), header + joinLines(hosts)
+}
+
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// parsePid parses the content of a pid file.
+func parsePid(data []byte) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,48 @@
+package dnsmasq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestFilesRoundTrip(t *testing.T) {
+	conf := `# generated with dnscontrol
+cname=www.example.com,test.example.com
+mx-host=example.com,mail.example.com,10
+ptr-record=1.0.0.10.in-addr.arpa,test.example.com
+srv-host=_sip._udp.example.com,sip.example.com,5060,10,20
+txt-record=comma.example.com,"a,b"
+txt-record=example.com,"v=spf1 -all"
+`
+	hosts := `# generated with dnscontrol
+10.0.0.1 test.example.com
+2001:db8::1 test.example.com
+`
+
+	confRecords, err := parseConf(strings.NewReader(conf), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostsRecords, err := parseHosts(strings.NewReader(hosts), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := append(models.Records{}, confRecords...)
+	records = append(records, hostsRecords...)
+	if len(records) != 8 {
+		t.Fatalf("got %d records, want 8", len(records))
+	}
+	if got := records[4].GetTargetTXTJoined(); got != "a,b" {
+		t.Errorf("TXT: got %q, want %q", got, "a,b")
+	}
+
+	gotConf, gotHosts := formatFiles(records, "generated with dnscontrol")
+	if gotConf != conf {
+		t.Errorf("conf:\ngot:\n%s\nwant:\n%s", gotConf, conf)
+	}
+	if gotHosts != hosts {
+		t.Errorf("hosts:\ngot:\n%s\nwant:\n%s", gotHosts, hosts)
+	}
+}
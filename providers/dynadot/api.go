@@ -112,9 +112,12 @@ func (c *dynadotProvider) updateNameservers(ns []string, domain string) error {
 	return nil
 }
 
+// apiURL is the endpoint of the API, replaced by the tests.
+var apiURL = "https://api.dynadot.com/api3.xml"
+
 func (c *dynadotProvider) get(command string, params requestParams) ([]byte, error) {
 	client := &http.Client{}
-	req, _ := http.NewRequest("GET", "https://api.dynadot.com/api3.xml", nil)
+	req, _ := http.NewRequest("GET", apiURL, nil)
 	q := req.URL.Query()
 
 	q.Add("key", c.key)
@@ -133,3 +136,80 @@ func (c *dynadotProvider) get(command string, params requestParams) ([]byte, err
 
 	return io.ReadAll(resp.Body)
 }
+
+type getDnssecResponse struct {
+	XMLName         xml.Name   `xml:"GetDnssecResponse"`
+	GetDnssecHeader header     `xml:"GetDnssecHeader"`
+	DnssecInfo      []dnssecDS `xml:"DnssecInfo>DsData"`
+}
+
+type dnssecDS struct {
+	KeyTag     uint16 `xml:"KeyTag"`
+	Algorithm  uint8  `xml:"Algorithm"`
+	DigestType uint8  `xml:"DigestType"`
+	Digest     string `xml:"Digest"`
+}
+
+type setDnssecResponse struct {
+	XMLName         xml.Name `xml:"SetDnssecResponse"`
+	SetDnssecHeader header   `xml:"SetDnssecHeader"`
+}
+
+type clearDnssecResponse struct {
+	XMLName           xml.Name `xml:"ClearDnssecResponse"`
+	ClearDnssecHeader header   `xml:"ClearDnssecHeader"`
+}
+
+func (c *dynadotProvider) getDS(domain string) ([]dnssecDS, error) {
+	b, err := c.get("get_dnssec", requestParams{"domain_name": domain})
+	if err != nil {
+		return nil, fmt.Errorf("failed DS list (Dynadot): %s", err)
+	}
+	var resp getDnssecResponse
+	if err := xml.Unmarshal(b, &resp); err != nil {
+		return nil, fmt.Errorf("failed DS list (Dynadot): %s", err)
+	}
+	if resp.GetDnssecHeader.SuccessCode != 0 {
+		return nil, fmt.Errorf("failed DS list (Dynadot): %s", resp.GetDnssecHeader.Error)
+	}
+	return resp.DnssecInfo, nil
+}
+
+// setDS replaces the DS record of a domain. Dynadot accepts a single DS
+// record per domain.
+func (c *dynadotProvider) setDS(domain string, ds *dnssecDS) error {
+	b, err := c.get("set_dnssec", requestParams{
+		"domain_name": domain,
+		"key_tag":     fmt.Sprint(ds.KeyTag),
+		"algorithm":   fmt.Sprint(ds.Algorithm),
+		"digest_type": fmt.Sprint(ds.DigestType),
+		"digest":      ds.Digest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed DS set (Dynadot): %s", err)
+	}
+	var resp setDnssecResponse
+	if err := xml.Unmarshal(b, &resp); err != nil {
+		return fmt.Errorf("failed DS set (Dynadot): %s", err)
+	}
+	if resp.SetDnssecHeader.SuccessCode != 0 {
+		return fmt.Errorf("failed DS set (Dynadot): %s", resp.SetDnssecHeader.Error)
+	}
+	return nil
+}
+
+// clearDS removes the DS record of a domain.
+func (c *dynadotProvider) clearDS(domain string) error {
+	b, err := c.get("clear_dnssec", requestParams{"domain_name": domain})
+	if err != nil {
+		return fmt.Errorf("failed DS clear (Dynadot): %s", err)
+	}
+	var resp clearDnssecResponse
+	if err := xml.Unmarshal(b, &resp); err != nil {
+		return fmt.Errorf("failed DS clear (Dynadot): %s", err)
+	}
+	if resp.ClearDnssecHeader.SuccessCode != 0 {
+		return fmt.Errorf("failed DS clear (Dynadot): %s", resp.ClearDnssecHeader.Error)
+	}
+	return nil
+}
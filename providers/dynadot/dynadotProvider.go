@@ -21,7 +21,8 @@ Info required in `creds.json`:
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
-	providers.CanConcur: providers.Cannot(),
+	providers.CanConcur:           providers.Cannot(),
+	providers.CanUseDSAtRegistrar: providers.Can("Only a single DS record is supported"),
 }
 
 func init() {
@@ -57,15 +58,76 @@ func (c *dynadotProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*m
 	sort.Strings(expected)
 	expectedNameservers := strings.Join(expected, ",")
 
+	var corrections []*models.Correction
 	if foundNameservers != expectedNameservers {
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Update nameservers (%s) -> (%s)", foundNameservers, expectedNameservers),
+			F: func() error {
+				return c.updateNameservers(expected, dc.Name)
+			},
+		})
+	}
+
+	dsCorrections, err := c.getDSCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, dsCorrections...), nil
+}
+
+func dsString(ds *dnssecDS) string {
+	return fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, strings.ToUpper(ds.Digest))
+}
+
+// getDSCorrections returns the corrections that publish dc.RegistrarDS.
+// The DS record at the registry is removed if there is none in
+// dnsconfig.js nor reported by the DNS providers.
+func (c *dynadotProvider) getDSCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if len(dc.RegistrarDS) > 1 {
+		return nil, fmt.Errorf("dynadot supports a single DS record, %s has %d", dc.Name, len(dc.RegistrarDS))
+	}
+	existing, err := c.getDS(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []string
+	for i := range existing {
+		found = append(found, dsString(&existing[i]))
+	}
+	foundDS := strings.Join(found, ", ")
+
+	if len(dc.RegistrarDS) == 0 {
+		if len(existing) == 0 {
+			return nil, nil
+		}
 		return []*models.Correction{
 			{
-				Msg: fmt.Sprintf("Update nameservers (%s) -> (%s)", foundNameservers, expectedNameservers),
+				Msg: fmt.Sprintf("Remove DS (%s)", foundDS),
 				F: func() error {
-					return c.updateNameservers(expected, dc.Name)
+					return c.clearDS(dc.Name)
 				},
 			},
 		}, nil
 	}
-	return nil, nil
+
+	rc := dc.RegistrarDS[0]
+	want := &dnssecDS{
+		KeyTag:     rc.DsKeyTag,
+		Algorithm:  rc.DsAlgorithm,
+		DigestType: rc.DsDigestType,
+		Digest:     rc.DsDigest,
+	}
+
+	if len(existing) == 1 && dsString(want) == foundDS {
+		return nil, nil
+	}
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update DS (%s) -> (%s)", foundDS, dsString(want)),
+			F: func() error {
+				return c.setDS(dc.Name, want)
+			},
+		},
+	}, nil
 }
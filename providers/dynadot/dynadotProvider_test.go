@@ -0,0 +1,98 @@
+package dynadot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestGetDSCorrections(t *testing.T) {
+	const existingDS = `<DnssecInfo><DsData><KeyTag>12345</KeyTag><Algorithm>13</Algorithm><DigestType>2</DigestType><Digest>2BB183AF5F22588179A53B0A98631FAD1A292118</Digest></DsData></DnssecInfo>`
+	var commands []string
+	var dnssecInfo string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.URL.Query().Get("command")
+		commands = append(commands, command)
+		if command == "clear_dnssec" {
+			w.Write([]byte(`<ClearDnssecResponse><ClearDnssecHeader><SuccessCode>0</SuccessCode><Status>success</Status></ClearDnssecHeader></ClearDnssecResponse>`))
+			return
+		}
+		w.Write([]byte(`<GetDnssecResponse><GetDnssecHeader><SuccessCode>0</SuccessCode><Status>success</Status></GetDnssecHeader>` +
+			dnssecInfo + `</GetDnssecResponse>`))
+	}))
+	defer srv.Close()
+	defer func(u string) { apiURL = u }(apiURL)
+	apiURL = srv.URL
+
+	ds := func(s string) models.Records {
+		rc := &models.RecordConfig{Type: "DS"}
+		rc.SetLabel("@", "example.com")
+		if err := rc.SetTargetDSString(s); err != nil {
+			t.Fatal(err)
+		}
+		return models.Records{rc}
+	}
+
+	for _, test := range []struct {
+		name        string
+		registrarDS models.Records
+		dnssecInfo  string
+		corrections int
+		commands    int
+	}{
+		{"no DS declared, no DS", nil, "", 0, 1},
+		{"no DS declared, DS exists", nil, existingDS, 1, 1},
+		{"same DS", ds("12345 13 2 2BB183AF5F22588179A53B0A98631FAD1A292118"), existingDS, 0, 1},
+		{"other DS", ds("54321 13 2 3BB183AF5F22588179A53B0A98631FAD1A292118"), existingDS, 1, 1},
+		{"new DS", ds("54321 13 2 3BB183AF5F22588179A53B0A98631FAD1A292118"), "", 1, 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			commands = nil
+			dnssecInfo = test.dnssecInfo
+			c := &dynadotProvider{}
+			corrections, err := c.getDSCorrections(&models.DomainConfig{Name: "example.com", RegistrarDS: test.registrarDS})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(corrections) != test.corrections {
+				t.Errorf("got %d corrections, want %d", len(corrections), test.corrections)
+			}
+			if len(commands) != test.commands {
+				t.Errorf("got the commands %v, want %d", commands, test.commands)
+			}
+		})
+	}
+}
+
+func TestClearDS(t *testing.T) {
+	var commands []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commands = append(commands, r.URL.Query().Get("command"))
+		if r.URL.Query().Get("command") == "clear_dnssec" {
+			w.Write([]byte(`<ClearDnssecResponse><ClearDnssecHeader><SuccessCode>0</SuccessCode><Status>success</Status></ClearDnssecHeader></ClearDnssecResponse>`))
+			return
+		}
+		w.Write([]byte(`<GetDnssecResponse><GetDnssecHeader><SuccessCode>0</SuccessCode><Status>success</Status></GetDnssecHeader>` +
+			`<DnssecInfo><DsData><KeyTag>12345</KeyTag><Algorithm>13</Algorithm><DigestType>2</DigestType><Digest>2BB183AF5F22588179A53B0A98631FAD1A292118</Digest></DsData></DnssecInfo></GetDnssecResponse>`))
+	}))
+	defer srv.Close()
+	defer func(u string) { apiURL = u }(apiURL)
+	apiURL = srv.URL
+
+	c := &dynadotProvider{}
+	corrections, err := c.getDSCorrections(&models.DomainConfig{Name: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 1 || corrections[0].Msg != "Remove DS (12345 13 2 2BB183AF5F22588179A53B0A98631FAD1A292118)" {
+		t.Fatalf("got the corrections %v, want the removal of the DS", corrections)
+	}
+	if err := corrections[0].F(); err != nil {
+		t.Fatal(err)
+	}
+	if len(commands) != 2 || commands[1] != "clear_dnssec" {
+		t.Errorf("got the commands %v, want get_dnssec and clear_dnssec", commands)
+	}
+}
@@ -0,0 +1,188 @@
+package efficientip
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const pageSize = 1000
+
+type efficientipProvider struct {
+	client   *http.Client
+	baseURL  string // https://host/rest/
+	username string
+	password string
+
+	server string // dns_name, e.g. the smart architecture
+	view   string // dnsview_name
+}
+
+type dnsZone struct {
+	ZoneID   string `json:"zone_id"`
+	ZoneName string `json:"zone_name"`
+	ZoneType string `json:"zone_type"`
+	DNSName  string `json:"dns_name"`
+	ViewName string `json:"dnsview_name"`
+}
+
+type dnsRR struct {
+	RRID       string `json:"rr_id"`
+	RRFullName string `json:"rr_full_name"`
+	RRType     string `json:"rr_type"`
+	RRTTL      string `json:"rr_ttl"`
+	Value1     string `json:"value1"`
+	Value2     string `json:"value2"`
+	Value3     string `json:"value3"`
+	Value4     string `json:"value4"`
+}
+
+type apiError struct {
+	Errno    string `json:"errno"`
+	ErrMsg   string `json:"errmsg"`
+	Severity string `json:"severity"`
+}
+
+// request calls a SOLIDserver REST service. All parameters are passed in
+// the query string, including for POST and PUT.
+func (c *efficientipProvider) request(method, service string, params url.Values, target any) error {
+	u := c.baseURL + service
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-IPM-Username", base64.StdEncoding.EncodeToString([]byte(c.username)))
+	req.Header.Set("X-IPM-Password", base64.StdEncoding.EncodeToString([]byte(c.password)))
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		var errs []apiError
+		if json.Unmarshal(data, &errs) == nil && len(errs) != 0 && errs[0].ErrMsg != "" {
+			return fmt.Errorf("EFFICIENTIP API error: %s: %s (errno %s)", resp.Status, errs[0].ErrMsg, errs[0].Errno)
+		}
+		return fmt.Errorf("EFFICIENTIP API error: %s: %s", resp.Status, string(data))
+	}
+
+	// Empty lists are returned as "204 No Content".
+	if target == nil || resp.StatusCode == http.StatusNoContent || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "\\'") + "'"
+}
+
+// scope returns the WHERE clause selecting the configured server and view.
+func (c *efficientipProvider) scope() string {
+	where := "dns_name=" + quote(c.server)
+	if c.view != "" {
+		where += " and dnsview_name=" + quote(c.view)
+	}
+	return where
+}
+
+// list fetches all pages of a list service.
+func list[T any](c *efficientipProvider, service, where string) ([]T, error) {
+	var items []T
+	for offset := 0; ; offset += pageSize {
+		var batch []T
+		params := url.Values{
+			"WHERE":  {where},
+			"limit":  {strconv.Itoa(pageSize)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.request(http.MethodGet, service, params, &batch); err != nil {
+			return nil, err
+		}
+		items = append(items, batch...)
+		if len(batch) < pageSize {
+			break
+		}
+	}
+	return items, nil
+}
+
+func (c *efficientipProvider) listZones() ([]dnsZone, error) {
+	zones, err := list[dnsZone](c, "dns_zone_list", c.scope()+" and zone_type='master'")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing zones from EFFICIENTIP: %w", err)
+	}
+	return zones, nil
+}
+
+func (c *efficientipProvider) findZone(domain string) (*dnsZone, error) {
+	zones, err := list[dnsZone](c, "dns_zone_list", c.scope()+" and zone_name="+quote(domain))
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching zone from EFFICIENTIP: %w", err)
+	}
+	if len(zones) != 1 {
+		return nil, fmt.Errorf("zone %q not found on EFFICIENTIP server %q", domain, c.server)
+	}
+	return &zones[0], nil
+}
+
+func (c *efficientipProvider) createZone(domain string) error {
+	params := url.Values{
+		"zone_name": {domain},
+		"zone_type": {"master"},
+		"dns_name":  {c.server},
+	}
+	if c.view != "" {
+		params.Set("dnsview_name", c.view)
+	}
+	if err := c.request(http.MethodPost, "dns_zone_add", params, nil); err != nil {
+		return fmt.Errorf("failed creating zone (EFFICIENTIP): %w", err)
+	}
+	return nil
+}
+
+func (c *efficientipProvider) getRecords(zoneID string) ([]dnsRR, error) {
+	records, err := list[dnsRR](c, "dns_rr_list", "zone_id="+quote(zoneID))
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching records from EFFICIENTIP: %w", err)
+	}
+	return records, nil
+}
+
+func (c *efficientipProvider) createRecord(zoneID string, params url.Values) error {
+	params.Set("zone_id", zoneID)
+	if err := c.request(http.MethodPost, "dns_rr_add", params, nil); err != nil {
+		return fmt.Errorf("failed create record (EFFICIENTIP): %w", err)
+	}
+	return nil
+}
+
+func (c *efficientipProvider) updateRecord(rrID string, params url.Values) error {
+	params.Set("rr_id", rrID)
+	if err := c.request(http.MethodPut, "dns_rr_update", params, nil); err != nil {
+		return fmt.Errorf("failed update record (EFFICIENTIP): %w", err)
+	}
+	return nil
+}
+
+func (c *efficientipProvider) deleteRecord(rrID string) error {
+	if err := c.request(http.MethodDelete, "dns_rr_delete", url.Values{"rr_id": {rrID}}, nil); err != nil {
+		return fmt.Errorf("failed delete record (EFFICIENTIP): %w", err)
+	}
+	return nil
+}
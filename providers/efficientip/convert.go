@@ -0,0 +1,99 @@
+package efficientip
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+func atou16(s string) (uint16, error) {
+	v, err := strconv.ParseUint(s, 10, 16)
+	return uint16(v), err
+}
+
+// toRc converts a resource record into a RecordConfig.
+func toRc(domain string, rr *dnsRR) (*models.RecordConfig, error) {
+	ttl, err := strconv.ParseUint(rr.RRTTL, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TTL %q of %s: %w", rr.RRTTL, rr.RRFullName, err)
+	}
+	rc := &models.RecordConfig{
+		Type:     rr.RRType,
+		TTL:      uint32(ttl),
+		Original: rr,
+	}
+	rc.SetLabelFromFQDN(rr.RRFullName, domain)
+
+	switch rr.RRType {
+	case "A", "AAAA":
+		err = rc.SetTarget(rr.Value1)
+	case "CNAME", "NS", "PTR":
+		err = rc.SetTarget(dot(rr.Value1))
+	case "MX":
+		var pref uint16
+		if pref, err = atou16(rr.Value1); err == nil {
+			err = rc.SetTargetMX(pref, dot(rr.Value2))
+		}
+	case "SRV":
+		var priority, weight, port uint16
+		if priority, err = atou16(rr.Value1); err != nil {
+			break
+		}
+		if weight, err = atou16(rr.Value2); err != nil {
+			break
+		}
+		if port, err = atou16(rr.Value3); err != nil {
+			break
+		}
+		err = rc.SetTargetSRV(priority, weight, port, dot(rr.Value4))
+	case "CAA":
+		var flag uint64
+		if flag, err = strconv.ParseUint(rr.Value1, 10, 8); err == nil {
+			err = rc.SetTargetCAA(uint8(flag), rr.Value2, strings.Trim(rr.Value3, `"`))
+		}
+	case "TXT":
+		err = rc.SetTargetTXT(rr.Value1)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", rr.RRType)
+	}
+	return rc, err
+}
+
+// toParams converts a RecordConfig into the parameters of dns_rr_add and dns_rr_update.
+func toParams(rc *models.RecordConfig) url.Values {
+	p := url.Values{
+		"rr_name": {rc.GetLabelFQDN()},
+		"rr_type": {rc.Type},
+		"rr_ttl":  {strconv.FormatUint(uint64(rc.TTL), 10)},
+	}
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "MX":
+		p.Set("value1", strconv.Itoa(int(rc.MxPreference)))
+		p.Set("value2", target)
+	case "SRV":
+		p.Set("value1", strconv.Itoa(int(rc.SrvPriority)))
+		p.Set("value2", strconv.Itoa(int(rc.SrvWeight)))
+		p.Set("value3", strconv.Itoa(int(rc.SrvPort)))
+		p.Set("value4", target)
+	case "CAA":
+		p.Set("value1", strconv.Itoa(int(rc.CaaFlag)))
+		p.Set("value2", rc.CaaTag)
+		p.Set("value3", rc.GetTargetField())
+	case "TXT":
+		p.Set("value1", rc.GetTargetTXTJoined())
+	default:
+		p.Set("value1", target)
+	}
+	return p
+}
@@ -0,0 +1,177 @@
+package efficientip
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for EfficientIP SOLIDserver DDI through its REST API.
+// API Documentation: https://docs.efficientip.com/latest/en/rest-api.html
+
+/*
+EfficientIP SOLIDserver provider:
+
+Info required in `creds.json`:
+   - host
+   - username
+   - password
+   - dns_server (DNS server or smart architecture name)
+   - view (optional)
+   - skip_tls_verify (optional)
+   - cert (optional, PEM CA certificate of SOLIDserver)
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Unimplemented(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Unimplemented(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Unimplemented(),
+	providers.CanUseTLSA:             providers.Unimplemented(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "EFFICIENTIP"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newEfficientip,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+	providers.RegisterTLSOptions(providerName)
+}
+
+// newEfficientip creates the provider.
+func newEfficientip(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &efficientipProvider{
+		baseURL:  fmt.Sprintf("https://%s/rest/", m["host"]),
+		username: m["username"],
+		password: m["password"],
+		server:   m["dns_server"],
+		view:     m["view"],
+	}
+	if m["host"] == "" || c.username == "" || c.password == "" {
+		return nil, fmt.Errorf("missing EFFICIENTIP host, username or password")
+	}
+	if c.server == "" {
+		return nil, fmt.Errorf("missing EFFICIENTIP dns_server")
+	}
+
+	client, err := tlsconfig.NewHTTPClient(m)
+	if err != nil {
+		return nil, fmt.Errorf("EFFICIENTIP: %w", err)
+	}
+	c.client = client
+
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+// The apex NS records are generated from the members of the smart architecture.
+func (c *efficientipProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *efficientipProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	zone, err := c.findZone(domain)
+	if err != nil {
+		return nil, err
+	}
+	records, err := c.getRecords(zone.ZoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		rr := &records[i]
+		if rr.RRType == "SOA" {
+			continue
+		}
+		if rr.RRType == "NS" && strings.EqualFold(strings.TrimSuffix(rr.RRFullName, "."), domain) {
+			continue
+		}
+		rc, err := toRc(domain, rr)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *efficientipProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	zone, err := c.findZone(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			params := toParams(change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(zone.ZoneID, params)
+				},
+			}
+		case diff2.CHANGE:
+			id := change.Old[0].Original.(*dnsRR).RRID
+			params := toParams(change.New[0])
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, EFFICIENTIP ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.updateRecord(id, params)
+				},
+			}
+		case diff2.DELETE:
+			id := change.Old[0].Original.(*dnsRR).RRID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, EFFICIENTIP ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.deleteRecord(id)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
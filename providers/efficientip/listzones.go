@@ -0,0 +1,34 @@
+package efficientip
+
+import (
+	"sort"
+	"strings"
+)
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *efficientipProvider) ListZones() ([]string, error) {
+	zones, err := c.listZones()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(zones))
+	for _, z := range zones {
+		names = append(names, strings.ToLower(z.ZoneName))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *efficientipProvider) EnsureZoneExists(domain string) error {
+	zones, err := c.ListZones()
+	if err != nil {
+		return err
+	}
+	for _, z := range zones {
+		if z == domain {
+			return nil
+		}
+	}
+	return c.createZone(domain)
+}
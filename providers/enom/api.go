@@ -0,0 +1,173 @@
+package enom
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	apiURL     = "https://reseller.enom.com/interface.asp"
+	testAPIURL = "https://resellertest.enom.com/interface.asp"
+	maxNS      = 12
+)
+
+type enomProvider struct {
+	uid    string
+	pw     string
+	apiURL string
+}
+
+// interfaceResponse is the part of the response common to all commands.
+type interfaceResponse struct {
+	ErrCount int `xml:"ErrCount"`
+	Errors   struct {
+		Err []string `xml:",any"`
+	} `xml:"errors"`
+	Done bool `xml:"Done"`
+}
+
+type dnsResponse struct {
+	interfaceResponse
+	Nameservers []string `xml:"dns"`
+	UseDNS      string   `xml:"UseDNS"`
+}
+
+type nsStatusResponse struct {
+	interfaceResponse
+	Status struct {
+		Name      string `xml:"name"`
+		IPAddress string `xml:"ipaddress"`
+	} `xml:"CheckNsStatus"`
+}
+
+// splitDomain returns the SLD and TLD of a domain, as expected by the API.
+func splitDomain(domain string) (string, string, error) {
+	tld, _ := publicsuffix.PublicSuffix(domain)
+	sld := strings.TrimSuffix(domain, "."+tld)
+	if sld == domain || sld == "" || strings.Contains(sld, ".") {
+		return "", "", fmt.Errorf("enom: cannot split %q into SLD and TLD", domain)
+	}
+	return sld, tld, nil
+}
+
+// call invokes an API command and decodes the response into target.
+// target must embed interfaceResponse.
+func (c *enomProvider) call(command string, params url.Values, target interface{ status() *interfaceResponse }) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	params.Set("command", command)
+	params.Set("uid", c.uid)
+	params.Set("pw", c.pw)
+	params.Set("responsetype", "xml")
+	u := c.apiURL + "?" + params.Encode()
+
+retry:
+	resp, err := http.Get(u)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("Enom rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("enom API error: %s: %s", resp.Status, string(body))
+	}
+
+	if err := xml.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("enom API error: %s: %s", resp.Status, string(body))
+	}
+	if s := target.status(); s.ErrCount > 0 {
+		return fmt.Errorf("enom API error: %s", strings.Join(s.Errors.Err, "; "))
+	}
+	return nil
+}
+
+func (r *interfaceResponse) status() *interfaceResponse { return r }
+
+func (c *enomProvider) domainParams(domain string) (url.Values, error) {
+	sld, tld, err := splitDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	return url.Values{"sld": {sld}, "tld": {tld}}, nil
+}
+
+func (c *enomProvider) getNameservers(domain string) ([]string, error) {
+	params, err := c.domainParams(domain)
+	if err != nil {
+		return nil, err
+	}
+	var r dnsResponse
+	if err := c.call("GetDNS", params, &r); err != nil {
+		return nil, fmt.Errorf("failed fetching nameservers from enom: %w", err)
+	}
+	return r.Nameservers, nil
+}
+
+func (c *enomProvider) updateNameservers(domain string, ns []string) error {
+	if len(ns) > maxNS {
+		return fmt.Errorf("enom accepts at most %d nameservers, got %d", maxNS, len(ns))
+	}
+	params, err := c.domainParams(domain)
+	if err != nil {
+		return err
+	}
+	for i, n := range ns {
+		params.Set("NS"+strconv.Itoa(i+1), n)
+	}
+	var r interfaceResponse
+	if err := c.call("ModifyNS", params, &r); err != nil {
+		return fmt.Errorf("failed updating nameservers (enom): %w", err)
+	}
+	return nil
+}
+
+// getHostIP returns the IP address registered for the nameserver host,
+// or "" if the host is not registered.
+func (c *enomProvider) getHostIP(host string) (string, error) {
+	var r nsStatusResponse
+	err := c.call("CheckNSStatus", url.Values{"CheckNSName": {host}}, &r)
+	if err != nil {
+		if r.ErrCount > 0 {
+			// Unknown hosts are reported as an error.
+			return "", nil
+		}
+		return "", fmt.Errorf("failed fetching host %s from enom: %w", host, err)
+	}
+	return r.Status.IPAddress, nil
+}
+
+func (c *enomProvider) registerHost(host, ip string) error {
+	var r interfaceResponse
+	if err := c.call("RegisterNameServer", url.Values{"Add": {host}, "IP": {ip}}, &r); err != nil {
+		return fmt.Errorf("failed registering host %s (enom): %w", host, err)
+	}
+	return nil
+}
+
+func (c *enomProvider) updateHost(host, oldIP, newIP string) error {
+	var r interfaceResponse
+	if err := c.call("UpdateNameServer", url.Values{"NS": {host}, "OldIP": {oldIP}, "NewIP": {newIP}}, &r); err != nil {
+		return fmt.Errorf("failed updating host %s (enom): %w", host, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package enom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Enom.
+// API Documentation: https://cp.enom.com/APICommandCatalog/
+
+/*
+Enom registrar:
+
+Info required in `creds.json`:
+   - uid reseller account login
+   - pw API token or password
+   - test (optional) "true" to use the test environment
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanConcur: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "ENOM"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	providers.RegisterRegistrarType(providerName, newReg, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+func newReg(m map[string]string) (providers.Registrar, error) {
+	c := &enomProvider{
+		uid:    m["uid"],
+		pw:     m["pw"],
+		apiURL: apiURL,
+	}
+	if c.uid == "" || c.pw == "" {
+		return nil, fmt.Errorf("missing ENOM uid or pw")
+	}
+	if m["test"] == "true" {
+		c.apiURL = testAPIURL
+	}
+	return c, nil
+}
+
+// GetRegistrarCorrections returns a list of corrections for this registrar.
+func (c *enomProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	nss, err := c.getNameservers(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	foundNameservers := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		foundNameservers = append(foundNameservers, strings.ToLower(strings.TrimSuffix(ns, ".")))
+	}
+	sort.Strings(foundNameservers)
+
+	expected := make([]string, 0, len(dc.Nameservers))
+	for _, ns := range dc.Nameservers {
+		expected = append(expected, ns.Name)
+	}
+	sort.Strings(expected)
+
+	// The hosts must be registered before they can be used as nameservers.
+	corrections, err := c.getHostCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	foundStr := strings.Join(foundNameservers, ",")
+	expectedStr := strings.Join(expected, ",")
+	if foundStr != expectedStr {
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundStr, expectedStr),
+			F: func() error {
+				return c.updateNameservers(dc.Name, expected)
+			},
+		})
+	}
+	return corrections, nil
+}
+
+// glueIPs returns the IPv4 address of each nameserver inside the domain,
+// taken from the A records of the zone.
+func glueIPs(dc *models.DomainConfig) (map[string]string, error) {
+	glue := map[string]string{}
+	for _, ns := range dc.Nameservers {
+		name := strings.TrimSuffix(ns.Name, ".")
+		if name != dc.Name && !strings.HasSuffix(name, "."+dc.Name) {
+			continue
+		}
+		for _, rc := range dc.Records {
+			if rc.Type != "A" || rc.GetLabelFQDN() != name {
+				continue
+			}
+			if ip, ok := glue[name]; ok && ip != rc.GetTargetField() {
+				return nil, fmt.Errorf("enom supports a single glue IPv4 address, %s has several", name)
+			}
+			glue[name] = rc.GetTargetField()
+		}
+	}
+	return glue, nil
+}
+
+// getHostCorrections returns the corrections that register the nameservers
+// of the domain that are inside the domain, with their glue address.
+func (c *enomProvider) getHostCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	glue, err := glueIPs(dc)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(glue))
+	for host := range glue {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var corrections []*models.Correction
+	for _, host := range hosts {
+		ip := glue[host]
+		current, err := c.getHostIP(host)
+		if err != nil {
+			return nil, err
+		}
+		switch current {
+		case ip:
+		case "":
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Register host %s (%s)", host, ip),
+				F: func() error {
+					return c.registerHost(host, ip)
+				},
+			})
+		default:
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Update host %s %s -> %s", host, current, ip),
+				F: func() error {
+					return c.updateHost(host, current, ip)
+				},
+			})
+		}
+	}
+	return corrections, nil
+}
@@ -0,0 +1,191 @@
+package etcd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// The provider uses the JSON gateway of the etcd v3 API.
+// API Documentation: https://etcd.io/docs/latest/dev-guide/api_grpc_gateway/
+
+type etcdProvider struct {
+	endpoint string
+	prefix   string
+	username string
+	password string
+	token    string
+	client   *http.Client
+}
+
+// service is the value of a key, as read by the CoreDNS etcd plugin.
+type service struct {
+	Host     string `json:"host,omitempty"`
+	Port     uint16 `json:"port,omitempty"`
+	Priority uint16 `json:"priority,omitempty"`
+	Weight   uint16 `json:"weight,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Mail     bool   `json:"mail,omitempty"`
+	TTL      uint32 `json:"ttl,omitempty"`
+}
+
+type keyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type rangeRequest struct {
+	Key      string `json:"key"`
+	RangeEnd string `json:"range_end,omitempty"`
+}
+
+type rangeResponse struct {
+	KVs []keyValue `json:"kvs"`
+}
+
+type requestOp struct {
+	RequestPut         *keyValue     `json:"requestPut,omitempty"`
+	RequestDeleteRange *rangeRequest `json:"requestDeleteRange,omitempty"`
+}
+
+type txnRequest struct {
+	Success []requestOp `json:"success"`
+}
+
+type authRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type authResponse struct {
+	Token string `json:"token"`
+}
+
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+func encode(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func decode(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	return string(b), err
+}
+
+// post sends a request to the gateway and decodes the response into target.
+func (c *etcdProvider) post(path string, body, target any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var er errorResponse
+		if json.Unmarshal(data, &er) == nil && er.Message != "" {
+			return fmt.Errorf("etcd API error: %s: %s", resp.Status, er.Message)
+		}
+		return fmt.Errorf("etcd API error: %s: %s", resp.Status, string(data))
+	}
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// authenticate fetches the token sent with the other requests, if a user is configured.
+func (c *etcdProvider) authenticate() error {
+	if c.username == "" || c.token != "" {
+		return nil
+	}
+	var resp authResponse
+	if err := c.post("/v3/auth/authenticate", authRequest{Name: c.username, Password: c.password}, &resp); err != nil {
+		return fmt.Errorf("failed authenticating to etcd: %w", err)
+	}
+	c.token = resp.Token
+	return nil
+}
+
+// getServices returns the services of a zone, by key.
+func (c *etcdProvider) getServices(domain string) (map[string]*service, error) {
+	if err := c.authenticate(); err != nil {
+		return nil, err
+	}
+
+	// The range covers the key of the apex and all the keys below it.
+	zoneKey := c.key(domain)
+	var resp rangeResponse
+	req := rangeRequest{Key: encode(zoneKey), RangeEnd: encode(zoneKey + "0")}
+	if err := c.post("/v3/kv/range", req, &resp); err != nil {
+		return nil, fmt.Errorf("failed fetching keys from etcd: %w", err)
+	}
+
+	services := map[string]*service{}
+	for _, kv := range resp.KVs {
+		key, err := decode(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		if key != zoneKey && !strings.HasPrefix(key, zoneKey+"/") {
+			continue
+		}
+		value, err := decode(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		var s service
+		if err := json.Unmarshal([]byte(value), &s); err != nil {
+			return nil, fmt.Errorf("invalid value of the etcd key %s: %w", key, err)
+		}
+		services[key] = &s
+	}
+	return services, nil
+}
+
+// update deletes and puts keys in a single transaction.
+func (c *etcdProvider) update(deletes []string, puts map[string]*service) error {
+	if err := c.authenticate(); err != nil {
+		return err
+	}
+
+	var txn txnRequest
+	for _, key := range deletes {
+		txn.Success = append(txn.Success, requestOp{RequestDeleteRange: &rangeRequest{Key: encode(key)}})
+	}
+	for key, s := range puts {
+		value, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		txn.Success = append(txn.Success, requestOp{RequestPut: &keyValue{Key: encode(key), Value: encode(string(value))}})
+	}
+	if err := c.post("/v3/kv/txn", txn, nil); err != nil {
+		return fmt.Errorf("failed updating keys (etcd): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package etcd
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// The CoreDNS etcd plugin uses the reversed labels of a name as the path of
+// its keys: www.example.com is /skydns/com/example/www. A name with several
+// records has one key per record below its path. The keys written by
+// DNSControl are named after the records, such as /skydns/com/example/www/dnscontrol-1a2b3c4d.
+
+const recordKeyPrefix = "dnscontrol-"
+
+// defaultTTL is the TTL used by the CoreDNS etcd plugin if a service has none.
+const defaultTTL = 300
+
+// key returns the key of a name.
+func (c *etcdProvider) key(fqdn string) string {
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(fqdn, ".")), ".")
+	slices.Reverse(labels)
+	return c.prefix + "/" + strings.Join(labels, "/")
+}
+
+// nameFromKey returns the name of the record stored in a key.
+func (c *etcdProvider) nameFromKey(key string) string {
+	labels := strings.Split(strings.TrimPrefix(key, c.prefix+"/"), "/")
+	if strings.HasPrefix(labels[len(labels)-1], recordKeyPrefix) {
+		labels = labels[:len(labels)-1]
+	}
+	slices.Reverse(labels)
+	return strings.Join(labels, ".")
+}
+
+// recordKey returns the key of a record.
+func (c *etcdProvider) recordKey(rc *models.RecordConfig) string {
+	h := fnv.New32a()
+	h.Write([]byte(rc.Type + " " + rc.GetTargetCombined()))
+	return fmt.Sprintf("%s/%s%08x", c.key(rc.GetLabelFQDN()), recordKeyPrefix, h.Sum32())
+}
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRc converts a service into a RecordConfig.
+func (c *etcdProvider) toRc(domain, key string, s *service) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		TTL:      s.TTL,
+		Original: key,
+	}
+	if rc.TTL == 0 {
+		rc.TTL = defaultTTL
+	}
+	rc.SetLabelFromFQDN(c.nameFromKey(key), domain)
+
+	var err error
+	switch ip := net.ParseIP(s.Host); {
+	case s.Text != "":
+		rc.Type = "TXT"
+		err = rc.SetTargetTXT(s.Text)
+	case s.Mail:
+		rc.Type = "MX"
+		err = rc.SetTargetMX(s.Priority, dot(s.Host))
+	case s.Port != 0:
+		rc.Type = "SRV"
+		err = rc.SetTargetSRV(s.Priority, s.Weight, s.Port, dot(s.Host))
+	case ip != nil && ip.To4() != nil:
+		rc.Type = "A"
+		err = rc.SetTarget(s.Host)
+	case ip != nil:
+		rc.Type = "AAAA"
+		err = rc.SetTarget(s.Host)
+	case s.Host != "":
+		rc.Type = "CNAME"
+		err = rc.SetTarget(dot(s.Host))
+	default:
+		return nil, fmt.Errorf("unsupported value of the etcd key %s", key)
+	}
+	return rc, err
+}
+
+// toService converts a RecordConfig into a service.
+func toService(rc *models.RecordConfig) *service {
+	s := &service{TTL: rc.TTL}
+	switch rc.Type {
+	case "TXT":
+		s.Text = rc.GetTargetTXTJoined()
+	case "MX":
+		s.Mail = true
+		s.Priority = rc.MxPreference
+		s.Host = strings.TrimSuffix(rc.GetTargetField(), ".")
+	case "SRV":
+		s.Priority = rc.SrvPriority
+		s.Weight = rc.SrvWeight
+		s.Port = rc.SrvPort
+		s.Host = strings.TrimSuffix(rc.GetTargetField(), ".")
+	default:
+		s.Host = strings.TrimSuffix(rc.GetTargetField(), ".")
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package etcd
+
+import "testing"
+
+func TestKeys(t *testing.T) {
+	c := &etcdProvider{prefix: "/skydns"}
+
+	for _, tst := range []struct {
+		fqdn, key string
+	}{
+		{"example.com", "/skydns/com/example"},
+		{"www.example.com.", "/skydns/com/example/www"},
+		{"*.Example.com", "/skydns/com/example/*"},
+	} {
+		if got := c.key(tst.fqdn); got != tst.key {
+			t.Errorf("key(%q) = %q, want %q", tst.fqdn, got, tst.key)
+		}
+	}
+
+	for _, tst := range []struct {
+		key, name string
+	}{
+		{"/skydns/com/example", "example.com"},
+		{"/skydns/com/example/www", "www.example.com"},
+		{"/skydns/com/example/www/dnscontrol-0badcafe", "www.example.com"},
+		{"/skydns/com/example/www/x1", "x1.www.example.com"},
+	} {
+		if got := c.nameFromKey(tst.key); got != tst.name {
+			t.Errorf("nameFromKey(%q) = %q, want %q", tst.key, got, tst.name)
+		}
+	}
+}
+
+func TestServiceRoundTrip(t *testing.T) {
+	c := &etcdProvider{prefix: "/skydns"}
+
+	for _, s := range []*service{
+		{Host: "10.0.0.1", TTL: 60},
+		{Host: "2001:db8::1", TTL: 60},
+		{Host: "target.example.net", TTL: 60},
+		{Host: "mail.example.com", Mail: true, Priority: 10, TTL: 60},
+		{Host: "sip.example.com", Port: 5060, Priority: 10, Weight: 20, TTL: 60},
+		{Text: "v=spf1 -all", TTL: 60},
+	} {
+		rc, err := c.toRc("example.com", "/skydns/com/example/www", s)
+		if err != nil {
+			t.Fatalf("toRc(%+v): %v", s, err)
+		}
+		if got := toService(rc); *got != *s {
+			t.Errorf("%s: got %+v, want %+v", rc.Type, got, s)
+		}
+		if rc.GetLabel() != "www" {
+			t.Errorf("%s: got label %q, want www", rc.Type, rc.GetLabel())
+		}
+	}
+
+	rc, err := c.toRc("example.com", "/skydns/com/example", &service{Host: "10.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.TTL != defaultTTL || rc.GetLabel() != "@" {
+		t.Errorf("apex: got %s TTL %d", rc.GetLabel(), rc.TTL)
+	}
+}
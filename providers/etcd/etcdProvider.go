@@ -0,0 +1,156 @@
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for the zones served by the etcd plugin of CoreDNS (and SkyDNS).
+// Plugin Documentation: https://coredns.io/plugins/etcd/
+
+/*
+etcd provider:
+
+Info required in `creds.json`:
+   - endpoint (optional) URL of the etcd server, default is http://127.0.0.1:2379
+   - path (optional) prefix of the keys, default is /skydns
+   - username, password (optional) credentials of an etcd user
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Cannot(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Cannot(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can("Zones are key prefixes in etcd, they do not need to be created"),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "ETCD"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newEtcd,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+	providers.RegisterTLSOptions(providerName)
+}
+
+// newEtcd creates the provider.
+func newEtcd(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &etcdProvider{
+		endpoint: strings.TrimSuffix(m["endpoint"], "/"),
+		prefix:   "/" + strings.Trim(m["path"], "/"),
+		username: m["username"],
+		password: m["password"],
+	}
+	if c.endpoint == "" {
+		c.endpoint = "http://127.0.0.1:2379"
+	}
+	if c.prefix == "/" {
+		c.prefix = "/skydns"
+	}
+	if c.username == "" && c.password != "" {
+		return nil, fmt.Errorf("ETCD username must be set with password")
+	}
+	client, err := tlsconfig.NewHTTPClient(m)
+	if err != nil {
+		return nil, fmt.Errorf("ETCD: %w", err)
+	}
+	c.client = client
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+// The NS records of the zones are served by CoreDNS itself.
+func (c *etcdProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *etcdProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	services, err := c.getServices(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(services))
+	for key := range services {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	existingRecords := make([]*models.RecordConfig, 0, len(keys))
+	for _, key := range keys {
+		rc, err := c.toRc(domain, key, services[key])
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+// The keys of a record set are replaced in a single transaction.
+func (c *etcdProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecordSet(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		switch change.Type {
+		case diff2.REPORT:
+			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
+			continue
+		case diff2.CREATE, diff2.CHANGE, diff2.DELETE:
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+
+		puts := map[string]*service{}
+		for _, rc := range change.New {
+			puts[c.recordKey(rc)] = toService(rc)
+		}
+		var deletes []string
+		for _, rc := range change.Old {
+			// A key can't be both deleted and put in a transaction.
+			if key := rc.Original.(string); puts[key] == nil {
+				deletes = append(deletes, key)
+			}
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: change.MsgsJoined,
+			F: func() error {
+				return c.update(deletes, puts)
+			},
+		})
+	}
+
+	return corrections, nil
+}
@@ -0,0 +1,170 @@
+package eurodns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const baseURL = "https://rest-api.eurodns.com"
+
+type eurodnsProvider struct {
+	appID  string
+	apiKey string
+}
+
+type record struct {
+	ID    int    `json:"id,omitempty"`
+	Type  string `json:"type"`
+	Host  string `json:"host"`
+	TTL   uint32 `json:"ttl"`
+	RData string `json:"rdata"`
+}
+
+// zone is the DNS zone of a domain. The record list is replaced as a whole.
+// The other fields are sent back unchanged.
+type zone struct {
+	Name          string          `json:"name"`
+	DomainConnect bool            `json:"domainConnect"`
+	Records       []record        `json:"records"`
+	URLForwards   json.RawMessage `json:"urlForwards,omitempty"`
+	MailForwards  json.RawMessage `json:"mailForwards,omitempty"`
+}
+
+type nameserver struct {
+	Name     string `json:"name"`
+	IPv4Addr string `json:"ipv4Addr,omitempty"`
+	IPv6Addr string `json:"ipv6Addr,omitempty"`
+}
+
+type domainInfo struct {
+	Name        string       `json:"name"`
+	Nameservers []nameserver `json:"nameservers"`
+}
+
+type errorResponse struct {
+	Errors []struct {
+		Code    int    `json:"code"`
+		Title   string `json:"title"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// do sends a request and decodes the response into target.
+func (c *eurodnsProvider) do(method, path string, body, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+retry:
+	req, err := http.NewRequest(method, baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-APP-ID", c.appID)
+	req.Header.Set("X-API-KEY", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("EuroDNS rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var er errorResponse
+		if json.Unmarshal(data, &er) == nil && len(er.Errors) > 0 {
+			return fmt.Errorf("eurodns API error: %s: %s", resp.Status, er.Errors[0].Message)
+		}
+		return fmt.Errorf("eurodns API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (c *eurodnsProvider) listZones() ([]string, error) {
+	var resp []struct {
+		Name string `json:"name"`
+	}
+	if err := c.do(http.MethodGet, "/dns-zones/", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed listing zones from eurodns: %w", err)
+	}
+	zones := make([]string, 0, len(resp))
+	for _, z := range resp {
+		zones = append(zones, z.Name)
+	}
+	return zones, nil
+}
+
+func (c *eurodnsProvider) getZone(domain string) (*zone, error) {
+	var z zone
+	if err := c.do(http.MethodGet, "/dns-zones/"+url.PathEscape(domain), nil, &z); err != nil {
+		return nil, fmt.Errorf("failed fetching zone from eurodns: %w", err)
+	}
+	return &z, nil
+}
+
+func (c *eurodnsProvider) updateZone(z *zone) error {
+	if err := c.do(http.MethodPut, "/dns-zones/"+url.PathEscape(z.Name), z, nil); err != nil {
+		return fmt.Errorf("failed updating zone (eurodns): %w", err)
+	}
+	return nil
+}
+
+func (c *eurodnsProvider) createZone(domain string) error {
+	z := &zone{Name: domain, Records: []record{}}
+	if err := c.do(http.MethodPost, "/dns-zones/", z, nil); err != nil {
+		return fmt.Errorf("failed creating zone (eurodns): %w", err)
+	}
+	return nil
+}
+
+func (c *eurodnsProvider) getNameservers(domain string) ([]nameserver, error) {
+	var d domainInfo
+	if err := c.do(http.MethodGet, "/domains/"+url.PathEscape(domain), nil, &d); err != nil {
+		return nil, fmt.Errorf("failed fetching domain from eurodns: %w", err)
+	}
+	return d.Nameservers, nil
+}
+
+func (c *eurodnsProvider) updateNameservers(domain string, ns []string) error {
+	body := struct {
+		Nameservers []nameserver `json:"nameservers"`
+	}{}
+	for _, n := range ns {
+		body.Nameservers = append(body.Nameservers, nameserver{Name: n})
+	}
+	if err := c.do(http.MethodPut, "/domains/"+url.PathEscape(domain)+"/nameservers", body, nil); err != nil {
+		return fmt.Errorf("failed updating nameservers (eurodns): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package eurodns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRc converts a EuroDNS record into a RecordConfig. The rdata is in zone
+// file format, with the host names written without the trailing dot.
+func toRc(domain string, r *record) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+	}
+	rc.SetLabel(r.Host, domain)
+
+	var err error
+	switch r.Type {
+	case "CNAME", "NS":
+		err = rc.SetTarget(dot(r.RData))
+	case "MX":
+		if err = rc.SetTargetMXString(r.RData); err == nil {
+			err = rc.SetTarget(dot(rc.GetTargetField()))
+		}
+	case "SRV":
+		if err = rc.SetTargetSRVString(r.RData); err == nil {
+			err = rc.SetTarget(dot(rc.GetTargetField()))
+		}
+	case "TXT":
+		err = rc.SetTargetTXT(r.RData)
+	case "A", "AAAA", "CAA", "DS", "TLSA":
+		err = rc.PopulateFromString(r.Type, r.RData, domain)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+// toRecord converts a RecordConfig into a EuroDNS record.
+func toRecord(rc *models.RecordConfig) record {
+	r := record{
+		Type: rc.Type,
+		Host: rc.GetLabel(),
+		TTL:  rc.TTL,
+	}
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "MX":
+		r.RData = fmt.Sprintf("%d %s", rc.MxPreference, target)
+	case "SRV":
+		r.RData = fmt.Sprintf("%d %d %d %s", rc.SrvPriority, rc.SrvWeight, rc.SrvPort, target)
+	case "TXT":
+		r.RData = rc.GetTargetTXTJoined()
+	case "CAA", "DS", "TLSA":
+		r.RData = rc.GetTargetCombined()
+	default:
+		r.RData = target
+	}
+	return r
+}
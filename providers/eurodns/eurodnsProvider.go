@@ -0,0 +1,186 @@
+package eurodns
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for EuroDNS.
+// API Documentation: https://docs.eurodns.com/
+
+/*
+EuroDNS provider:
+
+Info required in `creds.json`:
+   - app_id
+   - api_key
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Can(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{
+	"ns1.eurodns.com",
+	"ns2.eurodns.com",
+	"ns3.eurodns.com",
+	"ns4.eurodns.com",
+}
+
+func init() {
+	const providerName = "EURODNS"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	providers.RegisterRegistrarType(providerName, newReg)
+	fns := providers.DspFuncs{
+		Initializer:   newDsp,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+func newReg(conf map[string]string) (providers.Registrar, error) {
+	return newEurodns(conf)
+}
+
+func newDsp(conf map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	return newEurodns(conf)
+}
+
+// newEurodns creates the provider.
+func newEurodns(m map[string]string) (*eurodnsProvider, error) {
+	c := &eurodnsProvider{
+		appID:  m["app_id"],
+		apiKey: m["api_key"],
+	}
+	if c.appID == "" || c.apiKey == "" {
+		return nil, fmt.Errorf("missing EURODNS app_id or api_key")
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *eurodnsProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// isApexNS reports whether r is an apex NS record. These are managed by EuroDNS.
+func isApexNS(r *record) bool {
+	return r.Type == "NS" && (r.Host == "@" || r.Host == "")
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *eurodnsProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	z, err := c.getZone(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(z.Records))
+	for i := range z.Records {
+		r := &z.Records[i]
+		if isApexNS(r) {
+			continue
+		}
+		rc, err := toRc(domain, r)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+// The API replaces the records of the zone as a whole.
+func (c *eurodnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	msgs, changes, err := diff2.ByZone(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !changes {
+		return nil, nil
+	}
+
+	return []*models.Correction{
+		{
+			Msg: strings.Join(msgs, "\n"),
+			F: func() error {
+				z, err := c.getZone(dc.Name)
+				if err != nil {
+					return err
+				}
+				records := []record{}
+				for i := range z.Records {
+					if isApexNS(&z.Records[i]) {
+						records = append(records, z.Records[i])
+					}
+				}
+				for _, rc := range dc.Records {
+					records = append(records, toRecord(rc))
+				}
+				z.Records = records
+				return c.updateZone(z)
+			},
+		},
+	}, nil
+}
+
+// GetRegistrarCorrections returns a list of corrections for this registrar.
+func (c *eurodnsProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	nss, err := c.getNameservers(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	foundNameservers := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		foundNameservers = append(foundNameservers, strings.ToLower(strings.TrimSuffix(ns.Name, ".")))
+	}
+	sort.Strings(foundNameservers)
+
+	expected := make([]string, 0, len(dc.Nameservers))
+	for _, ns := range dc.Nameservers {
+		expected = append(expected, ns.Name)
+	}
+	sort.Strings(expected)
+
+	foundStr := strings.Join(foundNameservers, ",")
+	expectedStr := strings.Join(expected, ",")
+	if foundStr == expectedStr {
+		return nil, nil
+	}
+
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundStr, expectedStr),
+			F: func() error {
+				return c.updateNameservers(dc.Name, expected)
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,20 @@
+package eurodns
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *eurodnsProvider) ListZones() ([]string, error) {
+	return c.listZones()
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *eurodnsProvider) EnsureZoneExists(domain string) error {
+	zones, err := c.listZones()
+	if err != nil {
+		return err
+	}
+	for _, z := range zones {
+		if z == domain {
+			return nil
+		}
+	}
+	return c.createZone(domain)
+}
@@ -0,0 +1,325 @@
+package externaldns
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The provider uses the REST API of Kubernetes.
+// API Documentation: https://kubernetes.io/docs/reference/using-api/api-concepts/
+
+const (
+	apiPath = "/apis/externaldns.k8s.io/v1alpha1"
+
+	// The labels and annotations of the DNSEndpoint objects managed by DNSControl.
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "dnscontrol"
+	zoneAnnotation = "dnscontrol.org/zone"
+
+	// The files of the service account, when running in a pod.
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount/"
+)
+
+type externaldnsProvider struct {
+	server    string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+var errNotFound = errors.New("not found")
+
+// dnsEndpoint is a DNSEndpoint object, the CRD of external-dns.
+type dnsEndpoint struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Metadata   objectMeta `json:"metadata"`
+	Spec       struct {
+		Endpoints []*endpoint `json:"endpoints"`
+	} `json:"spec"`
+}
+
+type objectMeta struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace,omitempty"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+type endpoint struct {
+	DNSName    string   `json:"dnsName"`
+	RecordType string   `json:"recordType"`
+	Targets    []string `json:"targets"`
+	RecordTTL  uint32   `json:"recordTTL,omitempty"`
+}
+
+type dnsEndpointList struct {
+	Items []dnsEndpoint `json:"items"`
+}
+
+type status struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
+
+// kubeconfig is the subset of a kubeconfig file used by the provider.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string `yaml:"token"`
+			ClientCertificate     string `yaml:"client-certificate"`
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKey             string `yaml:"client-key"`
+			ClientKeyData         string `yaml:"client-key-data"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// readData returns the base64 encoded data, or the content of the file if data is empty.
+func readData(data, filename string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	if filename == "" {
+		return nil, nil
+	}
+	return os.ReadFile(filename)
+}
+
+// loadKubeconfig configures the client from a context of a kubeconfig file.
+// The clusters using an exec or auth-provider plugin are not supported.
+func (c *externaldnsProvider) loadKubeconfig(filename, context string) (*tls.Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var kc kubeconfig
+	if err := yaml.Unmarshal(data, &kc); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig %s: %w", filename, err)
+	}
+	if context == "" {
+		context = kc.CurrentContext
+	}
+
+	tlsConfig := &tls.Config{}
+	found := false
+	for _, ctx := range kc.Contexts {
+		if ctx.Name != context {
+			continue
+		}
+		found = true
+		if c.namespace == "" {
+			c.namespace = ctx.Context.Namespace
+		}
+		for _, cl := range kc.Clusters {
+			if cl.Name != ctx.Context.Cluster {
+				continue
+			}
+			c.server = cl.Cluster.Server
+			tlsConfig.InsecureSkipVerify = cl.Cluster.InsecureSkipTLSVerify
+			ca, err := readData(cl.Cluster.CertificateAuthorityData, cl.Cluster.CertificateAuthority)
+			if err != nil {
+				return nil, err
+			}
+			if len(ca) > 0 {
+				tlsConfig.RootCAs = x509.NewCertPool()
+				tlsConfig.RootCAs.AppendCertsFromPEM(ca)
+			}
+		}
+		for _, u := range kc.Users {
+			if u.Name != ctx.Context.User {
+				continue
+			}
+			c.token = u.User.Token
+			cert, err := readData(u.User.ClientCertificateData, u.User.ClientCertificate)
+			if err != nil {
+				return nil, err
+			}
+			key, err := readData(u.User.ClientKeyData, u.User.ClientKey)
+			if err != nil {
+				return nil, err
+			}
+			if len(cert) > 0 {
+				pair, err := tls.X509KeyPair(cert, key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid client certificate of user %s: %w", u.Name, err)
+				}
+				tlsConfig.Certificates = []tls.Certificate{pair}
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("context %q not found in kubeconfig %s", context, filename)
+	}
+	return tlsConfig, nil
+}
+
+// loadServiceAccount configures the client from the service account of the pod.
+func (c *externaldnsProvider) loadServiceAccount() (*tls.Config, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes pod, set server or kubeconfig")
+	}
+	c.server = "https://" + host + ":" + port
+
+	token, err := os.ReadFile(serviceAccountDir + "token")
+	if err != nil {
+		return nil, err
+	}
+	c.token = strings.TrimSpace(string(token))
+	if c.namespace == "" {
+		if ns, err := os.ReadFile(serviceAccountDir + "namespace"); err == nil {
+			c.namespace = strings.TrimSpace(string(ns))
+		}
+	}
+
+	ca, err := os.ReadFile(serviceAccountDir + "ca.crt")
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{RootCAs: x509.NewCertPool()}
+	tlsConfig.RootCAs.AppendCertsFromPEM(ca)
+	return tlsConfig, nil
+}
+
+// do sends a request and decodes the response into target.
+func (c *externaldnsProvider) do(method, path string, body, target any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.server+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		var st status
+		if json.Unmarshal(data, &st) == nil && st.Message != "" {
+			return fmt.Errorf("kubernetes API error: %s: %s", resp.Status, st.Message)
+		}
+		return fmt.Errorf("kubernetes API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (c *externaldnsProvider) collectionPath() string {
+	return apiPath + "/namespaces/" + url.PathEscape(c.namespace) + "/dnsendpoints"
+}
+
+// objectName returns the name of the DNSEndpoint object of a zone.
+func objectName(domain string) string {
+	return "dnscontrol-" + strings.ReplaceAll(strings.ToLower(domain), ".", "-")
+}
+
+func (c *externaldnsProvider) listObjects() ([]dnsEndpoint, error) {
+	var list dnsEndpointList
+	path := c.collectionPath() + "?labelSelector=" + url.QueryEscape(managedByLabel+"="+managedByValue)
+	if err := c.do(http.MethodGet, path, nil, &list); err != nil {
+		return nil, fmt.Errorf("failed listing DNSEndpoints: %w", err)
+	}
+	return list.Items, nil
+}
+
+// getObject returns the DNSEndpoint object of a zone, or nil if it doesn't exist.
+func (c *externaldnsProvider) getObject(domain string) (*dnsEndpoint, error) {
+	var obj dnsEndpoint
+	err := c.do(http.MethodGet, c.collectionPath()+"/"+objectName(domain), nil, &obj)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed fetching DNSEndpoint %s: %w", objectName(domain), err)
+	}
+	return &obj, nil
+}
+
+// putObject creates the DNSEndpoint object of a zone, or replaces it.
+func (c *externaldnsProvider) putObject(domain string, endpoints []*endpoint) error {
+	existing, err := c.getObject(domain)
+	if err != nil {
+		return err
+	}
+
+	obj := dnsEndpoint{
+		APIVersion: "externaldns.k8s.io/v1alpha1",
+		Kind:       "DNSEndpoint",
+		Metadata: objectMeta{
+			Name:        objectName(domain),
+			Namespace:   c.namespace,
+			Labels:      map[string]string{managedByLabel: managedByValue},
+			Annotations: map[string]string{zoneAnnotation: domain},
+		},
+	}
+	obj.Spec.Endpoints = endpoints
+
+	if existing == nil {
+		err = c.do(http.MethodPost, c.collectionPath(), obj, nil)
+	} else {
+		obj.Metadata.ResourceVersion = existing.Metadata.ResourceVersion
+		err = c.do(http.MethodPut, c.collectionPath()+"/"+obj.Metadata.Name, obj, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed updating DNSEndpoint %s: %w", obj.Metadata.Name, err)
+	}
+	return nil
+}
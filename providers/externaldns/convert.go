@@ -0,0 +1,104 @@
+package externaldns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// defaultTTL is used for the endpoints without TTL, which get the default TTL
+// of the external-dns provider.
+const defaultTTL = 300
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRecords converts an endpoint into RecordConfigs, one per target.
+func toRecords(domain string, ep *endpoint) (models.Records, error) {
+	var records models.Records
+	for _, target := range ep.Targets {
+		rc := &models.RecordConfig{
+			Type: ep.RecordType,
+			TTL:  ep.RecordTTL,
+		}
+		if rc.TTL == 0 {
+			rc.TTL = defaultTTL
+		}
+		rc.SetLabelFromFQDN(ep.DNSName, domain)
+
+		var err error
+		switch ep.RecordType {
+		case "CNAME", "NS", "PTR":
+			err = rc.SetTarget(dot(target))
+		case "MX":
+			if err = rc.SetTargetMXString(target); err == nil {
+				err = rc.SetTarget(dot(rc.GetTargetField()))
+			}
+		case "SRV":
+			if err = rc.SetTargetSRVString(target); err == nil {
+				err = rc.SetTarget(dot(rc.GetTargetField()))
+			}
+		case "TXT":
+			err = rc.SetTargetTXT(target)
+		default:
+			err = rc.PopulateFromString(ep.RecordType, target, domain)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unparsable record type=%q received from EXTERNALDNS: %w", ep.RecordType, err)
+		}
+		records = append(records, rc)
+	}
+	return records, nil
+}
+
+// toTarget returns the target of an endpoint for a record.
+func toTarget(rc *models.RecordConfig) string {
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "MX":
+		return fmt.Sprintf("%d %s", rc.MxPreference, target)
+	case "SRV":
+		return fmt.Sprintf("%d %d %d %s", rc.SrvPriority, rc.SrvWeight, rc.SrvPort, target)
+	case "TXT":
+		return rc.GetTargetTXTJoined()
+	default:
+		return target
+	}
+}
+
+// toEndpoints converts records into endpoints, one per record set.
+func toEndpoints(records models.Records) []*endpoint {
+	sets := map[models.RecordKey]*endpoint{}
+	for _, rc := range records {
+		key := rc.Key()
+		ep, ok := sets[key]
+		if !ok {
+			ep = &endpoint{
+				DNSName:    rc.GetLabelFQDN(),
+				RecordType: rc.Type,
+				RecordTTL:  rc.TTL,
+			}
+			sets[key] = ep
+		}
+		ep.Targets = append(ep.Targets, toTarget(rc))
+	}
+
+	endpoints := make([]*endpoint, 0, len(sets))
+	for _, ep := range sets {
+		sort.Strings(ep.Targets)
+		endpoints = append(endpoints, ep)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].DNSName != endpoints[j].DNSName {
+			return endpoints[i].DNSName < endpoints[j].DNSName
+		}
+		return endpoints[i].RecordType < endpoints[j].RecordType
+	})
+	return endpoints
+}
@@ -0,0 +1,37 @@
+package externaldns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEndpointsRoundTrip(t *testing.T) {
+	endpoints := []*endpoint{
+		{DNSName: "example.com", RecordType: "MX", Targets: []string{"10 mail.example.com", "20 mail2.example.com"}, RecordTTL: 300},
+		{DNSName: "example.com", RecordType: "TXT", Targets: []string{"v=spf1 -all"}, RecordTTL: 300},
+		{DNSName: "_sip._udp.example.com", RecordType: "SRV", Targets: []string{"10 20 5060 sip.example.com"}, RecordTTL: 60},
+		{DNSName: "test.example.com", RecordType: "A", Targets: []string{"10.0.0.1", "10.0.0.2"}, RecordTTL: 300},
+		{DNSName: "www.example.com", RecordType: "CNAME", Targets: []string{"test.example.com"}, RecordTTL: 300},
+	}
+
+	var all []*endpoint
+	for _, ep := range endpoints {
+		records, err := toRecords("example.com", ep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, toEndpoints(records)...)
+	}
+	if !reflect.DeepEqual(all, endpoints) {
+		for i := range all {
+			t.Logf("got %+v", all[i])
+		}
+		t.Errorf("round trip changed the endpoints")
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	if got := objectName("Example.co.uk"); got != "dnscontrol-example-co-uk" {
+		t.Errorf("objectName() = %q", got)
+	}
+}
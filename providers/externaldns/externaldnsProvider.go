@@ -0,0 +1,165 @@
+package externaldns
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for the DNSEndpoint custom resources of external-dns in Kubernetes.
+// CRD Documentation: https://kubernetes-sigs.github.io/external-dns/latest/docs/sources/crd/
+
+/*
+external-dns provider:
+
+Info required in `creds.json`:
+   - namespace (optional) namespace of the DNSEndpoint objects
+   - kubeconfig, context (optional) kubeconfig file and context used to reach the cluster
+   - server, token, certificate_authority (optional) used instead of a kubeconfig file
+
+	Without kubeconfig nor server, the service account of the pod is used.
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Cannot(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Can(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can("A DNSEndpoint object is created for each zone"),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "EXTERNALDNS"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newExternalDNS,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+	providers.RegisterTLSOptions(providerName)
+}
+
+// newExternalDNS creates the provider.
+func newExternalDNS(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &externaldnsProvider{
+		namespace: m["namespace"],
+	}
+
+	var tlsConfig *tls.Config
+	var err error
+	switch {
+	case m["server"] != "":
+		c.server, c.token = m["server"], m["token"]
+		// certificate_authority is the former name of tls_ca_file.
+		if m["certificate_authority"] != "" && m["tls_ca_file"] == "" {
+			m["tls_ca_file"] = m["certificate_authority"]
+		}
+		tlsConfig, err = tlsconfig.New(m)
+	case m["kubeconfig"] != "":
+		tlsConfig, err = c.loadKubeconfig(m["kubeconfig"], m["context"])
+	default:
+		tlsConfig, err = c.loadServiceAccount()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("EXTERNALDNS: %w", err)
+	}
+	c.server = strings.TrimSuffix(c.server, "/")
+	if c.server == "" {
+		return nil, fmt.Errorf("EXTERNALDNS: no server found")
+	}
+	if c.namespace == "" {
+		c.namespace = "default"
+	}
+
+	c.client = &http.Client{Transport: &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+// The NS records of the zones are managed by the providers of external-dns.
+func (c *externaldnsProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+// ListZones returns the zones of the DNSEndpoint objects managed by DNSControl.
+func (c *externaldnsProvider) ListZones() ([]string, error) {
+	objects, err := c.listObjects()
+	if err != nil {
+		return nil, err
+	}
+	var zones []string
+	for _, obj := range objects {
+		if zone := obj.Metadata.Annotations[zoneAnnotation]; zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *externaldnsProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	obj, err := c.getObject(domain)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+
+	var existingRecords models.Records
+	for _, ep := range obj.Spec.Endpoints {
+		records, err := toRecords(domain, ep)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, records...)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+// The endpoints of the zone are all replaced in a single update of its DNSEndpoint object.
+func (c *externaldnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	msgs, changes, err := diff2.ByZone(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !changes {
+		return nil, nil
+	}
+
+	endpoints := toEndpoints(dc.Records)
+	return []*models.Correction{
+		{
+			Msg: strings.Join(msgs, "\n"),
+			F: func() error {
+				return c.putObject(dc.Name, endpoints)
+			},
+		},
+	}, nil
+}
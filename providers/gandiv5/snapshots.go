@@ -0,0 +1,52 @@
+package gandiv5
+
+import (
+	"fmt"
+	"time"
+)
+
+// CreateSnapshot takes a LiveDNS snapshot of the zone, and returns its ID.
+func (client *gandiv5Provider) CreateSnapshot(domain string) (string, error) {
+	g := newLiveDNSClient(client)
+
+	response, err := g.CreateSnapshot(domain)
+	if err != nil {
+		return "", fmt.Errorf("gandi_v5: can't create the snapshot of %s: %w", domain, err)
+	}
+	if response.UUID != "" {
+		return response.UUID, nil
+	}
+
+	// The ID isn't always in the response: the new snapshot is the
+	// latest one.
+	snapshots, err := g.ListSnapshots(domain)
+	if err != nil {
+		return "", fmt.Errorf("gandi_v5: can't list the snapshots of %s: %w", domain, err)
+	}
+	var id string
+	var latest time.Time
+	for _, snapshot := range snapshots {
+		if id == "" || snapshot.CreatedAt.After(latest) {
+			id, latest = snapshot.ID, snapshot.CreatedAt
+		}
+	}
+	if id == "" {
+		return "", fmt.Errorf("gandi_v5: the snapshot of %s was not found after its creation", domain)
+	}
+	return id, nil
+}
+
+// RestoreSnapshot replaces the records of the zone by the records of a
+// LiveDNS snapshot.
+func (client *gandiv5Provider) RestoreSnapshot(domain, id string) error {
+	g := newLiveDNSClient(client)
+
+	snapshot, err := g.GetSnapshot(domain, id)
+	if err != nil {
+		return fmt.Errorf("gandi_v5: can't get the snapshot %s of %s: %w", id, domain, err)
+	}
+	if _, err := g.UpdateDomainRecords(domain, snapshot.ZoneData); err != nil {
+		return fmt.Errorf("gandi_v5: can't restore the snapshot %s of %s: %w", id, domain, err)
+	}
+	return nil
+}
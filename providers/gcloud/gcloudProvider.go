@@ -4,7 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"net/http"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -12,16 +13,27 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
 	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	gauth "golang.org/x/oauth2/google"
 	gdns "google.golang.org/api/dns/v1"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/api/impersonate"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 )
 
 const selfLinkBasePath = "https://www.googleapis.com/compute/v1/projects/"
 
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// scopes are the IAM permissions which the provider needs for the records.
+var scopes = providers.RequiredScopes{
+	Read:  []string{"dns.managedZones.list", "dns.resourceRecordSets.list"},
+	Write: []string{"dns.changes.create", "dns.resourceRecordSets.create", "dns.resourceRecordSets.update", "dns.resourceRecordSets.delete", "dns.managedZones.create"},
+}
+
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
@@ -59,7 +71,7 @@ func init() {
 		Initializer:   New,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, scopes)
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
@@ -88,6 +100,13 @@ func New(cfg map[string]string, metadata json.RawMessage) (providers.DNSServiceP
 	// fix it if we find that.
 
 	ctx := context.Background()
+	// The credentials which impersonate a service account need the scope of
+	// the IAM Credentials API.
+	scope := gdns.NdevClouddnsReadwriteScope
+	impersonated := cfg["impersonate_service_account"]
+	if impersonated != "" {
+		scope = cloudPlatformScope
+	}
 	var opt option.ClientOption
 	if key, ok := cfg["private_key"]; ok {
 		cfg["private_key"] = strings.Replace(key, "\\n", "\n", -1)
@@ -95,15 +114,43 @@ func New(cfg map[string]string, metadata json.RawMessage) (providers.DNSServiceP
 		if err != nil {
 			return nil, err
 		}
-		config, err := gauth.JWTConfigFromJSON(raw, gdns.NdevClouddnsReadwriteScope)
+		config, err := gauth.JWTConfigFromJSON(raw, scope)
 		if err != nil {
 			return nil, err
 		}
 		opt = option.WithTokenSource(config.TokenSource(ctx))
+	} else if file := cfg["credentials_file"]; file != "" {
+		// A service account key, or the external account of a workload
+		// identity federation (e.g. the OIDC token of a CI job).
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		creds, err := gauth.CredentialsFromJSON(ctx, raw, scope)
+		if err != nil {
+			return nil, err
+		}
+		opt = option.WithTokenSource(creds.TokenSource)
 	} else {
-		opt = option.WithScopes(gdns.NdevClouddnsReadwriteScope)
+		// The Application Default Credentials, e.g. the workload identity of
+		// GKE or the service account of Compute Engine.
+		opt = option.WithScopes(scope)
+	}
+	if impersonated != "" {
+		ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: impersonated,
+			Scopes:          []string{gdns.NdevClouddnsReadwriteScope},
+		}, opt)
+		if err != nil {
+			return nil, err
+		}
+		opt = option.WithTokenSource(ts)
+	}
+	transport, err := htransport.NewTransport(ctx, tlsconfig.NewSDKClient().Transport, opt)
+	if err != nil {
+		return nil, err
 	}
-	dcli, err := gdns.NewService(ctx, opt)
+	dcli, err := gdns.NewService(ctx, option.WithHTTPClient(&http.Client{Transport: transport}))
 	if err != nil {
 		return nil, err
 	}
@@ -130,20 +177,27 @@ func New(cfg map[string]string, metadata json.RawMessage) (providers.DNSServiceP
 			printer.Printf("GCLOUD :visibility %s configured\n", g.Visibility)
 		}
 		for i, v := range g.Networks {
-			if ok := networkURLCheck.MatchString(v); ok {
-				// the user specified a fully qualified network url
-				continue
-			}
-			if ok := networkNameCheck.MatchString(v); !ok {
-				return nil, fmt.Errorf("GCLOUD :networks set but %s does not appear to be a valid network name or url", v)
+			if g.Networks[i], err = g.networkURL(v); err != nil {
+				return nil, fmt.Errorf("GCLOUD :networks set but %w", err)
 			}
-			// assume target vpc network exists in the same project as the dns zones
-			g.Networks[i] = fmt.Sprintf("%s%s/global/networks/%s", selfLinkBasePath, g.project, v)
 		}
 	}
 	return g, g.loadZoneInfo()
 }
 
+// networkURL returns the URL of a network, given by its name or URL.
+func (g *gcloudProvider) networkURL(v string) (string, error) {
+	if ok := networkURLCheck.MatchString(v); ok {
+		// the user specified a fully qualified network url
+		return v, nil
+	}
+	if ok := networkNameCheck.MatchString(v); !ok {
+		return "", fmt.Errorf("%s does not appear to be a valid network name or url", v)
+	}
+	// assume target vpc network exists in the same project as the dns zones
+	return fmt.Sprintf("%s%s/global/networks/%s", selfLinkBasePath, g.project, v), nil
+}
+
 func (g *gcloudProvider) loadZoneInfo() error {
 	// TODO(asn-iac): In order to fully support split horizon domains within the same GCP project,
 	// need to parse the zone Visibility field from *ManagedZone, but currently
@@ -227,14 +281,11 @@ func (g *gcloudProvider) getZoneSets(domain string) (models.Records, error) {
 	oldRRs := map[key]*gdns.ResourceRecordSet{}
 	for _, set := range rrs {
 		oldRRs[keyFor(set)] = set
-		for _, rec := range set.Rrdatas {
-			rt, err := nativeToRecord(set, rec, domain)
-			if err != nil {
-				return nil, err
-			}
-
-			existingRecords = append(existingRecords, rt)
+		rts, err := nativeToRecords(set, domain)
+		if err != nil {
+			return nil, err
 		}
+		existingRecords = append(existingRecords, rts...)
 	}
 
 	return existingRecords, err
@@ -243,15 +294,23 @@ func (g *gcloudProvider) getZoneSets(domain string) (models.Records, error) {
 // GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
 func (g *gcloudProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
 
-	changes, err := diff2.ByRecordSet(existingRecords, dc, nil)
+	if err := checkRoutingPolicies(dc.Records); err != nil {
+		return nil, err
+	}
+
+	corrections, err := g.getZoneConfigCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diff2.ByRecordSet(existingRecords, dc, routingComparable)
 	if err != nil {
 		return nil, err
 	}
 	if len(changes) == 0 {
-		return nil, nil
+		return corrections, nil
 	}
 
-	var corrections []*models.Correction
 	batch := &gdns.Change{Kind: "dns#change"}
 	var accumlatedMsgs []string
 	var newMsgs []string
@@ -324,6 +383,11 @@ func mkRRSs(name, rType string, recs models.Records) *gdns.ResourceRecordSet {
 		Ttl:  int64(recs[0].TTL), // diff2 assures all TTLs in a ReceordSet are the same.
 	}
 
+	if recs[0].Metadata[metaRoutingPolicy] != "" {
+		newRRS.RoutingPolicy = mkRoutingPolicy(recs)
+		return newRRS
+	}
+
 	for _, r := range recs {
 		newRRS.Rrdatas = append(newRRS.Rrdatas, r.GetTargetCombinedFunc(txtutil.EncodeQuoted))
 	}
@@ -452,6 +516,12 @@ func (g *gcloudProvider) getRecords(domain string) ([]*gdns.ResourceRecordSet, e
 }
 
 func (g *gcloudProvider) EnsureZoneExists(domain string) error {
+	return g.EnsureZoneExistsWithMetadata(domain, nil)
+}
+
+// EnsureZoneExistsWithMetadata creates the zone, with the visibility,
+// networks, forwarding and peering of the domain metadata.
+func (g *gcloudProvider) EnsureZoneExistsWithMetadata(domain string, metadata map[string]string) error {
 	z, err := g.getZone(domain)
 	if err != nil {
 		if _, ok := err.(errNoExist); !ok {
@@ -472,20 +542,30 @@ func (g *gcloudProvider) EnsureZoneExists(domain string) error {
 		mz.NameServerSet = *g.nameServerSet
 		printer.Printf("with name_server_set %s ", *g.nameServerSet)
 	}
-	if len(g.Visibility) != 0 {
-		mz.Visibility = g.Visibility
-		printer.Printf("with %s visibility ", g.Visibility)
+	config, err := g.zoneConfig(metadata)
+	if err != nil {
+		return err
+	}
+	if len(config.Visibility) != 0 {
+		mz.Visibility = config.Visibility
+		printer.Printf("with %s visibility ", config.Visibility)
 		// prevent possible GCP resource name conflicts when split horizon can be properly implemented
-		mz.Name = strings.Replace(mz.Name, "zone-", "zone-"+g.Visibility+"-", 1)
+		mz.Name = strings.Replace(mz.Name, "zone-", "zone-"+config.Visibility+"-", 1)
 	}
-	if g.Networks != nil {
-		mzn := make([]*gdns.ManagedZonePrivateVisibilityConfigNetwork, 0, len(g.Networks))
+	if config.PrivateVisibilityConfig != nil {
 		printer.Printf("for network(s) ")
-		for _, v := range g.Networks {
-			printer.Printf("%s ", v)
-			mzn = append(mzn, &gdns.ManagedZonePrivateVisibilityConfigNetwork{NetworkUrl: v})
+		for _, v := range config.PrivateVisibilityConfig.Networks {
+			printer.Printf("%s ", v.NetworkUrl)
 		}
-		mz.PrivateVisibilityConfig = &gdns.ManagedZonePrivateVisibilityConfig{Networks: mzn}
+		mz.PrivateVisibilityConfig = config.PrivateVisibilityConfig
+	}
+	if config.ForwardingConfig != nil {
+		printer.Printf("forwarding to %s ", forwardingTargets(config.ForwardingConfig))
+		mz.ForwardingConfig = config.ForwardingConfig
+	}
+	if config.PeeringConfig != nil {
+		printer.Printf("peering with %s ", config.PeeringConfig.TargetNetwork.NetworkUrl)
+		mz.PeeringConfig = config.PeeringConfig
 	}
 	printer.Printf("\n")
 	g.zones[domain+"."], err = g.client.ManagedZones.Create(g.project, mz).Do()
@@ -522,7 +602,7 @@ func retryNeeded(resp *googleapi.ServerResponse, err error) bool {
 			backoff404 = false
 			return false // Give up. We've done this already.
 		}
-		log.Printf("Special 404 pause-and-retry for GCLOUD: Pausing %s\n", backoff)
+		printer.Printf("Special 404 pause-and-retry for GCLOUD: Pausing %s\n", backoff)
 		time.Sleep(backoff)
 		backoff404 = true
 		return true // Request a retry.
@@ -539,13 +619,13 @@ func retryNeeded(resp *googleapi.ServerResponse, err error) bool {
 	// file a bug with the contents!
 
 	if resp != nil {
-		log.Printf("NOTE: If you see this message, please file a bug with the output below:\n")
-		log.Printf("RUNCHANGE CODE = %+v\n", resp.HTTPStatusCode)
-		log.Printf("RUNCHANGE HEAD = %+v\n", resp.Header)
+		printer.Printf("NOTE: If you see this message, please file a bug with the output below:\n")
+		printer.Printf("RUNCHANGE CODE = %+v\n", resp.HTTPStatusCode)
+		printer.Printf("RUNCHANGE HEAD = %+v\n", resp.Header)
 	}
 
 	// a simple exponential back-off
-	log.Printf("Pausing due to ratelimit: %v seconds\n", backoff)
+	printer.Printf("Pausing due to ratelimit: %v seconds\n", backoff)
 	time.Sleep(backoff)
 	backoff = backoff + (backoff / 2)
 	if backoff > maxBackoff {
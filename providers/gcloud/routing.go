@@ -0,0 +1,361 @@
+package gcloud
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
+	gdns "google.golang.org/api/dns/v1"
+)
+
+// The routing policy of a record set is set in the metadata of its records.
+// Each record is an item of the policy: the records with the same weight
+// (weighted round robin) or location (geolocation) are one item. With the
+// failover policy, the primary records are the health-checked targets and
+// the backup records are geolocation items.
+const (
+	metaRoutingPolicy  = "gcloud_routing_policy"  // wrr, geo or failover
+	metaWeight         = "gcloud_weight"          // The weight of a wrr item.
+	metaLocation       = "gcloud_location"        // The location of a geo or backup item, e.g. us-east1.
+	metaFailover       = "gcloud_failover"        // primary or backup
+	metaHealthChecked  = "gcloud_health_checked"  // "true" if the targets of the item are health-checked.
+	metaHealthCheck    = "gcloud_health_check"    // The health check of the external endpoints.
+	metaTrickleTraffic = "gcloud_trickle_traffic" // The ratio of the traffic sent to the backup records.
+	metaEnableFencing  = "gcloud_enable_fencing"  // "true" to not fail over to the other locations.
+)
+
+const (
+	routingWRR      = "wrr"
+	routingGeo      = "geo"
+	routingFailover = "failover"
+)
+
+// routingPolicyKeys are the metadata of the routing policy, in the order of
+// the comparable strings.
+var routingPolicyKeys = []string{metaRoutingPolicy, metaWeight, metaLocation, metaFailover, metaHealthChecked, metaHealthCheck, metaTrickleTraffic, metaEnableFencing}
+
+// policyWideKeys are the metadata which are the same on all the records of a
+// routing policy.
+var policyWideKeys = []string{metaRoutingPolicy, metaHealthCheck, metaTrickleTraffic, metaEnableFencing}
+
+// normalizeNumber returns a number of the metadata in the format of
+// nativeToRecords.
+func normalizeNumber(s string) string {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// routingComparable returns the routing policy of a record for diff2.
+func routingComparable(rc *models.RecordConfig) string {
+	if rc.Metadata[metaRoutingPolicy] == "" {
+		return ""
+	}
+	var parts []string
+	for _, key := range routingPolicyKeys {
+		value := rc.Metadata[key]
+		switch key {
+		case metaWeight, metaTrickleTraffic:
+			value = normalizeNumber(value)
+		case metaHealthChecked:
+			// The primary records are always health-checked.
+			if isPrimary(rc) {
+				value = "true"
+			}
+			if value != "true" {
+				continue
+			}
+		case metaEnableFencing:
+			if value != "true" {
+				continue
+			}
+		case metaFailover, metaRoutingPolicy:
+			value = strings.ToLower(value)
+		}
+		if value != "" {
+			parts = append(parts, key+"="+value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// isPrimary reports whether a record is a primary record of a failover
+// routing policy.
+func isPrimary(rc *models.RecordConfig) bool {
+	return strings.EqualFold(rc.Metadata[metaRoutingPolicy], routingFailover) && strings.EqualFold(rc.Metadata[metaFailover], "primary")
+}
+
+// checkRoutingPolicies checks the routing policies of the record sets.
+func checkRoutingPolicies(records models.Records) error {
+	sets := map[models.RecordKey]models.Records{}
+	for _, rc := range records {
+		sets[rc.Key()] = append(sets[rc.Key()], rc)
+	}
+	for key, recs := range sets {
+		if err := checkRoutingPolicy(key, recs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRoutingPolicy(key models.RecordKey, recs models.Records) error {
+	policy := strings.ToLower(recs[0].Metadata[metaRoutingPolicy])
+	itemHealthChecked := map[string]bool{}
+	for _, rc := range recs {
+		for _, k := range policyWideKeys {
+			if rc.Metadata[k] != recs[0].Metadata[k] {
+				return fmt.Errorf("%s %s: all the records of a routing policy must have the same %s", key.NameFQDN, key.Type, k)
+			}
+		}
+		if policy == "" {
+			continue
+		}
+
+		healthChecked := rc.Metadata[metaHealthChecked] == "true"
+		switch policy {
+		case routingWRR:
+			if _, err := strconv.ParseFloat(rc.Metadata[metaWeight], 64); err != nil {
+				return fmt.Errorf("%s %s: invalid %s %q", key.NameFQDN, key.Type, metaWeight, rc.Metadata[metaWeight])
+			}
+		case routingGeo:
+			if rc.Metadata[metaLocation] == "" {
+				return fmt.Errorf("%s %s: %s is required by the geo routing policy", key.NameFQDN, key.Type, metaLocation)
+			}
+		case routingFailover:
+			switch strings.ToLower(rc.Metadata[metaFailover]) {
+			case "primary":
+				healthChecked = true
+			case "backup":
+				if rc.Metadata[metaLocation] == "" {
+					return fmt.Errorf("%s %s: %s is required by the backup records", key.NameFQDN, key.Type, metaLocation)
+				}
+			default:
+				return fmt.Errorf("%s %s: %s must be primary or backup", key.NameFQDN, key.Type, metaFailover)
+			}
+		default:
+			return fmt.Errorf("%s %s: unknown %s %q", key.NameFQDN, key.Type, metaRoutingPolicy, policy)
+		}
+
+		item := rc.Metadata[metaFailover] + " " + rc.Metadata[metaLocation] + " " + normalizeNumber(rc.Metadata[metaWeight])
+		if hc, ok := itemHealthChecked[item]; ok && hc != healthChecked {
+			return fmt.Errorf("%s %s: the records of an item of a routing policy must all be health-checked or not", key.NameFQDN, key.Type)
+		}
+		itemHealthChecked[item] = healthChecked
+
+		if healthChecked {
+			if rc.Type != "A" && rc.Type != "AAAA" {
+				return fmt.Errorf("%s %s: only the A and AAAA records can be health-checked", key.NameFQDN, key.Type)
+			}
+			if rc.Metadata[metaHealthCheck] == "" {
+				return fmt.Errorf("%s %s: %s is required by the health-checked records", key.NameFQDN, key.Type, metaHealthCheck)
+			}
+		}
+	}
+	return nil
+}
+
+// healthCheckedTargets returns the targets of the records as health-checked
+// external endpoints.
+func healthCheckedTargets(recs models.Records) *gdns.RRSetRoutingPolicyHealthCheckTargets {
+	targets := &gdns.RRSetRoutingPolicyHealthCheckTargets{}
+	for _, rc := range recs {
+		targets.ExternalEndpoints = append(targets.ExternalEndpoints, rc.GetTargetField())
+	}
+	return targets
+}
+
+// groupItems returns the records by item, for the metadata of the items.
+func groupItems(recs models.Records, key string) ([]string, map[string]models.Records) {
+	items := map[string]models.Records{}
+	var names []string
+	for _, rc := range recs {
+		name := rc.Metadata[key]
+		if key == metaWeight {
+			name = normalizeNumber(name)
+		}
+		if _, ok := items[name]; !ok {
+			names = append(names, name)
+		}
+		items[name] = append(items[name], rc)
+	}
+	sort.Strings(names)
+	return names, items
+}
+
+// geoItems returns the geolocation items of the records.
+func geoItems(recs models.Records) []*gdns.RRSetRoutingPolicyGeoPolicyGeoPolicyItem {
+	var result []*gdns.RRSetRoutingPolicyGeoPolicyGeoPolicyItem
+	locations, items := groupItems(recs, metaLocation)
+	for _, location := range locations {
+		item := &gdns.RRSetRoutingPolicyGeoPolicyGeoPolicyItem{Location: location}
+		item.HealthCheckedTargets, item.Rrdatas = itemTargets(items[location])
+		result = append(result, item)
+	}
+	return result
+}
+
+// itemTargets returns the health-checked targets or the data of the records
+// of an item.
+func itemTargets(recs models.Records) (*gdns.RRSetRoutingPolicyHealthCheckTargets, []string) {
+	if recs[0].Metadata[metaHealthChecked] == "true" {
+		return healthCheckedTargets(recs), nil
+	}
+	var rrdatas []string
+	for _, rc := range recs {
+		rrdatas = append(rrdatas, rc.GetTargetCombinedFunc(txtutil.EncodeQuoted))
+	}
+	return nil, rrdatas
+}
+
+// mkRoutingPolicy returns the routing policy of the records of a record set.
+func mkRoutingPolicy(recs models.Records) *gdns.RRSetRoutingPolicy {
+	meta := recs[0].Metadata
+	fencing := meta[metaEnableFencing] == "true"
+	policy := &gdns.RRSetRoutingPolicy{HealthCheck: meta[metaHealthCheck]}
+
+	switch strings.ToLower(meta[metaRoutingPolicy]) {
+	case routingWRR:
+		policy.Wrr = &gdns.RRSetRoutingPolicyWrrPolicy{}
+		weights, items := groupItems(recs, metaWeight)
+		for _, weight := range weights {
+			w, _ := strconv.ParseFloat(weight, 64)
+			item := &gdns.RRSetRoutingPolicyWrrPolicyWrrPolicyItem{Weight: w, ForceSendFields: []string{"Weight"}}
+			item.HealthCheckedTargets, item.Rrdatas = itemTargets(items[weight])
+			policy.Wrr.Items = append(policy.Wrr.Items, item)
+		}
+	case routingGeo:
+		policy.Geo = &gdns.RRSetRoutingPolicyGeoPolicy{EnableFencing: fencing, Items: geoItems(recs)}
+	case routingFailover:
+		var primary, backup models.Records
+		for _, rc := range recs {
+			if isPrimary(rc) {
+				primary = append(primary, rc)
+			} else {
+				backup = append(backup, rc)
+			}
+		}
+		trickle, _ := strconv.ParseFloat(meta[metaTrickleTraffic], 64)
+		policy.PrimaryBackup = &gdns.RRSetRoutingPolicyPrimaryBackupPolicy{
+			PrimaryTargets:   healthCheckedTargets(primary),
+			BackupGeoTargets: &gdns.RRSetRoutingPolicyGeoPolicy{EnableFencing: fencing, Items: geoItems(backup)},
+			TrickleTraffic:   trickle,
+		}
+	}
+	return policy
+}
+
+// nativeToRecords returns the records of a record set, with the metadata
+// of its routing policy.
+func nativeToRecords(set *gdns.ResourceRecordSet, origin string) (models.Records, error) {
+	policy := set.RoutingPolicy
+	if policy == nil {
+		var recs models.Records
+		for _, rec := range set.Rrdatas {
+			rc, err := nativeToRecord(set, rec, origin)
+			if err != nil {
+				return nil, err
+			}
+			recs = append(recs, rc)
+		}
+		return recs, nil
+	}
+
+	var recs models.Records
+	add := func(meta map[string]string, targets *gdns.RRSetRoutingPolicyHealthCheckTargets, rrdatas []string) error {
+		common := map[string]string{}
+		if policy.HealthCheck != "" {
+			common[metaHealthCheck] = policy.HealthCheck
+		}
+		for k, v := range meta {
+			common[k] = v
+		}
+		if targets != nil {
+			if len(targets.InternalLoadBalancers) != 0 {
+				return fmt.Errorf("the record set %s %s of GCLOUD has internal load balancer targets, which are not supported", set.Name, set.Type)
+			}
+			for _, endpoint := range targets.ExternalEndpoints {
+				rc, err := nativeToRecord(set, endpoint, origin)
+				if err != nil {
+					return err
+				}
+				rc.Metadata = map[string]string{metaHealthChecked: "true"}
+				for k, v := range common {
+					rc.Metadata[k] = v
+				}
+				recs = append(recs, rc)
+			}
+		}
+		for _, rrdata := range rrdatas {
+			rc, err := nativeToRecord(set, rrdata, origin)
+			if err != nil {
+				return err
+			}
+			rc.Metadata = map[string]string{}
+			for k, v := range common {
+				rc.Metadata[k] = v
+			}
+			recs = append(recs, rc)
+		}
+		return nil
+	}
+	addGeo := func(meta map[string]string, geo *gdns.RRSetRoutingPolicyGeoPolicy) error {
+		if geo == nil {
+			return nil
+		}
+		if geo.EnableFencing {
+			meta[metaEnableFencing] = "true"
+		}
+		for _, item := range geo.Items {
+			itemMeta := map[string]string{metaLocation: item.Location}
+			for k, v := range meta {
+				itemMeta[k] = v
+			}
+			if err := add(itemMeta, item.HealthCheckedTargets, item.Rrdatas); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case policy.Wrr != nil:
+		for _, item := range policy.Wrr.Items {
+			meta := map[string]string{metaRoutingPolicy: routingWRR, metaWeight: strconv.FormatFloat(item.Weight, 'f', -1, 64)}
+			if err := add(meta, item.HealthCheckedTargets, item.Rrdatas); err != nil {
+				return nil, err
+			}
+		}
+	case policy.Geo != nil:
+		if err := addGeo(map[string]string{metaRoutingPolicy: routingGeo}, policy.Geo); err != nil {
+			return nil, err
+		}
+	case policy.PrimaryBackup != nil:
+		meta := map[string]string{metaRoutingPolicy: routingFailover}
+		if trickle := policy.PrimaryBackup.TrickleTraffic; trickle != 0 {
+			meta[metaTrickleTraffic] = strconv.FormatFloat(trickle, 'f', -1, 64)
+		}
+		if backup := policy.PrimaryBackup.BackupGeoTargets; backup != nil && backup.EnableFencing {
+			meta[metaEnableFencing] = "true"
+		}
+		primary := map[string]string{metaFailover: "primary"}
+		for k, v := range meta {
+			primary[k] = v
+		}
+		if err := add(primary, policy.PrimaryBackup.PrimaryTargets, nil); err != nil {
+			return nil, err
+		}
+		meta[metaFailover] = "backup"
+		if err := addGeo(meta, policy.PrimaryBackup.BackupGeoTargets); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("the record set %s %s of GCLOUD has an unsupported routing policy", set.Name, set.Type)
+	}
+	return recs, nil
+}
@@ -0,0 +1,74 @@
+package gcloud
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func routedRecord(target string, meta map[string]string) *models.RecordConfig {
+	rc := &models.RecordConfig{Type: "A", TTL: 300, Metadata: meta}
+	rc.SetLabel("www", "example.com")
+	_ = rc.SetTarget(target)
+	return rc
+}
+
+func TestRoutingPolicyRoundTrip(t *testing.T) {
+	tests := map[string]models.Records{
+		"wrr": {
+			routedRecord("192.0.2.1", map[string]string{metaRoutingPolicy: "wrr", metaWeight: "0.9"}),
+			routedRecord("192.0.2.2", map[string]string{metaRoutingPolicy: "wrr", metaWeight: "0.1"}),
+		},
+		"geo": {
+			routedRecord("192.0.2.1", map[string]string{metaRoutingPolicy: "geo", metaLocation: "us-east1", metaHealthChecked: "true", metaHealthCheck: "hc"}),
+			routedRecord("192.0.2.2", map[string]string{metaRoutingPolicy: "geo", metaLocation: "europe-west1", metaHealthChecked: "true", metaHealthCheck: "hc"}),
+		},
+		"failover": {
+			routedRecord("192.0.2.1", map[string]string{metaRoutingPolicy: "failover", metaFailover: "primary", metaHealthCheck: "hc", metaTrickleTraffic: "0.1"}),
+			routedRecord("192.0.2.2", map[string]string{metaRoutingPolicy: "failover", metaFailover: "backup", metaLocation: "us-east1", metaHealthCheck: "hc", metaTrickleTraffic: "0.1"}),
+		},
+	}
+	for name, recs := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := checkRoutingPolicies(recs); err != nil {
+				t.Fatal(err)
+			}
+			set := mkRRSs("www.example.com.", "A", recs)
+			if len(set.Rrdatas) != 0 || set.RoutingPolicy == nil {
+				t.Fatalf("Expected a routing policy, got %+v", set)
+			}
+			got, err := nativeToRecords(set, "example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := map[string]bool{}
+			for _, rc := range recs {
+				expected[rc.GetTargetField()+" "+routingComparable(rc)] = true
+			}
+			if len(got) != len(recs) {
+				t.Fatalf("Expected %d records, got %d", len(recs), len(got))
+			}
+			for _, rc := range got {
+				if c := rc.GetTargetField() + " " + routingComparable(rc); !expected[c] {
+					t.Errorf("Unexpected record %s", c)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRoutingPolicies(t *testing.T) {
+	recs := models.Records{
+		routedRecord("192.0.2.1", map[string]string{metaRoutingPolicy: "wrr", metaWeight: "1"}),
+		routedRecord("192.0.2.2", map[string]string{}),
+	}
+	if err := checkRoutingPolicies(recs); err == nil {
+		t.Errorf("Expected an error for records with and without routing policy")
+	}
+	recs = models.Records{
+		routedRecord("192.0.2.1", map[string]string{metaRoutingPolicy: "failover", metaFailover: "primary"}),
+	}
+	if err := checkRoutingPolicies(recs); err == nil {
+		t.Errorf("Expected an error for a primary record without health check")
+	}
+}
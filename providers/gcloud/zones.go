@@ -0,0 +1,199 @@
+package gcloud
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+	gdns "google.golang.org/api/dns/v1"
+)
+
+// The type of the zone is set in the domain metadata. They default to the
+// visibility and networks of the provider metadata.
+const (
+	metaVisibility        = "gcloud_visibility"         // public or private
+	metaNetworks          = "gcloud_networks"           // The networks of a private zone, comma-separated names or URLs.
+	metaForwardingTargets = "gcloud_forwarding_targets" // The name servers of a forwarding zone, comma-separated addresses.
+	metaForwardingPath    = "gcloud_forwarding_path"    // default or private
+	metaPeeringNetwork    = "gcloud_peering_network"    // The network of a peering zone, a name or URL.
+)
+
+var zoneMetaKeys = []string{metaVisibility, metaNetworks, metaForwardingTargets, metaForwardingPath, metaPeeringNetwork}
+
+// hasZoneMetadata reports whether the type of the zone is set in the domain
+// metadata.
+func hasZoneMetadata(metadata map[string]string) bool {
+	for _, key := range zoneMetaKeys {
+		if metadata[key] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// zoneConfig returns a zone with the visibility, networks, forwarding and
+// peering of the domain metadata.
+func (g *gcloudProvider) zoneConfig(metadata map[string]string) (*gdns.ManagedZone, error) {
+	mz := &gdns.ManagedZone{Visibility: g.Visibility}
+	networks := g.Networks
+	if v := metadata[metaVisibility]; v != "" {
+		if ok := visibilityCheck.MatchString(v); !ok {
+			return nil, fmt.Errorf("GCLOUD %s set but not one of \"public\" or \"private\"", metaVisibility)
+		}
+		mz.Visibility = v
+	}
+	if v := metadata[metaNetworks]; v != "" {
+		networks = nil
+		for _, network := range splitList(v) {
+			url, err := g.networkURL(network)
+			if err != nil {
+				return nil, fmt.Errorf("GCLOUD %s set but %w", metaNetworks, err)
+			}
+			networks = append(networks, url)
+		}
+	}
+
+	if v := metadata[metaForwardingTargets]; v != "" {
+		path := metadata[metaForwardingPath]
+		if path != "" && path != "default" && path != "private" {
+			return nil, fmt.Errorf("GCLOUD %s set but not one of \"default\" or \"private\"", metaForwardingPath)
+		}
+		mz.ForwardingConfig = &gdns.ManagedZoneForwardingConfig{}
+		for _, target := range splitList(v) {
+			ip := net.ParseIP(target)
+			if ip == nil {
+				return nil, fmt.Errorf("GCLOUD %s set but %s is not an IP address", metaForwardingTargets, target)
+			}
+			ns := &gdns.ManagedZoneForwardingConfigNameServerTarget{ForwardingPath: path}
+			if ip.To4() != nil {
+				ns.Ipv4Address = target
+			} else {
+				ns.Ipv6Address = target
+			}
+			mz.ForwardingConfig.TargetNameServers = append(mz.ForwardingConfig.TargetNameServers, ns)
+		}
+	}
+	if v := metadata[metaPeeringNetwork]; v != "" {
+		if mz.ForwardingConfig != nil {
+			return nil, fmt.Errorf("GCLOUD %s and %s can't be both set", metaForwardingTargets, metaPeeringNetwork)
+		}
+		url, err := g.networkURL(v)
+		if err != nil {
+			return nil, fmt.Errorf("GCLOUD %s set but %w", metaPeeringNetwork, err)
+		}
+		mz.PeeringConfig = &gdns.ManagedZonePeeringConfig{TargetNetwork: &gdns.ManagedZonePeeringConfigTargetNetwork{NetworkUrl: url}}
+	}
+
+	// The forwarding and peering zones are private zones.
+	if mz.ForwardingConfig != nil || mz.PeeringConfig != nil || len(networks) != 0 {
+		if mz.Visibility == "public" {
+			return nil, fmt.Errorf("GCLOUD the networks, forwarding and peering are only for private zones")
+		}
+		mz.Visibility = "private"
+		if len(networks) == 0 {
+			return nil, fmt.Errorf("GCLOUD %s is required by the private zones", metaNetworks)
+		}
+		mz.PrivateVisibilityConfig = &gdns.ManagedZonePrivateVisibilityConfig{}
+		for _, url := range networks {
+			mz.PrivateVisibilityConfig.Networks = append(mz.PrivateVisibilityConfig.Networks, &gdns.ManagedZonePrivateVisibilityConfigNetwork{NetworkUrl: url})
+		}
+	}
+	return mz, nil
+}
+
+func zoneNetworks(mz *gdns.ManagedZone) string {
+	var networks []string
+	if mz.PrivateVisibilityConfig != nil {
+		for _, network := range mz.PrivateVisibilityConfig.Networks {
+			networks = append(networks, network.NetworkUrl)
+		}
+	}
+	sort.Strings(networks)
+	return strings.Join(networks, ", ")
+}
+
+func forwardingTargets(config *gdns.ManagedZoneForwardingConfig) string {
+	if config == nil {
+		return ""
+	}
+	var targets []string
+	for _, ns := range config.TargetNameServers {
+		target := ns.Ipv4Address + ns.Ipv6Address
+		if ns.ForwardingPath == "private" {
+			target += " (private)"
+		}
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return strings.Join(targets, ", ")
+}
+
+func peeringNetwork(config *gdns.ManagedZonePeeringConfig) string {
+	if config == nil || config.TargetNetwork == nil {
+		return ""
+	}
+	return config.TargetNetwork.NetworkUrl
+}
+
+// getZoneConfigCorrections returns the corrections of the networks,
+// forwarding and peering of the zone.
+func (g *gcloudProvider) getZoneConfigCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if !hasZoneMetadata(dc.Metadata) {
+		return nil, nil
+	}
+	zone, err := g.getZone(dc.Name)
+	if err != nil || zone == nil {
+		return nil, err
+	}
+	desired, err := g.zoneConfig(dc.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	visibility := desired.Visibility
+	if visibility == "" {
+		visibility = "public"
+	}
+	if zone.Visibility != "" && zone.Visibility != visibility {
+		return nil, fmt.Errorf("GCLOUD the visibility of the zone %s is %s, it can't be changed to %s", dc.Name, zone.Visibility, visibility)
+	}
+
+	patch := &gdns.ManagedZone{}
+	var msgs []string
+	// The networks, forwarding and peering are only managed when they are set.
+	if have, want := zoneNetworks(zone), zoneNetworks(desired); desired.PrivateVisibilityConfig != nil && have != want {
+		patch.PrivateVisibilityConfig = desired.PrivateVisibilityConfig
+		msgs = append(msgs, color.YellowString("± MODIFY zone %s networks (%s) -> (%s)", dc.Name, have, want))
+	}
+	if have, want := forwardingTargets(zone.ForwardingConfig), forwardingTargets(desired.ForwardingConfig); desired.ForwardingConfig != nil && have != want {
+		patch.ForwardingConfig = desired.ForwardingConfig
+		msgs = append(msgs, color.YellowString("± MODIFY zone %s forwarding (%s) -> (%s)", dc.Name, have, want))
+	}
+	if have, want := peeringNetwork(zone.PeeringConfig), peeringNetwork(desired.PeeringConfig); desired.PeeringConfig != nil && have != want {
+		patch.PeeringConfig = desired.PeeringConfig
+		msgs = append(msgs, color.YellowString("± MODIFY zone %s peering (%s) -> (%s)", dc.Name, have, want))
+	}
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+
+	return []*models.Correction{{
+		Msg: strings.Join(msgs, "\n"),
+		F: func() error {
+			_, err := g.client.ManagedZones.Patch(g.project, zone.Name, patch).Do()
+			return err
+		},
+	}}, nil
+}
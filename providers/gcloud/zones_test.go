@@ -0,0 +1,33 @@
+package gcloud
+
+import (
+	"testing"
+)
+
+func TestZoneConfig(t *testing.T) {
+	g := &gcloudProvider{project: "my-project"}
+	mz, err := g.zoneConfig(map[string]string{
+		metaNetworks:          "vpc-a, https://www.googleapis.com/compute/v1/projects/other-project/global/networks/vpc-b",
+		metaForwardingTargets: "10.0.0.2,2001:db8::53",
+		metaForwardingPath:    "private",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mz.Visibility != "private" {
+		t.Errorf("Expected a private zone, got %q", mz.Visibility)
+	}
+	if expected := "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/vpc-a, https://www.googleapis.com/compute/v1/projects/other-project/global/networks/vpc-b"; zoneNetworks(mz) != expected {
+		t.Errorf("Expected networks %s, got %s", expected, zoneNetworks(mz))
+	}
+	if expected := "10.0.0.2 (private), 2001:db8::53 (private)"; forwardingTargets(mz.ForwardingConfig) != expected {
+		t.Errorf("Expected forwarding targets %s, got %s", expected, forwardingTargets(mz.ForwardingConfig))
+	}
+
+	if _, err := g.zoneConfig(map[string]string{metaPeeringNetwork: "vpc-a"}); err == nil {
+		t.Errorf("Expected an error for a peering zone without networks")
+	}
+	if _, err := g.zoneConfig(map[string]string{metaVisibility: "public", metaNetworks: "vpc-a"}); err == nil {
+		t.Errorf("Expected an error for a public zone with networks")
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"path"
 	"strings"
 
 	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 )
 
 type gcoreZone struct {
@@ -55,7 +55,7 @@ func dnssdkDo(ctx context.Context, c *dnssdk.Client, apiKey string, method, uri
 	}
 
 	if c.Debug {
-		log.Printf("[DEBUG] dns api request: %s %s %s \n", method, uri, bs)
+		printer.Printf("[DEBUG] dns api request: %s %s %s \n", method, uri, bs)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), strings.NewReader(string(bs)))
@@ -0,0 +1,221 @@
+package gransy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	defaultEndpoint = "https://soap.subreg.cz/cmd.php"
+	soapNamespace   = "http://soap.subreg.cz/soap"
+)
+
+type gransyProvider struct {
+	endpoint string
+	login    string
+	password string
+	ssid     string
+}
+
+var errSessionExpired = errors.New("session expired")
+
+type apiError struct {
+	ErrorMsg  string `json:"errormsg"`
+	ErrorCode struct {
+		Major string `json:"major"`
+		Minor string `json:"minor"`
+	} `json:"errorcode"`
+}
+
+type apiResponse struct {
+	Error apiError `json:"error"`
+}
+
+type record struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	Prio    string `json:"prio"`
+	TTL     string `json:"ttl"`
+}
+
+// call invokes a method and copies the data of the response into target.
+func (c *gransyProvider) call(method string, params map[string]any, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	payload := encodeRequest(method, params)
+
+retry:
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", soapNamespace+"#"+method)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("Gransy rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+
+	m, err := decodeResponse(body)
+	if err != nil {
+		return fmt.Errorf("gransy API error: %s: %w", resp.Status, err)
+	}
+	if status, _ := m["status"].(string); status != "ok" {
+		var ar apiResponse
+		if err := convert(m, &ar); err != nil {
+			return fmt.Errorf("gransy API error: %s", string(body))
+		}
+		// Error 500:104 means the session is no longer valid.
+		if ar.Error.ErrorCode.Major == "500" && ar.Error.ErrorCode.Minor == "104" {
+			return errSessionExpired
+		}
+		return fmt.Errorf("gransy API error: %s (%s:%s)", ar.Error.ErrorMsg, ar.Error.ErrorCode.Major, ar.Error.ErrorCode.Minor)
+	}
+
+	if target == nil {
+		return nil
+	}
+	return convert(m["data"], target)
+}
+
+func (c *gransyProvider) doLogin() error {
+	var resp struct {
+		SSID string `json:"ssid"`
+	}
+	if err := c.call("Login", map[string]any{"login": c.login, "password": c.password}, &resp); err != nil {
+		return fmt.Errorf("gransy login failed: %w", err)
+	}
+	c.ssid = resp.SSID
+	return nil
+}
+
+// api calls a method with the session ID, logging in first if needed.
+// The session is renewed once if it has expired.
+func (c *gransyProvider) api(method string, params map[string]any, target any) error {
+	if c.ssid == "" {
+		if err := c.doLogin(); err != nil {
+			return err
+		}
+	}
+	params["ssid"] = c.ssid
+	err := c.call(method, params, target)
+	if errors.Is(err, errSessionExpired) {
+		if err := c.doLogin(); err != nil {
+			return err
+		}
+		params["ssid"] = c.ssid
+		err = c.call(method, params, target)
+	}
+	return err
+}
+
+func (c *gransyProvider) listDomains() ([]string, error) {
+	var resp struct {
+		Domains []struct {
+			Name string `json:"name"`
+		} `json:"domains"`
+	}
+	if err := c.api("Domains_List", map[string]any{}, &resp); err != nil {
+		return nil, fmt.Errorf("failed listing domains from gransy: %w", err)
+	}
+	domains := make([]string, 0, len(resp.Domains))
+	for _, d := range resp.Domains {
+		domains = append(domains, d.Name)
+	}
+	return domains, nil
+}
+
+func (c *gransyProvider) getNameservers(domain string) ([]string, error) {
+	var resp struct {
+		Hosts []string `json:"hosts"`
+	}
+	if err := c.api("Info_Domain", map[string]any{"domain": domain}, &resp); err != nil {
+		return nil, fmt.Errorf("failed fetching domain from gransy: %w", err)
+	}
+	return resp.Hosts, nil
+}
+
+func (c *gransyProvider) updateNameservers(domain string, ns []string) error {
+	hosts := make([]any, 0, len(ns))
+	for _, n := range ns {
+		hosts = append(hosts, map[string]any{"hostname": n})
+	}
+	order := map[string]any{
+		"domain": domain,
+		"type":   "ModifyNS",
+		"params": map[string]any{
+			"ns": map[string]any{"hosts": hosts},
+		},
+	}
+	if err := c.api("Make_Order", map[string]any{"order": order}, nil); err != nil {
+		return fmt.Errorf("failed updating nameservers (gransy): %w", err)
+	}
+	return nil
+}
+
+func (c *gransyProvider) getRecords(domain string) ([]record, error) {
+	var resp struct {
+		Records []record `json:"records"`
+	}
+	if err := c.api("Get_DNS_Zone", map[string]any{"domain": domain}, &resp); err != nil {
+		return nil, fmt.Errorf("failed fetching record list from gransy: %w", err)
+	}
+	return resp.Records, nil
+}
+
+func (r *record) params() map[string]any {
+	m := map[string]any{
+		"name":    r.Name,
+		"type":    r.Type,
+		"content": r.Content,
+		"prio":    r.Prio,
+		"ttl":     r.TTL,
+	}
+	if r.ID != "" {
+		m["id"] = r.ID
+	}
+	return m
+}
+
+func (c *gransyProvider) createRecord(domain string, r *record) error {
+	if err := c.api("Add_DNS_Record", map[string]any{"domain": domain, "record": r.params()}, nil); err != nil {
+		return fmt.Errorf("failed create record (gransy): %w", err)
+	}
+	return nil
+}
+
+func (c *gransyProvider) updateRecord(domain string, r *record) error {
+	if err := c.api("Modify_DNS_Record", map[string]any{"domain": domain, "record": r.params()}, nil); err != nil {
+		return fmt.Errorf("failed update record (gransy): %w", err)
+	}
+	return nil
+}
+
+func (c *gransyProvider) deleteRecord(domain, id string) error {
+	if err := c.api("Delete_DNS_Record", map[string]any{"domain": domain, "record": map[string]any{"id": id}}, nil); err != nil {
+		return fmt.Errorf("failed delete record (gransy): %w", err)
+	}
+	return nil
+}
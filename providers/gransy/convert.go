@@ -0,0 +1,80 @@
+package gransy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRc converts a Subreg record into a RecordConfig.
+// The apex is an empty name.
+func toRc(domain string, r *record) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      atoi32(r.TTL),
+		Original: r,
+	}
+	name := r.Name
+	if name == "" {
+		name = "@"
+	}
+	rc.SetLabel(name, domain)
+
+	var err error
+	switch r.Type {
+	case "A", "AAAA":
+		err = rc.SetTarget(r.Content)
+	case "CNAME", "NS":
+		err = rc.SetTarget(dot(r.Content))
+	case "MX":
+		err = rc.SetTargetMX(atoi16(r.Prio), dot(r.Content))
+	case "SRV":
+		// The content is "weight port target", the priority is separate.
+		if err = rc.SetTargetSRVPriorityString(atoi16(r.Prio), r.Content); err == nil {
+			err = rc.SetTarget(dot(rc.GetTargetField()))
+		}
+	case "TXT":
+		err = rc.SetTargetTXT(r.Content)
+	case "CAA", "SSHFP", "TLSA":
+		err = rc.PopulateFromString(r.Type, r.Content, domain)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+// toRecord converts a RecordConfig into a Subreg record.
+func toRecord(rc *models.RecordConfig) *record {
+	r := &record{
+		Type: rc.Type,
+		TTL:  strconv.FormatUint(uint64(rc.TTL), 10),
+	}
+	if label := rc.GetLabel(); label != "@" {
+		r.Name = label
+	}
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "MX":
+		r.Prio = strconv.Itoa(int(rc.MxPreference))
+		r.Content = target
+	case "SRV":
+		r.Prio = strconv.Itoa(int(rc.SrvPriority))
+		r.Content = fmt.Sprintf("%d %d %s", rc.SrvWeight, rc.SrvPort, target)
+	case "TXT":
+		r.Content = rc.GetTargetTXTJoined()
+	case "CAA", "SSHFP", "TLSA":
+		r.Content = rc.GetTargetCombined()
+	default:
+		r.Content = target
+	}
+	return r
+}
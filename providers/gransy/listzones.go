@@ -0,0 +1,6 @@
+package gransy
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *gransyProvider) ListZones() ([]string, error) {
+	return c.listDomains()
+}
@@ -0,0 +1,161 @@
+package gransy
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The Subreg SOAP API is RPC/encoded: the parameters and the responses are
+// associative arrays, serialized as apache "Map" elements of key/value items.
+// Lists are serialized as SOAP arrays of items without keys.
+
+const envelopeHeader = `<?xml version="1.0" encoding="UTF-8"?>` +
+	`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/"` +
+	` xmlns:SOAP-ENC="http://schemas.xmlsoap.org/soap/encoding/"` +
+	` xmlns:ns1="http://soap.subreg.cz/soap"` +
+	` xmlns:ns2="http://xml.apache.org/xml-soap"` +
+	` xmlns:xsd="http://www.w3.org/2001/XMLSchema"` +
+	` xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+	`<SOAP-ENV:Body>`
+
+const envelopeFooter = `</SOAP-ENV:Body></SOAP-ENV:Envelope>`
+
+// encodeRequest returns the envelope that calls method with data.
+func encodeRequest(method string, data map[string]any) []byte {
+	var b bytes.Buffer
+	b.WriteString(envelopeHeader)
+	fmt.Fprintf(&b, "<ns1:%s>", method)
+	encodeValue(&b, "data", data)
+	fmt.Fprintf(&b, "</ns1:%s>", method)
+	b.WriteString(envelopeFooter)
+	return b.Bytes()
+}
+
+func encodeValue(b *bytes.Buffer, tag string, v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		fmt.Fprintf(b, `<%s xsi:type="ns2:Map">`, tag)
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			b.WriteString("<item><key xsi:type=\"xsd:string\">")
+			xml.EscapeText(b, []byte(k))
+			b.WriteString("</key>")
+			encodeValue(b, "value", v[k])
+			b.WriteString("</item>")
+		}
+		fmt.Fprintf(b, "</%s>", tag)
+	case []any:
+		fmt.Fprintf(b, `<%s SOAP-ENC:arrayType="xsd:anyType[%d]" xsi:type="SOAP-ENC:Array">`, tag, len(v))
+		for _, e := range v {
+			encodeValue(b, "item", e)
+		}
+		fmt.Fprintf(b, "</%s>", tag)
+	case int:
+		fmt.Fprintf(b, `<%s xsi:type="xsd:int">%d</%s>`, tag, v, tag)
+	default:
+		fmt.Fprintf(b, `<%s xsi:type="xsd:string">`, tag)
+		xml.EscapeText(b, []byte(fmt.Sprint(v)))
+		fmt.Fprintf(b, "</%s>", tag)
+	}
+}
+
+// node is a value of the response: a map, a list or a scalar.
+type node struct {
+	Type  string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+	Items []item `xml:"item"`
+	Text  string `xml:",chardata"`
+}
+
+// item is an entry of a map (Key and Value are set) or of a list (the
+// item is the value).
+type item struct {
+	Key   *string `xml:"key"`
+	Value *node   `xml:"value"`
+	node
+}
+
+func (n *node) decode() any {
+	isMap := strings.HasSuffix(n.Type, ":Map") || (len(n.Items) > 0 && n.Items[0].Key != nil)
+	isList := strings.HasSuffix(n.Type, ":Array")
+	if !isMap && !isList && len(n.Items) == 0 {
+		// Empty values are null, so that they decode into any type.
+		if t := strings.TrimSpace(n.Text); t != "" {
+			return t
+		}
+		return nil
+	}
+	if isMap {
+		m := make(map[string]any, len(n.Items))
+		for i := range n.Items {
+			it := &n.Items[i]
+			if it.Key == nil || it.Value == nil {
+				continue
+			}
+			m[*it.Key] = it.Value.decode()
+		}
+		return m
+	}
+	l := make([]any, 0, len(n.Items))
+	for i := range n.Items {
+		l = append(l, n.Items[i].node.decode())
+	}
+	return l
+}
+
+type envelope struct {
+	Body struct {
+		Fault *struct {
+			Code   string `xml:"faultcode"`
+			String string `xml:"faultstring"`
+		} `xml:"Fault"`
+		Response struct {
+			Response node `xml:"response"`
+		} `xml:",any"`
+	} `xml:"Body"`
+}
+
+// decodeResponse decodes the response of a method into a map.
+func decodeResponse(body []byte) (map[string]any, error) {
+	var e envelope
+	if err := xml.Unmarshal(body, &e); err != nil {
+		return nil, err
+	}
+	if f := e.Body.Fault; f != nil {
+		return nil, fmt.Errorf("%s: %s", f.Code, f.String)
+	}
+	m, ok := e.Body.Response.Response.decode().(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response: %s", string(body))
+	}
+	return m, nil
+}
+
+// convert copies a decoded value into the struct pointed to by target.
+// The scalars of the response are all strings.
+func convert(v any, target any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, target)
+}
+
+// atoi16 converts a decoded number, 0 if it is empty or invalid.
+func atoi16(s string) uint16 {
+	n, _ := strconv.ParseUint(s, 10, 16)
+	return uint16(n)
+}
+
+func atoi32(s string) uint32 {
+	n, _ := strconv.ParseUint(s, 10, 32)
+	return uint32(n)
+}
@@ -0,0 +1,66 @@
+package gransy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeRequest(t *testing.T) {
+	got := string(encodeRequest("Add_DNS_Record", map[string]any{
+		"ssid":   "abc",
+		"domain": "example.com",
+		"record": map[string]any{"name": "www", "ttl": 300},
+	}))
+	want := `<ns1:Add_DNS_Record><data xsi:type="ns2:Map">` +
+		`<item><key xsi:type="xsd:string">domain</key><value xsi:type="xsd:string">example.com</value></item>` +
+		`<item><key xsi:type="xsd:string">record</key><value xsi:type="ns2:Map">` +
+		`<item><key xsi:type="xsd:string">name</key><value xsi:type="xsd:string">www</value></item>` +
+		`<item><key xsi:type="xsd:string">ttl</key><value xsi:type="xsd:int">300</value></item>` +
+		`</value></item>` +
+		`<item><key xsi:type="xsd:string">ssid</key><value xsi:type="xsd:string">abc</value></item>` +
+		`</data></ns1:Add_DNS_Record>`
+	if !strings.Contains(got, want) {
+		t.Errorf("encodeRequest() = %s, want it to contain %s", got, want)
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ns1="http://soap.subreg.cz/soap" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:SOAP-ENC="http://schemas.xmlsoap.org/soap/encoding/" xmlns:ns2="http://xml.apache.org/xml-soap">
+<SOAP-ENV:Body><ns1:Get_DNS_Zone_Response><response xsi:type="ns2:Map">
+<item><key xsi:type="xsd:string">status</key><value xsi:type="xsd:string">ok</value></item>
+<item><key xsi:type="xsd:string">data</key><value xsi:type="ns2:Map">
+<item><key xsi:type="xsd:string">domain</key><value xsi:type="xsd:string">example.com</value></item>
+<item><key xsi:type="xsd:string">records</key><value SOAP-ENC:arrayType="ns2:Map[1]" xsi:type="SOAP-ENC:Array">
+<item xsi:type="ns2:Map"><item><key xsi:type="xsd:string">id</key><value xsi:type="xsd:int">42</value></item><item><key xsi:type="xsd:string">name</key><value xsi:type="xsd:string">www</value></item></item>
+</value></item>
+<item><key xsi:type="xsd:string">hosts</key><value SOAP-ENC:arrayType="xsd:anyType[0]" xsi:type="SOAP-ENC:Array"></value></item>
+</value></item>
+</response></ns1:Get_DNS_Zone_Response></SOAP-ENV:Body></SOAP-ENV:Envelope>`
+
+	got, err := decodeResponse([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"status": "ok",
+		"data": map[string]any{
+			"domain":  "example.com",
+			"records": []any{map[string]any{"id": "42", "name": "www"}},
+			"hosts":   []any{},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeResponse() = %#v, want %#v", got, want)
+	}
+}
+
+func TestDecodeResponseFault(t *testing.T) {
+	body := `<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/"><SOAP-ENV:Body>` +
+		`<SOAP-ENV:Fault><faultcode>SOAP-ENV:Server</faultcode><faultstring>Bad request</faultstring></SOAP-ENV:Fault>` +
+		`</SOAP-ENV:Body></SOAP-ENV:Envelope>`
+	if _, err := decodeResponse([]byte(body)); err == nil {
+		t.Error("decodeResponse() did not return an error for a fault")
+	}
+}
@@ -0,0 +1,213 @@
+package hedns
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+)
+
+// The dynamic DNS of a record is set in the record metadata.
+const (
+	metaDynamic = "hedns_dynamic"  // "on" to enable the dynamic DNS of the record.
+	metaDdnsKey = "hedns_ddns_key" // The key of the dynamic DNS, generated if it isn't set.
+)
+
+var ddnsEndpoint = "https://dyn.dns.he.net/nic/update"
+
+func isDynamic(rc *models.RecordConfig) bool {
+	return rc.Metadata[metaDynamic] == "on"
+}
+
+// dynamicComparable adds the dynamic DNS to the comparison of the records.
+func dynamicComparable(rc *models.RecordConfig) string {
+	if isDynamic(rc) {
+		return "dynamic"
+	}
+	return ""
+}
+
+// checkDynamic checks that the dynamic records can be dynamic, and that
+// the generated keys can be saved.
+func (c *hednsProvider) checkDynamic(dc *models.DomainConfig) error {
+	for _, rc := range dc.Records {
+		if v := rc.Metadata[metaDynamic]; v != "" && v != "on" && v != "off" {
+			return fmt.Errorf("%s of %s must be \"on\" or \"off\"", metaDynamic, rc.GetLabelFQDN())
+		}
+		if !isDynamic(rc) {
+			if rc.Metadata[metaDdnsKey] != "" {
+				return fmt.Errorf("%s of %s is set but the record isn't dynamic", metaDdnsKey, rc.GetLabelFQDN())
+			}
+			continue
+		}
+		switch rc.Type {
+		case "A", "AAAA", "TXT":
+		default:
+			return fmt.Errorf("the %s record %s can't be dynamic: only A, AAAA and TXT records can be", rc.Type, rc.GetLabelFQDN())
+		}
+		if rc.Metadata[metaDdnsKey] == "" && c.DdnsKeyFile == "" {
+			return fmt.Errorf("the key of the dynamic record %s is generated, ddns-key-file must be set in creds.json to save it", rc.GetLabelFQDN())
+		}
+	}
+	return nil
+}
+
+// generateDdnsKey returns a random dynamic DNS key.
+func generateDdnsKey() (string, error) {
+	key := make([]byte, 18)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// saveDdnsKey saves the generated key of a dynamic record in the key file,
+// which is only readable by its owner. The keys aren't printed.
+func (c *hednsProvider) saveDdnsKey(rc *models.RecordConfig, key string) error {
+	keys := map[string]string{}
+	if content, err := os.ReadFile(c.DdnsKeyFile); err == nil {
+		if err := json.Unmarshal(content, &keys); err != nil {
+			return fmt.Errorf("can't parse %s: %w", c.DdnsKeyFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	keys[rc.GetLabelFQDN()+" "+rc.Type] = key
+	content, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.DdnsKeyFile, append(content, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Chmod(c.DdnsKeyFile, 0o600)
+}
+
+// setDdnsKey sets the key of a dynamic record. The key is generated and
+// saved in the key file if it isn't in the record metadata.
+func (c *hednsProvider) setDdnsKey(zoneID, recordID uint64, rc *models.RecordConfig) error {
+	key := rc.Metadata[metaDdnsKey]
+	if key == "" {
+		var err error
+		if key, err = generateDdnsKey(); err != nil {
+			return err
+		}
+		if err := c.saveDdnsKey(rc, key); err != nil {
+			return fmt.Errorf("can't save the key of %s: %w", rc.GetLabelFQDN(), err)
+		}
+	}
+
+	values := url.Values{
+		"menu":                {"edit_zone"},
+		"hosted_dns_zoneid":   {strconv.FormatUint(zoneID, 10)},
+		"hosted_dns_recordid": {strconv.FormatUint(recordID, 10)},
+		"hosted_dns_editzone": {"1"},
+		"Key":                 {key},
+		"Key2":                {key},
+		"generate_key":        {"Submit"},
+	}
+	response, err := c.httpClient.PostForm(apiEndpoint, values)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, err = c.parseResponseForDocumentAndErrors(response)
+	return err
+}
+
+// checkDdnsKey reports whether the key of the record metadata is the key of
+// the dynamic record. The record is updated with its current value, which
+// doesn't change it.
+func (c *hednsProvider) checkDdnsKey(rc *models.RecordConfig) (bool, error) {
+	values := url.Values{
+		"hostname": {rc.GetLabelFQDN()},
+		"password": {rc.Metadata[metaDdnsKey]},
+	}
+	if rc.Type == "TXT" {
+		values.Set("txt", rc.GetTargetTXTJoined())
+	} else {
+		values.Set("myip", rc.GetTargetField())
+	}
+	response, err := http.PostForm(ddnsEndpoint, values)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, err
+	}
+
+	status, _, _ := strings.Cut(strings.TrimSpace(string(body)), " ")
+	switch status {
+	case "good", "nochg":
+		return true, nil
+	case "badauth":
+		return false, nil
+	default:
+		return false, fmt.Errorf("can't check the dynamic DNS key of %s: %s", rc.GetLabelFQDN(), strings.TrimSpace(string(body)))
+	}
+}
+
+// getDdnsKeyCorrections returns the corrections of the keys of the dynamic
+// records which are unchanged, whose key in the record metadata isn't
+// their key.
+func (c *hednsProvider) getDdnsKeyCorrections(dc *models.DomainConfig, zoneID uint64, records models.Records, changed map[*models.RecordConfig]bool) ([]*models.Correction, error) {
+	var corrections []*models.Correction
+	for _, rc := range dc.Records {
+		if !isDynamic(rc) || rc.Metadata[metaDdnsKey] == "" || changed[rc] {
+			continue
+		}
+		recordID := matchRecordID(records, rc)
+		if recordID == 0 {
+			continue
+		}
+		ok, err := c.checkDdnsKey(rc)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			continue
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: color.YellowString("± MODIFY %s %s dynamic DNS key", rc.GetLabelFQDN(), rc.Type),
+			F: func() error {
+				return c.setDdnsKey(zoneID, recordID, rc)
+			},
+		})
+	}
+	return corrections, nil
+}
+
+// matchRecordID returns the ID of the existing record with the label, type
+// and value of the record, or 0.
+func matchRecordID(records models.Records, rc *models.RecordConfig) uint64 {
+	for _, existing := range records {
+		if existing.Key() == rc.Key() && existing.ToComparableNoTTL() == rc.ToComparableNoTTL() {
+			return existing.Original.(Record).RecordID
+		}
+	}
+	return 0
+}
+
+// findRecordID returns the ID of a record of the zone.
+func (c *hednsProvider) findRecordID(domain string, rc *models.RecordConfig) (uint64, error) {
+	records, err := c.GetZoneRecords(domain, nil)
+	if err != nil {
+		return 0, err
+	}
+	if recordID := matchRecordID(records, rc); recordID != 0 {
+		return recordID, nil
+	}
+	return 0, fmt.Errorf("the record %s %s was not found after its creation", rc.GetLabelFQDN(), rc.Type)
+}
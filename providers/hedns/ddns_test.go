@@ -0,0 +1,107 @@
+package hedns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dynamicRecord(t *testing.T, rtype, target, key string) *models.RecordConfig {
+	rc := &models.RecordConfig{Type: rtype, Metadata: map[string]string{metaDynamic: "on", metaDdnsKey: key}}
+	rc.SetLabel("home", "example.com")
+	if err := rc.PopulateFromString(rtype, target, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	return rc
+}
+
+func TestCheckDdnsKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.FormValue("hostname") != "home.example.com":
+			_, _ = w.Write([]byte("nohost"))
+		case r.FormValue("password") != "secret":
+			_, _ = w.Write([]byte("badauth"))
+		case r.FormValue("myip") != "":
+			_, _ = w.Write([]byte("nochg " + r.FormValue("myip")))
+		default:
+			_, _ = w.Write([]byte("good"))
+		}
+	}))
+	defer server.Close()
+	defer func(endpoint string) { ddnsEndpoint = endpoint }(ddnsEndpoint)
+	ddnsEndpoint = server.URL
+
+	c := &hednsProvider{}
+	for _, test := range []struct {
+		rc       *models.RecordConfig
+		expected bool
+	}{
+		{dynamicRecord(t, "A", "192.0.2.1", "secret"), true},
+		{dynamicRecord(t, "TXT", "hello", "secret"), true},
+		{dynamicRecord(t, "AAAA", "2001:db8::1", "old"), false},
+	} {
+		ok, err := c.checkDdnsKey(test.rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.rc.Type, test.expected, ok)
+		}
+	}
+
+	rc := dynamicRecord(t, "A", "192.0.2.1", "secret")
+	rc.SetLabel("other", "example.com")
+	if _, err := c.checkDdnsKey(rc); err == nil {
+		t.Error("Expected an error for an unknown host")
+	}
+}
+
+func TestSaveDdnsKey(t *testing.T) {
+	c := &hednsProvider{DdnsKeyFile: filepath.Join(t.TempDir(), "ddns-keys.json")}
+	if err := c.saveDdnsKey(dynamicRecord(t, "A", "192.0.2.1", ""), "key1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.saveDdnsKey(dynamicRecord(t, "TXT", "hello", ""), "key2"); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := os.Stat(c.DdnsKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the key file to be 0600, got %v", st.Mode().Perm())
+	}
+	content, err := os.ReadFile(c.DdnsKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]string{}
+	if err := json.Unmarshal(content, &keys); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys["home.example.com A"] != "key1" || keys["home.example.com TXT"] != "key2" {
+		t.Errorf("Unexpected keys %v", keys)
+	}
+}
+
+func TestCheckDynamic(t *testing.T) {
+	c := &hednsProvider{}
+	mx := dynamicRecord(t, "MX", "10 mx.example.com.", "secret")
+	generated := dynamicRecord(t, "A", "192.0.2.1", "")
+	for _, rc := range []*models.RecordConfig{mx, generated} {
+		if err := c.checkDynamic(&models.DomainConfig{Name: "example.com", Records: models.Records{rc}}); err == nil {
+			t.Errorf("Expected an error for %s", rc.Type)
+		}
+	}
+	c.DdnsKeyFile = "ddns-keys.json"
+	if err := c.checkDynamic(&models.DomainConfig{Name: "example.com", Records: models.Records{generated}}); err != nil {
+		t.Error(err)
+	}
+}
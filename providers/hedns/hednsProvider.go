@@ -38,6 +38,7 @@ Either of the following settings is required when two factor authentication is e
 Additionally
 	- session-file-path  (Path where a '.hedns-session' file will be created to allow a
                          session to persist between executions)
+	- ddns-key-file      (File where the generated dynamic DNS keys are saved)
 
 */
 
@@ -102,6 +103,7 @@ type hednsProvider struct {
 	TfaSecret       string
 	TfaValue        string
 	SessionFilePath string
+	DdnsKeyFile     string
 
 	httpClient http.Client
 }
@@ -136,6 +138,7 @@ func newHEDNSProvider(cfg map[string]string, _ json.RawMessage) (providers.DNSSe
 		TfaSecret:       totpSecret,
 		TfaValue:        totpValue,
 		SessionFilePath: sessionFilePath,
+		DdnsKeyFile:     cfg["ddns-key-file"],
 	}
 
 	// Create storage for the cookies
@@ -203,31 +206,57 @@ func (c *hednsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, recor
 }
 
 func (c *hednsProvider) getDiff2DomainCorrections(dc *models.DomainConfig, zoneID uint64, records models.Records) ([]*models.Correction, error) {
-	changes, err := diff2.ByRecord(records, dc, nil)
+	if err := c.checkDynamic(dc); err != nil {
+		return nil, err
+	}
+	changes, err := diff2.ByRecord(records, dc, dynamicComparable)
 	if err != nil {
 		return nil, err
 	}
 
 	var corrections []*models.Correction
+	changed := map[*models.RecordConfig]bool{}
 	for _, change := range changes {
 		switch change.Type {
 		case diff2.REPORT:
 			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
 		case diff2.CREATE:
 			record := change.New[0]
+			changed[record] = true
 			corrections = append(corrections, &models.Correction{
 				Msg: change.MsgsJoined,
 				F: func() error {
-					return c.createZoneRecord(zoneID, record)
+					if err := c.createZoneRecord(zoneID, record); err != nil {
+						return err
+					}
+					if !isDynamic(record) {
+						return nil
+					}
+					// The key is set once the ID of the new record is known.
+					recordID, err := c.findRecordID(dc.Name, record)
+					if err != nil {
+						return err
+					}
+					return c.setDdnsKey(zoneID, recordID, record)
 				},
 			})
 		case diff2.CHANGE:
 			record := change.New[0]
+			changed[record] = true
 			recordID := change.Old[0].Original.(Record).RecordID
+			// The key is kept if the record was already dynamic, unless it
+			// is in the record metadata.
+			setKey := isDynamic(record) && (!isDynamic(change.Old[0]) || record.Metadata[metaDdnsKey] != "")
 			corrections = append(corrections, &models.Correction{
 				Msg: change.MsgsJoined,
 				F: func() error {
-					return c.changeZoneRecord(zoneID, recordID, record)
+					if err := c.changeZoneRecord(zoneID, recordID, record); err != nil {
+						return err
+					}
+					if setKey {
+						return c.setDdnsKey(zoneID, recordID, record)
+					}
+					return nil
 				},
 			})
 		case diff2.DELETE:
@@ -243,7 +272,11 @@ func (c *hednsProvider) getDiff2DomainCorrections(dc *models.DomainConfig, zoneI
 		}
 	}
 
-	return corrections, nil
+	keyCorrections, err := c.getDdnsKeyCorrections(dc, zoneID, records, changed)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, keyCorrections...), nil
 }
 
 // GetZoneRecords returns all the records for the given domain
@@ -317,6 +350,9 @@ func (c *hednsProvider) GetZoneRecords(domain string, meta map[string]string) (m
 		}
 
 		rc.SetLabelFromFQDN(rc.Original.(Record).RecordName, domain)
+		if element.HasClass("dns_tr_dynamic") {
+			rc.Metadata = map[string]string{metaDynamic: "on"}
+		}
 
 		switch rc.Type {
 		case "ALIAS":
@@ -554,6 +590,10 @@ func (c *hednsProvider) editZoneRecord(zoneID uint64, recordID uint64, rc *model
 		values.Set("Priority", "-")
 	}
 
+	if isDynamic(rc) {
+		values.Set("dynamic", "1")
+	}
+
 	// Work out the content
 	switch rc.Type {
 	case "MX":
@@ -17,9 +17,10 @@ const (
 )
 
 type hetznerProvider struct {
-	apiKey             string
-	zones              map[string]zone
-	requestRateLimiter requestRateLimiter
+	apiKey              string
+	zones               map[string]zone
+	requestRateLimiter  requestRateLimiter
+	zoneImportThreshold int // The number of changes from which the zone file is imported (0 never).
 }
 
 func parseHeaderAsSeconds(header http.Header, headerName string, fallback time.Duration) (time.Duration, error) {
@@ -154,30 +155,47 @@ func (api *hetznerProvider) getZone(name string) (*zone, error) {
 }
 
 func (api *hetznerProvider) request(endpoint string, method string, request interface{}, target interface{}, statusOK func(code int) bool) error {
+	var requestBody []byte
+	if request != nil {
+		var err error
+		requestBody, err = json.Marshal(request)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := api.send(endpoint, method, "", requestBody, statusOK)
+	if err != nil || target == nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// send sends a request, retrying when rate-limited, and returns the body of
+// the response.
+func (api *hetznerProvider) send(endpoint string, method string, contentType string, requestBody []byte, statusOK func(code int) bool) ([]byte, error) {
 	if statusOK == nil {
 		statusOK = func(code int) bool {
 			return code == http.StatusOK
 		}
 	}
 	for {
-		var requestBody io.Reader
-		if request != nil {
-			requestBodySerialised, err := json.Marshal(request)
-			if err != nil {
-				return err
-			}
-			requestBody = bytes.NewBuffer(requestBodySerialised)
+		var body io.Reader
+		if requestBody != nil {
+			body = bytes.NewReader(requestBody)
 		}
-		req, err := http.NewRequest(method, baseURL+endpoint, requestBody)
+		req, err := http.NewRequest(method, baseURL+endpoint, body)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		req.Header.Add("Auth-API-Token", api.apiKey)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
 
 		api.requestRateLimiter.delayRequest()
 		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		cleanupResponseBody := func() {
 			err2 := resp.Body.Close()
@@ -189,7 +207,7 @@ func (api *hetznerProvider) request(endpoint string, method string, request inte
 		retry, err := api.requestRateLimiter.handleResponse(resp)
 		if err != nil {
 			cleanupResponseBody()
-			return err
+			return nil, err
 		}
 		if retry {
 			cleanupResponseBody()
@@ -200,15 +218,11 @@ func (api *hetznerProvider) request(endpoint string, method string, request inte
 			data, _ := io.ReadAll(resp.Body)
 			printer.Println(string(data))
 			cleanupResponseBody()
-			return fmt.Errorf("bad status code from HETZNER: %d not 200", resp.StatusCode)
-		}
-		if target == nil {
-			cleanupResponseBody()
-			return nil
+			return nil, fmt.Errorf("bad status code from HETZNER: %d not 200", resp.StatusCode)
 		}
-		err = json.NewDecoder(resp.Body).Decode(target)
+		data, err := io.ReadAll(resp.Body)
 		cleanupResponseBody()
-		return err
+		return data, err
 	}
 }
 
@@ -50,8 +50,14 @@ func New(settings map[string]string, _ json.RawMessage) (providers.DNSServicePro
 		return nil, fmt.Errorf("missing HETZNER api_key")
 	}
 
+	zoneImportThreshold, err := parseZoneImportThreshold(settings["zone_import_threshold"])
+	if err != nil {
+		return nil, err
+	}
+
 	return &hetznerProvider{
-		apiKey: apiKey,
+		apiKey:              apiKey,
+		zoneImportThreshold: zoneImportThreshold,
 	}, nil
 }
 
@@ -89,6 +95,19 @@ func (api *hetznerProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, e
 		return nil, err
 	}
 
+	if changes := len(create) + len(del) + len(modify); api.useZoneImport(changes) {
+		// Large changes are made by importing the zone file, which avoids
+		// the rate-limits of the per-record calls.
+		msgs := make([]string, 0, changes)
+		for _, cs := range []diff.Changeset{del, create, modify} {
+			for _, m := range cs {
+				msgs = append(msgs, m.String())
+			}
+		}
+		records := importedRecords(existingRecords, create, del, modify)
+		return append(corrections, api.zoneImportCorrection(domain, z, records, msgs)), nil
+	}
+
 	for _, m := range del {
 		r := m.Existing.Original.(*record)
 		corr := &models.Correction{
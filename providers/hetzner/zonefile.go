@@ -0,0 +1,126 @@
+package hetzner
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
+	"github.com/StackExchange/dnscontrol/v4/pkg/prettyzone"
+	"github.com/miekg/dns"
+)
+
+// defaultZoneImportThreshold is the number of changes from which the zone
+// file is imported instead of changing the records one by one.
+const defaultZoneImportThreshold = 100
+
+// parseZoneImportThreshold parses zone_import_threshold of creds.json.
+// 0 never imports the zone file.
+func parseZoneImportThreshold(v string) (int, error) {
+	if v == "" {
+		return defaultZoneImportThreshold, nil
+	}
+	threshold, err := strconv.Atoi(v)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("HETZNER zone_import_threshold (%s) must be a number of changes", v)
+	}
+	return threshold, nil
+}
+
+// useZoneImport reports whether the zone file is imported for this number
+// of changes.
+func (api *hetznerProvider) useZoneImport(changes int) bool {
+	return api.zoneImportThreshold > 0 && changes >= api.zoneImportThreshold
+}
+
+func (api *hetznerProvider) exportZoneFile(z *zone) (string, error) {
+	data, err := api.send(fmt.Sprintf("/zones/%s/export", z.ID), "GET", "", nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed exporting zone %q: %w", z.Name, err)
+	}
+	return string(data), nil
+}
+
+func (api *hetznerProvider) importZoneFile(z *zone, zonefile string) error {
+	if _, err := api.send(fmt.Sprintf("/zones/%s/import", z.ID), "POST", "text/plain", []byte(zonefile), nil); err != nil {
+		return fmt.Errorf("failed importing zone %q: %w", z.Name, err)
+	}
+	return nil
+}
+
+// soaOfZoneFile returns the SOA record of a zone file.
+func soaOfZoneFile(zonefile, origin string) (*models.RecordConfig, error) {
+	zp := dns.NewZoneParser(strings.NewReader(zonefile), dns.Fqdn(origin), "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if rr.Header().Rrtype == dns.TypeSOA {
+			rc, err := models.RRtoRC(rr, origin)
+			return &rc, err
+		}
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("can't parse the zone file of %q: %w", origin, err)
+	}
+	return nil, fmt.Errorf("no SOA record in the zone file of %q", origin)
+}
+
+// desiredZoneFile returns the zone file of the records with the SOA of
+// the current zone file, which the import keeps.
+func desiredZoneFile(current string, origin string, records models.Records) (string, error) {
+	soa, err := soaOfZoneFile(current, origin)
+	if err != nil {
+		return "", err
+	}
+	records = append(models.Records{soa}, records...)
+	var b bytes.Buffer
+	if err := prettyzone.WriteZoneFileRC(&b, records, origin, 0, nil); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// importedRecords returns the records of the zone after the changes. The
+// existing records which aren't changed, e.g. the ignored ones, are kept.
+func importedRecords(existing models.Records, create, del, modify diff.Changeset) models.Records {
+	replaced := map[*models.RecordConfig]bool{}
+	for _, m := range del {
+		replaced[m.Existing] = true
+	}
+	for _, m := range modify {
+		replaced[m.Existing] = true
+	}
+	var records models.Records
+	for _, rc := range existing {
+		if !replaced[rc] {
+			records = append(records, rc)
+		}
+	}
+	for _, m := range create {
+		records = append(records, m.Desired)
+	}
+	for _, m := range modify {
+		records = append(records, m.Desired)
+	}
+	return records
+}
+
+// zoneImportCorrection returns the correction replacing the records of the
+// zone by importing its zone file, in a single call.
+func (api *hetznerProvider) zoneImportCorrection(domain string, z *zone, records models.Records, msgs []string) *models.Correction {
+	desc := append([]string{fmt.Sprintf("Import of the zone file (%d changes):", len(msgs))}, msgs...)
+	return &models.Correction{
+		Msg: strings.Join(desc, "\n\t"),
+		F: func() error {
+			current, err := api.exportZoneFile(z)
+			if err != nil {
+				return err
+			}
+			zonefile, err := desiredZoneFile(current, domain, records)
+			if err != nil {
+				return err
+			}
+			return api.importZoneFile(z, zonefile)
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package hetzner
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestDesiredZoneFile(t *testing.T) {
+	current := `$ORIGIN example.com.
+$TTL 86400
+@	IN	SOA	hydrogen.ns.hetzner.com. dns.hetzner.com. 2024010101 86400 10800 3600000 3600
+@	IN	NS	hydrogen.ns.hetzner.com.
+old	IN	A	192.0.2.1
+`
+	a := &models.RecordConfig{Type: "A", TTL: 300}
+	a.SetLabel("www", "example.com")
+	a.SetTarget("192.0.2.2")
+
+	got, err := desiredZoneFile(current, "example.com", models.Records{a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "SOA") || !strings.Contains(got, "2024010101") {
+		t.Errorf("the SOA record isn't kept:\n%s", got)
+	}
+	if !strings.Contains(got, "192.0.2.2") || strings.Contains(got, "192.0.2.1") {
+		t.Errorf("the records aren't replaced:\n%s", got)
+	}
+}
+
+func TestParseZoneImportThreshold(t *testing.T) {
+	if got, _ := parseZoneImportThreshold(""); got != defaultZoneImportThreshold {
+		t.Errorf("default threshold = %d, want %d", got, defaultZoneImportThreshold)
+	}
+	if got, _ := parseZoneImportThreshold("0"); got != 0 {
+		t.Errorf("threshold = %d, want 0", got)
+	}
+	if _, err := parseZoneImportThreshold("many"); err == nil {
+		t.Error("expected an error for an invalid threshold")
+	}
+}
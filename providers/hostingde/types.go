@@ -3,11 +3,11 @@ package hostingde
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"net"
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/pkg/errors"
 )
 
@@ -214,7 +214,7 @@ func recordToNative(rc *models.RecordConfig) *record {
 		record.Priority = rc.SrvPriority
 		record.Content = fmt.Sprintf("%d %d %s", rc.SrvWeight, rc.SrvPort, strings.TrimSuffix(rc.GetTargetField(), "."))
 	default:
-		log.Printf("hosting.de rtype %v unimplemented", rc.Type)
+		printer.Warnf("hosting.de rtype %v unimplemented\n", rc.Type)
 	}
 
 	return record
@@ -0,0 +1,210 @@
+package hover
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// Hover has no public API. This uses the JSON API of the control panel,
+// which authenticates with a session cookie.
+
+const baseURL = "https://www.hover.com"
+
+type hoverProvider struct {
+	client     *http.Client
+	username   string
+	password   string
+	totpSecret string
+	loggedIn   bool
+}
+
+var errUnauthorized = errors.New("unauthorized")
+
+type domain struct {
+	ID          string   `json:"id"`
+	DomainName  string   `json:"domain_name"`
+	Nameservers []string `json:"nameservers"`
+	Entries     []entry  `json:"entries"`
+}
+
+type entry struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Content   string `json:"content"`
+	TTL       uint32 `json:"ttl"`
+	IsDefault bool   `json:"is_default,omitempty"`
+}
+
+type domainsResponse struct {
+	Succeeded bool     `json:"succeeded"`
+	Error     string   `json:"error"`
+	Domains   []domain `json:"domains"`
+}
+
+type statusResponse struct {
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error"`
+}
+
+// do sends a request and decodes the response into target.
+func (c *hoverProvider) do(method, path string, body, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+retry:
+	req, err := http.NewRequest(method, baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries:
+		retrycnt++
+		printer.Printf("Hover rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	case resp.StatusCode == http.StatusUnauthorized:
+		return errUnauthorized
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		var sr statusResponse
+		if json.Unmarshal(data, &sr) == nil && sr.Error != "" {
+			return fmt.Errorf("hover API error: %s: %s", resp.Status, sr.Error)
+		}
+		return fmt.Errorf("hover API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (c *hoverProvider) post(path string, body, target any) error {
+	return c.do(http.MethodPost, path, body, target)
+}
+
+// api calls an API endpoint, logging in first if needed. The session is
+// renewed once if it has expired.
+func (c *hoverProvider) api(method, path string, body any, target any) error {
+	if !c.loggedIn {
+		if err := c.login(); err != nil {
+			return err
+		}
+	}
+	var raw json.RawMessage
+	err := c.do(method, "/api"+path, body, &raw)
+	if errors.Is(err, errUnauthorized) {
+		if err := c.login(); err != nil {
+			return err
+		}
+		err = c.do(method, "/api"+path, body, &raw)
+	}
+	if err != nil {
+		return err
+	}
+	var sr struct {
+		Succeeded *bool  `json:"succeeded"`
+		Error     string `json:"error"`
+	}
+	if json.Unmarshal(raw, &sr) == nil && sr.Succeeded != nil && !*sr.Succeeded {
+		return fmt.Errorf("hover API error: %s", sr.Error)
+	}
+	if target == nil || raw == nil {
+		return nil
+	}
+	return json.Unmarshal(raw, target)
+}
+
+func (c *hoverProvider) listDomains() ([]domain, error) {
+	var resp domainsResponse
+	if err := c.api(http.MethodGet, "/domains", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed listing domains from hover: %w", err)
+	}
+	return resp.Domains, nil
+}
+
+func (c *hoverProvider) getDomain(name string) (*domain, error) {
+	var resp struct {
+		Domain domain `json:"domain"`
+	}
+	if err := c.api(http.MethodGet, "/domains/"+url.PathEscape(name), nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed fetching domain from hover: %w", err)
+	}
+	return &resp.Domain, nil
+}
+
+func (c *hoverProvider) getEntries(name string) ([]entry, error) {
+	var resp domainsResponse
+	if err := c.api(http.MethodGet, "/domains/"+url.PathEscape(name)+"/dns", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed fetching record list from hover: %w", err)
+	}
+	if len(resp.Domains) == 0 {
+		return nil, nil
+	}
+	return resp.Domains[0].Entries, nil
+}
+
+func (c *hoverProvider) createEntry(name string, e *entry) error {
+	if err := c.api(http.MethodPost, "/domains/"+url.PathEscape(name)+"/dns", e, nil); err != nil {
+		return fmt.Errorf("failed create record (hover): %w", err)
+	}
+	return nil
+}
+
+func (c *hoverProvider) updateEntry(e *entry) error {
+	if err := c.api(http.MethodPut, "/dns/"+url.PathEscape(e.ID), map[string]any{
+		"content": e.Content,
+		"ttl":     e.TTL,
+	}, nil); err != nil {
+		return fmt.Errorf("failed update record (hover): %w", err)
+	}
+	return nil
+}
+
+func (c *hoverProvider) deleteEntry(id string) error {
+	if err := c.api(http.MethodDelete, "/dns/"+url.PathEscape(id), nil, nil); err != nil {
+		return fmt.Errorf("failed delete record (hover): %w", err)
+	}
+	return nil
+}
+
+func (c *hoverProvider) updateNameservers(name string, nameservers []string) error {
+	if err := c.api(http.MethodPut, "/domains/"+url.PathEscape(name), map[string]any{
+		"field": "nameservers",
+		"value": nameservers,
+	}, nil); err != nil {
+		return fmt.Errorf("failed updating nameservers (hover): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package hover
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// totp computes the RFC 6238 code of a base32 secret at time t.
+func totp(secret string, t time.Time) (string, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return "", fmt.Errorf("invalid HOVER totp_secret: %w", err)
+	}
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
+
+type authResponse struct {
+	Succeeded bool   `json:"succeeded"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+}
+
+// login opens a session. The session is kept in the "hoverauth" cookie.
+func (c *hoverProvider) login() error {
+	var resp authResponse
+	if err := c.post("/signin/auth.json", map[string]string{
+		"username": c.username,
+		"password": c.password,
+	}, &resp); err != nil {
+		return fmt.Errorf("hover login failed: %w", err)
+	}
+
+	if resp.Status == "need_2fa" {
+		if c.totpSecret == "" {
+			return fmt.Errorf("hover login failed: two-factor authentication is enabled but totp_secret is not set")
+		}
+		code, err := totp(c.totpSecret, time.Now())
+		if err != nil {
+			return err
+		}
+		resp = authResponse{}
+		if err := c.post("/signin/auth2.json", map[string]string{"code": code}, &resp); err != nil {
+			return fmt.Errorf("hover two-factor authentication failed: %w", err)
+		}
+	}
+	if !resp.Succeeded && resp.Status != "completed" {
+		return fmt.Errorf("hover login failed: %s", resp.Error)
+	}
+	c.loggedIn = true
+	return nil
+}
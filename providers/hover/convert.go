@@ -0,0 +1,61 @@
+package hover
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRc converts a Hover DNS entry into a RecordConfig.
+func toRc(domain string, e *entry) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     e.Type,
+		TTL:      e.TTL,
+		Original: e,
+	}
+	rc.SetLabel(e.Name, domain)
+
+	var err error
+	switch e.Type {
+	case "A", "AAAA", "CAA":
+		err = rc.PopulateFromString(e.Type, e.Content, domain)
+	case "CNAME", "MX", "NS", "SRV":
+		// Host names are returned without the trailing dot.
+		if err = rc.PopulateFromString(e.Type, e.Content, domain); err == nil {
+			err = rc.SetTarget(dot(rc.GetTargetField()))
+		}
+	case "TXT":
+		err = rc.SetTargetTXT(e.Content)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", e.Type)
+	}
+	return rc, err
+}
+
+// toEntry converts a RecordConfig into a Hover DNS entry.
+func toEntry(rc *models.RecordConfig) *entry {
+	e := &entry{
+		Name: rc.GetLabel(),
+		Type: rc.Type,
+		TTL:  rc.TTL,
+	}
+	switch rc.Type {
+	case "TXT":
+		e.Content = rc.GetTargetTXTJoined()
+	case "MX", "SRV":
+		e.Content = strings.TrimSuffix(rc.GetTargetCombined(), ".")
+	case "CAA":
+		e.Content = rc.GetTargetCombined()
+	default:
+		e.Content = strings.TrimSuffix(rc.GetTargetField(), ".")
+	}
+	return e
+}
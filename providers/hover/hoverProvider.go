@@ -0,0 +1,198 @@
+package hover
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Hover.
+// There is no official API documentation; this uses the API of the control panel.
+
+/*
+Hover provider:
+
+Info required in `creds.json`:
+   - username
+   - password
+   - totp_secret (optional, required if two-factor authentication is enabled)
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Cannot(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{"ns1.hover.com", "ns2.hover.com"}
+
+func init() {
+	const providerName = "HOVER"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	providers.RegisterRegistrarType(providerName, newReg)
+	fns := providers.DspFuncs{
+		Initializer:   newDsp,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+func newReg(conf map[string]string) (providers.Registrar, error) {
+	return newHover(conf)
+}
+
+func newDsp(conf map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	return newHover(conf)
+}
+
+// newHover creates the provider.
+func newHover(m map[string]string) (*hoverProvider, error) {
+	c := &hoverProvider{
+		username:   m["username"],
+		password:   m["password"],
+		totpSecret: m["totp_secret"],
+	}
+	if c.username == "" || c.password == "" {
+		return nil, fmt.Errorf("missing HOVER username or password")
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	c.client = &http.Client{Jar: jar}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *hoverProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *hoverProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	entries, err := c.getEntries(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		if e.Type == "NS" && e.Name == "@" {
+			// The apex NS records are managed by Hover.
+			continue
+		}
+		rc, err := toRc(domain, e)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *hoverProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			e := toEntry(change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createEntry(dc.Name, e)
+				},
+			}
+		case diff2.CHANGE:
+			e := toEntry(change.New[0])
+			e.ID = change.Old[0].Original.(*entry).ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Hover ID: %s", change.Msgs[0], e.ID),
+				F: func() error {
+					return c.updateEntry(e)
+				},
+			}
+		case diff2.DELETE:
+			id := change.Old[0].Original.(*entry).ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Hover ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.deleteEntry(id)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
+
+// GetRegistrarCorrections returns a list of corrections for this registrar.
+func (c *hoverProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	d, err := c.getDomain(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]string, 0, len(d.Nameservers))
+	for _, ns := range d.Nameservers {
+		found = append(found, strings.ToLower(strings.TrimSuffix(ns, ".")))
+	}
+	sort.Strings(found)
+	foundNameservers := strings.Join(found, ",")
+
+	expected := make([]string, 0, len(dc.Nameservers))
+	for _, ns := range dc.Nameservers {
+		expected = append(expected, ns.Name)
+	}
+	sort.Strings(expected)
+	expectedNameservers := strings.Join(expected, ",")
+
+	if foundNameservers == expectedNameservers {
+		return nil, nil
+	}
+
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundNameservers, expectedNameservers),
+			F: func() error {
+				return c.updateNameservers(dc.Name, expected)
+			},
+		},
+	}, nil
+}
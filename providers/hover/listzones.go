@@ -0,0 +1,14 @@
+package hover
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *hoverProvider) ListZones() ([]string, error) {
+	domains, err := c.listDomains()
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(domains))
+	for _, d := range domains {
+		zones = append(zones, d.DomainName)
+	}
+	return zones, nil
+}
@@ -26,7 +26,9 @@ func getRRSetIDFromRecords(rcs models.Records) []string {
 	return slices.Compact(ids)
 }
 
-func nativeToRecords(n *model.ShowRecordSetByZoneResp, zoneName string) (models.Records, error) {
+// nativeToRecords converts a rrset. The line and the weight of the rrsets of
+// a private zone are dropped: they can't be set in the private zones.
+func nativeToRecords(n *model.ShowRecordSetByZoneResp, zoneName string, privateZone bool) (models.Records, error) {
 	if n.Name == nil || n.Type == nil || n.Records == nil || n.Ttl == nil {
 		return nil, fmt.Errorf("missing required fields in Huaweicloud's RRset: %+v", n)
 	}
@@ -45,10 +47,10 @@ func nativeToRecords(n *model.ShowRecordSetByZoneResp, zoneName string) (models.
 		if err := rc.PopulateFromString(recType, value, zoneName); err != nil {
 			return nil, fmt.Errorf("unparsable record received from Huaweicloud: %w", err)
 		}
-		if n.Line != nil {
+		if n.Line != nil && !privateZone {
 			rc.Metadata[metaLine] = *n.Line
 		}
-		if n.Weight != nil {
+		if n.Weight != nil && !privateZone {
 			rc.Metadata[metaWeight] = fmt.Sprintf("%d", *n.Weight)
 		}
 		if n.Description != nil {
@@ -2,6 +2,7 @@ package huaweicloud
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -25,8 +26,10 @@ Info required in `creds.json`:
    - KeyId
    - SecretKey
    - Region
+   - ZoneType (optional, "public" or "private", default "public")
+   - VpcId (required to create private zones)
 
-Record level metadata available:
+Record level metadata available (public zones only):
    - hw_line (refer below Huawei Cloud DNS API documentation for available lines, default "default_view")
              (https://support.huaweicloud.com/intl/en-us/api-dns/en-us_topic_0085546214.html)
    - hw_weight (0-1000, default "1")
@@ -39,6 +42,8 @@ type huaweicloudProvider struct {
 	domainByZoneID map[string]string
 	zoneIDByDomain map[string]string
 	region         *region.Region
+	privateZone    bool
+	vpcID          string
 }
 
 const (
@@ -47,6 +52,9 @@ const (
 	metaKey       = "hw_rrset_key"
 	defaultWeight = "1"
 	defaultLine   = "default_view"
+
+	zoneTypePublic  = "public"
+	zoneTypePrivate = "private"
 )
 
 // newHuaweicloud creates the provider.
@@ -74,6 +82,15 @@ func newHuaweicloud(m map[string]string, metadata json.RawMessage) (providers.DN
 	c := &huaweicloudProvider{
 		client: dnssdk.NewDnsClient(client),
 		region: region,
+		vpcID:  m["VpcId"],
+	}
+
+	switch m["ZoneType"] {
+	case "", zoneTypePublic:
+	case zoneTypePrivate:
+		c.privateZone = true
+	default:
+		return nil, fmt.Errorf("huaweicloud: invalid ZoneType %q, expected %q or %q", m["ZoneType"], zoneTypePublic, zoneTypePrivate)
 	}
 
 	return c, nil
@@ -151,6 +168,11 @@ func (c *huaweicloudProvider) GetNameservers(domain string) ([]*models.Nameserve
 		return nil, err
 	}
 
+	// Private zones are answered by the VPC resolvers and are never delegated.
+	if c.privateZone {
+		return nil, nil
+	}
+
 	payload := &model.ShowPublicZoneNameServerRequest{
 		ZoneId: c.zoneIDByDomain[domain],
 	}
@@ -1,6 +1,7 @@
 package huaweicloud
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
@@ -17,6 +18,10 @@ func (c *huaweicloudProvider) EnsureZoneExists(domain string) error {
 		return nil
 	}
 
+	if c.privateZone {
+		return c.createPrivateZone(domain)
+	}
+
 	printer.Printf("Adding zone for %s to huaweicloud account in region %s\n", domain, c.region.Id)
 	createPayload := model.CreatePublicZoneRequest{
 		Body: &model.CreatePublicZoneReq{
@@ -50,10 +55,44 @@ func (c *huaweicloudProvider) ListZones() ([]string, error) {
 	return zones, nil
 }
 
+func (c *huaweicloudProvider) createPrivateZone(domain string) error {
+	if c.vpcID == "" {
+		return fmt.Errorf("huaweicloud: VpcId is required to create the private zone %s", domain)
+	}
+
+	printer.Printf("Adding private zone for %s to huaweicloud account in region %s (VPC %s)\n", domain, c.region.Id, c.vpcID)
+	createPayload := model.CreatePrivateZoneRequest{
+		Body: &model.CreatePrivateZoneReq{
+			Name:     domain,
+			ZoneType: zoneTypePrivate,
+			Router: &model.Router{
+				RouterId:     c.vpcID,
+				RouterRegion: &c.region.Id,
+			},
+		},
+	}
+	res, err := c.client.CreatePrivateZone(&createPayload)
+	if err != nil {
+		return err
+	}
+	if res.Id == nil {
+		// clear cache
+		c.zoneIDByDomain = nil
+		c.domainByZoneID = nil
+		return nil
+	}
+	c.zoneIDByDomain[domain] = *res.Id
+	c.domainByZoneID[*res.Id] = domain
+	return nil
+}
+
 func (c *huaweicloudProvider) getZones() error {
 	if c.zoneIDByDomain != nil {
 		return nil
 	}
+	if c.privateZone {
+		return c.getPrivateZones()
+	}
 
 	var nextMarker *string
 	c.zoneIDByDomain = make(map[string]string)
@@ -93,3 +132,44 @@ func (c *huaweicloudProvider) getZones() error {
 		}
 	}
 }
+
+func (c *huaweicloudProvider) getPrivateZones() error {
+	var nextMarker *string
+	c.zoneIDByDomain = make(map[string]string)
+	c.domainByZoneID = make(map[string]string)
+
+	for {
+		listPayload := model.ListPrivateZonesRequest{
+			Type:   zoneTypePrivate,
+			Marker: nextMarker,
+		}
+		zonesRes, err := c.client.ListPrivateZones(&listPayload)
+		if err != nil {
+			return err
+		}
+		// empty zones
+		if zonesRes.Zones == nil {
+			return nil
+		}
+		for _, zone := range *zonesRes.Zones {
+			// just a safety check
+			if zone.Name == nil || zone.Id == nil {
+				continue
+			}
+			domain := strings.TrimSuffix(*zone.Name, ".")
+			c.zoneIDByDomain[domain] = *zone.Id
+			c.domainByZoneID[*zone.Id] = domain
+		}
+
+		// if has next page, continue to get next page
+		if zonesRes.Links != nil && zonesRes.Links.Next != nil {
+			marker, err := parseMarkerFromURL(*zonesRes.Links.Next)
+			if err != nil {
+				return err
+			}
+			nextMarker = &marker
+		} else {
+			return nil
+		}
+	}
+}
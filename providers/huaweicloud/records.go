@@ -30,7 +30,7 @@ func (c *huaweicloudProvider) GetZoneRecords(domain string, meta map[string]stri
 		if *rec.Type == "SOA" {
 			continue
 		}
-		nativeRecords, err := nativeToRecords(&rec, domain)
+		nativeRecords, err := nativeToRecords(&rec, domain, c.privateZone)
 		if err != nil {
 			return nil, err
 		}
@@ -54,14 +54,20 @@ func (c *huaweicloudProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 		return nil, fmt.Errorf("zone %s not found", dc.Name)
 	}
 
-	addDefaultMeta(dc.Records)
+	if c.privateZone {
+		if err := checkPrivateZoneMeta(dc.Records); err != nil {
+			return nil, err
+		}
+	} else {
+		addDefaultMeta(dc.Records)
+	}
 
 	// Make delete happen earlier than creates & updates.
 	var corrections []*models.Correction
 	var deletions []*models.Correction
 	var reports []*models.Correction
 
-	changes, err := diff2.ByRecordSet(existing, dc, genComparable)
+	changes, err := diff2.ByRecordSet(existing, dc, c.genComparable)
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +79,8 @@ func (c *huaweicloudProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 		case diff2.CREATE:
 			fallthrough
 		case diff2.CHANGE:
-			newRecordsColl := collectRecordsByLineAndWeightAndKey(change.New)
-			oldRecordsColl := collectRecordsByLineAndWeightAndKey(change.Old)
+			newRecordsColl := collectRecordsByLineAndWeightAndKey(change.New, c.privateZone)
+			oldRecordsColl := collectRecordsByLineAndWeightAndKey(change.Old, c.privateZone)
 			corrections = append(corrections, &models.Correction{
 				Msg: change.MsgsJoined,
 				F: func() error {
@@ -136,13 +142,16 @@ func (c *huaweicloudProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 	return result, nil
 }
 
-func collectRecordsByLineAndWeightAndKey(records models.Records) map[string]models.Records {
+// collectRecordsByLineAndWeightAndKey groups the records by rrset. The rrsets
+// of a private zone have no line or weight, only a key.
+func collectRecordsByLineAndWeightAndKey(records models.Records, privateZone bool) map[string]models.Records {
 	recordsByLineAndWeight := make(map[string]models.Records)
 	for _, rec := range records {
-		line := rec.Metadata[metaLine]
-		weight := rec.Metadata[metaWeight]
 		rrsetKey := rec.Metadata[metaKey]
-		key := weight + "," + line + "," + rrsetKey
+		key := ",," + rrsetKey
+		if !privateZone {
+			key = rec.Metadata[metaWeight] + "," + rec.Metadata[metaLine] + "," + rrsetKey
+		}
 		recordsByLineAndWeight[key] = append(recordsByLineAndWeight[key], rec)
 	}
 	return recordsByLineAndWeight
@@ -164,11 +173,24 @@ func addDefaultMeta(recs models.Records) {
 	}
 }
 
-func genComparable(rec *models.RecordConfig) string {
+// checkPrivateZoneMeta rejects the routing metadata that private zones don't support.
+func checkPrivateZoneMeta(recs models.Records) error {
+	for _, r := range recs {
+		if r.Metadata[metaLine] != "" || r.Metadata[metaWeight] != "" {
+			return fmt.Errorf("%s %s: %s and %s are not supported in private zones", r.GetLabelFQDN(), r.Type, metaLine, metaWeight)
+		}
+	}
+	return nil
+}
+
+func (c *huaweicloudProvider) genComparable(rec *models.RecordConfig) string {
 	// apex ns
 	if rec.Type == "NS" && rec.Name == "@" {
 		return ""
 	}
+	if c.privateZone {
+		return "key=" + rec.Metadata[metaKey]
+	}
 	weight := rec.Metadata[metaWeight]
 	line := rec.Metadata[metaLine]
 	key := rec.Metadata[metaKey]
@@ -200,6 +222,9 @@ func (c *huaweicloudProvider) deleteRRSets(zoneID string, rrsets []string) error
 }
 
 func (c *huaweicloudProvider) createRRSet(zoneID string, rc *model.ShowRecordSetByZoneResp) error {
+	if c.privateZone {
+		return c.createPrivateRRSet(zoneID, rc)
+	}
 	createPayload := &model.CreateRecordSetWithLineRequest{
 		ZoneId: zoneID,
 		Body: &model.CreateRecordSetWithLineRequestBody{
@@ -223,6 +248,26 @@ func (c *huaweicloudProvider) createRRSet(zoneID string, rc *model.ShowRecordSet
 	return nil
 }
 
+// createPrivateRRSet creates a rrset in a private zone, which has no lines or weights.
+func (c *huaweicloudProvider) createPrivateRRSet(zoneID string, rc *model.ShowRecordSetByZoneResp) error {
+	createPayload := &model.CreateRecordSetRequest{
+		ZoneId: zoneID,
+		Body: &model.CreateRecordSetRequestBody{
+			Name:        *rc.Name,
+			Type:        *rc.Type,
+			Ttl:         rc.Ttl,
+			Records:     *rc.Records,
+			Description: rc.Description,
+		},
+	}
+	var err error
+	withRetry(func() error {
+		_, err = c.client.CreateRecordSet(createPayload)
+		return err
+	})
+	return err
+}
+
 func (c *huaweicloudProvider) updateRRSet(zoneID, rrsetID string, rc *model.ShowRecordSetByZoneResp) error {
 	updatePayload := &model.UpdateRecordSetsRequest{
 		ZoneId:      zoneID,
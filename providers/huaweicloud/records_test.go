@@ -0,0 +1,102 @@
+package huaweicloud
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dns/v2/model"
+)
+
+func nativeRRSet(ttl int32, line string, weight int32) *model.ShowRecordSetByZoneResp {
+	id, name, typ, key := "rrset1", "www.example.com.", "A", ""
+	return &model.ShowRecordSetByZoneResp{
+		Id:          &id,
+		Name:        &name,
+		Type:        &typ,
+		Ttl:         &ttl,
+		Records:     &[]string{"192.0.2.1"},
+		Line:        &line,
+		Weight:      &weight,
+		Description: &key,
+	}
+}
+
+func TestNativeToRecordsPrivateZone(t *testing.T) {
+	n := nativeRRSet(300, defaultLine, 1)
+
+	recs, err := nativeToRecords(n, "example.com", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recs[0].Metadata[metaLine] != defaultLine || recs[0].Metadata[metaWeight] != "1" {
+		t.Errorf("Expected the line and the weight in a public zone, got %v", recs[0].Metadata)
+	}
+
+	recs, err = nativeToRecords(n, "example.com", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := recs[0].Metadata[metaLine]; ok {
+		t.Errorf("Expected no line in a private zone, got %v", recs[0].Metadata)
+	}
+	if _, ok := recs[0].Metadata[metaWeight]; ok {
+		t.Errorf("Expected no weight in a private zone, got %v", recs[0].Metadata)
+	}
+}
+
+func TestCollectRecordsPrivateZone(t *testing.T) {
+	// The API returns a line and a weight for the rrsets of a private zone,
+	// the desired records have none.
+	old := &models.RecordConfig{Metadata: map[string]string{metaLine: defaultLine, metaWeight: "1"}}
+	desired := &models.RecordConfig{Metadata: map[string]string{}}
+
+	oldColl := collectRecordsByLineAndWeightAndKey(models.Records{old}, true)
+	newColl := collectRecordsByLineAndWeightAndKey(models.Records{desired}, true)
+	for key := range newColl {
+		if _, ok := oldColl[key]; !ok {
+			t.Errorf("Expected the rrset %q to be updated, got the old rrsets %v", key, oldColl)
+		}
+	}
+
+	oldColl = collectRecordsByLineAndWeightAndKey(models.Records{old}, false)
+	newColl = collectRecordsByLineAndWeightAndKey(models.Records{desired}, false)
+	for key := range newColl {
+		if _, ok := oldColl[key]; ok {
+			t.Errorf("Expected the rrsets of a public zone to differ by line and weight, got %q in both", key)
+		}
+	}
+}
+
+func TestPrivateZoneChangeUpdates(t *testing.T) {
+	c := &huaweicloudProvider{privateZone: true}
+	existing, err := nativeToRecords(nativeRRSet(300, defaultLine, 1), "example.com", c.privateZone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc := &models.RecordConfig{Type: "A", TTL: 600, Metadata: map[string]string{}}
+	rc.SetLabel("www", "example.com")
+	if err := rc.SetTarget("192.0.2.1"); err != nil {
+		t.Fatal(err)
+	}
+	dc := &models.DomainConfig{Name: "example.com", Records: models.Records{rc}}
+
+	changes, err := diff2.ByRecordSet(existing, dc, c.genComparable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Type != diff2.CHANGE {
+		t.Fatalf("Expected one change, got %v", changes)
+	}
+	oldColl := collectRecordsByLineAndWeightAndKey(changes[0].Old, c.privateZone)
+	newColl := collectRecordsByLineAndWeightAndKey(changes[0].New, c.privateZone)
+	if len(oldColl) != 1 || len(newColl) != 1 {
+		t.Fatalf("Expected one old and one new rrset, got %v and %v", oldColl, newColl)
+	}
+	for key := range newColl {
+		if ids := getRRSetIDFromRecords(oldColl[key]); len(ids) != 1 || ids[0] != "rrset1" {
+			t.Errorf("Expected the rrset rrset1 to be updated, got %v", ids)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package infoblox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultWAPIVersion = "2.12"
+	defaultView        = "default"
+	pageSize           = "1000"
+)
+
+type infobloxProvider struct {
+	client   *http.Client
+	baseURL  string // https://host/wapi/vX.Y/
+	username string
+	password string
+	view     string
+
+	zones map[string]*zoneAuth
+}
+
+type zoneAuth struct {
+	Ref           string `json:"_ref,omitempty"`
+	FQDN          string `json:"fqdn"`
+	View          string `json:"view,omitempty"`
+	DisplayDomain string `json:"display_domain,omitempty"`
+	ZoneFormat    string `json:"zone_format,omitempty"`
+	DefaultTTL    uint32 `json:"soa_default_ttl,omitempty"`
+}
+
+type extAttr struct {
+	Value any `json:"value"`
+}
+
+// wapiRecord holds the fields of all supported record objects. Numeric
+// fields are pointers so that zero values are sent.
+type wapiRecord struct {
+	Ref      string             `json:"_ref,omitempty"`
+	Name     string             `json:"name,omitempty"`
+	View     string             `json:"view,omitempty"`
+	TTL      *uint32            `json:"ttl,omitempty"`
+	UseTTL   *bool              `json:"use_ttl,omitempty"`
+	Extattrs map[string]extAttr `json:"extattrs"`
+
+	Ipv4addr      string  `json:"ipv4addr,omitempty"`       // record:a
+	Ipv6addr      string  `json:"ipv6addr,omitempty"`       // record:aaaa
+	Canonical     string  `json:"canonical,omitempty"`      // record:cname
+	MailExchanger string  `json:"mail_exchanger,omitempty"` // record:mx
+	Preference    *uint16 `json:"preference,omitempty"`     // record:mx
+	Text          string  `json:"text,omitempty"`           // record:txt
+	Priority      *uint16 `json:"priority,omitempty"`       // record:srv
+	Weight        *uint16 `json:"weight,omitempty"`         // record:srv
+	Port          *uint16 `json:"port,omitempty"`           // record:srv
+	Target        string  `json:"target,omitempty"`         // record:srv
+	Ptrdname      string  `json:"ptrdname,omitempty"`       // record:ptr
+	CaFlag        *uint8  `json:"ca_flag,omitempty"`        // record:caa
+	CaTag         string  `json:"ca_tag,omitempty"`         // record:caa
+	CaValue       string  `json:"ca_value,omitempty"`       // record:caa
+
+	// rtype is the DNSControl type; it is not part of the object.
+	rtype string
+}
+
+// recordObjects maps the supported record types to their WAPI object
+// and the fields to return.
+var recordObjects = map[string]struct {
+	object string
+	fields string
+}{
+	"A":     {"record:a", "name,view,ttl,use_ttl,extattrs,ipv4addr"},
+	"AAAA":  {"record:aaaa", "name,view,ttl,use_ttl,extattrs,ipv6addr"},
+	"CAA":   {"record:caa", "name,view,ttl,use_ttl,extattrs,ca_flag,ca_tag,ca_value"},
+	"CNAME": {"record:cname", "name,view,ttl,use_ttl,extattrs,canonical"},
+	"MX":    {"record:mx", "name,view,ttl,use_ttl,extattrs,mail_exchanger,preference"},
+	"PTR":   {"record:ptr", "name,view,ttl,use_ttl,extattrs,ptrdname"},
+	"SRV":   {"record:srv", "name,view,ttl,use_ttl,extattrs,priority,weight,port,target"},
+	"TXT":   {"record:txt", "name,view,ttl,use_ttl,extattrs,text"},
+}
+
+type pagedResponse struct {
+	Result     json.RawMessage `json:"result"`
+	NextPageID string          `json:"next_page_id"`
+}
+
+type apiError struct {
+	Error string `json:"Error"`
+	Code  string `json:"code"`
+	Text  string `json:"text"`
+}
+
+// request sends a WAPI request and decodes the JSON response into target.
+func (c *infobloxProvider) request(method, path string, query url.Values, body any, target any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		var ae apiError
+		if json.Unmarshal(data, &ae) == nil && ae.Text != "" {
+			return fmt.Errorf("INFOBLOX API error: %s: %s", resp.Status, ae.Text)
+		}
+		return fmt.Errorf("INFOBLOX API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// getAll fetches all pages of a WAPI object.
+func getAll[T any](c *infobloxProvider, object string, query url.Values) ([]T, error) {
+	query.Set("_paging", "1")
+	query.Set("_max_results", pageSize)
+	query.Set("_return_as_object", "1")
+
+	var items []T
+	for {
+		var resp pagedResponse
+		if err := c.request(http.MethodGet, object, query, nil, &resp); err != nil {
+			return nil, err
+		}
+		var batch []T
+		if err := json.Unmarshal(resp.Result, &batch); err != nil {
+			return nil, err
+		}
+		items = append(items, batch...)
+		if resp.NextPageID == "" {
+			break
+		}
+		query = url.Values{"_page_id": {resp.NextPageID}}
+	}
+	return items, nil
+}
+
+// zoneName returns the DNS name of a zone. Reverse zones are named after
+// their network (e.g. "192.168.1.0/24") in WAPI.
+func (z *zoneAuth) zoneName() string {
+	if z.DisplayDomain != "" {
+		return strings.ToLower(z.DisplayDomain)
+	}
+	return strings.ToLower(z.FQDN)
+}
+
+func (c *infobloxProvider) loadZones() error {
+	if c.zones != nil {
+		return nil
+	}
+	zones, err := getAll[zoneAuth](c, "zone_auth", url.Values{
+		"view":           {c.view},
+		"_return_fields": {"fqdn,view,display_domain,zone_format,soa_default_ttl"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed listing zones from INFOBLOX: %w", err)
+	}
+	c.zones = make(map[string]*zoneAuth, len(zones))
+	for i := range zones {
+		c.zones[zones[i].zoneName()] = &zones[i]
+	}
+	return nil
+}
+
+func (c *infobloxProvider) findZone(domain string) (*zoneAuth, error) {
+	if err := c.loadZones(); err != nil {
+		return nil, err
+	}
+	z, ok := c.zones[domain]
+	if !ok {
+		return nil, fmt.Errorf("zone %q not found in INFOBLOX view %q", domain, c.view)
+	}
+	return z, nil
+}
+
+func (c *infobloxProvider) createZone(domain string) error {
+	z := &zoneAuth{FQDN: domain, View: c.view}
+	if err := c.request(http.MethodPost, "zone_auth", nil, z, nil); err != nil {
+		return fmt.Errorf("failed creating zone (INFOBLOX): %w", err)
+	}
+	c.zones = nil
+	return nil
+}
+
+func (c *infobloxProvider) getRecords(zone *zoneAuth) ([]*wapiRecord, error) {
+	var records []*wapiRecord
+	for _, rtype := range sortedTypes() {
+		obj := recordObjects[rtype]
+		batch, err := getAll[*wapiRecord](c, obj.object, url.Values{
+			"zone":           {zone.FQDN},
+			"view":           {c.view},
+			"_return_fields": {obj.fields},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching %s records from INFOBLOX: %w", rtype, err)
+		}
+		for _, r := range batch {
+			r.rtype = rtype
+		}
+		records = append(records, batch...)
+	}
+	return records, nil
+}
+
+func (c *infobloxProvider) createRecord(rec *wapiRecord) error {
+	rec.View = c.view
+	if err := c.request(http.MethodPost, recordObjects[rec.rtype].object, nil, rec, nil); err != nil {
+		return fmt.Errorf("failed create record (INFOBLOX): %w", err)
+	}
+	return nil
+}
+
+func (c *infobloxProvider) updateRecord(ref string, rec *wapiRecord) error {
+	// The view of an object can't be changed.
+	rec.View = ""
+	if err := c.request(http.MethodPut, ref, nil, rec, nil); err != nil {
+		return fmt.Errorf("failed update record (INFOBLOX): %w", err)
+	}
+	return nil
+}
+
+func (c *infobloxProvider) deleteRecord(ref string) error {
+	if err := c.request(http.MethodDelete, ref, nil, nil, nil); err != nil {
+		return fmt.Errorf("failed delete record (INFOBLOX): %w", err)
+	}
+	return nil
+}
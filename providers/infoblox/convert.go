@@ -0,0 +1,164 @@
+package infoblox
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
+)
+
+// metaEAPrefix is the prefix of the metadata keys holding extensible
+// attributes, e.g. "infoblox_ea_Owner".
+const metaEAPrefix = "infoblox_ea_"
+
+func sortedTypes() []string {
+	types := make([]string, 0, len(recordObjects))
+	for t := range recordObjects {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// eaString renders an extensible attribute value. List values are joined with commas.
+func eaString(v any) string {
+	if list, ok := v.([]any); ok {
+		parts := make([]string, 0, len(list))
+		for _, item := range list {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+func genComparable(rc *models.RecordConfig) string {
+	var keys []string
+	for k := range rc.Metadata {
+		if strings.HasPrefix(k, metaEAPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", strings.TrimPrefix(k, metaEAPrefix), rc.Metadata[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// toRc converts a WAPI record object into a RecordConfig.
+func toRc(domain string, defaultTTL uint32, r *wapiRecord) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.rtype,
+		TTL:      defaultTTL,
+		Metadata: map[string]string{},
+		Original: r,
+	}
+	if deref(r.UseTTL) {
+		rc.TTL = deref(r.TTL)
+	}
+	rc.SetLabelFromFQDN(r.Name, domain)
+	for k, v := range r.Extattrs {
+		rc.Metadata[metaEAPrefix+k] = eaString(v.Value)
+	}
+
+	var err error
+	switch r.rtype {
+	case "A":
+		err = rc.SetTarget(r.Ipv4addr)
+	case "AAAA":
+		err = rc.SetTarget(r.Ipv6addr)
+	case "CNAME":
+		err = rc.SetTarget(dot(r.Canonical))
+	case "PTR":
+		err = rc.SetTarget(dot(r.Ptrdname))
+	case "MX":
+		err = rc.SetTargetMX(deref(r.Preference), dot(r.MailExchanger))
+	case "SRV":
+		err = rc.SetTargetSRV(deref(r.Priority), deref(r.Weight), deref(r.Port), dot(r.Target))
+	case "CAA":
+		err = rc.SetTargetCAA(deref(r.CaFlag), r.CaTag, r.CaValue)
+	case "TXT":
+		// Texts with several strings are stored in zone file format.
+		if strings.HasPrefix(r.Text, `"`) {
+			var txt string
+			if txt, err = txtutil.ParseQuoted(r.Text); err == nil {
+				err = rc.SetTargetTXT(txt)
+			}
+		} else {
+			err = rc.SetTargetTXT(r.Text)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.rtype)
+	}
+	return rc, err
+}
+
+// toWapi converts a RecordConfig into a WAPI record object.
+func toWapi(rc *models.RecordConfig) *wapiRecord {
+	r := &wapiRecord{
+		Name:     rc.GetLabelFQDN(),
+		TTL:      ptr(rc.TTL),
+		UseTTL:   ptr(true),
+		Extattrs: map[string]extAttr{},
+		rtype:    rc.Type,
+	}
+	for k, v := range rc.Metadata {
+		if name, ok := strings.CutPrefix(k, metaEAPrefix); ok {
+			r.Extattrs[name] = extAttr{Value: v}
+		}
+	}
+
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "A":
+		r.Ipv4addr = target
+	case "AAAA":
+		r.Ipv6addr = target
+	case "CNAME":
+		r.Canonical = target
+	case "PTR":
+		r.Ptrdname = target
+	case "MX":
+		r.MailExchanger = target
+		r.Preference = ptr(rc.MxPreference)
+	case "SRV":
+		r.Target = target
+		r.Priority = ptr(rc.SrvPriority)
+		r.Weight = ptr(rc.SrvWeight)
+		r.Port = ptr(rc.SrvPort)
+	case "CAA":
+		r.CaFlag = ptr(rc.CaaFlag)
+		r.CaTag = rc.CaaTag
+		r.CaValue = rc.GetTargetField()
+	case "TXT":
+		if len(rc.GetTargetTXTSegmented()) > 1 {
+			r.Text = txtutil.EncodeQuoted(rc.GetTargetTXTJoined())
+		} else {
+			r.Text = rc.GetTargetTXTJoined()
+		}
+	}
+	return r
+}
@@ -0,0 +1,184 @@
+package infoblox
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Infoblox NIOS through the Web API (WAPI).
+// API Documentation: https://docs.infoblox.com/space/nios90/1081638142/Infoblox+WAPI+documentation
+
+/*
+Infoblox NIOS provider:
+
+Info required in `creds.json`:
+   - host (Grid Master hostname)
+   - username
+   - password
+   - view (optional, default "default")
+   - wapi_version (optional, default "2.12")
+   - skip_tls_verify (optional)
+   - cert (optional, PEM CA certificate of the Grid Master)
+
+Record level metadata available:
+   - infoblox_ea_<name> (extensible attribute <name>)
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Unimplemented(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Unimplemented(),
+	providers.CanUseDSForChildren:    providers.Unimplemented(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Unimplemented(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Unimplemented(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "INFOBLOX"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newInfoblox,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+	providers.RegisterTLSOptions(providerName)
+}
+
+// newInfoblox creates the provider.
+func newInfoblox(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	if m["host"] == "" {
+		return nil, fmt.Errorf("missing INFOBLOX host")
+	}
+	if m["username"] == "" || m["password"] == "" {
+		return nil, fmt.Errorf("missing INFOBLOX username or password")
+	}
+
+	version := m["wapi_version"]
+	if version == "" {
+		version = defaultWAPIVersion
+	}
+	c := &infobloxProvider{
+		baseURL:  fmt.Sprintf("https://%s/wapi/v%s/", m["host"], version),
+		username: m["username"],
+		password: m["password"],
+		view:     m["view"],
+	}
+	if c.view == "" {
+		c.view = defaultView
+	}
+
+	client, err := tlsconfig.NewHTTPClient(m)
+	if err != nil {
+		return nil, fmt.Errorf("INFOBLOX: %w", err)
+	}
+	c.client = client
+
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+// The apex NS records are generated from the Grid member assignments.
+func (c *infobloxProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *infobloxProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	zone, err := c.findZone(domain)
+	if err != nil {
+		return nil, err
+	}
+	records, err := c.getRecords(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for _, r := range records {
+		rc, err := toRc(domain, zone.DefaultTTL, r)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *infobloxProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existingRecords, dc, genComparable)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			rec := toWapi(change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(rec)
+				},
+			}
+		case diff2.CHANGE:
+			ref := change.Old[0].Original.(*wapiRecord).Ref
+			rec := toWapi(change.New[0])
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, INFOBLOX ref: %s", change.Msgs[0], shortRef(ref)),
+				F: func() error {
+					return c.updateRecord(ref, rec)
+				},
+			}
+		case diff2.DELETE:
+			ref := change.Old[0].Original.(*wapiRecord).Ref
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, INFOBLOX ref: %s", change.Msgs[0], shortRef(ref)),
+				F: func() error {
+					return c.deleteRecord(ref)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
+
+// shortRef returns the object ID of a reference such as
+// "record:a/ZG5zLmJpbmRfYSQ...:www.example.com/default".
+func shortRef(ref string) string {
+	_, id, ok := strings.Cut(ref, "/")
+	if !ok {
+		return ref
+	}
+	id, _, _ = strings.Cut(id, ":")
+	return id
+}
@@ -0,0 +1,27 @@
+package infoblox
+
+import "sort"
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *infobloxProvider) ListZones() ([]string, error) {
+	if err := c.loadZones(); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(c.zones))
+	for name := range c.zones {
+		zones = append(zones, name)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *infobloxProvider) EnsureZoneExists(domain string) error {
+	if err := c.loadZones(); err != nil {
+		return err
+	}
+	if _, ok := c.zones[domain]; ok {
+		return nil
+	}
+	return c.createZone(domain)
+}
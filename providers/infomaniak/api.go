@@ -0,0 +1,182 @@
+package infomaniak
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const baseURL = "https://api.infomaniak.com/1"
+
+type infomaniakProvider struct {
+	token   string
+	domains map[string]int64 // Domain name -> product ID
+}
+
+type apiResponse struct {
+	Result string          `json:"result"`
+	Data   json.RawMessage `json:"data"`
+	Error  *struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+type product struct {
+	ID           int64  `json:"id"`
+	CustomerName string `json:"customer_name"`
+	ServiceName  string `json:"service_name"`
+}
+
+type record struct {
+	ID     int64  `json:"id,omitempty"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	TTL    uint32 `json:"ttl"`
+	Target string `json:"target"`
+}
+
+func (c *infomaniakProvider) request(method, path string, query url.Values, body, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	u := baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+retry:
+	req, err := http.NewRequest(method, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("Infomaniak rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+
+	var ar apiResponse
+	if err := json.Unmarshal(data, &ar); err != nil {
+		return fmt.Errorf("infomaniak API error: %s: %s", resp.Status, string(data))
+	}
+	if ar.Result != "success" {
+		if ar.Error != nil {
+			return fmt.Errorf("infomaniak API error: %s: %s (%s)", resp.Status, ar.Error.Description, ar.Error.Code)
+		}
+		return fmt.Errorf("infomaniak API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(ar.Data, target)
+}
+
+// loadDomains fetches the domain products of the account.
+func (c *infomaniakProvider) loadDomains() error {
+	if c.domains != nil {
+		return nil
+	}
+	var products []product
+	if err := c.request(http.MethodGet, "/product", url.Values{"service_name": {"domain"}}, nil, &products); err != nil {
+		return fmt.Errorf("failed listing domains from infomaniak: %w", err)
+	}
+	c.domains = make(map[string]int64, len(products))
+	for _, p := range products {
+		c.domains[strings.ToLower(p.CustomerName)] = p.ID
+	}
+	return nil
+}
+
+// domainID returns the product ID of a domain.
+func (c *infomaniakProvider) domainID(domain string) (int64, error) {
+	if err := c.loadDomains(); err != nil {
+		return 0, err
+	}
+	id, ok := c.domains[strings.ToLower(domain)]
+	if !ok {
+		return 0, fmt.Errorf("domain %q not found in the infomaniak account", domain)
+	}
+	return id, nil
+}
+
+func domainPath(id int64) string {
+	return "/domain/" + strconv.FormatInt(id, 10)
+}
+
+func (c *infomaniakProvider) getRecords(id int64) ([]record, error) {
+	var records []record
+	if err := c.request(http.MethodGet, domainPath(id)+"/dns/record", nil, nil, &records); err != nil {
+		return nil, fmt.Errorf("failed fetching record list from infomaniak: %w", err)
+	}
+	return records, nil
+}
+
+func (c *infomaniakProvider) createRecord(id int64, rec *record) error {
+	if err := c.request(http.MethodPost, domainPath(id)+"/dns/record", nil, rec, nil); err != nil {
+		return fmt.Errorf("failed create record (infomaniak): %w", err)
+	}
+	return nil
+}
+
+func (c *infomaniakProvider) updateRecord(id int64, rec *record) error {
+	if err := c.request(http.MethodPut, domainPath(id)+"/dns/record/"+strconv.FormatInt(rec.ID, 10), nil, rec, nil); err != nil {
+		return fmt.Errorf("failed update record (infomaniak): %w", err)
+	}
+	return nil
+}
+
+func (c *infomaniakProvider) deleteRecord(id, recordID int64) error {
+	if err := c.request(http.MethodDelete, domainPath(id)+"/dns/record/"+strconv.FormatInt(recordID, 10), nil, nil, nil); err != nil {
+		return fmt.Errorf("failed delete record (infomaniak): %w", err)
+	}
+	return nil
+}
+
+func (c *infomaniakProvider) getNameservers(id int64) ([]string, error) {
+	var nameservers []string
+	if err := c.request(http.MethodGet, domainPath(id)+"/nameservers", nil, nil, &nameservers); err != nil {
+		return nil, fmt.Errorf("failed fetching nameservers from infomaniak: %w", err)
+	}
+	return nameservers, nil
+}
+
+func (c *infomaniakProvider) updateNameservers(id int64, nameservers []string) error {
+	if err := c.request(http.MethodPut, domainPath(id)+"/nameservers", nil, map[string]any{"nameservers": nameservers}, nil); err != nil {
+		return fmt.Errorf("failed updating nameservers (infomaniak): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package infomaniak
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// apexSource is the source of the records at the apex of a zone.
+const apexSource = "."
+
+// toRc converts an Infomaniak record into a RecordConfig.
+func toRc(domain string, r *record) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+	}
+	label := r.Source
+	if label == apexSource {
+		label = "@"
+	}
+	rc.SetLabel(label, domain)
+
+	var err error
+	switch r.Type {
+	case "TXT":
+		err = rc.SetTargetTXT(r.Target)
+	case "A", "AAAA", "CAA", "SSHFP", "TLSA":
+		err = rc.PopulateFromString(r.Type, r.Target, domain)
+	case "CNAME", "DNAME", "MX", "NS", "PTR", "SRV":
+		// Host names are returned without the trailing dot.
+		if err = rc.PopulateFromString(r.Type, r.Target, domain); err == nil {
+			err = rc.SetTarget(dot(rc.GetTargetField()))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRecord converts a RecordConfig into an Infomaniak record.
+func toRecord(rc *models.RecordConfig) *record {
+	r := &record{
+		Source: rc.GetLabel(),
+		Type:   rc.Type,
+		TTL:    rc.TTL,
+	}
+	if r.Source == "@" {
+		r.Source = apexSource
+	}
+	switch rc.Type {
+	case "TXT":
+		r.Target = rc.GetTargetTXTJoined()
+	case "MX", "SRV":
+		r.Target = strings.TrimSuffix(rc.GetTargetCombined(), ".")
+	case "CAA", "SSHFP", "TLSA":
+		r.Target = rc.GetTargetCombined()
+	default:
+		r.Target = strings.TrimSuffix(rc.GetTargetField(), ".")
+	}
+	return r
+}
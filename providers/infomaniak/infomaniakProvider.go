@@ -0,0 +1,206 @@
+package infomaniak
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Infomaniak.
+// API Documentation: https://developer.infomaniak.com/docs/api
+
+/*
+Infomaniak provider:
+
+Info required in `creds.json`:
+   - api_token
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Unimplemented(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseDNAME:            providers.Can(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Can(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Cannot(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{"ns11.infomaniak.ch", "ns12.infomaniak.ch"}
+
+func init() {
+	const providerName = "INFOMANIAK"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	providers.RegisterRegistrarType(providerName, newReg)
+	fns := providers.DspFuncs{
+		Initializer:   newDsp,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+func newReg(conf map[string]string) (providers.Registrar, error) {
+	return newInfomaniak(conf)
+}
+
+func newDsp(conf map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	return newInfomaniak(conf)
+}
+
+// newInfomaniak creates the provider.
+func newInfomaniak(m map[string]string) (*infomaniakProvider, error) {
+	c := &infomaniakProvider{
+		token: m["api_token"],
+	}
+	if c.token == "" {
+		return nil, fmt.Errorf("missing INFOMANIAK api_token")
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *infomaniakProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *infomaniakProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	id, err := c.domainID(domain)
+	if err != nil {
+		return nil, err
+	}
+	records, err := c.getRecords(id)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		r := &records[i]
+		if r.Type == "SOA" {
+			continue
+		}
+		if r.Type == "NS" && (r.Source == apexSource || r.Source == "") {
+			// The apex NS records are managed by Infomaniak.
+			continue
+		}
+		rc, err := toRc(domain, r)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *infomaniakProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	id, err := c.domainID(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			rec := toRecord(change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(id, rec)
+				},
+			}
+		case diff2.CHANGE:
+			rec := toRecord(change.New[0])
+			rec.ID = change.Old[0].Original.(*record).ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Infomaniak ID: %d", change.Msgs[0], rec.ID),
+				F: func() error {
+					return c.updateRecord(id, rec)
+				},
+			}
+		case diff2.DELETE:
+			recordID := change.Old[0].Original.(*record).ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Infomaniak ID: %d", change.Msgs[0], recordID),
+				F: func() error {
+					return c.deleteRecord(id, recordID)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
+
+// GetRegistrarCorrections returns a list of corrections for this registrar.
+func (c *infomaniakProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	id, err := c.domainID(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	nss, err := c.getNameservers(id)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		found = append(found, strings.ToLower(strings.TrimSuffix(ns, ".")))
+	}
+	sort.Strings(found)
+	foundNameservers := strings.Join(found, ",")
+
+	expected := make([]string, 0, len(dc.Nameservers))
+	for _, ns := range dc.Nameservers {
+		expected = append(expected, ns.Name)
+	}
+	sort.Strings(expected)
+	expectedNameservers := strings.Join(expected, ",")
+
+	if foundNameservers == expectedNameservers {
+		return nil, nil
+	}
+
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundNameservers, expectedNameservers),
+			F: func() error {
+				return c.updateNameservers(id, expected)
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,16 @@
+package infomaniak
+
+import "sort"
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *infomaniakProvider) ListZones() ([]string, error) {
+	if err := c.loadDomains(); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(c.domains))
+	for name := range c.domains {
+		zones = append(zones, name)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
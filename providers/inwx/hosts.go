@@ -0,0 +1,142 @@
+package inwx
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/nrdcg/goinwx"
+)
+
+// errCodeObjectNotFound is the result code of INWX for an object which
+// does not exist.
+const errCodeObjectNotFound = 2303
+
+// host is a host object of the registry, i.e. a nameserver with its glue
+// addresses.
+type host struct {
+	RoID int
+	IPs  []string
+}
+
+// glueIPs returns the IPv4 and IPv6 addresses of each nameserver inside the
+// domain, taken from the A and AAAA records of the zone.
+// The nameservers without such records are left unchanged.
+func glueIPs(dc *models.DomainConfig) map[string][]string {
+	glue := map[string][]string{}
+	for _, ns := range dc.Nameservers {
+		name := strings.TrimSuffix(ns.Name, ".")
+		if name != dc.Name && !strings.HasSuffix(name, "."+dc.Name) {
+			continue
+		}
+		for _, rc := range dc.Records {
+			if (rc.Type == "A" || rc.Type == "AAAA") && rc.GetLabelFQDN() == name {
+				glue[name] = append(glue[name], rc.GetTargetIP().String())
+			}
+		}
+		sort.Strings(glue[name])
+	}
+	return glue
+}
+
+// getHost returns the host object of the hostname, or nil if it does not
+// exist.
+func (api *inwxAPI) getHost(hostname string) (*host, error) {
+	req := api.client.NewRequest("host.info", map[string]interface{}{
+		"hostname": hostname,
+	})
+	resp, err := api.client.Do(req)
+	if err != nil {
+		var errResp *goinwx.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Code == errCodeObjectNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed fetching host %s (INWX): %w", hostname, err)
+	}
+
+	h := &host{}
+	switch roID := resp["roId"].(type) {
+	case int64:
+		h.RoID = int(roID)
+	case int:
+		h.RoID = roID
+	}
+	switch ips := resp["ip"].(type) {
+	case string:
+		h.IPs = []string{ips}
+	case []interface{}:
+		for _, ip := range ips {
+			if s, ok := ip.(string); ok {
+				h.IPs = append(h.IPs, s)
+			}
+		}
+	}
+	for i, ip := range h.IPs {
+		// Normalize the IPv6 addresses like GetTargetIP.
+		if parsed := net.ParseIP(ip); parsed != nil {
+			h.IPs[i] = parsed.String()
+		}
+	}
+	sort.Strings(h.IPs)
+	return h, nil
+}
+
+func (api *inwxAPI) createHost(hostname string, ips []string) error {
+	req := api.client.NewRequest("host.create", map[string]interface{}{
+		"hostname": hostname,
+		"ip":       ips,
+	})
+	_, err := api.client.Do(req)
+	return err
+}
+
+func (api *inwxAPI) updateHost(h *host, ips []string) error {
+	req := api.client.NewRequest("host.update", map[string]interface{}{
+		"roId": h.RoID,
+		"ip":   ips,
+	})
+	_, err := api.client.Do(req)
+	return err
+}
+
+// getHostCorrections returns the corrections that create or update the
+// host objects of the nameservers of the domain that are inside the
+// domain, with their glue addresses.
+func (api *inwxAPI) getHostCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	glue := glueIPs(dc)
+	hostnames := make([]string, 0, len(glue))
+	for hostname := range glue {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+
+	var corrections []*models.Correction
+	for _, hostname := range hostnames {
+		ips := glue[hostname]
+		current, err := api.getHost(hostname)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case current == nil:
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Create host %s (%s)", hostname, strings.Join(ips, ", ")),
+				F: func() error {
+					return api.createHost(hostname, ips)
+				},
+			})
+		case !slices.Equal(current.IPs, ips):
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Update host %s %s -> %s", hostname, strings.Join(current.IPs, ", "), strings.Join(ips, ", ")),
+				F: func() error {
+					return api.updateHost(current, ips)
+				},
+			})
+		}
+	}
+	return corrections, nil
+}
@@ -0,0 +1,39 @@
+package inwx
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestGlueIPs(t *testing.T) {
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Nameservers: []*models.Nameserver{
+			{Name: "ns1.example.com"},
+			{Name: "ns2.example.com."},
+			{Name: "ns3.example.com"},
+			{Name: "ns.example.net"},
+		},
+	}
+	for _, r := range []struct{ typ, label, target string }{
+		{"A", "ns1", "192.0.2.1"},
+		{"AAAA", "ns1", "2001:db8::1"},
+		{"A", "ns2", "192.0.2.2"},
+		{"A", "www", "192.0.2.3"},
+	} {
+		rc := &models.RecordConfig{Type: r.typ}
+		rc.SetLabel(r.label, dc.Name)
+		rc.SetTarget(r.target)
+		dc.Records = append(dc.Records, rc)
+	}
+
+	want := map[string][]string{
+		"ns1.example.com": {"192.0.2.1", "2001:db8::1"},
+		"ns2.example.com": {"192.0.2.2"},
+	}
+	if got := glueIPs(dc); !reflect.DeepEqual(got, want) {
+		t.Errorf("glueIPs() = %v, want %v", got, want)
+	}
+}
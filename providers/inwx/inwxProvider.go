@@ -393,15 +393,19 @@ func (api *inwxAPI) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.
 	sort.Strings(expected)
 	expectedNameservers := strings.Join(expected, ",")
 
+	// The hosts must exist before they can be used as nameservers.
+	corrections, err := api.getHostCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+
 	if foundNameservers != expectedNameservers {
-		return []*models.Correction{
-			{
-				Msg: fmt.Sprintf("Update nameservers %s -> %s", foundNameservers, expectedNameservers),
-				F:   api.updateNameservers(expected, dc.Name),
-			},
-		}, nil
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundNameservers, expectedNameservers),
+			F:   api.updateNameservers(expected, dc.Name),
+		})
 	}
-	return nil, nil
+	return corrections, nil
 }
 
 // fetchNameserverDomains returns the domains configured in INWX nameservers
@@ -0,0 +1,141 @@
+package linode
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+)
+
+// The settings of the domain set in the domain metadata. The settings
+// which aren't set are left unchanged.
+const (
+	metaSOAEmail   = "linode_soa_email"   // The email of the SOA record.
+	metaRefreshSec = "linode_refresh_sec" // The refresh of the SOA record.
+	metaRetrySec   = "linode_retry_sec"   // The retry of the SOA record.
+	metaExpireSec  = "linode_expire_sec"  // The expire of the SOA record.
+	metaTTLSec     = "linode_ttl_sec"     // The default TTL of the records.
+	metaAXFRIPs    = "linode_axfr_ips"    // The comma-separated IP addresses allowed to transfer the zone.
+)
+
+// domainSettings are the settings of a domain.
+type domainSettings struct {
+	SOAEmail   string   `json:"soa_email,omitempty"`
+	RefreshSec uint32   `json:"refresh_sec"`
+	RetrySec   uint32   `json:"retry_sec"`
+	ExpireSec  uint32   `json:"expire_sec"`
+	TTLSec     uint32   `json:"ttl_sec"`
+	AXFRIPs    []string `json:"axfr_ips"`
+}
+
+func (api *linodeProvider) getDomain(domainID int) (*domainSettings, error) {
+	settings := &domainSettings{}
+	if err := api.get(fmt.Sprintf("%s/%d", domainsPath, domainID), settings); err != nil {
+		return nil, fmt.Errorf("failed fetching domain (Linode): %s", err)
+	}
+	return settings, nil
+}
+
+func (api *linodeProvider) updateDomain(domainID int, settings *domainSettings) error {
+	req, err := api.newRequest(http.MethodPut, fmt.Sprintf("%s/%d", domainsPath, domainID), settings)
+	if err != nil {
+		return err
+	}
+
+	resp, err := api.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return api.handleErrors(resp)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// desiredSettings returns the settings of the domain with the ones of the
+// domain metadata, and the names of the settings which are changed.
+func desiredSettings(metadata map[string]string, existing *domainSettings) (*domainSettings, []string, error) {
+	desired := *existing
+	var changes []string
+
+	if v, ok := metadata[metaSOAEmail]; ok && v != existing.SOAEmail {
+		desired.SOAEmail = v
+		changes = append(changes, fmt.Sprintf("soa_email (%s -> %s)", existing.SOAEmail, v))
+	}
+
+	for _, timer := range []struct {
+		key, name string
+		value     *uint32
+	}{
+		{metaRefreshSec, "refresh_sec", &desired.RefreshSec},
+		{metaRetrySec, "retry_sec", &desired.RetrySec},
+		{metaExpireSec, "expire_sec", &desired.ExpireSec},
+		{metaTTLSec, "ttl_sec", &desired.TTLSec},
+	} {
+		v, ok := metadata[timer.key]
+		if !ok {
+			continue
+		}
+		seconds, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s (%s) must be a number of seconds", timer.key, v)
+		}
+		// Linode rounds the timers up like the TTLs.
+		fixed := fixTTL(uint32(seconds))
+		if fixed != *timer.value {
+			changes = append(changes, fmt.Sprintf("%s (%d -> %d)", timer.name, *timer.value, fixed))
+			*timer.value = fixed
+		}
+	}
+
+	if v, ok := metadata[metaAXFRIPs]; ok {
+		ips := []string{}
+		for _, ip := range strings.Split(v, ",") {
+			ip = strings.TrimSpace(ip)
+			if ip == "" {
+				continue
+			}
+			if net.ParseIP(ip) == nil {
+				return nil, nil, fmt.Errorf("%s: %q is not an IP address", metaAXFRIPs, ip)
+			}
+			ips = append(ips, ip)
+		}
+		sort.Strings(ips)
+		current := slices.Clone(existing.AXFRIPs)
+		sort.Strings(current)
+		if !slices.Equal(ips, current) {
+			changes = append(changes, fmt.Sprintf("axfr_ips (%s -> %s)", strings.Join(current, ","), strings.Join(ips, ",")))
+			desired.AXFRIPs = ips
+		}
+	}
+
+	return &desired, changes, nil
+}
+
+// getDomainCorrections returns the correction of the settings of the
+// domain, if they differ from the domain metadata.
+func (api *linodeProvider) getDomainCorrections(dc *models.DomainConfig, domainID int) ([]*models.Correction, error) {
+	existing, err := api.getDomain(domainID)
+	if err != nil {
+		return nil, err
+	}
+	desired, changes, err := desiredSettings(dc.Metadata, existing)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return []*models.Correction{{
+		Msg: color.YellowString("± MODIFY %s settings: %s", dc.Name, strings.Join(changes, ", ")),
+		F: func() error {
+			return api.updateDomain(domainID, desired)
+		},
+	}}, nil
+}
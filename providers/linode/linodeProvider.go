@@ -215,7 +215,11 @@ func (api *linodeProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, ex
 		corrections = append(corrections, corr)
 	}
 
-	return corrections, nil
+	domainCorrections, err := api.getDomainCorrections(dc, domainID)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, domainCorrections...), nil
 }
 
 func (api *linodeProvider) getRecordsForDomain(domainID int, domain string) (models.Records, error) {
@@ -21,3 +21,44 @@ func TestFixTTL(t *testing.T) {
 		}
 	}
 }
+
+func TestDesiredSettings(t *testing.T) {
+	existing := &domainSettings{SOAEmail: "old@example.com", RefreshSec: 0, TTLSec: 3600, AXFRIPs: []string{"192.0.2.2", "192.0.2.1"}}
+
+	desired, changes, err := desiredSettings(map[string]string{
+		metaSOAEmail:   "hostmaster@example.com",
+		metaRefreshSec: "7000",
+		metaTTLSec:     "3600",
+		metaAXFRIPs:    "192.0.2.1, 192.0.2.2",
+	}, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Errorf("Expected the changes of soa_email and refresh_sec, got %v", changes)
+	}
+	if desired.SOAEmail != "hostmaster@example.com" || desired.RefreshSec != 7200 || desired.TTLSec != 3600 {
+		t.Errorf("Unexpected settings %+v", desired)
+	}
+
+	desired, changes, err = desiredSettings(map[string]string{metaAXFRIPs: ""}, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || len(desired.AXFRIPs) != 0 {
+		t.Errorf("Expected the transfers to be disallowed, got %v %v", changes, desired.AXFRIPs)
+	}
+
+	if _, changes, _ := desiredSettings(nil, existing); len(changes) != 0 {
+		t.Errorf("Expected no changes without metadata, got %v", changes)
+	}
+
+	for _, metadata := range []map[string]string{
+		{metaRetrySec: "soon"},
+		{metaAXFRIPs: "192.0.2.1,primary.example.com"},
+	} {
+		if _, _, err := desiredSettings(metadata, existing); err == nil {
+			t.Errorf("Expected an error for %v", metadata)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -137,7 +138,7 @@ func NewClient(apiUser, apiPassword string, region string, modifyns bool, fetchn
 // CreateRecordSimulate only prints info about a record addition. Used for debugging.
 func (c *APIClient) CreateRecordSimulate(domain string, subdomain string, record paramStruct) error {
 	if c.Debug {
-		fmt.Printf("create: domain: %s; subdomain: %s; record: %+v\n", domain, subdomain, record)
+		printer.Printf("create: domain: %s; subdomain: %s; record: %+v\n", domain, subdomain, record)
 	}
 	return nil
 }
@@ -250,14 +251,14 @@ func (c *APIClient) GetDomainNS(domain string) ([]string, error) {
 	}
 
 	if c.Debug {
-		fmt.Printf("DEBUG: getZoneRecords(@) START\n")
+		printer.Printf("DEBUG: getZoneRecords(@) START\n")
 	}
 	for i, rec := range resp.ZoneRecords {
 		ns := rec.GetZR()
 		if ns.Type == "NS" {
 			apexNSRecords = append(apexNSRecords, ns.Rdata)
 			if c.Debug {
-				fmt.Printf("DEBUG: HERE %d: %v\n", i, ns)
+				printer.Printf("DEBUG: HERE %d: %v\n", i, ns)
 			}
 		}
 	}
@@ -269,7 +270,7 @@ func (c *APIClient) GetDomainNS(domain string) ([]string, error) {
 
 // UpdateRecordSimulate only prints info about a record update. Used for debugging.
 func (c *APIClient) UpdateRecordSimulate(domain string, subdomain string, rec paramStruct) error {
-	fmt.Printf("got update: domain: %s; subdomain: %s; record: %v\n", domain, subdomain, rec)
+	printer.Printf("got update: domain: %s; subdomain: %s; record: %v\n", domain, subdomain, rec)
 	return nil
 }
 
@@ -310,7 +311,7 @@ func (c *APIClient) UpdateRecord(domain string, subdomain string, rec paramStruc
 
 // DeleteRecordSimulate only prints info about a record deletion. Used for debugging.
 func (c *APIClient) DeleteRecordSimulate(domain string, subdomain string, recordID uint32) error {
-	fmt.Printf("delete: domain: %s; subdomain: %s; recordID: %d\n", domain, subdomain, recordID)
+	printer.Printf("delete: domain: %s; subdomain: %s; recordID: %d\n", domain, subdomain, recordID)
 	return nil
 }
 
@@ -369,8 +370,7 @@ func (c *APIClient) rpcCall(call *methodCall, resp response) error {
 	callBody = append([]byte(`<?xml version="1.0"?>`+"\n"), callBody...)
 
 	if c.Debug {
-		fmt.Print(string(callBody))
-		fmt.Printf("\n")
+		printer.Printf("%s\n", callBody)
 	}
 
 	respBody, err := c.httpPost(c.BaseURL, "text/xml", bytes.NewReader(callBody))
@@ -379,8 +379,7 @@ func (c *APIClient) rpcCall(call *methodCall, resp response) error {
 	}
 
 	if c.Debug {
-		fmt.Print(string(respBody))
-		fmt.Printf("\n")
+		printer.Printf("%s\n", respBody)
 	}
 
 	err = xml.Unmarshal(respBody, resp)
@@ -391,7 +390,7 @@ func (c *APIClient) rpcCall(call *methodCall, resp response) error {
 	//yes - loopia are stoopid - the 429 error code comes from the DB behind the http proxy
 	c.requestRateLimiter.handleXMLResponse(resp)
 	if resp.faultCode() == 429 {
-		fmt.Printf("XMLresp: %+v\n", resp)
+		printer.Debugf("XMLresp: %+v\n", resp)
 		c.requestRateLimiter.handleRateLimitedRequest()
 	} else if resp.faultCode() != 0 {
 		return rpcError{
@@ -415,7 +414,7 @@ func (c *APIClient) httpPost(url string, bodyType string, body io.Reader) ([]byt
 	cleanupResponseBody := func() {
 		err := resp.Body.Close()
 		if err != nil {
-			fmt.Printf("failed closing response body: %q\n", err)
+			printer.Warnf("failed closing response body: %q\n", err)
 		}
 	}
 
@@ -534,7 +533,7 @@ func (requestRateLimiter *requestRateLimiter) handleRateLimitedRequest() {
 	case "second":
 		message = fmt.Sprintf(message, "Second", "Minute")
 	}
-	fmt.Print(message)
+	printer.Warnf("%s\n", message)
 }
 
 func (requestRateLimiter *requestRateLimiter) handleResponse(resp http.Response) {
@@ -141,13 +141,13 @@ func (c *APIClient) ListZones() ([]string, error) {
 
 	zones := make([]string, len(listResp))
 	if c.Debug {
-		fmt.Printf("DEBUG: DOMAIN LIST START\n")
+		printer.Printf("DEBUG: DOMAIN LIST START\n")
 	}
 	for i, zone := range listResp {
 		for _, prop := range zone.Properties {
 			if prop.Name() == "domain" { // the zones name is stored in property 'domain'
 				if c.Debug {
-					fmt.Printf("DEBUG: DOMAIN LIST %d: %v\n", i, prop.String())
+					printer.Printf("DEBUG: DOMAIN LIST %d: %v\n", i, prop.String())
 				}
 				// zone := zone
 				zones[i] = prop.String()
@@ -155,7 +155,7 @@ func (c *APIClient) ListZones() ([]string, error) {
 		}
 	}
 	if c.Debug {
-		fmt.Printf("DEBUG: DOMAIN LIST END\n")
+		printer.Printf("DEBUG: DOMAIN LIST END\n")
 	}
 	return zones, nil
 }
@@ -176,7 +176,7 @@ func (c *APIClient) GetZoneRecords(domain string, meta map[string]string) (model
 	}
 
 	if c.Debug {
-		fmt.Printf("Amount of subdomains: %d\n", len(subdomains))
+		printer.Printf("Amount of subdomains: %d\n", len(subdomains))
 	}
 
 	// Convert them to DNScontrol's native format:
@@ -185,7 +185,7 @@ func (c *APIClient) GetZoneRecords(domain string, meta map[string]string) (model
 		//here seems like a good place to get the records for a subdomain.
 		//fukn ballz tho: each subdomain requires one API call. 💩
 		if c.Debug {
-			fmt.Printf("%s\n", subdomain)
+			printer.Printf("%s\n", subdomain)
 		}
 		//step 2: records for subdomains
 		// Get subdomain records:
@@ -208,7 +208,7 @@ func (c *APIClient) GetZoneRecords(domain string, meta map[string]string) (model
 	}
 
 	if c.Debug {
-		fmt.Printf("length of existingRecords: %d\n", len(existingRecords))
+		printer.Printf("length of existingRecords: %d\n", len(existingRecords))
 	}
 
 	return existingRecords, nil
@@ -0,0 +1,189 @@
+package msdns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+)
+
+// metaRecordAging is the record metadata of the aging of a record:
+// "static" (the default) or "dynamic". Dynamic records have a timestamp
+// and are deleted by the scavenging when they are stale.
+const metaRecordAging = "msdns_aging"
+
+const (
+	agingStatic  = "static"
+	agingDynamic = "dynamic"
+)
+
+// The aging and scavenging settings of the zone set in the domain metadata.
+// The settings which aren't set are left unchanged.
+const (
+	metaZoneAging      = "msdns_zone_aging"      // "true" to scavenge the stale records of the zone.
+	metaRefreshHours   = "msdns_refresh_hours"   // The refresh interval, in hours.
+	metaNoRefreshHours = "msdns_norefresh_hours" // The no-refresh interval, in hours.
+)
+
+// zoneAging is the JSON of Get-DnsServerZoneAging.
+type zoneAging struct {
+	AgingEnabled      bool            `json:"AgingEnabled"`
+	RefreshInterval   ciValueDuration `json:"RefreshInterval"`
+	NoRefreshInterval ciValueDuration `json:"NoRefreshInterval"`
+}
+
+// parseZoneAging decodes the JSON output of generatePSZoneAgingGet.
+func parseZoneAging(contents []byte) (*zoneAging, error) {
+	aging := &zoneAging{}
+	if err := json.Unmarshal(contents, aging); err != nil {
+		return nil, fmt.Errorf("PSZoneAging json error: %w", err)
+	}
+	return aging, nil
+}
+
+func generatePSZoneAgingGet(dnsserver, domain string) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `Get-DnsServerZoneAging`)
+	if dnsserver != "" {
+		fmt.Fprintf(&b, ` -ComputerName "%s"`, dnsserver)
+	}
+	fmt.Fprintf(&b, ` -Name "%s"`, domain)
+	fmt.Fprintf(&b, ` | `)
+	fmt.Fprintf(&b, `ConvertTo-Json`)
+	return b.String()
+}
+
+func generatePSZoneAgingSet(dnsserver, domain string, aging *zoneAging) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `Set-DnsServerZoneAging`)
+	if dnsserver != "" {
+		fmt.Fprintf(&b, ` -ComputerName "%s"`, dnsserver)
+	}
+	fmt.Fprintf(&b, ` -Name "%s"`, domain)
+	fmt.Fprintf(&b, ` -Aging $%t`, aging.AgingEnabled)
+	fmt.Fprintf(&b, ` -RefreshInterval $(New-TimeSpan -Seconds %d)`, uint32(aging.RefreshInterval.TotalSeconds))
+	fmt.Fprintf(&b, ` -NoRefreshInterval $(New-TimeSpan -Seconds %d)`, uint32(aging.NoRefreshInterval.TotalSeconds))
+	return b.String()
+}
+
+// hasZoneAging reports whether the domain metadata sets the aging of the zone.
+func hasZoneAging(metadata map[string]string) bool {
+	for _, k := range []string{metaZoneAging, metaRefreshHours, metaNoRefreshHours} {
+		if _, ok := metadata[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// desiredZoneAging returns the aging of the zone with the settings of the
+// domain metadata, and the names of the settings which are changed.
+func desiredZoneAging(metadata map[string]string, existing *zoneAging) (*zoneAging, []string, error) {
+	desired := *existing
+	var changes []string
+
+	if v, ok := metadata[metaZoneAging]; ok {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s (%s) must be true or false", metaZoneAging, v)
+		}
+		if enabled != existing.AgingEnabled {
+			desired.AgingEnabled = enabled
+			changes = append(changes, fmt.Sprintf("aging (%t -> %t)", existing.AgingEnabled, enabled))
+		}
+	}
+
+	for _, interval := range []struct {
+		key, name string
+		value     *ciValueDuration
+	}{
+		{metaRefreshHours, "refresh", &desired.RefreshInterval},
+		{metaNoRefreshHours, "norefresh", &desired.NoRefreshInterval},
+	} {
+		v, ok := metadata[interval.key]
+		if !ok {
+			continue
+		}
+		hours, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || hours == 0 {
+			return nil, nil, fmt.Errorf("%s (%s) must be a number of hours", interval.key, v)
+		}
+		current := uint64(interval.value.TotalSeconds) / 3600
+		if hours != current {
+			changes = append(changes, fmt.Sprintf("%s (%dh -> %dh)", interval.name, current, hours))
+			interval.value.TotalSeconds = float64(hours * 3600)
+		}
+	}
+
+	return &desired, changes, nil
+}
+
+// getZoneAgingCorrections returns the correction of the aging of the zone,
+// if it differs from the domain metadata.
+func (client *msdnsProvider) getZoneAgingCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if !hasZoneAging(dc.Metadata) {
+		return nil, nil
+	}
+	existing, err := client.shell.GetZoneAging(client.dnsserver, dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	desired, changes, err := desiredZoneAging(dc.Metadata, existing)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return []*models.Correction{{
+		Msg: color.YellowString("± MODIFY %s aging: %s", dc.Name, strings.Join(changes, ", ")),
+		F: func() error {
+			return client.shell.SetZoneAging(client.dnsserver, dc.Name, desired)
+		},
+	}}, nil
+}
+
+// setRecordAging sets the aging of an existing record from its timestamp,
+// which static records don't have.
+func setRecordAging(rc *models.RecordConfig, timestamp json.RawMessage) {
+	if rc.Metadata == nil {
+		rc.Metadata = map[string]string{}
+	}
+	if len(timestamp) == 0 || string(timestamp) == "null" {
+		rc.Metadata[metaRecordAging] = agingStatic
+	} else {
+		rc.Metadata[metaRecordAging] = agingDynamic
+	}
+}
+
+// isDynamic reports whether the record ages.
+func isDynamic(rc *models.RecordConfig) bool {
+	return rc.Metadata[metaRecordAging] == agingDynamic
+}
+
+// checkRecordAging checks the aging of the records, and reports whether
+// one of them sets it. Otherwise the aging of the records is not compared,
+// so that DNSControl doesn't make the dynamic records static.
+func checkRecordAging(dc *models.DomainConfig) (bool, error) {
+	var used bool
+	for _, rc := range dc.Records {
+		v, ok := rc.Metadata[metaRecordAging]
+		if !ok {
+			continue
+		}
+		if v != agingStatic && v != agingDynamic {
+			return false, fmt.Errorf("%s of %s must be %s or %s", metaRecordAging, rc.GetLabelFQDN(), agingStatic, agingDynamic)
+		}
+		used = true
+	}
+	return used, nil
+}
+
+// agingComparable adds the aging of the records to their comparison.
+func agingComparable(rc *models.RecordConfig) string {
+	if isDynamic(rc) {
+		return "aging=" + agingDynamic
+	}
+	return ""
+}
@@ -63,6 +63,7 @@ func nativeToRecords(nr nativeRecord, origin string) (*models.RecordConfig, erro
 	}
 	rc.SetLabel(nr.HostName, origin)
 	rc.TTL = uint32(nr.TimeToLive.TotalSeconds)
+	setRecordAging(rc, nr.Timestamp)
 
 	sprops, uprops, err := extractProps(nr.RecordData.CimInstanceProperties)
 	if err != nil {
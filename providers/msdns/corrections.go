@@ -13,7 +13,16 @@ func (client *msdnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 
 	models.PostProcessRecords(foundRecords)
 
-	changes, err := diff2.ByRecord(foundRecords, dc, nil)
+	usesAging, err := checkRecordAging(dc)
+	if err != nil {
+		return nil, err
+	}
+	var compFunc func(*models.RecordConfig) string
+	if usesAging {
+		compFunc = agingComparable
+	}
+
+	changes, err := diff2.ByRecord(foundRecords, dc, compFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +75,12 @@ func (client *msdnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 		corrections = append(corrections, corr)
 	}
 
+	agingCorrections, err := client.getZoneAgingCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	corrections = append(corrections, agingCorrections...)
+
 	return corrections, nil
 }
 
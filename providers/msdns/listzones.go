@@ -1,5 +1,35 @@
 package msdns
 
+import (
+	"fmt"
+	"strings"
+)
+
+// The storage of a new zone set in the domain metadata, instead of
+// replication_scope and directory_partition of creds.json.
+const (
+	metaReplicationScope   = "msdns_replication_scope"   // The Active Directory replication scope of a new zone.
+	metaDirectoryPartition = "msdns_directory_partition" // The directory partition of a new zone of the Custom replication scope.
+)
+
+// checkReplicationScope checks the replication scope of new zones.
+// Zones without a scope are stored in a zone file.
+func checkReplicationScope(replicationScope, directoryPartition string) error {
+	switch replicationScope {
+	case "", "Forest", "Domain", "Legacy":
+		if directoryPartition != "" {
+			return fmt.Errorf("msdns: a directory partition requires the Custom replication scope")
+		}
+	case "Custom":
+		if directoryPartition == "" {
+			return fmt.Errorf("msdns: the Custom replication scope requires a directory partition")
+		}
+	default:
+		return fmt.Errorf("msdns: invalid replication scope %q (expected Forest, Domain, Legacy or Custom)", replicationScope)
+	}
+	return nil
+}
+
 func (client *msdnsProvider) ListZones() ([]string, error) {
 	zones, err := client.shell.GetDNSServerZoneAll(client.dnsserver)
 	if err != nil {
@@ -7,3 +37,31 @@ func (client *msdnsProvider) ListZones() ([]string, error) {
 	}
 	return zones, err
 }
+
+// EnsureZoneExists creates a zone if it does not exist
+func (client *msdnsProvider) EnsureZoneExists(domain string) error {
+	return client.EnsureZoneExistsWithMetadata(domain, nil)
+}
+
+// EnsureZoneExistsWithMetadata creates a zone if it does not exist, with
+// the replication scope of the domain metadata.
+func (client *msdnsProvider) EnsureZoneExistsWithMetadata(domain string, metadata map[string]string) error {
+	replicationScope, directoryPartition := client.replicationScope, client.directoryPartition
+	if v, ok := metadata[metaReplicationScope]; ok {
+		replicationScope, directoryPartition = v, metadata[metaDirectoryPartition]
+	}
+	if err := checkReplicationScope(replicationScope, directoryPartition); err != nil {
+		return err
+	}
+
+	zones, err := client.shell.GetDNSServerZoneAll(client.dnsserver)
+	if err != nil {
+		return err
+	}
+	for _, z := range zones {
+		if strings.EqualFold(z, domain) {
+			return nil
+		}
+	}
+	return client.shell.ZoneCreate(client.dnsserver, domain, replicationScope, directoryPartition)
+}
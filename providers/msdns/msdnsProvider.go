@@ -16,6 +16,9 @@ type msdnsProvider struct {
 	psusername string      // Remote username for PSSession
 	pspassword string      // Remote password for PSSession
 	shell      DNSAccessor // Handle for
+
+	replicationScope   string // AD replication scope of new zones ("" for file-backed zones)
+	directoryPartition string // AD directory partition of new zones of the Custom replication scope
 }
 
 var features = providers.DocumentationNotes{
@@ -31,7 +34,7 @@ var features = providers.DocumentationNotes{
 	providers.CanUsePTR:              providers.Can(),
 	providers.CanUseSRV:              providers.Can(),
 	providers.CanUseTLSA:             providers.Unimplemented(),
-	providers.DocCreateDomains:       providers.Cannot("This provider assumes the zone already existing on the dns server"),
+	providers.DocCreateDomains:       providers.Can(),
 	providers.DocDualHost:            providers.Cannot("This driver does not manage NS records, so should not be used for dual-host scenarios"),
 	providers.DocOfficiallySupported: providers.Can(),
 }
@@ -48,11 +51,13 @@ func init() {
 	}
 	providers.RegisterDomainServiceProviderType(providerName, fns, features)
 	providers.RegisterMaintainer(providerName, providerMaintainer)
+	providers.RegisterTLSOptions(providerName)
 }
 
 func newDNS(config map[string]string, metadata json.RawMessage) (providers.DNSServiceProvider, error) {
 
-	if runtime.GOOS != "windows" {
+	winrmHost := config["winrm_host"]
+	if winrmHost == "" && runtime.GOOS != "windows" {
 		printer.Println("INFO: MSDNS deactivated. Required OS not detected.")
 		return providers.None{}, nil
 	}
@@ -60,12 +65,22 @@ func newDNS(config map[string]string, metadata json.RawMessage) (providers.DNSSe
 	var err error
 
 	p := &msdnsProvider{
-		dnsserver:  config["dnsserver"],
-		pssession:  config["pssession"],
-		psusername: config["psusername"],
-		pspassword: config["pspassword"],
+		dnsserver:          config["dnsserver"],
+		pssession:          config["pssession"],
+		psusername:         config["psusername"],
+		pspassword:         config["pspassword"],
+		replicationScope:   config["replication_scope"],
+		directoryPartition: config["directory_partition"],
+	}
+	if err := checkReplicationScope(p.replicationScope, p.directoryPartition); err != nil {
+		return nil, err
+	}
+
+	if winrmHost != "" {
+		p.shell, err = newWinRM(config)
+	} else {
+		p.shell, err = newPowerShell(config)
 	}
-	p.shell, err = newPowerShell(config)
 	if err != nil {
 		return nil, err
 	}
@@ -79,10 +79,20 @@ func (psh *psHandle) GetDNSServerZoneAll(dnsserver string) ([]string, error) {
 		return nil, fmt.Errorf("unexpected stderr from Get-DnsServerZones: %q", stderr)
 	}
 
+	return parseZoneAll([]byte(stdout))
+}
+
+// parseZoneAll decodes the JSON output of generatePSZoneAll.
+func parseZoneAll(contents []byte) ([]string, error) {
 	var zones []dnsZone
-	err = json.Unmarshal([]byte(stdout), &zones)
+	err := json.Unmarshal(contents, &zones)
 	if err != nil {
-		return nil, err
+		// A single zone is not wrapped in a list.
+		var zone dnsZone
+		if json.Unmarshal(contents, &zone) != nil {
+			return nil, err
+		}
+		zones = []dnsZone{zone}
 	}
 
 	var result []string
@@ -106,6 +116,72 @@ func generatePSZoneAll(dnsserver string) string {
 	return b.String()
 }
 
+func (psh *psHandle) ZoneCreate(dnsserver, domain, replicationScope, directoryPartition string) error {
+	c := generatePSZoneCreate(dnsserver, domain, replicationScope, directoryPartition)
+	eLog(c)
+	_, stderr, err := psh.shell.Execute("\n\r" + c + "\n\r")
+	if err != nil {
+		printer.Printf("PowerShell code was:\nSTART\n%s\nEND\n", c)
+		return err
+	}
+	if stderr != "" {
+		printer.Printf("STDERROR = %q\n", stderr)
+		printer.Printf("PowerShell code was:\nSTART\n%s\nEND\n", c)
+		return fmt.Errorf("unexpected stderr from PSZoneCreate: %q", stderr)
+	}
+	return nil
+}
+
+// generatePSZoneCreate generates the PowerShell command that creates a
+// primary zone. The zone is stored in Active Directory if replicationScope
+// is set ("Forest", "Domain", "Legacy" or "Custom" with directoryPartition)
+// and in a zone file otherwise.
+func generatePSZoneCreate(dnsserver, domain, replicationScope, directoryPartition string) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `Add-DnsServerPrimaryZone`)
+	if dnsserver != "" {
+		fmt.Fprintf(&b, ` -ComputerName "%s"`, dnsserver)
+	}
+	fmt.Fprintf(&b, ` -Name "%s"`, domain)
+	if replicationScope != "" {
+		fmt.Fprintf(&b, ` -ReplicationScope "%s"`, replicationScope)
+		if directoryPartition != "" {
+			fmt.Fprintf(&b, ` -DirectoryPartitionName "%s"`, directoryPartition)
+		}
+	} else {
+		fmt.Fprintf(&b, ` -ZoneFile "%s.dns"`, domain)
+	}
+	return b.String()
+}
+
+func (psh *psHandle) GetZoneAging(dnsserver, domain string) (*zoneAging, error) {
+	stdout, stderr, err := psh.shell.Execute("\n\r" + generatePSZoneAgingGet(dnsserver, domain) + "\n\r")
+	if err != nil {
+		return nil, err
+	}
+	if stderr != "" {
+		printer.Printf("STDERROR = %q\n", stderr)
+		return nil, fmt.Errorf("unexpected stderr from Get-DnsServerZoneAging: %q", stderr)
+	}
+	return parseZoneAging([]byte(stdout))
+}
+
+func (psh *psHandle) SetZoneAging(dnsserver, domain string, aging *zoneAging) error {
+	c := generatePSZoneAgingSet(dnsserver, domain, aging)
+	eLog(c)
+	_, stderr, err := psh.shell.Execute("\n\r" + c + "\n\r")
+	if err != nil {
+		printer.Printf("PowerShell code was:\nSTART\n%s\nEND\n", c)
+		return err
+	}
+	if stderr != "" {
+		printer.Printf("STDERROR = %q\n", stderr)
+		printer.Printf("PowerShell code was:\nSTART\n%s\nEND\n", c)
+		return fmt.Errorf("unexpected stderr from PSZoneAging: %q", stderr)
+	}
+	return nil
+}
+
 func (psh *psHandle) GetDNSZoneRecords(dnsserver, domain string) ([]nativeRecord, error) {
 
 	tmpfile, err := os.CreateTemp("", "zonerecords.*.json")
@@ -138,8 +214,13 @@ func (psh *psHandle) GetDNSZoneRecords(dnsserver, domain string) ([]nativeRecord
 	//printer.Printf("CONTENTS STR = %q\n", contents[:10])
 	//printer.Printf("CONTENTS HEX = %v\n", []byte(contents)[:10])
 	//os.WriteFile("/temp/list.json", contents, 0777)
+	return parseZoneDump(contents)
+}
+
+// parseZoneDump decodes the JSON output of generatePSZoneDump.
+func parseZoneDump(contents []byte) ([]nativeRecord, error) {
 	var records []nativeRecord
-	err = json.Unmarshal(contents, &records)
+	err := json.Unmarshal(contents, &records)
 	if err != nil {
 		// PowerShell generates bad JSON if there is only one record.  Therefore, if there
 		// is an error we try decoding the bad format before completing erroring out.
@@ -158,7 +239,8 @@ func (psh *psHandle) GetDNSZoneRecords(dnsserver, domain string) ([]nativeRecord
 func generatePSZoneDump(dnsserver, domainname, filename string) string {
 	// @dnsserver: Hostname of the DNS server.
 	// @domainname: Name of the domain.
-	// @filename: Where to write the resulting JSON file.
+	// @filename: Where to write the resulting JSON file. If empty, the
+	// JSON is written to stdout.
 	// NB(tlim): On Windows PowerShell, the JSON file will be UTF8 with
 	// a BOM.  A UTF-8 file shouldn't have a BOM, but Microsoft messed up.
 	// When we switch to PowerShell Core, the BOM will disappear.
@@ -187,12 +269,15 @@ func generatePSZoneDump(dnsserver, domainname, filename string) string {
 	fmt.Fprintf(&b, `Select-Object -Property * -ExcludeProperty Cim*`)
 	fmt.Fprintf(&b, ` | `)
 	fmt.Fprintf(&b, `ConvertTo-Json -depth 4`) // Tested with 3 (causes errors).  4 and larger work.
-	fmt.Fprintf(&b, ` | `)
 
 	// Prevously we captured stdout. Now we write it to a file. This is
 	// safer since there is no chance of junk accidentally being mixed
-	// into stdout.
-	fmt.Fprintf(&b, `Out-File "%s" -Encoding utf8`, filename)
+	// into stdout.  Remote (WinRM) sessions can't share a file with us
+	// and read stdout instead.
+	if filename != "" {
+		fmt.Fprintf(&b, ` | `)
+		fmt.Fprintf(&b, `Out-File "%s" -Encoding utf8`, filename)
+	}
 	return b.String()
 }
 
@@ -298,6 +383,9 @@ func generatePSCreate(dnsserver, domain string, rec *models.RecordConfig) string
 	fmt.Fprintf(&b, ` -ZoneName "%s"`, domain)
 	fmt.Fprintf(&b, ` -Name "%s"`, rec.GetLabel())
 	fmt.Fprintf(&b, ` -TimeToLive $(New-TimeSpan -Seconds %d)`, rec.TTL)
+	if isDynamic(rec) {
+		fmt.Fprintf(&b, ` -AgeRecord`)
+	}
 	switch rec.Type {
 	case "A":
 		fmt.Fprintf(&b, ` -A -IPv4Address "%s"`, rec.GetTargetIP())
@@ -120,6 +120,12 @@ func Test_generatePSModify(t *testing.T) {
 		Name: "@",
 	}
 	recA2.SetTarget("10.20.30.40")
+	recA3 := &models.RecordConfig{
+		Type:     "A",
+		Name:     "@",
+		Metadata: map[string]string{metaRecordAging: agingDynamic},
+	}
+	recA3.SetTarget("10.20.30.40")
 
 	recMX1 := &models.RecordConfig{
 		Type:         "MX",
@@ -148,6 +154,9 @@ func Test_generatePSModify(t *testing.T) {
 		{name: "A", args: args{domain: "example.com", dnsserver: "", old: recA1, rec: recA2},
 			want: `echo DELETE "A" "@" "1.2.3.4" ; Remove-DnsServerResourceRecord -Force -ZoneName "example.com" -Name "@" -RRType "A" -RecordData "1.2.3.4" ; echo CREATE "A" "@" "10.20.30.40" ; Add-DnsServerResourceRecord -ZoneName "example.com" -Name "@" -TimeToLive $(New-TimeSpan -Seconds 0) -A -IPv4Address "10.20.30.40"`,
 		},
+		{name: "A-dynamic", args: args{domain: "example.com", dnsserver: "", old: recA2, rec: recA3},
+			want: `echo DELETE "A" "@" "10.20.30.40" ; Remove-DnsServerResourceRecord -Force -ZoneName "example.com" -Name "@" -RRType "A" -RecordData "10.20.30.40" ; echo CREATE "A" "@" "10.20.30.40" ; Add-DnsServerResourceRecord -ZoneName "example.com" -Name "@" -TimeToLive $(New-TimeSpan -Seconds 0) -AgeRecord -A -IPv4Address "10.20.30.40"`,
+		},
 		{name: "MX1", args: args{domain: "example.com", dnsserver: "", old: recMX1, rec: recMX2},
 			want: `echo DELETE "MX" "@" "5 foo.com." ; Remove-DnsServerResourceRecord -Force -ZoneName "example.com" -Name "@" -RRType "MX" -RecordData 5,"foo.com." ; echo CREATE "MX" "@" "50 foo2.com." ; Add-DnsServerResourceRecord -ZoneName "example.com" -Name "@" -TimeToLive $(New-TimeSpan -Seconds 0) -MX -MailExchange "foo2.com." -Preference 50`,
 		},
@@ -166,3 +175,62 @@ func Test_generatePSModify(t *testing.T) {
 		})
 	}
 }
+
+func Test_generatePSZoneCreate(t *testing.T) {
+	tests := []struct {
+		name               string
+		dnsserver          string
+		replicationScope   string
+		directoryPartition string
+		want               string
+	}{
+		{
+			name: "file",
+			want: `Add-DnsServerPrimaryZone -Name "example.com" -ZoneFile "example.com.dns"`,
+		},
+		{
+			name:             "ad",
+			dnsserver:        "mydnsserver",
+			replicationScope: "Domain",
+			want:             `Add-DnsServerPrimaryZone -ComputerName "mydnsserver" -Name "example.com" -ReplicationScope "Domain"`,
+		},
+		{
+			name:               "custom",
+			replicationScope:   "Custom",
+			directoryPartition: "DnsZones.corp.example.com",
+			want:               `Add-DnsServerPrimaryZone -Name "example.com" -ReplicationScope "Custom" -DirectoryPartitionName "DnsZones.corp.example.com"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := generatePSZoneCreate(tt.dnsserver, "example.com", tt.replicationScope, tt.directoryPartition); got != tt.want {
+				t.Errorf("generatePSZoneCreate() = got=(\n%s\n) want=(\n%s\n)", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generatePSZoneAgingSet(t *testing.T) {
+	existing := &zoneAging{
+		RefreshInterval:   ciValueDuration{TotalSeconds: 168 * 3600},
+		NoRefreshInterval: ciValueDuration{TotalSeconds: 168 * 3600},
+	}
+	metadata := map[string]string{metaZoneAging: "true", metaRefreshHours: "72", metaNoRefreshHours: "168"}
+
+	desired, changes, err := desiredZoneAging(metadata, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "aging (false -> true), refresh (168h -> 72h)"; strings.Join(changes, ", ") != want {
+		t.Errorf("desiredZoneAging() changes = %q, want %q", strings.Join(changes, ", "), want)
+	}
+
+	want := `Set-DnsServerZoneAging -ComputerName "mydnsserver" -Name "example.com" -Aging $true -RefreshInterval $(New-TimeSpan -Seconds 259200) -NoRefreshInterval $(New-TimeSpan -Seconds 604800)`
+	if got := generatePSZoneAgingSet("mydnsserver", "example.com", desired); got != want {
+		t.Errorf("generatePSZoneAgingSet() = got=(\n%s\n) want=(\n%s\n)", got, want)
+	}
+
+	if _, _, err := desiredZoneAging(map[string]string{metaRefreshHours: "1w"}, existing); err == nil {
+		t.Errorf("desiredZoneAging() expected an error for an invalid interval")
+	}
+}
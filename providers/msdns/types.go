@@ -11,6 +11,9 @@ type DNSAccessor interface {
 	Exit()
 	GetDNSServerZoneAll(dnsserver string) ([]string, error)
 	GetDNSZoneRecords(dnsserver, domain string) ([]nativeRecord, error)
+	ZoneCreate(dnsserver, domain, replicationScope, directoryPartition string) error
+	GetZoneAging(dnsserver, domain string) (*zoneAging, error)
+	SetZoneAging(dnsserver, domain string, aging *zoneAging) error
 	RecordCreate(dnsserver, domain string, rec *models.RecordConfig) error
 	RecordDelete(dnsserver, domain string, rec *models.RecordConfig) error
 	RecordModify(dnsserver, domain string, old, rec *models.RecordConfig) error
@@ -33,6 +36,7 @@ type nativeRecord struct {
 	TimeToLive struct {
 		TotalSeconds float64 `json:"TotalSeconds"`
 	} `json:"TimeToLive"`
+	Timestamp json.RawMessage `json:"Timestamp"` // null for static records
 }
 
 type ciProperty struct {
@@ -0,0 +1,164 @@
+package msdns
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
+	"github.com/StackExchange/dnscontrol/v4/pkg/winrm"
+)
+
+// winrmHandle runs the PowerShell commands on a remote Windows host
+// through WinRM. Unlike psHandle it doesn't require DNSControl to run on
+// Windows.
+type winrmHandle struct {
+	client *winrm.Client
+}
+
+// psPreamble silences progress records and makes PowerShell write UTF-8.
+const psPreamble = `$ProgressPreference = 'SilentlyContinue' ; [Console]::OutputEncoding = [Text.Encoding]::UTF8 ; `
+
+func newWinRM(config map[string]string) (*winrmHandle, error) {
+	host := config["winrm_host"]
+	port := config["winrm_port"]
+	if port == "" {
+		port = "5986"
+	}
+
+	tlsConfig, err := tlsconfig.New(config)
+	if err != nil {
+		return nil, fmt.Errorf("msdns: %w", err)
+	}
+
+	var ntlm bool
+	switch auth := strings.ToLower(config["winrm_auth"]); auth {
+	case "", "ntlm":
+		ntlm = true
+	case "basic":
+	default:
+		return nil, fmt.Errorf("msdns: unknown winrm_auth %q (expected ntlm or basic)", auth)
+	}
+
+	scheme := "https"
+	if v := config["winrm_https"]; v != "" {
+		https, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("msdns: invalid winrm_https %q: %w", v, err)
+		}
+		if !https {
+			if !ntlm {
+				return nil, fmt.Errorf("msdns: basic authentication requires HTTPS")
+			}
+			scheme = "http"
+		}
+	}
+	if scheme == "http" {
+		printer.Warnf("MSDNS: WinRM over HTTP is not encrypted; use HTTPS if possible\n")
+	}
+
+	endpoint := url.URL{Scheme: scheme, Host: net.JoinHostPort(host, port), Path: "/wsman"}
+	printer.Printf("INFO: PowerShell commands will run on %q through WinRM\n", host)
+
+	return &winrmHandle{
+		client: winrm.New(winrm.Config{
+			Endpoint:  endpoint.String(),
+			Username:  config["winrm_username"],
+			Password:  config["winrm_password"],
+			NTLM:      ntlm,
+			TLSConfig: tlsConfig,
+		}),
+	}, nil
+}
+
+// run executes a PowerShell command. Output on stderr or a non-zero exit
+// code is treated as an error.
+func (w *winrmHandle) run(what, c string) (string, error) {
+	eLog(c)
+	stdout, stderr, code, err := w.client.RunPowerShell(psPreamble + c)
+	if err != nil {
+		printer.Printf("PowerShell code was:\nSTART\n%s\nEND\n", c)
+		return "", err
+	}
+	if stderr != "" || code != 0 {
+		printer.Printf("STDERROR = %q\n", stderr)
+		printer.Printf("PowerShell code was:\nSTART\n%s\nEND\n", c)
+		return "", fmt.Errorf("unexpected stderr from %s (exit code %d): %q", what, code, stderr)
+	}
+	return stdout, nil
+}
+
+func (w *winrmHandle) Exit() {}
+
+func (w *winrmHandle) GetDNSServerZoneAll(dnsserver string) ([]string, error) {
+	stdout, err := w.run("Get-DnsServerZones", generatePSZoneAll(dnsserver))
+	if err != nil {
+		return nil, err
+	}
+	return parseZoneAll([]byte(stdout))
+}
+
+func (w *winrmHandle) GetDNSZoneRecords(dnsserver, domain string) ([]nativeRecord, error) {
+	stdout, err := w.run("PSZoneDump", generatePSZoneDump(dnsserver, domain, ""))
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(stdout) == "" {
+		return nil, nil
+	}
+	return parseZoneDump([]byte(stdout))
+}
+
+func (w *winrmHandle) ZoneCreate(dnsserver, domain, replicationScope, directoryPartition string) error {
+	_, err := w.run("PSZoneCreate", generatePSZoneCreate(dnsserver, domain, replicationScope, directoryPartition))
+	return err
+}
+
+func (w *winrmHandle) GetZoneAging(dnsserver, domain string) (*zoneAging, error) {
+	stdout, err := w.run("Get-DnsServerZoneAging", generatePSZoneAgingGet(dnsserver, domain))
+	if err != nil {
+		return nil, err
+	}
+	return parseZoneAging([]byte(stdout))
+}
+
+func (w *winrmHandle) SetZoneAging(dnsserver, domain string, aging *zoneAging) error {
+	_, err := w.run("PSZoneAging", generatePSZoneAgingSet(dnsserver, domain, aging))
+	return err
+}
+
+func (w *winrmHandle) RecordCreate(dnsserver, domain string, rec *models.RecordConfig) error {
+	var c string
+	if rec.Type == "NAPTR" {
+		c = generatePSCreateNaptr(dnsserver, domain, rec)
+	} else {
+		c = generatePSCreate(dnsserver, domain, rec)
+	}
+	_, err := w.run("PSCreate", c)
+	return err
+}
+
+func (w *winrmHandle) RecordDelete(dnsserver, domain string, rec *models.RecordConfig) error {
+	var c string
+	if rec.Type == "NAPTR" {
+		c = generatePSDeleteNaptr(dnsserver, domain, rec)
+	} else {
+		c = generatePSDelete(dnsserver, domain, rec)
+	}
+	_, err := w.run("PSDelete", c)
+	return err
+}
+
+func (w *winrmHandle) RecordModify(dnsserver, domain string, old, rec *models.RecordConfig) error {
+	_, err := w.run("PSModify", generatePSModify(dnsserver, domain, old, rec))
+	return err
+}
+
+func (w *winrmHandle) RecordModifyTTL(dnsserver, domain string, old *models.RecordConfig, newTTL uint32) error {
+	_, err := w.run("PSModify", generatePSModifyTTL(dnsserver, domain, old, newTTL))
+	return err
+}
@@ -0,0 +1,173 @@
+package namecheap
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	nc "github.com/billputer/go-namecheap"
+)
+
+// The email types of a domain managed by the provider. setHosts resets
+// the email type of the domain if it isn't set.
+const (
+	emailTypeFWD = "FWD" // Email forwarding, the NAMECHEAP_EMAILFWD records.
+	emailTypeMX  = "MX"  // The MX records.
+)
+
+// hostsResult is the result of getHosts, with the email type.
+type hostsResult struct {
+	nc.DomainDNSGetHostsResult
+	EmailType string `xml:"EmailType,attr"`
+}
+
+type emailForward struct {
+	Mailbox string `xml:"mailbox,attr"`
+	Address string `xml:",chardata"`
+}
+
+type emailForwardingResult struct {
+	Domain   string         `xml:"Domain,attr"`
+	Forwards []emailForward `xml:"Forward"`
+}
+
+// apiResponse is the response of the calls go-namecheap doesn't implement.
+type apiResponse struct {
+	Status     string                 `xml:"Status,attr"`
+	Errors     nc.ApiErrors           `xml:"Errors>Error"`
+	Hosts      *hostsResult           `xml:"CommandResponse>DomainDNSGetHostsResult"`
+	Forwarding *emailForwardingResult `xml:"CommandResponse>DomainDNSGetEmailForwardingResult"`
+}
+
+// call calls the API like go-namecheap, whose errors doWithRetry
+// recognizes.
+func (n *namecheapProvider) call(command string, params url.Values) (*apiResponse, error) {
+	params.Set("ApiUser", n.client.ApiUser)
+	params.Set("ApiKey", n.client.ApiToken)
+	params.Set("UserName", n.client.UserName)
+	params.Set("ClientIp", n.client.ClientIp)
+	params.Set("Command", command)
+
+	response, err := n.client.HttpClient.PostForm(n.client.BaseURL, params)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from api: %d", response.StatusCode)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &apiResponse{}
+	if err := xml.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	if result.Status == "" {
+		return nil, errors.New("failed to parse xml from api")
+	}
+	if result.Status == "ERROR" {
+		return nil, result.Errors
+	}
+	return result, nil
+}
+
+// getHosts returns the records of the domain and its email type.
+func (n *namecheapProvider) getHosts(sld, tld string) (*hostsResult, error) {
+	result, err := n.call("namecheap.domains.dns.getHosts", url.Values{"SLD": {sld}, "TLD": {tld}})
+	if err != nil {
+		return nil, err
+	}
+	if result.Hosts == nil {
+		return nil, fmt.Errorf("no hosts in the response of getHosts for %s.%s", sld, tld)
+	}
+	return result.Hosts, nil
+}
+
+// setHosts replaces the records of the domain, and sets its email type
+// unless it is empty.
+func (n *namecheapProvider) setHosts(sld, tld string, hosts []nc.DomainDNSHost, emailType string) error {
+	params := url.Values{"SLD": {sld}, "TLD": {tld}}
+	for i, h := range hosts {
+		params.Set(fmt.Sprintf("HostName%d", i+1), h.Name)
+		params.Set(fmt.Sprintf("RecordType%d", i+1), h.Type)
+		params.Set(fmt.Sprintf("Address%d", i+1), h.Address)
+		if h.Type == "MX" {
+			params.Set(fmt.Sprintf("MXPref%d", i+1), strconv.Itoa(h.MXPref))
+		}
+		params.Set(fmt.Sprintf("TTL%d", i+1), strconv.Itoa(h.TTL))
+	}
+	if emailType != "" {
+		params.Set("EmailType", emailType)
+	}
+	_, err := n.call("namecheap.domains.dns.setHosts", params)
+	return err
+}
+
+// getEmailForwarding returns the email forwarding of the domain.
+func (n *namecheapProvider) getEmailForwarding(domain string) ([]emailForward, error) {
+	result, err := n.call("namecheap.domains.dns.getEmailForwarding", url.Values{"DomainName": {domain}})
+	if err != nil {
+		return nil, err
+	}
+	if result.Forwarding == nil {
+		return nil, nil
+	}
+	return result.Forwarding.Forwards, nil
+}
+
+// setEmailForwarding replaces the email forwarding of the domain.
+func (n *namecheapProvider) setEmailForwarding(domain string, forwards []emailForward) error {
+	params := url.Values{"DomainName": {domain}}
+	for i, f := range forwards {
+		params.Set(fmt.Sprintf("MailBox%d", i+1), f.Mailbox)
+		params.Set(fmt.Sprintf("ForwardTo%d", i+1), f.Address)
+	}
+	_, err := n.call("namecheap.domains.dns.setEmailForwarding", params)
+	return err
+}
+
+// forwardsToRecords converts the email forwarding to NAMECHEAP_EMAILFWD
+// records: the label is the mailbox and the target the address.
+func forwardsToRecords(forwards []emailForward, origin string) models.Records {
+	var records models.Records
+	for _, f := range forwards {
+		rc := &models.RecordConfig{Type: "NAMECHEAP_EMAILFWD", Original: f}
+		rc.SetLabel(f.Mailbox, origin)
+		rc.SetTarget(f.Address)
+		records = append(records, rc)
+	}
+	return records
+}
+
+// checkEmailForwarding checks the NAMECHEAP_EMAILFWD records, which have
+// no TTL, and returns the email type of the domain.
+func checkEmailForwarding(dc *models.DomainConfig) (string, error) {
+	var emailType string
+	for _, rc := range dc.Records {
+		switch rc.Type {
+		case "MX":
+			if emailType == emailTypeFWD {
+				return "", fmt.Errorf("%s: Namecheap can't use MX records and NAMECHEAP_EMAILFWD records together", dc.Name)
+			}
+			emailType = emailTypeMX
+		case "NAMECHEAP_EMAILFWD":
+			if emailType == emailTypeMX {
+				return "", fmt.Errorf("%s: Namecheap can't use MX records and NAMECHEAP_EMAILFWD records together", dc.Name)
+			}
+			if rc.GetLabel() == "@" {
+				return "", fmt.Errorf("%s: the mailbox of NAMECHEAP_EMAILFWD %s is missing", dc.Name, rc.GetTargetField())
+			}
+			emailType = emailTypeFWD
+			rc.TTL = 0
+		}
+	}
+	return emailType, nil
+}
@@ -53,6 +53,7 @@ func init() {
 	providers.RegisterCustomRecordType("URL", providerName, "")
 	providers.RegisterCustomRecordType("URL301", providerName, "")
 	providers.RegisterCustomRecordType("FRAME", providerName, "")
+	providers.RegisterCustomRecordType("NAMECHEAP_EMAILFWD", providerName, "")
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
@@ -120,16 +121,28 @@ func doWithRetry(f func() error) {
 // GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
 func (n *namecheapProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
 	sld, tld := splitDomain(domain)
-	var records *nc.DomainDNSGetHostsResult
+	var records *hostsResult
 	var err error
 	doWithRetry(func() error {
-		records, err = n.client.DomainsDNSGetHosts(sld, tld)
+		records, err = n.getHosts(sld, tld)
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// The email forwarding is only used with its email type.
+	var forwards []emailForward
+	if records.EmailType == emailTypeFWD {
+		doWithRetry(func() error {
+			forwards, err = n.getEmailForwarding(domain)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// namecheap has this really annoying feature where they add some parking records if you have no records.
 	// This causes a few problems for our purposes, specifically the integration tests.
 	// lets detect that one case and pretend it is a no-op.
@@ -138,7 +151,7 @@ func (n *namecheapProvider) GetZoneRecords(domain string, meta map[string]string
 			strings.Contains(records.Hosts[0].Address, "parkingpage") &&
 			records.Hosts[1].Type == "URL" {
 			// return an empty zone
-			return nil, nil
+			return forwardsToRecords(forwards, domain), nil
 		}
 	}
 
@@ -166,7 +179,11 @@ func (n *namecheapProvider) GetZoneRecords(domain string, meta map[string]string
 	// 		actual = append(actual, rec)
 	// 	}
 
-	return toRecords(records, domain)
+	existing, err := toRecords(&records.DomainDNSGetHostsResult, domain)
+	if err != nil {
+		return nil, err
+	}
+	return append(existing, forwardsToRecords(forwards, domain)...), nil
 }
 
 // // GetDomainCorrections returns the corrections for the domain.
@@ -249,6 +266,11 @@ func (n *namecheapProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, a
 		return true
 	})
 
+	emailType, err := checkEmailForwarding(dc)
+	if err != nil {
+		return nil, err
+	}
+
 	toReport, create, delete, modify, err := diff.NewCompat(dc).IncrementalDiff(actual)
 	if err != nil {
 		return nil, err
@@ -278,7 +300,7 @@ func (n *namecheapProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, a
 			&models.Correction{
 				Msg: msg,
 				F: func() error {
-					return n.generateRecords(dc)
+					return n.generateRecords(dc, emailType)
 				},
 			})
 	}
@@ -310,12 +332,17 @@ func toRecords(result *nc.DomainDNSGetHostsResult, origin string) ([]*models.Rec
 	return records, nil
 }
 
-func (n *namecheapProvider) generateRecords(dc *models.DomainConfig) error {
+func (n *namecheapProvider) generateRecords(dc *models.DomainConfig, emailType string) error {
 
 	var recs []nc.DomainDNSHost
+	var forwards []emailForward
 
 	id := 1
 	for _, r := range dc.Records {
+		if r.Type == "NAMECHEAP_EMAILFWD" {
+			forwards = append(forwards, emailForward{Mailbox: r.GetLabel(), Address: r.GetTargetField()})
+			continue
+		}
 		var value string
 		switch rtype := r.Type; rtype { // #rtype_variations
 		case "CAA":
@@ -338,7 +365,14 @@ func (n *namecheapProvider) generateRecords(dc *models.DomainConfig) error {
 	sld, tld := splitDomain(dc.Name)
 	var err error
 	doWithRetry(func() error {
-		_, err = n.client.DomainDNSSetHosts(sld, tld, recs)
+		err = n.setHosts(sld, tld, recs, emailType)
+		return err
+	})
+	if err != nil || len(forwards) == 0 {
+		return err
+	}
+	doWithRetry(func() error {
+		err = n.setEmailForwarding(dc.Name, forwards)
 		return err
 	})
 	return err
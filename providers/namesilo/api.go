@@ -0,0 +1,218 @@
+package namesilo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	apiURL      = "https://www.namesilo.com/api/"
+	batchURL    = "https://www.namesilo.com/apibatch/"
+	sandboxURL  = "https://sandbox.namesilo.com/api/"
+	apiVersion  = "1"
+	maxNSPerReq = 13
+)
+
+type namesiloProvider struct {
+	key     string
+	baseURL string
+}
+
+// replyStatus is the part of the reply common to all operations.
+// Codes 300 to 302 are successes, anything else is an error.
+type replyStatus struct {
+	Code   int    `xml:"code"`
+	Detail string `xml:"detail"`
+}
+
+type response struct {
+	Reply struct {
+		replyStatus
+		Inner []byte `xml:",innerxml"`
+	} `xml:"reply"`
+}
+
+type resourceRecord struct {
+	RecordID string `xml:"record_id"`
+	Type     string `xml:"type"`
+	Host     string `xml:"host"`
+	Value    string `xml:"value"`
+	TTL      uint32 `xml:"ttl"`
+	Distance uint16 `xml:"distance"`
+}
+
+type dsRecord struct {
+	Digest     string `xml:"digest"`
+	DigestType uint8  `xml:"digest_type"`
+	Algorithm  uint8  `xml:"algorithm"`
+	KeyTag     uint16 `xml:"key_tag"`
+}
+
+// call invokes an API operation and decodes the reply into target.
+func (c *namesiloProvider) call(operation string, params url.Values, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("version", apiVersion)
+	params.Set("type", "xml")
+	params.Set("key", c.key)
+	u := c.baseURL + operation + "?" + params.Encode()
+
+retry:
+	resp, err := http.Get(u)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("NameSilo rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("namesilo API error: %s: %s", resp.Status, string(body))
+	}
+
+	var r response
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return fmt.Errorf("namesilo API error: %s: %s", resp.Status, string(body))
+	}
+	if r.Reply.Code < 300 || r.Reply.Code > 302 {
+		return fmt.Errorf("namesilo API error: %d: %s", r.Reply.Code, r.Reply.Detail)
+	}
+
+	if target == nil {
+		return nil
+	}
+	// The reply element carries the operation specific fields.
+	return xml.Unmarshal(append(append([]byte("<reply>"), r.Reply.Inner...), "</reply>"...), target)
+}
+
+func (c *namesiloProvider) listDomains() ([]string, error) {
+	var reply struct {
+		Domains []string `xml:"domains>domain"`
+	}
+	if err := c.call("listDomains", nil, &reply); err != nil {
+		return nil, fmt.Errorf("failed listing domains from namesilo: %w", err)
+	}
+	return reply.Domains, nil
+}
+
+func (c *namesiloProvider) getNameservers(domain string) ([]string, error) {
+	var reply struct {
+		Nameservers []string `xml:"nameservers>nameserver"`
+	}
+	if err := c.call("getDomainInfo", url.Values{"domain": {domain}}, &reply); err != nil {
+		return nil, fmt.Errorf("failed fetching nameservers from namesilo: %w", err)
+	}
+	return reply.Nameservers, nil
+}
+
+func (c *namesiloProvider) updateNameservers(domain string, ns []string) error {
+	if len(ns) > maxNSPerReq {
+		return fmt.Errorf("namesilo accepts at most %d nameservers, got %d", maxNSPerReq, len(ns))
+	}
+	params := url.Values{"domain": {domain}}
+	for i, n := range ns {
+		params.Set("ns"+strconv.Itoa(i+1), n)
+	}
+	if err := c.call("changeNameServers", params, nil); err != nil {
+		return fmt.Errorf("failed updating nameservers (namesilo): %w", err)
+	}
+	return nil
+}
+
+func (c *namesiloProvider) getRecords(domain string) ([]resourceRecord, error) {
+	var reply struct {
+		Records []resourceRecord `xml:"resource_record"`
+	}
+	if err := c.call("dnsListRecords", url.Values{"domain": {domain}}, &reply); err != nil {
+		return nil, fmt.Errorf("failed fetching record list from namesilo: %w", err)
+	}
+	return reply.Records, nil
+}
+
+func recordParams(domain string, rec *resourceRecord) url.Values {
+	return url.Values{
+		"domain":     {domain},
+		"rrhost":     {rec.Host},
+		"rrvalue":    {rec.Value},
+		"rrdistance": {strconv.Itoa(int(rec.Distance))},
+		"rrttl":      {strconv.Itoa(int(rec.TTL))},
+	}
+}
+
+func (c *namesiloProvider) createRecord(domain string, rec *resourceRecord) error {
+	params := recordParams(domain, rec)
+	params.Set("rrtype", rec.Type)
+	if err := c.call("dnsAddRecord", params, nil); err != nil {
+		return fmt.Errorf("failed create record (namesilo): %w", err)
+	}
+	return nil
+}
+
+func (c *namesiloProvider) updateRecord(domain string, rec *resourceRecord) error {
+	params := recordParams(domain, rec)
+	params.Set("rrid", rec.RecordID)
+	if err := c.call("dnsUpdateRecord", params, nil); err != nil {
+		return fmt.Errorf("failed update record (namesilo): %w", err)
+	}
+	return nil
+}
+
+func (c *namesiloProvider) deleteRecord(domain, id string) error {
+	if err := c.call("dnsDeleteRecord", url.Values{"domain": {domain}, "rrid": {id}}, nil); err != nil {
+		return fmt.Errorf("failed delete record (namesilo): %w", err)
+	}
+	return nil
+}
+
+func (c *namesiloProvider) getDS(domain string) ([]dsRecord, error) {
+	var reply struct {
+		Records []dsRecord `xml:"ds_record"`
+	}
+	if err := c.call("dnsSecListRecords", url.Values{"domain": {domain}}, &reply); err != nil {
+		return nil, fmt.Errorf("failed fetching DS records from namesilo: %w", err)
+	}
+	return reply.Records, nil
+}
+
+func dsParams(domain string, ds *dsRecord) url.Values {
+	return url.Values{
+		"domain":     {domain},
+		"digest":     {ds.Digest},
+		"keyTag":     {strconv.Itoa(int(ds.KeyTag))},
+		"digestType": {strconv.Itoa(int(ds.DigestType))},
+		"alg":        {strconv.Itoa(int(ds.Algorithm))},
+	}
+}
+
+func (c *namesiloProvider) createDS(domain string, ds *dsRecord) error {
+	if err := c.call("dnsSecAddRecord", dsParams(domain, ds), nil); err != nil {
+		return fmt.Errorf("failed create DS record (namesilo): %w", err)
+	}
+	return nil
+}
+
+func (c *namesiloProvider) deleteDS(domain string, ds *dsRecord) error {
+	if err := c.call("dnsSecDeleteRecord", dsParams(domain, ds), nil); err != nil {
+		return fmt.Errorf("failed delete DS record (namesilo): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package namesilo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// minTTL is the lowest TTL accepted by NameSilo.
+const minTTL = 3600
+
+func fixTTL(ttl uint32) uint32 {
+	if ttl < minTTL {
+		return minTTL
+	}
+	return ttl
+}
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRc converts a NameSilo record into a RecordConfig.
+// The API returns the host as a FQDN.
+func toRc(domain string, r *resourceRecord) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+	}
+	rc.SetLabelFromFQDN(r.Host, domain)
+
+	var err error
+	switch r.Type {
+	case "A", "AAAA":
+		err = rc.SetTarget(r.Value)
+	case "CNAME":
+		err = rc.SetTarget(dot(r.Value))
+	case "MX":
+		err = rc.SetTargetMX(r.Distance, dot(r.Value))
+	case "SRV":
+		// The value is "weight port target", the priority is the distance.
+		err = rc.SetTargetSRVPriorityString(r.Distance, r.Value)
+		if err == nil {
+			err = rc.SetTarget(dot(rc.GetTargetField()))
+		}
+	case "TXT":
+		err = rc.SetTargetTXT(r.Value)
+	case "CAA":
+		err = rc.PopulateFromString(r.Type, r.Value, domain)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+// toRecord converts a RecordConfig into a NameSilo record.
+// The host is sent relative to the domain, the apex being empty.
+func toRecord(rc *models.RecordConfig) *resourceRecord {
+	r := &resourceRecord{
+		Type: rc.Type,
+		TTL:  fixTTL(rc.TTL),
+	}
+	if label := rc.GetLabel(); label != "@" {
+		r.Host = label
+	}
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "MX":
+		r.Distance = rc.MxPreference
+		r.Value = target
+	case "SRV":
+		r.Distance = rc.SrvPriority
+		r.Value = fmt.Sprintf("%d %d %s", rc.SrvWeight, rc.SrvPort, target)
+	case "TXT":
+		r.Value = rc.GetTargetTXTJoined()
+	case "CAA":
+		r.Value = rc.GetTargetCombined()
+	default:
+		r.Value = target
+	}
+	return r
+}
@@ -0,0 +1,6 @@
+package namesilo
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *namesiloProvider) ListZones() ([]string, error) {
+	return c.listDomains()
+}
@@ -0,0 +1,262 @@
+package namesilo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for NameSilo.
+// API Documentation: https://www.namesilo.com/api-reference
+
+/*
+NameSilo provider:
+
+Info required in `creds.json`:
+   - api_key
+   - batch (optional) "true" to use the batch API server
+   - sandbox (optional) "true" to use the sandbox API server
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSAtRegistrar:    providers.Can(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Cannot(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{
+	"ns1.dnsowl.com",
+	"ns2.dnsowl.com",
+	"ns3.dnsowl.com",
+}
+
+func init() {
+	const providerName = "NAMESILO"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	providers.RegisterRegistrarType(providerName, newReg)
+	fns := providers.DspFuncs{
+		Initializer:   newDsp,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+func newReg(conf map[string]string) (providers.Registrar, error) {
+	return newNamesilo(conf)
+}
+
+func newDsp(conf map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	return newNamesilo(conf)
+}
+
+// newNamesilo creates the provider.
+func newNamesilo(m map[string]string) (*namesiloProvider, error) {
+	c := &namesiloProvider{
+		key:     m["api_key"],
+		baseURL: apiURL,
+	}
+	if c.key == "" {
+		return nil, fmt.Errorf("missing NAMESILO api_key")
+	}
+	switch {
+	case m["sandbox"] == "true":
+		c.baseURL = sandboxURL
+	case m["batch"] == "true":
+		c.baseURL = batchURL
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *namesiloProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *namesiloProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		rc, err := toRc(domain, &records[i])
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *namesiloProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	for _, rc := range dc.Records {
+		rc.TTL = fixTTL(rc.TTL)
+	}
+
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			rec := toRecord(change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(dc.Name, rec)
+				},
+			}
+		case diff2.CHANGE:
+			rec := toRecord(change.New[0])
+			rec.RecordID = change.Old[0].Original.(*resourceRecord).RecordID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, NameSilo ID: %s", change.Msgs[0], rec.RecordID),
+				F: func() error {
+					return c.updateRecord(dc.Name, rec)
+				},
+			}
+		case diff2.DELETE:
+			id := change.Old[0].Original.(*resourceRecord).RecordID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, NameSilo ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.deleteRecord(dc.Name, id)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
+
+// GetRegistrarCorrections returns a list of corrections for this registrar.
+func (c *namesiloProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	nss, err := c.getNameservers(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	foundNameservers := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		foundNameservers = append(foundNameservers, strings.ToLower(strings.TrimSuffix(ns, ".")))
+	}
+	sort.Strings(foundNameservers)
+
+	expected := make([]string, 0, len(dc.Nameservers))
+	for _, ns := range dc.Nameservers {
+		expected = append(expected, ns.Name)
+	}
+	sort.Strings(expected)
+
+	var corrections []*models.Correction
+	foundStr := strings.Join(foundNameservers, ",")
+	expectedStr := strings.Join(expected, ",")
+	if foundStr != expectedStr {
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundStr, expectedStr),
+			F: func() error {
+				return c.updateNameservers(dc.Name, expected)
+			},
+		})
+	}
+
+	dsCorrections, err := c.getDSCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, dsCorrections...), nil
+}
+
+func dsString(ds *dsRecord) string {
+	return fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, strings.ToUpper(ds.Digest))
+}
+
+// getDSCorrections returns the corrections that publish dc.RegistrarDS.
+// NameSilo cannot update a DS record, so changes are a deletion and a creation.
+// The DS records at the registry are left untouched if there are none in
+// dnsconfig.js nor reported by the DNS providers.
+func (c *namesiloProvider) getDSCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if len(dc.RegistrarDS) == 0 {
+		return nil, nil
+	}
+	existing, err := c.getDS(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	for i := range existing {
+		found[dsString(&existing[i])] = true
+	}
+	wanted := map[string]bool{}
+
+	var corrections []*models.Correction
+	for _, rc := range dc.RegistrarDS {
+		ds := &dsRecord{
+			KeyTag:     rc.DsKeyTag,
+			Algorithm:  rc.DsAlgorithm,
+			DigestType: rc.DsDigestType,
+			Digest:     rc.DsDigest,
+		}
+		key := dsString(ds)
+		wanted[key] = true
+		if found[key] {
+			continue
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Add DS (%s)", key),
+			F: func() error {
+				return c.createDS(dc.Name, ds)
+			},
+		})
+	}
+	for i := range existing {
+		ds := &existing[i]
+		key := dsString(ds)
+		if wanted[key] {
+			continue
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Remove DS (%s)", key),
+			F: func() error {
+				return c.deleteDS(dc.Name, ds)
+			},
+		})
+	}
+	return corrections, nil
+}
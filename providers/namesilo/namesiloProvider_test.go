@@ -0,0 +1,26 @@
+package namesilo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestGetDSCorrectionsNoneDeclared(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer srv.Close()
+
+	// The DS records set at NameSilo are kept.
+	c := &namesiloProvider{baseURL: srv.URL + "/"}
+	corrections, err := c.getDSCorrections(&models.DomainConfig{Name: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 0 {
+		t.Errorf("got %d corrections, want none", len(corrections))
+	}
+}
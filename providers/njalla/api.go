@@ -0,0 +1,170 @@
+package njalla
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const apiURL = "https://njal.la/api/1/"
+
+type njallaProvider struct {
+	token string
+}
+
+type apiRequest struct {
+	Method string `json:"method"`
+	Params any    `json:"params"`
+}
+
+type apiResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type domain struct {
+	Name        string   `json:"name"`
+	Status      string   `json:"status"`
+	Nameservers []string `json:"nameservers"`
+}
+
+type record struct {
+	ID      json.Number `json:"id,omitempty"`
+	Domain  string      `json:"domain,omitempty"`
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Content string      `json:"content"`
+	TTL     uint32      `json:"ttl"`
+	Prio    *uint16     `json:"prio,omitempty"`
+	Weight  *uint16     `json:"weight,omitempty"`
+	Port    *uint16     `json:"port,omitempty"`
+}
+
+// call invokes an API method and decodes the result into target.
+func (c *njallaProvider) call(method string, params, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	payload, err := json.Marshal(apiRequest{Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+retry:
+	req, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Njalla "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("Njalla rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+
+	var ar apiResponse
+	if err := json.Unmarshal(body, &ar); err != nil {
+		return fmt.Errorf("njalla API error: %s: %s", resp.Status, string(body))
+	}
+	if ar.Error != nil {
+		return fmt.Errorf("njalla API error: %s (code %d)", ar.Error.Message, ar.Error.Code)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("njalla API error: %s: %s", resp.Status, string(body))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(ar.Result, target)
+}
+
+func (c *njallaProvider) listDomains() ([]string, error) {
+	var resp struct {
+		Domains []domain `json:"domains"`
+	}
+	if err := c.call("list-domains", map[string]any{}, &resp); err != nil {
+		return nil, fmt.Errorf("failed listing domains from njalla: %w", err)
+	}
+	domains := make([]string, 0, len(resp.Domains))
+	for _, d := range resp.Domains {
+		domains = append(domains, d.Name)
+	}
+	sort.Strings(domains)
+	return domains, nil
+}
+
+func (c *njallaProvider) getDomain(name string) (*domain, error) {
+	var d domain
+	if err := c.call("get-domain", map[string]any{"domain": name}, &d); err != nil {
+		return nil, fmt.Errorf("failed fetching domain from njalla: %w", err)
+	}
+	return &d, nil
+}
+
+func (c *njallaProvider) updateNameservers(name string, nameservers []string) error {
+	if err := c.call("edit-domain", map[string]any{
+		"domain":      name,
+		"nameservers": nameservers,
+	}, nil); err != nil {
+		return fmt.Errorf("failed updating nameservers (njalla): %w", err)
+	}
+	return nil
+}
+
+func (c *njallaProvider) getRecords(domain string) ([]record, error) {
+	var resp struct {
+		Records []record `json:"records"`
+	}
+	if err := c.call("list-records", map[string]any{"domain": domain}, &resp); err != nil {
+		return nil, fmt.Errorf("failed fetching record list from njalla: %w", err)
+	}
+	return resp.Records, nil
+}
+
+func (c *njallaProvider) createRecord(rec *record) error {
+	if err := c.call("add-record", rec, nil); err != nil {
+		return fmt.Errorf("failed create record (njalla): %w", err)
+	}
+	return nil
+}
+
+func (c *njallaProvider) updateRecord(rec *record) error {
+	if err := c.call("edit-record", rec, nil); err != nil {
+		return fmt.Errorf("failed update record (njalla): %w", err)
+	}
+	return nil
+}
+
+func (c *njallaProvider) deleteRecord(domain string, id json.Number) error {
+	if err := c.call("remove-record", map[string]any{
+		"domain": domain,
+		"id":     id,
+	}, nil); err != nil {
+		return fmt.Errorf("failed delete record (njalla): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package njalla
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// TTLs accepted by Njalla.
+var allowedTTLs = []uint32{60, 300, 900, 3600, 10800, 21600, 86400}
+
+// fixTTL rounds ttl up to the next TTL accepted by Njalla.
+func fixTTL(ttl uint32) uint32 {
+	for _, t := range allowedTTLs {
+		if ttl <= t {
+			return t
+		}
+	}
+	return allowedTTLs[len(allowedTTLs)-1]
+}
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+func deref(p *uint16) uint16 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// toRc converts a Njalla record into a RecordConfig.
+func toRc(domain string, r *record) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+	}
+	rc.SetLabel(r.Name, domain)
+
+	var err error
+	switch r.Type {
+	case "A", "AAAA":
+		err = rc.SetTarget(r.Content)
+	case "ANAME":
+		rc.Type = "ALIAS"
+		err = rc.SetTarget(dot(r.Content))
+	case "CNAME", "NS", "PTR":
+		err = rc.SetTarget(dot(r.Content))
+	case "MX":
+		err = rc.SetTargetMX(deref(r.Prio), dot(r.Content))
+	case "SRV":
+		err = rc.SetTargetSRV(deref(r.Prio), deref(r.Weight), deref(r.Port), dot(r.Content))
+	case "TXT":
+		err = rc.SetTargetTXT(r.Content)
+	case "CAA", "SSHFP", "TLSA":
+		err = rc.PopulateFromString(r.Type, r.Content, domain)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+// toRecord converts a RecordConfig into a Njalla record.
+func toRecord(domain string, rc *models.RecordConfig) *record {
+	r := &record{
+		Domain: domain,
+		Name:   rc.GetLabel(),
+		Type:   rc.Type,
+		TTL:    rc.TTL,
+	}
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "ALIAS":
+		r.Type = "ANAME"
+		r.Content = target
+	case "MX":
+		prio := rc.MxPreference
+		r.Prio = &prio
+		r.Content = target
+	case "SRV":
+		prio, weight, port := rc.SrvPriority, rc.SrvWeight, rc.SrvPort
+		r.Prio, r.Weight, r.Port = &prio, &weight, &port
+		r.Content = target
+	case "TXT":
+		r.Content = rc.GetTargetTXTJoined()
+	case "CAA", "SSHFP", "TLSA":
+		r.Content = rc.GetTargetCombined()
+	default:
+		r.Content = target
+	}
+	return r
+}
@@ -0,0 +1,6 @@
+package njalla
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *njallaProvider) ListZones() ([]string, error) {
+	return c.listDomains()
+}
@@ -0,0 +1,200 @@
+package njalla
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Njalla.
+// API Documentation: https://njal.la/api/
+
+/*
+Njalla provider:
+
+Info required in `creds.json`:
+   - api_token
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Can(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Can(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Cannot(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{
+	"1-you.njalla.no",
+	"2-can.njalla.in",
+	"3-get.njalla.fo",
+}
+
+func init() {
+	const providerName = "NJALLA"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	providers.RegisterRegistrarType(providerName, newReg)
+	fns := providers.DspFuncs{
+		Initializer:   newDsp,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+func newReg(conf map[string]string) (providers.Registrar, error) {
+	return newNjalla(conf)
+}
+
+func newDsp(conf map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	return newNjalla(conf)
+}
+
+// newNjalla creates the provider.
+func newNjalla(m map[string]string) (*njallaProvider, error) {
+	c := &njallaProvider{
+		token: m["api_token"],
+	}
+	if c.token == "" {
+		return nil, fmt.Errorf("missing NJALLA api_token")
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *njallaProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *njallaProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		r := &records[i]
+		if r.Type == "NS" && r.Name == "@" {
+			// The apex NS records are managed by Njalla.
+			continue
+		}
+		rc, err := toRc(domain, r)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *njallaProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	for _, rc := range dc.Records {
+		rc.TTL = fixTTL(rc.TTL)
+	}
+
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			rec := toRecord(dc.Name, change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(rec)
+				},
+			}
+		case diff2.CHANGE:
+			rec := toRecord(dc.Name, change.New[0])
+			rec.ID = change.Old[0].Original.(*record).ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Njalla ID: %s", change.Msgs[0], rec.ID),
+				F: func() error {
+					return c.updateRecord(rec)
+				},
+			}
+		case diff2.DELETE:
+			id := change.Old[0].Original.(*record).ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Njalla ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.deleteRecord(dc.Name, id)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
+
+// GetRegistrarCorrections returns a list of corrections for this registrar.
+func (c *njallaProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	d, err := c.getDomain(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	// No custom nameservers means that the domain uses Njalla's.
+	found := d.Nameservers
+	if len(found) == 0 {
+		found = defaultNS
+	}
+	foundNameservers := make([]string, 0, len(found))
+	for _, ns := range found {
+		foundNameservers = append(foundNameservers, strings.ToLower(strings.TrimSuffix(ns, ".")))
+	}
+	sort.Strings(foundNameservers)
+
+	expected := make([]string, 0, len(dc.Nameservers))
+	for _, ns := range dc.Nameservers {
+		expected = append(expected, ns.Name)
+	}
+	sort.Strings(expected)
+
+	foundStr := strings.Join(foundNameservers, ",")
+	expectedStr := strings.Join(expected, ",")
+	if foundStr == expectedStr {
+		return nil, nil
+	}
+
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundStr, expectedStr),
+			F: func() error {
+				return c.updateNameservers(dc.Name, expected)
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,145 @@
+package ns1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+// The answer metadata and the filter chain are set in the record metadata.
+const (
+	// metaAnswerPrefix prefixes the fields of the metadata of the answer,
+	// e.g. ns1_meta_up, ns1_meta_weight or ns1_meta_georegion. Lists are
+	// comma-separated.
+	metaAnswerPrefix = "ns1_meta_"
+	// metaFilters is the JSON filter chain of the record set, e.g.
+	// [{"filter":"up"},{"filter":"select_first_n","config":{"N":1}}].
+	metaFilters = "ns1_filters"
+)
+
+// answerMeta returns the answer metadata of the record metadata, or nil.
+func answerMeta(rc *models.RecordConfig) (*data.Meta, error) {
+	fields := map[string]interface{}{}
+	for k, v := range rc.Metadata {
+		if field, ok := strings.CutPrefix(k, metaAnswerPrefix); ok {
+			fields[field] = v
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	meta := data.MetaFromMap(fields)
+	known := meta.StringMap()
+	for field := range fields {
+		if _, ok := known[field]; !ok {
+			return nil, fmt.Errorf("%s%s of %s is not a metadata field of NS1", metaAnswerPrefix, field, rc.GetLabelFQDN())
+		}
+	}
+	if errs := meta.Validate(); len(errs) != 0 {
+		return nil, fmt.Errorf("invalid NS1 metadata of %s: %v", rc.GetLabelFQDN(), errs[0])
+	}
+	return meta, nil
+}
+
+// recordFilters returns the filter chain of the record metadata, or nil.
+func recordFilters(rc *models.RecordConfig) ([]*filter.Filter, error) {
+	v := rc.Metadata[metaFilters]
+	if v == "" {
+		return nil, nil
+	}
+	var filters []*filter.Filter
+	if err := json.Unmarshal([]byte(v), &filters); err != nil {
+		return nil, fmt.Errorf("invalid %s of %s: %w", metaFilters, rc.GetLabelFQDN(), err)
+	}
+	for _, f := range filters {
+		if f == nil || f.Type == "" {
+			return nil, fmt.Errorf("invalid %s of %s: a filter has no name", metaFilters, rc.GetLabelFQDN())
+		}
+		if f.Config == nil {
+			// NS1 returns an empty configuration.
+			f.Config = filter.Config{}
+		}
+	}
+	return filters, nil
+}
+
+// setAnswerMetadata sets the record metadata of the answer metadata and
+// the filter chain of an existing record.
+func setAnswerMetadata(rc *models.RecordConfig, meta *data.Meta, filters []*filter.Filter) error {
+	if meta != nil {
+		for field, v := range meta.StringMap() {
+			rc.Metadata[metaAnswerPrefix+field] = fmt.Sprint(v)
+		}
+	}
+	if len(filters) != 0 {
+		j, err := json.Marshal(filters)
+		if err != nil {
+			return err
+		}
+		rc.Metadata[metaFilters] = string(j)
+	}
+	return nil
+}
+
+// checkAnswerMetadata checks the answer metadata and the filter chains of
+// the records, which must be the same for all the records of a set.
+func checkAnswerMetadata(dc *models.DomainConfig) error {
+	filters := map[models.RecordKey]string{}
+	for _, rc := range dc.Records {
+		if _, err := answerMeta(rc); err != nil {
+			return err
+		}
+		if _, err := recordFilters(rc); err != nil {
+			return err
+		}
+		key := rc.Key()
+		if f, ok := filters[key]; ok && f != rc.Metadata[metaFilters] {
+			return fmt.Errorf("the %s records of %s have different %s", rc.Type, rc.GetLabelFQDN(), metaFilters)
+		}
+		filters[key] = rc.Metadata[metaFilters]
+	}
+	return nil
+}
+
+// answerComparable adds the answer metadata and the filter chain to the
+// comparison of the records. They are normalized as NS1 returns them.
+func answerComparable(rc *models.RecordConfig) string {
+	var parts []string
+	if meta, _ := answerMeta(rc); meta != nil {
+		for field, v := range meta.StringMap() {
+			parts = append(parts, fmt.Sprintf("%s=%v", field, v))
+		}
+		sort.Strings(parts)
+	}
+	if filters, _ := recordFilters(rc); len(filters) != 0 {
+		j, _ := json.Marshal(filters)
+		parts = append(parts, "filters="+string(j))
+	}
+	return strings.Join(parts, " ")
+}
+
+// setAnswers sets the answer metadata and the filter chain of the records
+// of a set, whose answers are in the same order.
+func setAnswers(rec *dns.Record, recs models.Records) error {
+	for i, rc := range recs {
+		meta, err := answerMeta(rc)
+		if err != nil {
+			return err
+		}
+		rec.Answers[i].Meta = meta
+	}
+	filters, err := recordFilters(recs[0])
+	if err != nil {
+		return err
+	}
+	if filters != nil {
+		rec.Filters = filters
+	}
+	return nil
+}
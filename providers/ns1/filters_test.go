@@ -0,0 +1,52 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+func TestAnswerComparable(t *testing.T) {
+	desired := &models.RecordConfig{Type: "A", Metadata: map[string]string{
+		"ns1_meta_up":        "true",
+		"ns1_meta_weight":    "10",
+		"ns1_meta_georegion": "US-WEST,US-EAST",
+		metaFilters:          `[{"filter":"up"},{"filter":"select_first_n","config":{"N":1}}]`,
+	}}
+	desired.SetLabel("www", "example.com")
+
+	// The record as NS1 returns it.
+	existing := &models.RecordConfig{Type: "A", Metadata: map[string]string{}}
+	existing.SetLabel("www", "example.com")
+	meta := &data.Meta{Up: true, Weight: float64(10), Georegion: []interface{}{"US-EAST", "US-WEST"}}
+	filters := []*filter.Filter{
+		{Type: "up", Config: filter.Config{}},
+		{Type: "select_first_n", Config: filter.Config{"N": float64(1)}},
+	}
+	if err := setAnswerMetadata(existing, meta, filters); err != nil {
+		t.Fatal(err)
+	}
+
+	if d, e := answerComparable(desired), answerComparable(existing); d != e {
+		t.Errorf("Expected the same comparable, got %q and %q", d, e)
+	}
+
+	plain := &models.RecordConfig{Type: "A"}
+	if answerComparable(plain) != "" {
+		t.Errorf("Expected no comparable without metadata, got %q", answerComparable(plain))
+	}
+
+	for _, metadata := range []map[string]string{
+		{"ns1_meta_nonexistent": "1"},
+		{metaFilters: "up"},
+		{metaFilters: `[{"config":{}}]`},
+	} {
+		rc := &models.RecordConfig{Type: "A", Metadata: metadata}
+		rc.SetLabel("@", "example.com")
+		if err := checkAnswerMetadata(&models.DomainConfig{Records: models.Records{rc}}); err == nil {
+			t.Errorf("Expected an error for %v", metadata)
+		}
+	}
+}
@@ -137,10 +137,51 @@ func (n *nsone) GetZoneRecords(domain string, meta map[string]string) (models.Re
 
 	found := models.Records{}
 	for _, r := range z.Records {
-		zrs, err := convert(r, domain)
+		var zrs models.Records
+		if tier, _ := r.Tier.Int64(); tier > 1 {
+			// The answer metadata and the filters are not in the zone.
+			zrs, err = n.convertRecord(r, domain)
+		} else {
+			zrs, err = convert(r, domain)
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, zrs...)
+	}
+	return found, nil
+}
+
+// convertRecord converts a record with its answer metadata and filters.
+func (n *nsone) convertRecord(zr *dns.ZoneRecord, domain string) (models.Records, error) {
+	var rec *dns.Record
+	for rtr := 0; ; rtr++ {
+		var httpResp *http.Response
+		var err error
+		rec, httpResp, err = n.Records.Get(domain, zr.Domain, zr.Type)
+		if httpResp != nil && httpResp.StatusCode == http.StatusTooManyRequests && rtr < clientRetries {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	found := models.Records{}
+	for _, ans := range rec.Answers {
+		full := *zr
+		full.ShortAns = []string{strings.Join(ans.Rdata, " ")}
+		zrs, err := convert(&full, domain)
 		if err != nil {
 			return nil, err
 		}
+		for _, rc := range zrs {
+			rc.Metadata = map[string]string{}
+			if err := setAnswerMetadata(rc, ans.Meta, rec.Filters); err != nil {
+				return nil, err
+			}
+		}
 		found = append(found, zrs...)
 	}
 	return found, nil
@@ -207,7 +248,11 @@ func (n *nsone) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecor
 		corrections = append(corrections, dnssecCorrections)
 	}
 
-	changes, err := diff2.ByRecordSet(existingRecords, dc, nil)
+	if err := checkAnswerMetadata(dc); err != nil {
+		return nil, err
+	}
+
+	changes, err := diff2.ByRecordSet(existingRecords, dc, answerComparable)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +290,11 @@ func (n *nsone) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecor
 
 func (n *nsone) add(recs models.Records, domain string) error {
 	for rtr := 0; ; rtr++ {
-		httpResp, err := n.Records.Create(buildRecord(recs, domain, ""))
+		rec, err := buildRecord(recs, domain, "")
+		if err != nil {
+			return err
+		}
+		httpResp, err := n.Records.Create(rec)
 		if httpResp.StatusCode == http.StatusTooManyRequests && rtr < clientRetries {
 			continue
 		}
@@ -269,7 +318,11 @@ func (n *nsone) remove(key models.RecordKey, domain string) error {
 
 func (n *nsone) modify(recs models.Records, domain string) error {
 	for rtr := 0; ; rtr++ {
-		httpResp, err := n.Records.Update(buildRecord(recs, domain, ""))
+		rec, err := buildRecord(recs, domain, "")
+		if err != nil {
+			return err
+		}
+		httpResp, err := n.Records.Update(rec)
 		if httpResp.StatusCode == http.StatusTooManyRequests && rtr < clientRetries {
 			continue
 		}
@@ -300,7 +353,7 @@ func (n *nsone) configureDNSSEC(domain string, enabled bool) error {
 	}
 }
 
-func buildRecord(recs models.Records, domain string, id string) *dns.Record {
+func buildRecord(recs models.Records, domain string, id string) (*dns.Record, error) {
 	r := recs[0]
 	rec := &dns.Record{
 		Domain:  r.GetLabelFQDN(),
@@ -357,7 +410,7 @@ func buildRecord(recs models.Records, domain string, id string) *dns.Record {
 			rec.AddAnswer(&dns.Answer{Rdata: strings.Fields(r.GetTargetField())})
 		}
 	}
-	return rec
+	return rec, setAnswers(rec, recs)
 }
 
 func convert(zr *dns.ZoneRecord, domain string) ([]*models.RecordConfig, error) {
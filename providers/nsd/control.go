@@ -0,0 +1,56 @@
+package nsd
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+func run(command []string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(command[0], append(command[1:], args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	out := stdout.String()
+	if err != nil || strings.HasPrefix(out, "error") {
+		return "", fmt.Errorf("%s %s: %v: %s", command[0], strings.Join(args, " "), err, strings.TrimSpace(stderr.String()+out))
+	}
+	return out, nil
+}
+
+// signZone runs the signing command, with {zone} and {file} replaced by the
+// zone and its zone file.
+func (c *nsdProvider) signZone(domain, zonefile string) error {
+	if len(c.sign) == 0 {
+		return nil
+	}
+	r := strings.NewReplacer("{zone}", domain, "{file}", zonefile)
+	command := make([]string, len(c.sign))
+	for i, arg := range c.sign {
+		command[i] = r.Replace(arg)
+	}
+	printer.Printf("SIGNING ZONE: %s\n", domain)
+	_, err := run(command)
+	return err
+}
+
+// loadZone reloads a zone in NSD, or adds it if NSD doesn't serve it.
+func (c *nsdProvider) loadZone(domain string) error {
+	if _, err := run(c.control, "zonestatus", domain); err == nil {
+		printer.Printf("RELOADING ZONE: %s\n", domain)
+		_, err = run(c.control, "reload", domain)
+		return err
+	}
+	if c.pattern == "" {
+		printer.Warnf("NSD does not serve %s, add it to its configuration or set pattern in creds.json\n", domain)
+		return nil
+	}
+	printer.Printf("ADDING ZONE: %s (pattern %s)\n", domain, c.pattern)
+	_, err := run(c.control, "addzone", domain, c.pattern)
+	return err
+}
@@ -0,0 +1,142 @@
+package nsd
+
+/*
+
+nsd -
+  Generate zone files for NSD, and load them with nsd-control.
+
+	The zone files are written by the BIND provider, which also bumps the
+	serial numbers. After a zone file is written, it is optionally signed
+	by a command, then the zone is reloaded (or added if NSD doesn't serve
+	it yet) with nsd-control.
+
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/StackExchange/dnscontrol/v4/providers/bind"
+	"github.com/google/shlex"
+)
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot("Use sign_command to sign the zone files"),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDHCID:            providers.Can(),
+	providers.CanUseDNAME:            providers.Can(),
+	providers.CanUseDS:               providers.Can(),
+	providers.CanUseDNSKEY:           providers.Can(),
+	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseLOC:              providers.Can(),
+	providers.CanUseNAPTR:            providers.Can(),
+	providers.CanUseOPENPGPKEY:       providers.Can(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Can(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Can(),
+	providers.CanUseSVCB:             providers.Can(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Can("Zones are added to NSD with the pattern set in creds.json"),
+	providers.DocDualHost:            providers.Can(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "NSD"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newNSD,
+		RecordAuditor: bind.AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// nsdProvider writes the zone files with the BIND provider.
+type nsdProvider struct {
+	providers.DNSServiceProvider
+	directory      string
+	filenameformat string
+	control        []string // The nsd-control command and its options.
+	pattern        string   // The pattern of the zones added to NSD.
+	sign           []string // The command that signs a zone file, if any.
+}
+
+func newNSD(config map[string]string, providermeta json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &nsdProvider{
+		directory:      config["directory"],
+		filenameformat: config["filenameformat"],
+		pattern:        config["pattern"],
+	}
+	if c.directory == "" {
+		c.directory = "zones"
+	}
+	if c.filenameformat == "" {
+		c.filenameformat = "%U.zone"
+	}
+
+	dsp, err := bind.NewBind(map[string]string{
+		"directory":      c.directory,
+		"filenameformat": c.filenameformat,
+	}, providermeta)
+	if err != nil {
+		return nil, err
+	}
+	c.DNSServiceProvider = dsp
+
+	command := config["command"]
+	if command == "" {
+		command = "nsd-control"
+	}
+	if c.control, err = shlex.Split(command); err != nil || len(c.control) == 0 {
+		return nil, fmt.Errorf("NSD invalid command %q: %v", command, err)
+	}
+	if config["config"] != "" {
+		c.control = append(c.control, "-c", config["config"])
+	}
+	if config["sign_command"] != "" {
+		if c.sign, err = shlex.Split(config["sign_command"]); err != nil {
+			return nil, fmt.Errorf("NSD invalid sign_command %q: %w", config["sign_command"], err)
+		}
+	}
+	return c, nil
+}
+
+// ListZones returns all the zones in the directory.
+func (c *nsdProvider) ListZones() ([]string, error) {
+	return c.DNSServiceProvider.(providers.ZoneLister).ListZones()
+}
+
+// GetZoneRecordsCorrections returns the corrections that write the zone file,
+// then sign it and load it in NSD.
+func (c *nsdProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundRecords models.Records) ([]*models.Correction, error) {
+	corrections, err := c.DNSServiceProvider.GetZoneRecordsCorrections(dc, foundRecords)
+	if err != nil {
+		return nil, err
+	}
+	zonefile := filepath.Join(c.directory, bind.ZoneFileName(c.filenameformat, dc))
+	for _, corr := range corrections {
+		if corr.F == nil {
+			continue
+		}
+		write := corr.F
+		corr.F = func() error {
+			if err := write(); err != nil {
+				return err
+			}
+			if err := c.signZone(dc.Name, zonefile); err != nil {
+				return err
+			}
+			return c.loadZone(dc.Name)
+		}
+	}
+	return corrections, nil
+}
@@ -0,0 +1,137 @@
+package opensrs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	opensrs "github.com/philhug/opensrs-go/opensrs"
+)
+
+// The opensrs-go client only knows the nameserver commands. The DNSSEC
+// commands are sent with its request signing and XML encoding.
+
+// dnssecRecord is a DS record as sent and returned by the DNSSEC commands.
+// The XML encoding of the client only handles strings.
+type dnssecRecord struct {
+	KeyTag     string `json:"key_tag"`
+	Algorithm  string `json:"algorithm"`
+	DigestType string `json:"digest_type"`
+	Digest     string `json:"digest"`
+}
+
+type dnssecResponse struct {
+	IsSuccess    string `json:"is_success"`
+	ResponseCode string `json:"response_code"`
+	ResponseText string `json:"response_text"`
+	Attributes   struct {
+		Dnssec []dnssecRecord `json:"dnssec"`
+	} `json:"attributes"`
+}
+
+// dnssecCall sends a DOMAIN command and decodes the response.
+func (c *opensrsProvider) dnssecCall(action string, attributes map[string]any) (*dnssecResponse, error) {
+	client := c.getClient()
+	payload := map[string]any{
+		"protocol":   "XCP",
+		"action":     action,
+		"object":     "DOMAIN",
+		"attributes": attributes,
+	}
+	req, err := client.NewRequest(http.MethodPost, "", payload)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openSRS %s failed: %s", action, resp.Status)
+	}
+
+	var r dnssecResponse
+	if err := opensrs.FromXml(body, &r); err != nil {
+		return nil, err
+	}
+	if r.IsSuccess != "1" {
+		return nil, fmt.Errorf("openSRS %s failed: %s %s", action, r.ResponseCode, r.ResponseText)
+	}
+	return &r, nil
+}
+
+func (c *opensrsProvider) getDNSSEC(domainName string) ([]dnssecRecord, error) {
+	r, err := c.dnssecCall("GET_DNSSEC_INFO", map[string]any{"domain": domainName})
+	if err != nil {
+		return nil, err
+	}
+	return r.Attributes.Dnssec, nil
+}
+
+// setDNSSEC replaces the DS records of the domain.
+func (c *opensrsProvider) setDNSSEC(domainName string, records []dnssecRecord) error {
+	_, err := c.dnssecCall("SET_DNSSEC_INFO", map[string]any{
+		"domain": domainName,
+		"dnssec": records,
+	})
+	return err
+}
+
+func dsString(ds dnssecRecord) string {
+	return fmt.Sprintf("%s %s %s %s", ds.KeyTag, ds.Algorithm, ds.DigestType, strings.ToUpper(ds.Digest))
+}
+
+func dsList(records []dnssecRecord) string {
+	l := make([]string, 0, len(records))
+	for _, ds := range records {
+		l = append(l, dsString(ds))
+	}
+	sort.Strings(l)
+	return strings.Join(l, ", ")
+}
+
+// getDSCorrections returns the corrections that publish dc.RegistrarDS.
+// The DS records at the registry are left untouched if there are none in
+// dnsconfig.js nor reported by the DNS providers, as before OpenSRS could
+// publish them.
+func (c *opensrsProvider) getDSCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if len(dc.RegistrarDS) == 0 {
+		return nil, nil
+	}
+	existing, err := c.getDNSSEC(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make([]dnssecRecord, 0, len(dc.RegistrarDS))
+	for _, rc := range dc.RegistrarDS {
+		expected = append(expected, dnssecRecord{
+			KeyTag:     strconv.Itoa(int(rc.DsKeyTag)),
+			Algorithm:  strconv.Itoa(int(rc.DsAlgorithm)),
+			DigestType: strconv.Itoa(int(rc.DsDigestType)),
+			Digest:     rc.DsDigest,
+		})
+	}
+
+	actual, want := dsList(existing), dsList(expected)
+	if actual == want {
+		return nil, nil
+	}
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update DS (%s) -> (%s)", actual, want),
+			F: func() error {
+				return c.setDNSSEC(dc.Name, expected)
+			},
+		},
+	}, nil
+}
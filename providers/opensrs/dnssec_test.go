@@ -0,0 +1,20 @@
+package opensrs
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestGetDSCorrectionsNoneDeclared(t *testing.T) {
+	// Without a client, any request to OpenSRS panics: the DS records set
+	// at the registry are not even read.
+	c := &opensrsProvider{}
+	corrections, err := c.getDSCorrections(&models.DomainConfig{Name: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(corrections) != 0 {
+		t.Errorf("got %d corrections, want none", len(corrections))
+	}
+}
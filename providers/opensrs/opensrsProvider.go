@@ -15,7 +15,8 @@ import (
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
-	providers.CanConcur: providers.Cannot(),
+	providers.CanConcur:           providers.Cannot(),
+	providers.CanUseDSAtRegistrar: providers.Can(),
 }
 
 func init() {
@@ -65,15 +66,17 @@ func (c *opensrsProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*m
 	expected := strings.Join(expectedSet, ",")
 
 	if actual != expected {
-		return []*models.Correction{
-			{
-				Msg: fmt.Sprintf("Update nameservers %s -> %s", actual, expected),
-				F:   c.updateNameserversFunc(expectedSet, dc.Name),
-			},
-		}, nil
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", actual, expected),
+			F:   c.updateNameserversFunc(expectedSet, dc.Name),
+		})
 	}
 
-	return corrections, nil
+	dsCorrections, err := c.getDSCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, dsCorrections...), nil
 }
 
 // OpenSRS calls
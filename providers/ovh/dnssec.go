@@ -0,0 +1,79 @@
+package ovh
+
+import (
+	"fmt"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+)
+
+// The DNSSEC statuses of an OVH zone.
+const (
+	dnssecEnabled           = "enabled"
+	dnssecDisabled          = "disabled"
+	dnssecEnableInProgress  = "enableInProgress"
+	dnssecDisableInProgress = "disableInProgress"
+)
+
+// dnssecStatus describes the DNSSEC of a DNS zone.
+type dnssecStatus struct {
+	Status string `json:"status"`
+}
+
+func (c *ovhProvider) fetchDNSSEC(fqdn string) (string, error) {
+	var response dnssecStatus
+	err := c.client.CallAPI("GET", fmt.Sprintf("/domain/zone/%s/dnssec", fqdn), nil, &response, true)
+	if err != nil {
+		return "", err
+	}
+	return response.Status, nil
+}
+
+func (c *ovhProvider) enableDNSSEC(fqdn string) error {
+	return c.client.CallAPI("POST", fmt.Sprintf("/domain/zone/%s/dnssec", fqdn), nil, &Void{}, true)
+}
+
+func (c *ovhProvider) disableDNSSEC(fqdn string) error {
+	return c.client.CallAPI("DELETE", fmt.Sprintf("/domain/zone/%s/dnssec", fqdn), nil, &Void{}, true)
+}
+
+// getDNSSECCorrections returns the correction that enables or disables the
+// DNSSEC of the zone, as requested by AUTODNSSEC_ON or AUTODNSSEC_OFF. A
+// zone whose DNSSEC is being enabled or disabled is left as is.
+func (c *ovhProvider) getDNSSECCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if dc.AutoDNSSEC == "" {
+		return nil, nil
+	}
+	zone, err := c.fetchZone(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !zone.DNSSecSupported {
+		if dc.AutoDNSSEC == "on" {
+			return nil, fmt.Errorf("OVH doesn't support DNSSEC for the zone %s", dc.Name)
+		}
+		return nil, nil
+	}
+	status, err := c.fetchDNSSEC(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case dc.AutoDNSSEC == "on" && (status == dnssecDisabled || status == dnssecDisableInProgress):
+		return []*models.Correction{{
+			Msg: color.YellowString("± MODIFY zone %s DNSSEC (%s) -> (%s)", dc.Name, status, dnssecEnabled),
+			F: func() error {
+				return c.enableDNSSEC(dc.Name)
+			},
+		}}, nil
+	case dc.AutoDNSSEC == "off" && (status == dnssecEnabled || status == dnssecEnableInProgress):
+		return []*models.Correction{{
+			Msg: color.YellowString("± MODIFY zone %s DNSSEC (%s) -> (%s)", dc.Name, status, dnssecDisabled),
+			F: func() error {
+				return c.disableDNSSEC(dc.Name)
+			},
+		}}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,198 @@
+package ovh
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+)
+
+// DSKey describes a key published at the registry in ovh's protocol. OVH
+// takes the public keys and computes the DS records.
+type DSKey struct {
+	ID        int    `json:"id,omitempty"`
+	Algorithm int    `json:"algorithm"`
+	Flags     int    `json:"flags"`
+	PublicKey string `json:"publicKey"`
+	Tag       int    `json:"tag"`
+	Status    string `json:"status,omitempty"`
+}
+
+// UpdateDSKeys describes the list of keys of a domain in ovh's protocol.
+type UpdateDSKeys struct {
+	Keys []DSKey `json:"keys"`
+}
+
+func (k DSKey) String() string {
+	return fmt.Sprintf("%d %d %d", k.Tag, k.Flags, k.Algorithm)
+}
+
+func (k DSKey) toDNSKEY(fqdn string) *dns.DNSKEY {
+	return &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: dns.Fqdn(fqdn), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET},
+		Flags:     uint16(k.Flags),
+		Protocol:  3,
+		Algorithm: uint8(k.Algorithm),
+		PublicKey: k.PublicKey,
+	}
+}
+
+func dsKeyFromDNSKEY(key *dns.DNSKEY) DSKey {
+	return DSKey{
+		Algorithm: int(key.Algorithm),
+		Flags:     int(key.Flags),
+		PublicKey: key.PublicKey,
+		Tag:       int(key.KeyTag()),
+	}
+}
+
+// keysList returns the sorted keys as a string, with their public keys for
+// comparison.
+func keysList(keys []DSKey, publicKeys bool) string {
+	list := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if publicKeys {
+			list = append(list, k.String()+" "+k.PublicKey)
+		} else {
+			list = append(list, k.String())
+		}
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
+// keyForDS returns the key of the DS record, or nil if none of the keys
+// matches it.
+func keyForDS(fqdn string, rc *models.RecordConfig, keys []DSKey) *DSKey {
+	for i, k := range keys {
+		ds := k.toDNSKEY(fqdn).ToDS(rc.DsDigestType)
+		if ds != nil && ds.KeyTag == rc.DsKeyTag && ds.Algorithm == rc.DsAlgorithm && strings.EqualFold(ds.Digest, rc.DsDigest) {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+func (c *ovhProvider) fetchDSKeys(fqdn string) ([]DSKey, error) {
+	var ids []int
+	if err := c.client.CallAPI("GET", "/domain/"+fqdn+"/dsRecord", nil, &ids, true); err != nil {
+		return nil, err
+	}
+
+	var keys []DSKey
+	for _, id := range ids {
+		var key DSKey
+		if err := c.client.CallAPI("GET", fmt.Sprintf("/domain/%s/dsRecord/%d", fqdn, id), nil, &key, true); err != nil {
+			return nil, err
+		}
+		// skip the keys that are being deleted
+		if key.Status == "deleting" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (c *ovhProvider) updateDSKeys(fqdn string, keys []DSKey) error {
+	update := UpdateDSKeys{Keys: make([]DSKey, 0, len(keys))}
+	for _, k := range keys {
+		update.Keys = append(update.Keys, DSKey{Algorithm: k.Algorithm, Flags: k.Flags, PublicKey: k.PublicKey, Tag: k.Tag})
+	}
+	var task Task
+	err := c.client.CallAPI("POST", fmt.Sprintf("/domain/%s/dsRecord", fqdn), &update, &task, true)
+	if err != nil {
+		return err
+	}
+	if task.Status == "error" {
+		return fmt.Errorf("API error while updating the DS records of %s: %s", fqdn, task.Comment)
+	}
+	return nil
+}
+
+// lookupDNSKEY returns the DNSKEY records of the zone served by its
+// nameservers.
+func lookupDNSKEY(fqdn string, nameservers []*models.Nameserver) ([]DSKey, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(fqdn), dns.TypeDNSKEY)
+	m.SetEdns0(4096, true)
+
+	var lastErr error
+	for _, ns := range nameservers {
+		server := net.JoinHostPort(strings.TrimSuffix(ns.Name, "."), "53")
+		client := &dns.Client{}
+		r, _, err := client.Exchange(m, server)
+		if err == nil && r.Truncated {
+			client.Net = "tcp"
+			r, _, err = client.Exchange(m, server)
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		var keys []DSKey
+		for _, rr := range r.Answer {
+			if key, ok := rr.(*dns.DNSKEY); ok {
+				keys = append(keys, dsKeyFromDNSKEY(key))
+			}
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("can't fetch the DNSKEY records of %s: %w", fqdn, lastErr)
+}
+
+// getDSCorrections returns the correction that publishes dc.RegistrarDS.
+// OVH takes the public keys of the DS records: they are the keys already
+// published, or the DNSKEY records served by the nameservers of the domain.
+// The DS records of the domains that use the DNS of OVH are managed by OVH.
+// The keys at the registry are left untouched if there are no DS records in
+// dnsconfig.js nor reported by the DNS providers.
+func (c *ovhProvider) getDSCorrections(dc *models.DomainConfig, hosted bool) ([]*models.Correction, error) {
+	if hosted || len(dc.RegistrarDS) == 0 {
+		// Either OVH publishes the DS records, or they are not managed.
+		return nil, nil
+	}
+	existing, err := c.fetchDSKeys(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var served []DSKey
+	var lookedUp bool
+	seen := map[int]bool{}
+	wanted := []DSKey{}
+	for _, rc := range dc.RegistrarDS {
+		key := keyForDS(dc.Name, rc, existing)
+		if key == nil {
+			if !lookedUp {
+				if served, err = lookupDNSKEY(dc.Name, dc.Nameservers); err != nil {
+					return nil, err
+				}
+				lookedUp = true
+			}
+			if key = keyForDS(dc.Name, rc, served); key == nil {
+				return nil, fmt.Errorf("OVH needs the public key of the DS record %d %d %d of %s, which its nameservers don't serve", rc.DsKeyTag, rc.DsAlgorithm, rc.DsDigestType, dc.Name)
+			}
+		}
+		if seen[key.Tag] {
+			continue
+		}
+		seen[key.Tag] = true
+		wanted = append(wanted, *key)
+	}
+
+	if keysList(existing, true) == keysList(wanted, true) {
+		return nil, nil
+	}
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update DS keys (%s) -> (%s)", keysList(existing, false), keysList(wanted, false)),
+			F: func() error {
+				return c.updateDSKeys(dc.Name, wanted)
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,122 @@
+package ovh
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// GlueRecord describes the glue record of a nameserver in ovh's protocol.
+type GlueRecord struct {
+	Host string   `json:"host,omitempty"`
+	IPs  []string `json:"ips"`
+}
+
+// glueIPs returns the IPv4 and IPv6 addresses of each nameserver inside the
+// domain, taken from the A and AAAA records of the zone.
+// The nameservers without such records are left unchanged.
+func glueIPs(dc *models.DomainConfig) map[string][]string {
+	glue := map[string][]string{}
+	for _, ns := range dc.Nameservers {
+		name := strings.TrimSuffix(ns.Name, ".")
+		if name != dc.Name && !strings.HasSuffix(name, "."+dc.Name) {
+			continue
+		}
+		for _, rc := range dc.Records {
+			if (rc.Type == "A" || rc.Type == "AAAA") && rc.GetLabelFQDN() == name {
+				glue[name] = append(glue[name], rc.GetTargetIP().String())
+			}
+		}
+		sort.Strings(glue[name])
+	}
+	return glue
+}
+
+// fetchGlueRecord returns the glue record of the host, or nil if it does not
+// exist.
+func (c *ovhProvider) fetchGlueRecord(fqdn, host string) (*GlueRecord, error) {
+	var glue GlueRecord
+	err := c.client.CallAPI("GET", fmt.Sprintf("/domain/%s/glueRecord/%s", fqdn, host), nil, &glue, true)
+	if err != nil {
+		var apiError *ovh.APIError
+		if errors.As(err, &apiError) && apiError.Code == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for i, ip := range glue.IPs {
+		// Normalize the IPv6 addresses like GetTargetIP.
+		if parsed := net.ParseIP(ip); parsed != nil {
+			glue.IPs[i] = parsed.String()
+		}
+	}
+	sort.Strings(glue.IPs)
+	return &glue, nil
+}
+
+func (c *ovhProvider) createGlueRecord(fqdn, host string, ips []string) error {
+	var task Task
+	err := c.client.CallAPI("POST", fmt.Sprintf("/domain/%s/glueRecord", fqdn), &GlueRecord{Host: host, IPs: ips}, &task, true)
+	if err != nil {
+		return err
+	}
+	if task.Status == "error" {
+		return fmt.Errorf("API error while creating the glue record %s of %s: %s", host, fqdn, task.Comment)
+	}
+	return nil
+}
+
+func (c *ovhProvider) updateGlueRecord(fqdn, host string, ips []string) error {
+	var task Task
+	err := c.client.CallAPI("POST", fmt.Sprintf("/domain/%s/glueRecord/%s/update", fqdn, host), &GlueRecord{IPs: ips}, &task, true)
+	if err != nil {
+		return err
+	}
+	if task.Status == "error" {
+		return fmt.Errorf("API error while updating the glue record %s of %s: %s", host, fqdn, task.Comment)
+	}
+	return nil
+}
+
+// getGlueCorrections returns the corrections that create or update the glue
+// records of the nameservers of the domain that are inside the domain.
+func (c *ovhProvider) getGlueCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	glue := glueIPs(dc)
+	hosts := make([]string, 0, len(glue))
+	for host := range glue {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var corrections []*models.Correction
+	for _, host := range hosts {
+		ips := glue[host]
+		current, err := c.fetchGlueRecord(dc.Name, host)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case current == nil:
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Create glue record %s (%s)", host, strings.Join(ips, ", ")),
+				F: func() error {
+					return c.createGlueRecord(dc.Name, host, ips)
+				},
+			})
+		case !slices.Equal(current.IPs, ips):
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Update glue record %s %s -> %s", host, strings.Join(current.IPs, ", "), strings.Join(ips, ", ")),
+				F: func() error {
+					return c.updateGlueRecord(dc.Name, host, ips)
+				},
+			})
+		}
+	}
+	return corrections, nil
+}
@@ -12,6 +12,10 @@ import (
 	"github.com/ovh/go-ovh/ovh"
 )
 
+// metaRefresh controls the refresh of the zone: "auto" after the changes of
+// the records, which is the default, "always" or "never".
+const metaRefresh = "ovh_refresh"
+
 type ovhProvider struct {
 	client *ovh.Client
 	zones  map[string]bool
@@ -20,10 +24,12 @@ type ovhProvider struct {
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Can(),
 	providers.CanGetZones:            providers.Can(),
 	providers.CanConcur:              providers.Cannot(),
 	providers.CanUseAlias:            providers.Cannot(),
 	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDSAtRegistrar:    providers.Can("The DNSKEY records of the DS records must be served by the nameservers"),
 	providers.CanUseLOC:              providers.Unimplemented(),
 	providers.CanUsePTR:              providers.Cannot(),
 	providers.CanUseSRV:              providers.Can(),
@@ -148,16 +154,11 @@ func (c *ovhProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, actual
 		return nil, err
 	}
 
-	// Only refresh zone if there's a real modification
-	reportOnlyCorrections := true
-	for _, c := range corrections {
-		if c.F != nil {
-			reportOnlyCorrections = false
-			break
-		}
+	refresh, err := shouldRefresh(dc, corrections)
+	if err != nil {
+		return nil, err
 	}
-
-	if !reportOnlyCorrections {
+	if refresh {
 		corrections = append(corrections, &models.Correction{
 			Msg: "REFRESH zone " + dc.Name,
 			F: func() error {
@@ -166,7 +167,35 @@ func (c *ovhProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, actual
 		})
 	}
 
-	return corrections, nil
+	// DNSSEC is enabled once the records are refreshed.
+	dnssecCorrections, err := c.getDNSSECCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, dnssecCorrections...), nil
+}
+
+// shouldRefresh reports whether the zone is refreshed after the
+// corrections. Only refresh zone if there's a real modification, unless the
+// domain metadata says otherwise.
+func shouldRefresh(dc *models.DomainConfig, corrections []*models.Correction) (bool, error) {
+	reportOnlyCorrections := true
+	for _, c := range corrections {
+		if c.F != nil {
+			reportOnlyCorrections = false
+			break
+		}
+	}
+	switch dc.Metadata[metaRefresh] {
+	case "", "auto":
+		return !reportOnlyCorrections, nil
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%s must be \"auto\", \"always\" or \"never\"", metaRefresh)
+	}
 }
 
 func (c *ovhProvider) getDiff2DomainCorrections(dc *models.DomainConfig, actual models.Records) ([]*models.Correction, error) {
@@ -252,20 +281,34 @@ func (c *ovhProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*model
 	sort.Strings(expectedNs)
 	expected := strings.Join(expectedNs, ",")
 
+	// the glue records are created before the nameservers which need them
+	corrections, err := c.getGlueCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if we need to change something
 	if actual != expected {
-		return []*models.Correction{
-			{
-				Msg: fmt.Sprintf("Change Nameservers from '%s' to '%s'", actual, expected),
-				F: func() error {
-					err := c.updateNS(dc.Name, expectedNs)
-					if err != nil {
-						return err
-					}
-					return nil
-				}},
-		}, nil
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Change Nameservers from '%s' to '%s'", actual, expected),
+			F: func() error {
+				err := c.updateNS(dc.Name, expectedNs)
+				if err != nil {
+					return err
+				}
+				return nil
+			}})
 	}
 
-	return nil, nil
+	// the nameservers of OVH stay in use unless they are changed
+	domain, err := c.fetchDomain(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	hosted := domain.NameServerType == "hosted" && actual == expected
+	dsCorrections, err := c.getDSCorrections(dc, hosted)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, dsCorrections...), nil
 }
@@ -1,8 +1,11 @@
 package ovh
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
 	"github.com/ovh/go-ovh/ovh"
 )
 
@@ -49,3 +52,96 @@ func Test_getOVHEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func Test_shouldRefresh(t *testing.T) {
+	report := []*models.Correction{{Msg: "report"}}
+	change := []*models.Correction{{Msg: "change", F: func() error { return nil }}}
+	tests := []struct {
+		refresh     string
+		corrections []*models.Correction
+		want        bool
+	}{
+		{"", nil, false},
+		{"", report, false},
+		{"", change, true},
+		{"auto", change, true},
+		{"always", nil, true},
+		{"never", change, false},
+	}
+	for _, tt := range tests {
+		dc := &models.DomainConfig{Name: "example.com", Metadata: map[string]string{metaRefresh: tt.refresh}}
+		got, err := shouldRefresh(dc, tt.corrections)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("shouldRefresh(%q, %d corrections) = %v, want %v", tt.refresh, len(tt.corrections), got, tt.want)
+		}
+	}
+
+	dc := &models.DomainConfig{Name: "example.com", Metadata: map[string]string{metaRefresh: "sometimes"}}
+	if _, err := shouldRefresh(dc, nil); err == nil {
+		t.Error("Expected an error for an invalid ovh_refresh")
+	}
+}
+
+func Test_keyForDS(t *testing.T) {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+		PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==",
+	}
+	ds := key.ToDS(dns.SHA256)
+	rc := &models.RecordConfig{Type: "DS"}
+	rc.SetLabel("@", "example.com")
+	if err := rc.SetTargetDS(ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []DSKey{{Tag: 1, Flags: 257, Algorithm: 13, PublicKey: "AAAA"}, dsKeyFromDNSKEY(key)}
+	if got := keyForDS("example.com", rc, keys); got == nil || got.Tag != int(ds.KeyTag) {
+		t.Errorf("keyForDS() = %v, want the key %d", got, ds.KeyTag)
+	}
+	if got := keyForDS("example.com", rc, keys[:1]); got != nil {
+		t.Errorf("keyForDS() = %v, want nil", got)
+	}
+}
+
+func Test_getDSCorrectionsNoneDeclared(t *testing.T) {
+	// Without a client, any request to OVH panics: the keys at the registry
+	// are not even read.
+	c := &ovhProvider{}
+	for _, autoDNSSEC := range []string{"", "on", "off"} {
+		dc := &models.DomainConfig{Name: "example.com", AutoDNSSEC: autoDNSSEC}
+		corrections, err := c.getDSCorrections(dc, false)
+		if err != nil || len(corrections) != 0 {
+			t.Errorf("AUTODNSSEC %q: getDSCorrections() = %v, %v, want no corrections", autoDNSSEC, corrections, err)
+		}
+	}
+}
+
+func Test_glueIPs(t *testing.T) {
+	dc := &models.DomainConfig{
+		Name:        "example.com",
+		Nameservers: []*models.Nameserver{{Name: "ns1.example.com."}, {Name: "ns2.example.com"}, {Name: "ns.other.net"}},
+	}
+	for _, r := range []struct{ label, rtype, ip string }{
+		{"ns1", "AAAA", "2001:db8::1"},
+		{"ns1", "A", "192.0.2.1"},
+		{"www", "A", "192.0.2.80"},
+	} {
+		rc := &models.RecordConfig{Type: r.rtype}
+		rc.SetLabel(r.label, dc.Name)
+		if err := rc.SetTarget(r.ip); err != nil {
+			t.Fatal(err)
+		}
+		dc.Records = append(dc.Records, rc)
+	}
+
+	glue := glueIPs(dc)
+	if len(glue) != 1 || strings.Join(glue["ns1.example.com"], ",") != "192.0.2.1,2001:db8::1" {
+		t.Errorf("glueIPs() = %v", glue)
+	}
+}
@@ -296,6 +296,15 @@ type Domain struct {
 	TransferLockStatus string `json:"transferLockStatus,omitempty"`
 }
 
+func (c *ovhProvider) fetchDomain(fqdn string) (*Domain, error) {
+	var domain Domain
+	err := c.client.CallAPI("GET", fmt.Sprintf("/domain/%s", fqdn), nil, &domain, true)
+	if err != nil {
+		return nil, err
+	}
+	return &domain, nil
+}
+
 func (c *ovhProvider) updateNS(fqdn string, ns []string) error {
 	// we first need to make sure we can edit the NS
 	// by default zones are in "hosted" mode meaning they default
@@ -0,0 +1,146 @@
+package porkbun
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// dsRecord is a DS record published by Porkbun in the parent zone.
+type dsRecord struct {
+	KeyTag     string `json:"keyTag"`
+	Algorithm  string `json:"alg"`
+	DigestType string `json:"digestType"`
+	Digest     string `json:"digest"`
+}
+
+type dnssecResponse struct {
+	Records json.RawMessage `json:"records"`
+}
+
+func dsString(ds *dsRecord) string {
+	return fmt.Sprintf("%s %s %s %s", ds.KeyTag, ds.Algorithm, ds.DigestType, strings.ToUpper(ds.Digest))
+}
+
+func (c *porkbunProvider) getDS(domain string) ([]dsRecord, error) {
+	bodyString, err := c.post(fmt.Sprintf("/dns/getDnssecRecords/%s", domain), requestParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching DS records from porkbun: %w", err)
+	}
+
+	return parseDS(bodyString)
+}
+
+// parseDS returns the DS records of a response of getDnssecRecords.
+func parseDS(body []byte) ([]dsRecord, error) {
+	var dr dnssecResponse
+	if err := json.Unmarshal(body, &dr); err != nil {
+		return nil, fmt.Errorf("failed parsing DS records from porkbun: %w", err)
+	}
+	// The records are an object indexed by key tag, or an empty list.
+	var byKeyTag map[string]dsRecord
+	var records []dsRecord
+	if err := json.Unmarshal(dr.Records, &byKeyTag); err == nil {
+		for _, ds := range byKeyTag {
+			records = append(records, ds)
+		}
+	} else if len(dr.Records) != 0 {
+		if err := json.Unmarshal(dr.Records, &records); err != nil {
+			return nil, fmt.Errorf("failed parsing DS records from porkbun: %w", err)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return dsString(&records[i]) < dsString(&records[j]) })
+	return records, nil
+}
+
+func (c *porkbunProvider) createDS(domain string, ds *dsRecord) error {
+	params := requestParams{
+		"keyTag":     ds.KeyTag,
+		"alg":        ds.Algorithm,
+		"digestType": ds.DigestType,
+		"digest":     ds.Digest,
+	}
+	if _, err := c.post(fmt.Sprintf("/dns/createDnssecRecord/%s", domain), params); err != nil {
+		return fmt.Errorf("failed create DS record (porkbun): %w", err)
+	}
+	return nil
+}
+
+// deleteDS deletes the DS records of the key tag.
+func (c *porkbunProvider) deleteDS(domain string, keyTag string) error {
+	if _, err := c.post(fmt.Sprintf("/dns/deleteDnssecRecord/%s/%s", domain, keyTag), requestParams{}); err != nil {
+		return fmt.Errorf("failed delete DS record (porkbun): %w", err)
+	}
+	return nil
+}
+
+// getDSCorrections returns the corrections that publish dc.RegistrarDS.
+// The DS records at the registry are left untouched if there are none in
+// dnsconfig.js nor reported by the DNS providers: they may have been set
+// in the web console.
+func (c *porkbunProvider) getDSCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	if len(dc.RegistrarDS) == 0 {
+		return nil, nil
+	}
+	existing, err := c.getDS(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	return c.dsCorrections(dc.Name, existing, dc.RegistrarDS), nil
+}
+
+// dsCorrections returns the corrections that replace the existing DS
+// records of domain by registrarDS. Porkbun deletes the DS records by key
+// tag, so the wanted records of a key tag which is deleted are created
+// again.
+func (c *porkbunProvider) dsCorrections(domain string, existing []dsRecord, registrarDS models.Records) []*models.Correction {
+	var wanted []*dsRecord
+	isWanted := map[string]bool{}
+	for _, rc := range registrarDS {
+		ds := &dsRecord{
+			KeyTag:     strconv.Itoa(int(rc.DsKeyTag)),
+			Algorithm:  strconv.Itoa(int(rc.DsAlgorithm)),
+			DigestType: strconv.Itoa(int(rc.DsDigestType)),
+			Digest:     rc.DsDigest,
+		}
+		wanted = append(wanted, ds)
+		isWanted[dsString(ds)] = true
+	}
+
+	var corrections []*models.Correction
+	found := map[string]bool{}
+	deleted := map[string]bool{}
+	for i := range existing {
+		ds := &existing[i]
+		key := dsString(ds)
+		found[key] = true
+		if isWanted[key] || deleted[ds.KeyTag] {
+			continue
+		}
+		deleted[ds.KeyTag] = true
+		keyTag := ds.KeyTag
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Remove DS (key tag %s)", keyTag),
+			F: func() error {
+				return c.deleteDS(domain, keyTag)
+			},
+		})
+	}
+	for _, ds := range wanted {
+		key := dsString(ds)
+		if found[key] && !deleted[ds.KeyTag] {
+			continue
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Add DS (%s)", key),
+			F: func() error {
+				return c.createDS(domain, ds)
+			},
+		})
+	}
+	return corrections
+}
@@ -0,0 +1,85 @@
+package porkbun
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestParseDS(t *testing.T) {
+	for _, test := range []struct {
+		name, body string
+		expected   []string
+	}{
+		{"object", `{"status":"SUCCESS","records":{"2371":{"keyTag":"2371","alg":"13","digestType":"2","digest":"abcdef"},"1234":{"keyTag":"1234","alg":"8","digestType":"2","digest":"012345"}}}`,
+			[]string{"1234 8 2 012345", "2371 13 2 ABCDEF"}},
+		{"empty list", `{"status":"SUCCESS","records":[]}`, nil},
+		{"list", `{"status":"SUCCESS","records":[{"keyTag":"2371","alg":"13","digestType":"2","digest":"abcdef"}]}`,
+			[]string{"2371 13 2 ABCDEF"}},
+		{"no records", `{"status":"SUCCESS"}`, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := parseDS([]byte(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for i := range records {
+				got = append(got, dsString(&records[i]))
+			}
+			if strings.Join(got, ", ") != strings.Join(test.expected, ", ") {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+
+	if _, err := parseDS([]byte(`{"records":"bad"}`)); err == nil {
+		t.Error("Expected an error for bad records, got none")
+	}
+}
+
+func TestDSCorrections(t *testing.T) {
+	ds := func(s string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "DS"}
+		rc.SetLabel("@", "example.com")
+		if err := rc.SetTargetDSString(s); err != nil {
+			t.Fatal(err)
+		}
+		return rc
+	}
+	existing := []dsRecord{
+		{KeyTag: "2371", Algorithm: "13", DigestType: "2", Digest: "ABCDEF"},
+		{KeyTag: "2371", Algorithm: "13", DigestType: "4", Digest: "012345"},
+		{KeyTag: "1234", Algorithm: "13", DigestType: "2", Digest: "FEDCBA"},
+	}
+	c := &porkbunProvider{}
+
+	msgs := func(corrections []*models.Correction) string {
+		var m []string
+		for _, c := range corrections {
+			m = append(m, c.Msg)
+		}
+		return strings.Join(m, "; ")
+	}
+
+	// The same records: nothing to do.
+	got := msgs(c.dsCorrections("example.com", existing, models.Records{ds("2371 13 2 ABCDEF"), ds("2371 13 4 012345"), ds("1234 13 2 FEDCBA")}))
+	if got != "" {
+		t.Errorf("Expected no corrections, got %q", got)
+	}
+
+	// A record of the key tag 2371 changes: the key tag is deleted, and
+	// its other record is created again. The key tag 1234 is deleted.
+	got = msgs(c.dsCorrections("example.com", existing, models.Records{ds("2371 13 2 ABCDEF"), ds("2371 13 4 543210")}))
+	expected := "Remove DS (key tag 2371); Remove DS (key tag 1234); Add DS (2371 13 2 ABCDEF); Add DS (2371 13 4 543210)"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// No DS declared: the DS records set in the web console are kept.
+	corrections, err := c.getDSCorrections(&models.DomainConfig{Name: "example.com"})
+	if err != nil || len(corrections) != 0 {
+		t.Errorf("Expected no corrections, got %v, %v", corrections, err)
+	}
+}
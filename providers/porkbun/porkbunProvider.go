@@ -63,6 +63,7 @@ var features = providers.DocumentationNotes{
 	providers.CanUseAlias:            providers.Can(),
 	providers.CanUseCAA:              providers.Unimplemented(), // CAA record for base domain is pinning to a fixed set once configure
 	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSAtRegistrar:    providers.Can(),
 	providers.CanUseDSForChildren:    providers.Cannot(),
 	providers.CanUseLOC:              providers.Cannot(),
 	providers.CanUseNAPTR:            providers.Cannot(),
@@ -387,16 +388,19 @@ func (c *porkbunProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*m
 	sort.Strings(expected)
 	expectedNameservers := strings.Join(expected, ",")
 
-	if foundNameservers == expectedNameservers {
-		return nil, nil
-	}
-
-	return []*models.Correction{
-		{
+	var corrections []*models.Correction
+	if foundNameservers != expectedNameservers {
+		corrections = append(corrections, &models.Correction{
 			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundNameservers, expectedNameservers),
 			F: func() error {
 				return c.updateNameservers(expected, dc.Name)
 			},
-		},
-	}, nil
+		})
+	}
+
+	dsCorrections, err := c.getDSCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	return append(corrections, dsCorrections...), nil
 }
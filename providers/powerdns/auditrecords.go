@@ -1,8 +1,12 @@
 package powerdns
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rejectif"
+	"github.com/miekg/dns"
 )
 
 // AuditRecords returns a list of errors corresponding to the records
@@ -14,5 +18,24 @@ func AuditRecords(records []*models.RecordConfig) []error {
 	a.Add("TXT", rejectif.TxtHasDoubleQuotes) // Last verified 2023-11-11
 	a.Add("TXT", rejectif.TxtHasBackslash)    // Last verified 2023-11-11
 
+	a.Add("LUA", luaHasInvalidContent) // Last verified 2026-10-14
+
 	return a.Audit(records)
 }
+
+// luaHasInvalidContent detects LUA records that are not a record type
+// followed by a quoted snippet.
+func luaHasInvalidContent(rc *models.RecordConfig) error {
+	rtype, snippet, _ := strings.Cut(rc.GetTargetField(), " ")
+	switch rtype {
+	case "LUA", "SOA":
+		return fmt.Errorf("LUA records can't return %s records", rtype)
+	}
+	if _, ok := dns.StringToType[rtype]; !ok {
+		return fmt.Errorf("LUA record returns unknown record type %q", rtype)
+	}
+	if len(snippet) < 3 || !strings.HasPrefix(snippet, `"`) || !strings.HasSuffix(snippet, `"`) {
+		return fmt.Errorf("LUA record snippet must be quoted")
+	}
+	return nil
+}
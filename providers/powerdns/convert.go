@@ -27,6 +27,16 @@ func toRecordConfig(domain string, r zones.Record, ttl int, name string, rtype s
 		// So we need to strip away " and split into multiple string
 		// We can't use SetTargetRFC1035Quoted, it would split the long strings into multiple parts
 		return rc, rc.SetTargetTXTs(parseTxt(r.Content))
+	case "LUA":
+		// The content of LUA records is kept as is: rtype "snippet"
+		return rc, rc.SetTarget(r.Content)
+	case "LOC":
+		// The LOC records are parsed exactly, see loc.go.
+		lat, lon, alt, size, horiz, vert, err := parseLOCContent(r.Content)
+		if err != nil {
+			return rc, rc.PopulateFromString(rtype, r.Content, domain)
+		}
+		return rc, rc.SetTargetLOC(0, lat, lon, alt, size, horiz, vert)
 	default:
 		return rc, rc.PopulateFromString(rtype, r.Content, domain)
 	}
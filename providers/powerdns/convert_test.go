@@ -52,3 +52,35 @@ func TestParseText(t *testing.T) {
 	multipleLong := parseTxt(fmt.Sprintf("\"%s\" \"%s\"", strings.Repeat("A", 300), strings.Repeat("B", 300)))
 	assert.Equal(t, []string{strings.Repeat("A", 300), strings.Repeat("B", 300)}, multipleLong)
 }
+
+func TestLOCContent(t *testing.T) {
+	for _, content := range []string{
+		"51 56 0.123 N 5 54 0.000 E 4.00m 1.00m 10000.00m 10.00m",
+		"33 51 35.999 S 151 12 40.001 W -12.34m 0.00m 90000000.00m 0.05m",
+		"0 0 0.000 N 0 0 0.000 E 0.00m 1.00m 10000.00m 10.00m",
+	} {
+		record := zones.Record{Content: content}
+		recordConfig, err := toRecordConfig("example.com", record, 300, "loc", "LOC")
+		assert.NoError(t, err)
+		assert.Equal(t, content, locContent(recordConfig))
+	}
+
+	// Other representations are sent as PowerDNS returns them.
+	recordConfig, err := toRecordConfig("example.com", zones.Record{Content: "52 22 23 N 4 53 32 E -2m 1m 10000m 10m"}, 300, "loc", "LOC")
+	assert.NoError(t, err)
+	assert.Equal(t, "52 22 23.000 N 4 53 32.000 E -2.00m 1.00m 10000.00m 10.00m", locContent(recordConfig))
+}
+
+func TestLUA(t *testing.T) {
+	content := `A "ifportup(443, {'192.0.2.1', '192.0.2.2'})"`
+	recordConfig, err := toRecordConfig("example.com", zones.Record{Content: content}, 60, "www.example.com.", "LUA")
+	assert.NoError(t, err)
+	assert.Equal(t, "www", recordConfig.GetLabel())
+	assert.Equal(t, content, recordConfig.GetTargetCombined())
+	assert.NoError(t, luaHasInvalidContent(recordConfig))
+
+	for _, invalid := range []string{`A ifportup(443)`, `BOGUS "pickrandom({'192.0.2.1'})"`, `LUA "x"`, `A ""`} {
+		recordConfig.SetTarget(invalid)
+		assert.Error(t, luaHasInvalidContent(recordConfig), invalid)
+	}
+}
@@ -58,8 +58,12 @@ func (dsp *powerdnsProvider) getDiff2DomainCorrections(dc *models.DomainConfig,
 // buildRecordList returns a list of records for the PowerDNS resource record set from a change
 func buildRecordList(change diff2.Change) (records []zones.Record) {
 	for _, recordContent := range change.New {
+		content := recordContent.GetTargetCombined()
+		if recordContent.Type == "LOC" {
+			content = locContent(recordContent)
+		}
 		records = append(records, zones.Record{
-			Content: recordContent.GetTargetCombined(),
+			Content: content,
 		})
 	}
 	return
@@ -46,19 +46,55 @@ func (dsp *powerdnsProvider) GetZoneRecords(domain string, meta map[string]strin
 
 // GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
 func (dsp *powerdnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existing models.Records) ([]*models.Correction, error) {
+	if err := dsp.setRegistrarDSMode(dc); err != nil {
+		return nil, err
+	}
 
 	corrections, err := dsp.getDiff2DomainCorrections(dc, existing)
 	if err != nil {
 		return nil, err
 	}
 
+	// Rectify the signed zones after their records are changed
+	rectifyCorrection, err := dsp.getRectifyCorrection(dc, corrections)
+	if err != nil {
+		return nil, err
+	}
+	if rectifyCorrection != nil {
+		corrections = append(corrections, rectifyCorrection)
+	}
+
 	// DNSSec corrections
 	dnssecCorrections, err := dsp.getDNSSECCorrections(dc)
 	if err != nil {
 		return nil, err
 	}
 
-	return append(corrections, dnssecCorrections...), nil
+	corrections = append(corrections, dnssecCorrections...)
+
+	// DNSSec key rollovers, once the signing of the zone is settled
+	if len(dnssecCorrections) == 0 {
+		rolloverCorrections, err := dsp.getRolloverCorrections(dc)
+		if err != nil {
+			return nil, err
+		}
+		corrections = append(corrections, rolloverCorrections...)
+	}
+
+	// Zone settings corrections
+	settingsCorrections, err := dsp.getZoneSettingsCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	corrections = append(corrections, settingsCorrections...)
+
+	// Zone metadata corrections
+	metadataCorrections, err := dsp.getZoneMetadataCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(corrections, metadataCorrections...), nil
 }
 
 // EnsureZoneExists creates a zone if it does not exist
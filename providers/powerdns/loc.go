@@ -0,0 +1,148 @@
+package powerdns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// The PowerDNS API returns the LOC records in its own representation, with
+// all the fields and a fixed number of decimals:
+//
+//	51 56 0.123 N 5 54 0.000 E 4.00m 1.00m 10000.00m 10.00m
+//
+// Its parser does not read back every representation accepted by dnscontrol
+// the same way (https://github.com/PowerDNS/pdns/issues/10558), so the
+// records are sent in this representation, and it is parsed with integer
+// arithmetic so the values round-trip exactly.
+
+const (
+	locEquator      = 1 << 31  // RFC 1876, Section 2.
+	locAltitudeBase = 10000000 // 100,000m below the WGS 84 spheroid, in cm.
+)
+
+// locContent returns a LOC record in the representation of PowerDNS.
+func locContent(rc *models.RecordConfig) string {
+	return fmt.Sprintf("%s %s %s %s %s %s",
+		locAngle(rc.LocLatitude, "N", "S"),
+		locAngle(rc.LocLongitude, "E", "W"),
+		locCentimeters(int64(rc.LocAltitude)-locAltitudeBase),
+		locCentimeters(locPrecision(rc.LocSize)),
+		locCentimeters(locPrecision(rc.LocHorizPre)),
+		locCentimeters(locPrecision(rc.LocVertPre)),
+	)
+}
+
+// locAngle formats a latitude or longitude, in thousandths of arc seconds.
+func locAngle(v uint32, positive, negative string) string {
+	ms := int64(v) - locEquator
+	hemisphere := positive
+	if ms < 0 {
+		ms, hemisphere = -ms, negative
+	}
+	return fmt.Sprintf("%d %d %d.%03d %s", ms/3600000, ms/60000%60, ms/1000%60, ms%1000, hemisphere)
+}
+
+// locCentimeters formats a distance in meters with 2 decimals.
+func locCentimeters(cm int64) string {
+	sign := ""
+	if cm < 0 {
+		cm, sign = -cm, "-"
+	}
+	return fmt.Sprintf("%s%d.%02dm", sign, cm/100, cm%100)
+}
+
+// locPrecision returns the centimeters of a size or precision, which is
+// encoded as a mantissa and a power of 10.
+func locPrecision(b uint8) int64 {
+	cm := int64(b >> 4)
+	for e := b & 0x0f; e > 0; e-- {
+		cm *= 10
+	}
+	return cm
+}
+
+// parseLOCContent parses a LOC record in the representation of PowerDNS.
+func parseLOCContent(content string) (lat, lon, alt uint32, size, horiz, vert uint8, err error) {
+	f := strings.Fields(content)
+	if len(f) != 12 {
+		return 0, 0, 0, 0, 0, 0, fmt.Errorf("unexpected LOC content %q", content)
+	}
+	if lat, err = parseLOCAngle(f[0:4], "N", "S"); err != nil {
+		return
+	}
+	if lon, err = parseLOCAngle(f[4:8], "E", "W"); err != nil {
+		return
+	}
+	var cm int64
+	if cm, err = parseFixed(strings.TrimSuffix(f[8], "m"), 2); err != nil {
+		return
+	}
+	alt = uint32(cm + locAltitudeBase)
+	for i, p := range []*uint8{&size, &horiz, &vert} {
+		if cm, err = parseFixed(strings.TrimSuffix(f[9+i], "m"), 2); err != nil {
+			return
+		}
+		if *p, err = toLOCPrecision(cm); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// parseLOCAngle parses the degrees, minutes, seconds and hemisphere of a
+// latitude or longitude.
+func parseLOCAngle(f []string, positive, negative string) (uint32, error) {
+	deg, err := strconv.ParseInt(f[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	min, err := strconv.ParseInt(f[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	ms, err := parseFixed(f[2], 3)
+	if err != nil {
+		return 0, err
+	}
+	ms += (deg*60 + min) * 60000
+	switch strings.ToUpper(f[3]) {
+	case positive:
+		return uint32(locEquator + ms), nil
+	case negative:
+		return uint32(locEquator - ms), nil
+	}
+	return 0, fmt.Errorf("unexpected LOC hemisphere %q", f[3])
+}
+
+// parseFixed parses a decimal number as an integer number of 10^-decimals.
+func parseFixed(s string, decimals int) (int64, error) {
+	negative := strings.HasPrefix(s, "-")
+	whole, frac, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if len(frac) > decimals {
+		return 0, fmt.Errorf("too many decimals in %q", s)
+	}
+	v, err := strconv.ParseInt(whole+frac+strings.Repeat("0", decimals-len(frac)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// toLOCPrecision encodes centimeters as a mantissa and a power of 10.
+func toLOCPrecision(cm int64) (uint8, error) {
+	m, e := cm, uint8(0)
+	for m > 9 && m%10 == 0 {
+		m /= 10
+		e++
+	}
+	if m > 9 || m < 0 || e > 9 {
+		return 0, fmt.Errorf("LOC size or precision of %dcm can't be encoded", cm)
+	}
+	return uint8(m)<<4 | e, nil
+}
@@ -0,0 +1,117 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+)
+
+// The zone metadata managed from the domain metadata. The values are
+// comma-separated lists.
+const (
+	metaAlsoNotify    = "powerdns_also_notify"
+	metaAllowAXFRFrom = "powerdns_allow_axfr_from"
+	metaEnableLua     = "powerdns_enable_lua_records"
+)
+
+// zoneMetadataKinds maps the domain metadata to the PowerDNS metadata kinds.
+var zoneMetadataKinds = []struct{ meta, kind string }{
+	{metaAlsoNotify, "ALSO-NOTIFY"},
+	{metaAllowAXFRFrom, "ALLOW-AXFR-FROM"},
+	{metaEnableLua, "ENABLE-LUA-RECORDS"},
+}
+
+// zoneMetadata is a metadata item of a zone.
+// https://doc.powerdns.com/authoritative/http-api/metadata.html
+type zoneMetadata struct {
+	Kind     string   `json:"kind"`
+	Metadata []string `json:"metadata"`
+}
+
+func (dsp *powerdnsProvider) metadataPath(domain string, kind string) string {
+	path := fmt.Sprintf("/servers/%s/zones/%s/metadata", url.PathEscape(dsp.ServerName), url.PathEscape(canonical(domain)))
+	if kind != "" {
+		path += "/" + url.PathEscape(kind)
+	}
+	return path
+}
+
+// getZoneMetadata returns the metadata items of a zone, by kind.
+func (dsp *powerdnsProvider) getZoneMetadata(domain string) (map[string][]string, error) {
+	var items []zoneMetadata
+	if err := dsp.api.Get(context.Background(), dsp.metadataPath(domain, ""), &items); err != nil {
+		return nil, err
+	}
+	metadata := map[string][]string{}
+	for _, item := range items {
+		metadata[item.Kind] = item.Metadata
+	}
+	return metadata, nil
+}
+
+// setZoneMetadata replaces the values of a metadata item, or deletes it if there are none.
+func (dsp *powerdnsProvider) setZoneMetadata(domain string, kind string, values []string) error {
+	path := dsp.metadataPath(domain, kind)
+	if len(values) == 0 {
+		return dsp.api.Delete(context.Background(), path, nil)
+	}
+	item := zoneMetadata{Kind: kind, Metadata: values}
+	return dsp.api.Put(context.Background(), path, nil, pdnshttp.WithJSONRequestBody(item))
+}
+
+// splitMetadataList returns the sorted values of a comma-separated list.
+func splitMetadataList(s string) []string {
+	values := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// getZoneMetadataCorrections returns corrections that update the metadata
+// items of a zone. Only the items set in the domain metadata are managed, an
+// empty value removes the item.
+func (dsp *powerdnsProvider) getZoneMetadataCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	var existing map[string][]string
+	var corrections []*models.Correction
+	for _, k := range zoneMetadataKinds {
+		value, ok := dc.Metadata[k.meta]
+		if !ok {
+			continue
+		}
+		if existing == nil {
+			var err error
+			if existing, err = dsp.getZoneMetadata(dc.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		desired := splitMetadataList(value)
+		current := append([]string{}, existing[k.kind]...)
+		sort.Strings(current)
+		if strings.Join(current, ",") == strings.Join(desired, ",") {
+			continue
+		}
+
+		kind := k.kind
+		msg := fmt.Sprintf("Set %s metadata to %q (was %q)", kind, strings.Join(desired, ","), strings.Join(current, ","))
+		if len(desired) == 0 {
+			msg = fmt.Sprintf("Remove %s metadata (was %q)", kind, strings.Join(current, ","))
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: msg,
+			F: func() error {
+				return dsp.setZoneMetadata(dc.Name, kind, desired)
+			},
+		})
+	}
+	return corrections, nil
+}
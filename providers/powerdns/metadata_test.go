@@ -0,0 +1,359 @@
+package powerdns
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	pdns "github.com/mittwald/go-powerdns"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestProvider returns a provider whose API is served by responses, by
+// method and path. The requests are recorded with their body.
+func newTestProvider(t *testing.T, responses map[string]interface{}) (*powerdnsProvider, *[]string) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		if response, ok := responses[r.Method+" "+r.URL.Path]; ok {
+			json.NewEncoder(w).Encode(response)
+		} else if r.Method == http.MethodPost {
+			w.Write(body)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := pdns.New(pdns.WithBaseURL(server.URL), pdns.WithAPIKeyAuthentication("key"), pdns.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &powerdnsProvider{
+		ServerName: "localhost",
+		client:     client,
+		api:        pdnshttp.NewClient(server.URL, server.Client(), &pdnshttp.APIKeyAuthenticator{APIKey: "key"}, io.Discard),
+	}, &requests
+}
+
+func TestGetZoneMetadataCorrections(t *testing.T) {
+	dsp, requests := newTestProvider(t, map[string]interface{}{
+		"GET /api/v1/servers/localhost/zones/example.com./metadata": []zoneMetadata{
+			{Kind: "ALSO-NOTIFY", Metadata: []string{"192.0.2.2", "192.0.2.1"}},
+			{Kind: "ALLOW-AXFR-FROM", Metadata: []string{"AUTO-NS"}},
+			{Kind: "SOA-EDIT", Metadata: []string{"INCEPTION-INCREMENT"}},
+		},
+	})
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Metadata: map[string]string{
+			metaAlsoNotify:    "192.0.2.1, 192.0.2.2, 192.0.2.3",
+			metaAllowAXFRFrom: "",
+		},
+	}
+
+	corrections, err := dsp.getZoneMetadataCorrections(dc)
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 2) {
+		assert.Equal(t, `Set ALSO-NOTIFY metadata to "192.0.2.1,192.0.2.2,192.0.2.3" (was "192.0.2.1,192.0.2.2")`, corrections[0].Msg)
+		assert.Equal(t, `Remove ALLOW-AXFR-FROM metadata (was "AUTO-NS")`, corrections[1].Msg)
+		for _, c := range corrections {
+			assert.NoError(t, c.F())
+		}
+	}
+	assert.Equal(t, []string{
+		"GET /api/v1/servers/localhost/zones/example.com./metadata ",
+		"PUT /api/v1/servers/localhost/zones/example.com./metadata/ALSO-NOTIFY {\"kind\":\"ALSO-NOTIFY\",\"metadata\":[\"192.0.2.1\",\"192.0.2.2\",\"192.0.2.3\"]}\n",
+		"DELETE /api/v1/servers/localhost/zones/example.com./metadata/ALLOW-AXFR-FROM ",
+	}, *requests)
+}
+
+func TestGetZoneSettingsCorrections(t *testing.T) {
+	dsp, requests := newTestProvider(t, map[string]interface{}{
+		"GET /api/v1/servers/localhost/tsigkeys": []tsigKey{
+			{ID: "other-key.", Name: "other-key", Algorithm: "hmac-sha256"},
+		},
+		"GET /api/v1/servers/localhost/zones/example.com.": map[string]interface{}{
+			"master_tsig_key_ids": []string{"old-key."},
+		},
+	})
+	dsp.tsigKeys = map[string]*tsigKey{
+		"xfr-key": {Name: "xfr-key", Algorithm: "hmac-sha256", Key: "c2VjcmV0"},
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Metadata: map[string]string{
+			metaTSIGAllowAXFR:  "xfr-key",
+			metaAXFRMasterTSIG: "other-key",
+		},
+	}
+
+	corrections, err := dsp.getZoneSettingsCorrections(dc)
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 3) {
+		assert.Equal(t, `Create TSIG key xfr-key (hmac-sha256)`, corrections[0].Msg)
+		assert.Equal(t, `Set TSIG-ALLOW-AXFR keys to "xfr-key." (was "old-key.")`, corrections[1].Msg)
+		assert.Equal(t, `Set AXFR-MASTER-TSIG keys to "other-key." (was "")`, corrections[2].Msg)
+		for _, c := range corrections {
+			assert.NoError(t, c.F())
+		}
+	}
+	assert.Equal(t, []string{
+		"GET /api/v1/servers/localhost/tsigkeys ",
+		"GET /api/v1/servers/localhost/zones/example.com. ",
+		"POST /api/v1/servers/localhost/tsigkeys {\"name\":\"xfr-key\",\"algorithm\":\"hmac-sha256\",\"key\":\"c2VjcmV0\"}\n",
+		"PUT /api/v1/servers/localhost/zones/example.com. {\"master_tsig_key_ids\":[\"xfr-key.\"]}\n",
+		"PUT /api/v1/servers/localhost/zones/example.com. {\"slave_tsig_key_ids\":[\"other-key.\"]}\n",
+	}, *requests)
+}
+
+func TestGetRectifyCorrection(t *testing.T) {
+	dsp, _ := newTestProvider(t, map[string]interface{}{
+		"GET /api/v1/servers/localhost/zones/signed.example.": map[string]interface{}{"dnssec": true},
+		"GET /api/v1/servers/localhost/zones/auto.example.":   map[string]interface{}{"dnssec": true, "api_rectify": true},
+		"GET /api/v1/servers/localhost/zones/plain.example.":  map[string]interface{}{"dnssec": false},
+	})
+	changes := []*models.Correction{{Msg: "change", F: func() error { return nil }}}
+
+	for domain, expected := range map[string]bool{"signed.example": true, "auto.example": false, "plain.example": false} {
+		c, err := dsp.getRectifyCorrection(&models.DomainConfig{Name: domain}, changes)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, c != nil, domain)
+	}
+
+	c, err := dsp.getRectifyCorrection(&models.DomainConfig{Name: "signed.example"}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	disabled := false
+	dsp.AutoRectify = &disabled
+	c, err = dsp.getRectifyCorrection(&models.DomainConfig{Name: "signed.example"}, changes)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+}
+
+func TestGetZoneSettingsCorrectionsCatalog(t *testing.T) {
+	dsp, requests := newTestProvider(t, map[string]interface{}{
+		"GET /api/v1/servers/localhost/zones/member.example.":  map[string]interface{}{"kind": "Master", "catalog": ""},
+		"GET /api/v1/servers/localhost/zones/catalog.example.": map[string]interface{}{"kind": "Producer", "catalog": ""},
+		"GET /api/v1/servers/localhost/zones/native.example.":  map[string]interface{}{"kind": "Native", "catalog": ""},
+		"GET /api/v1/servers/localhost/zones/removed.example.": map[string]interface{}{"kind": "Slave", "catalog": "catalog.example."},
+	})
+	dsp.Catalog = "catalog.example"
+
+	corrections, err := dsp.getZoneSettingsCorrections(&models.DomainConfig{Name: "member.example"})
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 1) {
+		assert.Equal(t, `Set catalog to "catalog.example." (was "")`, corrections[0].Msg)
+		assert.NoError(t, corrections[0].F())
+		assert.Equal(t, "PUT /api/v1/servers/localhost/zones/member.example. {\"catalog\":\"catalog.example.\"}\n", (*requests)[len(*requests)-1])
+	}
+
+	corrections, err = dsp.getZoneSettingsCorrections(&models.DomainConfig{Name: "catalog.example"})
+	assert.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	_, err = dsp.getZoneSettingsCorrections(&models.DomainConfig{Name: "native.example"})
+	assert.Error(t, err)
+
+	dsp.Catalog = ""
+	corrections, err = dsp.getZoneSettingsCorrections(&models.DomainConfig{Name: "removed.example", Metadata: map[string]string{metaSOAEdit: ""}})
+	assert.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	corrections, err = dsp.getZoneSettingsCorrections(&models.DomainConfig{Name: "removed.example", Metadata: map[string]string{metaCatalog: ""}})
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 1) {
+		assert.Equal(t, `Set catalog to "" (was "catalog.example.")`, corrections[0].Msg)
+	}
+}
+
+func TestGetZoneSettingsCorrectionsKind(t *testing.T) {
+	dsp, requests := newTestProvider(t, map[string]interface{}{
+		"GET /api/v1/servers/localhost/zones/example.com.": map[string]interface{}{"kind": "Native", "masters": []string{}},
+	})
+	dsp.Catalog = "catalog.example"
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Metadata: map[string]string{
+			metaZoneKind: "Slave",
+			metaMasters:  "192.0.2.2, 192.0.2.1:5300",
+		},
+	}
+
+	corrections, err := dsp.getZoneSettingsCorrections(dc)
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 3) {
+		assert.Equal(t, `Set kind to "Slave" (was "Native")`, corrections[0].Msg)
+		assert.Equal(t, `Set masters to "192.0.2.1:5300,192.0.2.2" (was "")`, corrections[1].Msg)
+		assert.Equal(t, `Set catalog to "catalog.example." (was "")`, corrections[2].Msg)
+		for _, c := range corrections {
+			assert.NoError(t, c.F())
+		}
+	}
+	assert.Equal(t, []string{
+		"GET /api/v1/servers/localhost/zones/example.com. ",
+		"PUT /api/v1/servers/localhost/zones/example.com. {\"kind\":\"Slave\"}\n",
+		"PUT /api/v1/servers/localhost/zones/example.com. {\"masters\":[\"192.0.2.1:5300\",\"192.0.2.2\"]}\n",
+		"PUT /api/v1/servers/localhost/zones/example.com. {\"catalog\":\"catalog.example.\"}\n",
+	}, *requests)
+
+	dc.Metadata[metaZoneKind] = "Bogus"
+	_, err = dsp.getZoneSettingsCorrections(dc)
+	assert.Error(t, err)
+}
+
+func TestGetRolloverCorrections(t *testing.T) {
+	const keysPath = "/api/v1/servers/localhost/zones/example.com./cryptokeys"
+	const metadataPath = "/api/v1/servers/localhost/zones/example.com./metadata"
+	responses := map[string]interface{}{
+		"GET " + keysPath: []map[string]interface{}{
+			{"id": 1, "keytype": "csk", "active": true, "published": true, "ds": []string{"1111 13 1 aa", "1111 13 2 0123456789abcdef"}},
+		},
+		"GET " + metadataPath: []zoneMetadata{},
+		"POST " + keysPath:    map[string]interface{}{"id": 2, "keytype": "csk", "active": true, "published": true},
+	}
+	dsp, requests := newTestProvider(t, responses)
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+	dc := &models.DomainConfig{
+		Name:     "example.com",
+		Metadata: map[string]string{metaKSKRollover: "2026-10"},
+	}
+
+	// Step 1: the new key.
+	corrections, err := dsp.getRolloverCorrections(dc)
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 1) {
+		assert.Equal(t, "DNSSEC KSK rollover 2026-10: create the new csk (step 1/3)", corrections[0].Msg)
+		assert.NoError(t, corrections[0].F())
+	}
+	assert.Equal(t, []string{
+		"POST " + keysPath + " {\"keytype\":\"csk\",\"active\":true,\"published\":true}\n",
+		"PUT " + metadataPath + "/X-DNSCONTROL-KSK-ROLLOVER {\"kind\":\"X-DNSCONTROL-KSK-ROLLOVER\",\"metadata\":[\"{\\\"id\\\":\\\"2026-10\\\",\\\"step\\\":1,\\\"time\\\":\\\"2026-10-14T12:00:00Z\\\",\\\"old\\\":[1],\\\"new\\\":2}\"]}\n",
+	}, (*requests)[2:])
+
+	// Step 2 waits for the propagation of the new key.
+	responses["GET "+keysPath] = []map[string]interface{}{
+		{"id": 1, "keytype": "csk", "active": true, "published": true, "ds": []string{"1111 13 2 0123456789abcdef"}},
+		{"id": 2, "keytype": "csk", "active": true, "published": true, "ds": []string{"2222 13 2 fedcba9876543210"}},
+	}
+	responses["GET "+metadataPath] = []zoneMetadata{{
+		Kind:     "X-DNSCONTROL-KSK-ROLLOVER",
+		Metadata: []string{`{"id":"2026-10","step":1,"time":"2026-10-14T12:00:00Z","old":[1],"new":2}`},
+	}}
+	corrections, err = dsp.getRolloverCorrections(dc)
+	assert.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	// Step 2 waits for the DS of the new key in the parent zone.
+	now = now.Add(defaultRolloverWait)
+	parent := []string{"1111 13 2 0123456789ABCDEF"}
+	saved := lookupParentDS
+	t.Cleanup(func() { lookupParentDS = saved })
+	lookupParentDS = func(domain string) ([]string, error) { return parent, nil }
+	corrections, err = dsp.getRolloverCorrections(dc)
+	assert.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	parent = []string{"2222 13 2 FEDCBA9876543210"}
+	corrections, err = dsp.getRolloverCorrections(dc)
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 1) {
+		assert.Equal(t, "DNSSEC KSK rollover 2026-10: the parent zone has the DS of the new key (step 2/3)", corrections[0].Msg)
+	}
+
+	// Step 3: the old key is deleted, if the parent zone still has the DS
+	// of the new key.
+	responses["GET "+metadataPath] = []zoneMetadata{{
+		Kind:     "X-DNSCONTROL-KSK-ROLLOVER",
+		Metadata: []string{`{"id":"2026-10","step":2,"time":"2026-10-14T12:00:00Z","old":[1],"new":2}`},
+	}}
+	parent = []string{"1111 13 2 0123456789ABCDEF"}
+	corrections, err = dsp.getRolloverCorrections(dc)
+	assert.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	parent = []string{"2222 13 2 FEDCBA9876543210"}
+	corrections, err = dsp.getRolloverCorrections(dc)
+	assert.NoError(t, err)
+	if assert.Len(t, corrections, 1) {
+		assert.Equal(t, "DNSSEC KSK rollover 2026-10: delete the old keys 1 (step 3/3)", corrections[0].Msg)
+		*requests = nil
+		assert.NoError(t, corrections[0].F())
+		assert.Equal(t, "DELETE "+keysPath+"/1 ", (*requests)[0])
+	}
+
+	// The rollover is done.
+	responses["GET "+metadataPath] = []zoneMetadata{{
+		Kind:     "X-DNSCONTROL-KSK-ROLLOVER",
+		Metadata: []string{`{"id":"2026-10","step":3,"time":"2026-10-14T12:00:00Z","old":[1],"new":2}`},
+	}}
+	corrections, err = dsp.getRolloverCorrections(dc)
+	assert.NoError(t, err)
+	assert.Empty(t, corrections)
+
+	dc.Metadata[metaZSKRollover] = "2026-10"
+	_, err = dsp.getRolloverCorrections(dc)
+	assert.Error(t, err)
+}
+
+func TestGetDSRecordsRollover(t *testing.T) {
+	const keysPath = "/api/v1/servers/localhost/zones/example.com./cryptokeys"
+	const metadataPath = "/api/v1/servers/localhost/zones/example.com./metadata"
+	responses := map[string]interface{}{
+		"GET " + keysPath: []map[string]interface{}{
+			{"id": 1, "keytype": "csk", "active": true, "published": true, "ds": []string{"1111 13 1 aa", "1111 13 2 0123456789abcdef"}},
+			{"id": 2, "keytype": "csk", "active": true, "published": true, "ds": []string{"2222 13 2 fedcba9876543210"}},
+		},
+		"GET " + metadataPath: []zoneMetadata{{
+			Kind:     "X-DNSCONTROL-KSK-ROLLOVER",
+			Metadata: []string{`{"id":"2026-10","step":1,"time":"2026-10-14T12:00:00Z","old":[1],"new":2}`},
+		}},
+	}
+	dsp, requests := newTestProvider(t, responses)
+	now := time.Date(2026, 10, 14, 13, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	ds := func() []string {
+		records, err := dsp.GetDSRecords("example.com")
+		assert.NoError(t, err)
+		var s []string
+		for _, rc := range records {
+			s = append(s, rc.GetTargetCombined())
+		}
+		return s
+	}
+
+	// Without the registrar DS metadata, nothing is reported.
+	dc := &models.DomainConfig{Name: "example.com", Metadata: map[string]string{metaKSKRollover: "2026-10"}}
+	assert.NoError(t, dsp.setRegistrarDSMode(dc))
+	assert.Empty(t, ds())
+	assert.Empty(t, *requests)
+
+	// The DS of the old key until the new one was published for the wait,
+	// then the DS of the new key.
+	dc.Metadata[metaRolloverDS] = "on"
+	assert.NoError(t, dsp.setRegistrarDSMode(dc))
+	assert.Equal(t, []string{"1111 13 2 0123456789ABCDEF"}, ds())
+	now = now.Add(defaultRolloverWait)
+	assert.Equal(t, []string{"2222 13 2 FEDCBA9876543210"}, ds())
+
+	// Once the rollover is done, the DS of the active keys.
+	responses["GET "+keysPath] = []map[string]interface{}{
+		{"id": 2, "keytype": "csk", "active": true, "published": true, "ds": []string{"2222 13 2 fedcba9876543210"}},
+		{"id": 3, "keytype": "zsk", "active": true, "published": true, "ds": []string{"3333 13 2 abcdef"}},
+	}
+	responses["GET "+metadataPath] = []zoneMetadata{{
+		Kind:     "X-DNSCONTROL-KSK-ROLLOVER",
+		Metadata: []string{`{"id":"2026-10","step":3,"time":"2026-10-14T12:00:00Z","old":[1],"new":2}`},
+	}}
+	assert.Equal(t, []string{"2222 13 2 FEDCBA9876543210"}, ds())
+}
@@ -1,16 +1,17 @@
 package powerdns
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"net/http"
-	"strconv"
+	"io"
+	"sync"
+	"time"
 
 	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/pdnshttp"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	pdns "github.com/mittwald/go-powerdns"
 )
@@ -25,7 +26,7 @@ var features = providers.DocumentationNotes{
 	providers.CanUseCAA:              providers.Can(),
 	providers.CanUseDS:               providers.Can(),
 	providers.CanUseDHCID:            providers.Can(),
-	providers.CanUseLOC:              providers.Unimplemented("Normalization within the PowerDNS API seems to be buggy, so disabled", "https://github.com/PowerDNS/pdns/issues/10558"),
+	providers.CanUseLOC:              providers.Can(),
 	providers.CanUseNAPTR:            providers.Can(),
 	providers.CanUsePTR:              providers.Can(),
 	providers.CanUseSRV:              providers.Can(),
@@ -45,11 +46,14 @@ func init() {
 	}
 	providers.RegisterDomainServiceProviderType(providerName, fns, features)
 	providers.RegisterMaintainer(providerName, providerMaintainer)
+	providers.RegisterTLSOptions(providerName)
+	providers.RegisterCustomRecordType("POWERDNS_LUA", providerName, "LUA")
 }
 
 // powerdnsProvider represents the powerdnsProvider DNSServiceProvider.
 type powerdnsProvider struct {
 	client         pdns.Client
+	api            *pdnshttp.Client // For the API endpoints that the client does not implement.
 	APIKey         string
 	APIUrl         string
 	ServerName     string
@@ -57,8 +61,17 @@ type powerdnsProvider struct {
 	DNSSecOnCreate bool           `json:"dnssec_on_create"`
 	ZoneKind       zones.ZoneKind `json:"zone_kind"`
 	SOAEditAPI     string         `json:"soa_edit_api,omitempty"`
+	AutoRectify    *bool          `json:"auto_rectify,omitempty"`
+	Catalog        string         `json:"catalog,omitempty"`
 
 	nameservers []*models.Nameserver
+
+	tsigKeys       map[string]*tsigKey // The TSIG keys declared in creds.json.
+	tsigKeysDir    string              // Where the TSIG keys generated by PowerDNS are written.
+	serverTSIGKeys map[string]tsigKey  // The TSIG keys of the server, once listed.
+
+	registrarDSMu sync.Mutex
+	registrarDS   map[string]time.Duration // The domains whose DS records are reported, with their rollover wait.
 }
 
 // newDSP initializes a PowerDNS DNSServiceProvider.
@@ -80,6 +93,13 @@ func newDSP(m map[string]string, metadata json.RawMessage) (providers.DNSService
 		return nil, fmt.Errorf("PowerDNS server name is required")
 	}
 
+	var err error
+	dsp.tsigKeys, err = parseTSIGKeys(m["tsigKeys"])
+	if err != nil {
+		return nil, err
+	}
+	dsp.tsigKeysDir = m["tsigKeysDir"]
+
 	// load js config
 	if len(metadata) != 0 {
 		err := json.Unmarshal(metadata, dsp)
@@ -91,37 +111,18 @@ func newDSP(m map[string]string, metadata json.RawMessage) (providers.DNSService
 	for _, ns := range dsp.DefaultNS {
 		nss = append(nss, ns[0:len(ns)-1])
 	}
-	var err error
 	dsp.nameservers, err = models.ToNameservers(nss)
 	if err != nil {
 		return dsp, err
 	}
 
-	client := &http.Client{}
-
-	if _, ok := m["skipTLSVerify"]; ok {
-		if client.Transport == nil {
-			client.Transport = &http.Transport{TLSClientConfig: &tls.Config{}}
-		}
-
-		client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify, err = strconv.ParseBool(m["skipTLSVerify"])
-		if err != nil {
-			return dsp, err
-		}
+	// skipTLSVerify is the former name of skip_tls_verify.
+	if v, ok := m["skipTLSVerify"]; ok && m["skip_tls_verify"] == "" {
+		m["skip_tls_verify"] = v
 	}
-
-	if _, ok := m["cert"]; ok {
-		if client.Transport == nil {
-			client.Transport = &http.Transport{TLSClientConfig: &tls.Config{}}
-		}
-
-		roots := x509.NewCertPool()
-		ok := roots.AppendCertsFromPEM([]byte(m["cert"]))
-		if !ok {
-			return dsp, fmt.Errorf("unable to parse given certificate")
-		}
-
-		client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+	client, err := tlsconfig.NewHTTPClient(m)
+	if err != nil {
+		return dsp, err
 	}
 
 	var clientErr error
@@ -130,5 +131,9 @@ func newDSP(m map[string]string, metadata json.RawMessage) (providers.DNSService
 		pdns.WithAPIKeyAuthentication(dsp.APIKey),
 		pdns.WithHTTPClient(client),
 	)
-	return dsp, clientErr
+	if clientErr != nil {
+		return dsp, clientErr
+	}
+	dsp.api = pdnshttp.NewClient(dsp.APIUrl, client, &pdnshttp.APIKeyAuthenticator{APIKey: dsp.APIKey}, io.Discard)
+	return dsp, nil
 }
@@ -0,0 +1,419 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/miekg/dns"
+	"github.com/mittwald/go-powerdns/apis/cryptokeys"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+)
+
+// The DNSSEC key rollovers are requested with the domain metadata. A
+// rollover is done once per value, e.g. "2026-10", in 3 steps that are
+// separated by the rollover wait:
+//
+//	KSK/CSK (double signature): 1. create the new key, which signs with
+//	the old one. 2. check that the parent zone has the DS of the new key.
+//	3. delete the old key, if the parent zone still has the DS.
+//	ZSK (pre-publication): 1. publish the new key. 2. sign with the new key
+//	instead of the old one. 3. delete the old key.
+//
+// The progress is saved in a custom metadata of the zone, so each push does
+// the next step when it is due.
+const (
+	metaKSKRollover     = "powerdns_ksk_rollover"
+	metaZSKRollover     = "powerdns_zsk_rollover"
+	metaRolloverWait    = "powerdns_rollover_wait"
+	metaRolloverDS      = "powerdns_registrar_ds"
+	defaultRolloverWait = 24 * time.Hour
+	rolloverSteps       = 3
+)
+
+// rolloverState is the progress of a rollover, saved as JSON in the
+// X-DNSCONTROL-KSK-ROLLOVER and X-DNSCONTROL-ZSK-ROLLOVER metadata.
+type rolloverState struct {
+	ID   string    `json:"id"`   // The value of the rollover metadata.
+	Step int       `json:"step"` // The last step done.
+	Time time.Time `json:"time"` // When the last step was done.
+	Old  []int     `json:"old"`  // The keys that are replaced.
+	New  int       `json:"new"`  // The new key.
+}
+
+// timeNow is replaced by the tests.
+var timeNow = time.Now
+
+// rollover describes the rollover of a type of key.
+type rollover struct {
+	name, meta, kind string
+	ksk              bool // Whether the keys sign the DNSKEY records.
+}
+
+var rollovers = []rollover{
+	{"KSK", metaKSKRollover, "X-DNSCONTROL-KSK-ROLLOVER", true},
+	{"ZSK", metaZSKRollover, "X-DNSCONTROL-ZSK-ROLLOVER", false},
+}
+
+// rolledKey returns whether a key is concerned by a rollover.
+func (r rollover) rolledKey(key cryptokeys.Cryptokey) bool {
+	if r.ksk {
+		return key.KeyType == "ksk" || key.KeyType == "csk"
+	}
+	return key.KeyType == "zsk"
+}
+
+// setCryptokey activates or deactivates a key. It is published either way.
+func (dsp *powerdnsProvider) setCryptokey(domain string, id int, active bool) error {
+	path := fmt.Sprintf("/servers/%s/zones/%s/cryptokeys/%d", url.PathEscape(dsp.ServerName), url.PathEscape(canonical(domain)), id)
+	return dsp.api.Put(context.Background(), path, nil, pdnshttp.WithJSONRequestBody(map[string]bool{"active": active, "published": true}))
+}
+
+// saveRollover saves the progress of a rollover.
+func (dsp *powerdnsProvider) saveRollover(domain string, kind string, state rolloverState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return dsp.setZoneMetadata(domain, kind, []string{string(value)})
+}
+
+// rolloverWait returns the time between the steps of the rollovers of dc.
+func rolloverWait(dc *models.DomainConfig) (time.Duration, error) {
+	value := dc.Metadata[metaRolloverWait]
+	if value == "" {
+		return defaultRolloverWait, nil
+	}
+	wait, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("bad metadata value for %s: %q: %w", metaRolloverWait, value, err)
+	}
+	return wait, nil
+}
+
+// readRolloverState returns the progress of the rollover r saved in the
+// metadata of the zone, if any.
+func readRolloverState(domain string, metadata map[string][]string, r rollover) (rolloverState, error) {
+	var state rolloverState
+	if values := metadata[r.kind]; len(values) > 0 {
+		if err := json.Unmarshal([]byte(values[0]), &state); err != nil {
+			return state, fmt.Errorf("bad %s metadata of %s: %w", r.kind, domain, err)
+		}
+	}
+	return state, nil
+}
+
+// setRegistrarDSMode records whether the DS records of dc are reported to
+// its registrar by GetDSRecords, with the registrar DS metadata.
+func (dsp *powerdnsProvider) setRegistrarDSMode(dc *models.DomainConfig) error {
+	wait, err := rolloverWait(dc)
+	if err != nil {
+		return err
+	}
+	dsp.registrarDSMu.Lock()
+	defer dsp.registrarDSMu.Unlock()
+	if dsp.registrarDS == nil {
+		dsp.registrarDS = map[string]time.Duration{}
+	}
+	if dc.Metadata[metaRolloverDS] == "on" {
+		dsp.registrarDS[dc.Name] = wait
+	} else {
+		delete(dsp.registrarDS, dc.Name)
+	}
+	return nil
+}
+
+// parentKeys returns the keys whose DS records belong in the parent zone:
+// the active KSK, but during the KSK rollover state the new key instead of
+// the old ones, once the new key was published for the wait.
+func parentKeys(keys []cryptokeys.Cryptokey, state rolloverState, wait time.Duration) map[int]bool {
+	inProgress := state.ID != "" && state.Step < rolloverSteps
+	replaced := inProgress && (state.Step >= 2 || !timeNow().Before(state.Time.Add(wait)))
+	old := map[int]bool{}
+	for _, id := range state.Old {
+		old[id] = true
+	}
+
+	ids := map[int]bool{}
+	for _, key := range keys {
+		if !key.Active || !rollovers[0].rolledKey(key) {
+			continue
+		}
+		if inProgress && (replaced && old[key.ID] || !replaced && key.ID == state.New) {
+			continue
+		}
+		ids[key.ID] = true
+	}
+	return ids
+}
+
+// GetDSRecords returns the DS records of the keys that sign the DNSKEY
+// records, to be published by the registrar, for the domains with the
+// registrar DS metadata. During a KSK rollover, those of the new key replace
+// the old ones once the new key was published for the rollover wait.
+func (dsp *powerdnsProvider) GetDSRecords(domain string) (models.Records, error) {
+	dsp.registrarDSMu.Lock()
+	wait, ok := dsp.registrarDS[domain]
+	dsp.registrarDSMu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	keys, err := dsp.client.Cryptokeys().ListCryptokeys(context.Background(), dsp.ServerName, canonical(domain))
+	if err != nil {
+		if e, ok := err.(pdnshttp.ErrUnexpectedStatus); ok && e.StatusCode == http.StatusNotFound {
+			// The zone does not exist yet.
+			return nil, nil
+		}
+		return nil, err
+	}
+	metadata, err := dsp.getZoneMetadata(domain)
+	if err != nil {
+		return nil, err
+	}
+	state, err := readRolloverState(domain, metadata, rollovers[0])
+	if err != nil {
+		return nil, err
+	}
+	return keysDS(domain, keys, parentKeys(keys, state, wait))
+}
+
+// lookupParentDS returns the DS records of the domain in its parent zone, as
+// "keytag algorithm digesttype digest", from the DNS servers of the system.
+// It is replaced by the tests.
+var lookupParentDS = func(domain string) ([]string, error) {
+	conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil {
+		return nil, err
+	}
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(domain), dns.TypeDS)
+	c := new(dns.Client)
+	err = fmt.Errorf("no DNS server")
+	for _, server := range conf.Servers {
+		in, _, xerr := c.Exchange(m, net.JoinHostPort(server, conf.Port))
+		if xerr != nil {
+			err = xerr
+			continue
+		}
+		if in.Rcode != dns.RcodeSuccess {
+			err = fmt.Errorf("%s answered %s", server, dns.RcodeToString[in.Rcode])
+			continue
+		}
+		var records []string
+		for _, rr := range in.Answer {
+			if ds, ok := rr.(*dns.DS); ok {
+				records = append(records, fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest))
+			}
+		}
+		return records, nil
+	}
+	return nil, err
+}
+
+// inParent returns whether the DS records of the parent zone have one of
+// those of the key id.
+func inParent(keys []cryptokeys.Cryptokey, id int, parent []string) bool {
+	for _, key := range keys {
+		if key.ID != id {
+			continue
+		}
+		for _, ds := range key.DS {
+			for _, p := range parent {
+				if strings.EqualFold(strings.Join(strings.Fields(ds), " "), strings.Join(strings.Fields(p), " ")) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// keysDS returns the SHA-256 DS records of some keys.
+func keysDS(domain string, keys []cryptokeys.Cryptokey, ids map[int]bool) (models.Records, error) {
+	var records models.Records
+	for _, key := range keys {
+		if !ids[key.ID] {
+			continue
+		}
+		for _, ds := range key.DS {
+			if f := strings.Fields(ds); len(f) != 4 || f[2] != "2" {
+				continue
+			}
+			rc := &models.RecordConfig{Type: "DS", TTL: models.DefaultTTL, Metadata: map[string]string{}}
+			rc.SetLabel("@", domain)
+			if err := rc.SetTargetDSString(ds); err != nil {
+				return nil, err
+			}
+			records = append(records, rc)
+		}
+	}
+	return records, nil
+}
+
+// getRolloverCorrections returns the correction of the next step of the
+// rollovers in progress. A KSK rollover only goes past the second step once
+// the parent zone has the DS records of the new key: with the registrar DS
+// metadata, the registrar publishes them (GetDSRecords).
+func (dsp *powerdnsProvider) getRolloverCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	requested := false
+	for _, r := range rollovers {
+		if dc.Metadata[r.meta] != "" {
+			requested = true
+		}
+	}
+	if !requested {
+		return nil, nil
+	}
+	registrarDS := dc.Metadata[metaRolloverDS] == "on" && dc.AutoDNSSEC == "on" && len(dc.RegistrarDS) == 0 && dc.RegistrarInstance != nil &&
+		providers.ProviderHasCapability(dc.RegistrarInstance.ProviderType, providers.CanUseDSAtRegistrar)
+
+	wait, err := rolloverWait(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := dsp.client.Cryptokeys().ListCryptokeys(context.Background(), dsp.ServerName, canonical(dc.Name))
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := dsp.getZoneMetadata(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, r := range rollovers {
+		state, err := readRolloverState(dc.Name, metadata, r)
+		if err != nil {
+			return nil, err
+		}
+		id := dc.Metadata[r.meta]
+		if id == "" || (state.ID == id && state.Step == rolloverSteps) {
+			continue
+		}
+
+		name := fmt.Sprintf("DNSSEC %s rollover %s", r.name, id)
+		if state.ID != id {
+			// Step 1: a new key for the active ones.
+			state = rolloverState{ID: id}
+			keyType := "zsk"
+			kind := r.kind
+			for _, key := range keys {
+				if key.Active && r.rolledKey(key) {
+					state.Old = append(state.Old, key.ID)
+					keyType = key.KeyType
+				}
+			}
+			if len(state.Old) == 0 {
+				return nil, fmt.Errorf("%s of %s: there is no active key to replace", name, dc.Name)
+			}
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("%s: create the new %s (step 1/%d)", name, keyType, rolloverSteps),
+				F: func() error {
+					key, err := dsp.client.Cryptokeys().CreateCryptokey(context.Background(), dsp.ServerName, canonical(dc.Name), cryptokeys.Cryptokey{
+						KeyType:   keyType,
+						Active:    r.ksk,
+						Published: true,
+					})
+					if err != nil {
+						return err
+					}
+					state.Step, state.Time, state.New = 1, timeNow(), key.ID
+					return dsp.saveRollover(dc.Name, kind, state)
+				},
+			})
+			continue
+		}
+
+		due := state.Time.Add(wait)
+		if timeNow().Before(due) {
+			printer.Printf("%s of %s: step %d/%d is due after %s\n", name, dc.Name, state.Step+1, rolloverSteps, due.Format(time.RFC3339))
+			continue
+		}
+
+		step := state.Step + 1
+		if r.ksk {
+			// The old key is only retired once the parent zone has the DS
+			// of the new key, or the chain of trust would break.
+			ds, err := keysDS(dc.Name, keys, map[int]bool{state.New: true})
+			if err != nil {
+				return nil, err
+			}
+			var dsText []string
+			for _, rc := range ds {
+				dsText = append(dsText, rc.GetTargetCombined())
+			}
+			parent, err := lookupParentDS(dc.Name)
+			if err != nil {
+				printer.Warnf("%s of %s: cannot read the DS records of the parent zone: %s\n", name, dc.Name, err)
+				continue
+			}
+			if !inParent(keys, state.New, parent) {
+				how := "they must be set at the registrar"
+				if registrarDS {
+					how = "they are given to the registrar"
+				}
+				printer.Printf("%s of %s: step %d/%d waits for the DS records %s of the new key in the parent zone, %s\n",
+					name, dc.Name, step, rolloverSteps, strings.Join(dsText, ", "), how)
+				continue
+			}
+		}
+
+		var msg string
+		var f func() error
+		switch {
+		case step == 2 && r.ksk:
+			msg = fmt.Sprintf("%s: the parent zone has the DS of the new key", name)
+			f = func() error { return nil }
+		case step == 2:
+			msg = fmt.Sprintf("%s: sign with the new key", name)
+			f = func() error {
+				if err := dsp.setCryptokey(dc.Name, state.New, true); err != nil {
+					return err
+				}
+				for _, id := range state.Old {
+					if err := dsp.setCryptokey(dc.Name, id, false); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		default:
+			var old []string
+			for _, id := range state.Old {
+				old = append(old, strconv.Itoa(id))
+			}
+			msg = fmt.Sprintf("%s: delete the old keys %s", name, strings.Join(old, ", "))
+			f = func() error {
+				for _, id := range state.Old {
+					if err := dsp.client.Cryptokeys().DeleteCryptokey(context.Background(), dsp.ServerName, canonical(dc.Name), id); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+		kind, do := r.kind, f
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("%s (step %d/%d)", msg, step, rolloverSteps),
+			F: func() error {
+				if err := do(); err != nil {
+					return err
+				}
+				state.Step, state.Time = step, timeNow()
+				return dsp.saveRollover(dc.Name, kind, state)
+			},
+		})
+	}
+	return corrections, nil
+}
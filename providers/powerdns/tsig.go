@@ -0,0 +1,198 @@
+package powerdns
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+)
+
+// tsigKey is a TSIG key of the server.
+// https://doc.powerdns.com/authoritative/http-api/tsigkey.html
+type tsigKey struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	Key       string `json:"key,omitempty"`
+}
+
+// parseTSIGKeys parses the tsigKeys setting of creds.json, a comma-separated
+// list of algorithm:name[:secret]. The keys without a secret are generated by
+// PowerDNS.
+func parseTSIGKeys(raw string) (map[string]*tsigKey, error) {
+	keys := map[string]*tsigKey{}
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		arr := strings.Split(item, ":")
+		if len(arr) != 2 && len(arr) != 3 {
+			return nil, fmt.Errorf("invalid TSIG key format %q in PowerDNS tsigKeys, use algorithm:name[:secret]", item)
+		}
+		key := &tsigKey{Name: tsigKeyName(arr[1]), Algorithm: strings.ToLower(arr[0])}
+		switch key.Algorithm {
+		case "hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512":
+		default:
+			return nil, fmt.Errorf("unknown algorithm %q of TSIG key %s in PowerDNS tsigKeys", arr[0], key.Name)
+		}
+		if len(arr) == 3 {
+			if _, err := base64.StdEncoding.DecodeString(arr[2]); err != nil {
+				return nil, fmt.Errorf("cannot decode Base64 secret of TSIG key %s in PowerDNS tsigKeys", key.Name)
+			}
+			key.Key = arr[2]
+		}
+		keys[key.Name] = key
+	}
+	return keys, nil
+}
+
+// tsigKeyName returns the name of a TSIG key as it is compared.
+func tsigKeyName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
+
+// loadTSIGKeys lists the TSIG keys of the server, once.
+func (dsp *powerdnsProvider) loadTSIGKeys() (map[string]tsigKey, error) {
+	if dsp.serverTSIGKeys != nil {
+		return dsp.serverTSIGKeys, nil
+	}
+	var keys []tsigKey
+	path := fmt.Sprintf("/servers/%s/tsigkeys", url.PathEscape(dsp.ServerName))
+	if err := dsp.api.Get(context.Background(), path, &keys); err != nil {
+		return nil, err
+	}
+	dsp.serverTSIGKeys = map[string]tsigKey{}
+	for _, key := range keys {
+		dsp.serverTSIGKeys[tsigKeyName(key.Name)] = key
+	}
+	return dsp.serverTSIGKeys, nil
+}
+
+// getTSIGKeySecret returns the secret of a TSIG key of the server.
+func (dsp *powerdnsProvider) getTSIGKeySecret(id string) (string, error) {
+	var key tsigKey
+	path := fmt.Sprintf("/servers/%s/tsigkeys/%s", url.PathEscape(dsp.ServerName), url.PathEscape(id))
+	if err := dsp.api.Get(context.Background(), path, &key); err != nil {
+		return "", err
+	}
+	return key.Key, nil
+}
+
+// createTSIGKey creates a TSIG key, unless it was already created for another zone.
+func (dsp *powerdnsProvider) createTSIGKey(declared *tsigKey) error {
+	if _, ok := dsp.serverTSIGKeys[declared.Name]; ok {
+		return nil
+	}
+	var created tsigKey
+	path := fmt.Sprintf("/servers/%s/tsigkeys", url.PathEscape(dsp.ServerName))
+	if err := dsp.api.Post(context.Background(), path, &created, pdnshttp.WithJSONRequestBody(declared)); err != nil {
+		return err
+	}
+	dsp.serverTSIGKeys[declared.Name] = created
+	if declared.Key == "" {
+		return dsp.writeTSIGKey(created)
+	}
+	return nil
+}
+
+// updateTSIGKey replaces the algorithm and the secret of a TSIG key.
+func (dsp *powerdnsProvider) updateTSIGKey(id string, declared *tsigKey) error {
+	path := fmt.Sprintf("/servers/%s/tsigkeys/%s", url.PathEscape(dsp.ServerName), url.PathEscape(id))
+	return dsp.api.Put(context.Background(), path, nil, pdnshttp.WithJSONRequestBody(declared))
+}
+
+// writeTSIGKey writes a key generated by PowerDNS in the tsigKeysDir directory,
+// in the format of BIND, readable by the current user only.
+func (dsp *powerdnsProvider) writeTSIGKey(key tsigKey) error {
+	if dsp.tsigKeysDir == "" {
+		printer.Warnf("The secret of the TSIG key %s was generated by PowerDNS, set tsigKeysDir in creds.json to save it\n", key.Name)
+		return nil
+	}
+	if err := os.MkdirAll(dsp.tsigKeysDir, 0o700); err != nil {
+		return err
+	}
+	filename := filepath.Join(dsp.tsigKeysDir, tsigKeyName(key.Name)+".key")
+	content := fmt.Sprintf("key \"%s\" {\n\talgorithm %s;\n\tsecret \"%s\";\n};\n", tsigKeyName(key.Name), key.Algorithm, key.Key)
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the permissions of an existing file.
+	return os.Chmod(filename, 0o600)
+}
+
+// getTSIGKeyCorrections returns corrections that create or update the TSIG
+// keys used by the zone metadata, when they are declared in creds.json.
+func (dsp *powerdnsProvider) getTSIGKeyCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	names := map[string]bool{}
+	for _, meta := range []string{metaTSIGAllowAXFR, metaAXFRMasterTSIG} {
+		for _, name := range splitMetadataList(dc.Metadata[meta]) {
+			names[tsigKeyName(name)] = true
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	existing, err := dsp.loadTSIGKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var corrections []*models.Correction
+	for _, name := range sorted {
+		declared := dsp.tsigKeys[name]
+		current, ok := existing[name]
+		switch {
+		case declared == nil && !ok:
+			printer.Warnf("TSIG key %s of %s is neither in PowerDNS nor in tsigKeys of creds.json\n", name, dc.Name)
+		case declared == nil:
+			// Managed outside of dnscontrol.
+		case !ok:
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Create TSIG key %s (%s)", name, declared.Algorithm),
+				F: func() error {
+					return dsp.createTSIGKey(declared)
+				},
+			})
+		default:
+			changed := !strings.EqualFold(current.Algorithm, declared.Algorithm)
+			if declared.Key != "" && !changed {
+				secret, err := dsp.getTSIGKeySecret(current.ID)
+				if err != nil {
+					return nil, err
+				}
+				changed = secret != declared.Key
+			}
+			if !changed {
+				continue
+			}
+			if declared.Key == "" {
+				printer.Warnf("TSIG key %s is %s in PowerDNS, set its secret in creds.json to change it to %s\n", name, current.Algorithm, declared.Algorithm)
+				continue
+			}
+			id := current.ID
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Update TSIG key %s (%s)", name, declared.Algorithm),
+				F: func() error {
+					return dsp.updateTSIGKey(id, declared)
+				},
+			})
+		}
+	}
+	return corrections, nil
+}
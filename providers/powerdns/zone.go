@@ -0,0 +1,212 @@
+package powerdns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/mittwald/go-powerdns/apis/zones"
+	"github.com/mittwald/go-powerdns/pdnshttp"
+)
+
+// The zone settings managed from the domain metadata. The TSIG keys are
+// comma-separated lists of names.
+const (
+	metaTSIGAllowAXFR  = "powerdns_tsig_allow_axfr"
+	metaAXFRMasterTSIG = "powerdns_axfr_master_tsig"
+	metaSOAEdit        = "powerdns_soa_edit"
+	metaSOAEditAPI     = "powerdns_soa_edit_api"
+	metaCatalog        = "powerdns_catalog"
+	metaZoneKind       = "powerdns_zone_kind"
+	metaMasters        = "powerdns_masters"
+)
+
+// zoneSettings are the settings of a zone that are not available as
+// metadata through the API, they are changed with the zones endpoint.
+// The fields that are not set are left unchanged.
+// https://doc.powerdns.com/authoritative/http-api/zone.html
+type zoneSettings struct {
+	Kind             *zones.ZoneKind `json:"kind,omitempty"`
+	Catalog          *string         `json:"catalog,omitempty"`
+	Masters          *[]string       `json:"masters,omitempty"`
+	DNSSec           *bool           `json:"dnssec,omitempty"`
+	APIRectify       *bool           `json:"api_rectify,omitempty"`
+	SOAEdit          *string         `json:"soa_edit,omitempty"`
+	SOAEditAPI       *string         `json:"soa_edit_api,omitempty"`
+	MasterTSIGKeyIDs *[]string       `json:"master_tsig_key_ids,omitempty"`
+	SlaveTSIGKeyIDs  *[]string       `json:"slave_tsig_key_ids,omitempty"`
+}
+
+func (dsp *powerdnsProvider) zonePath(domain string) string {
+	return fmt.Sprintf("/servers/%s/zones/%s", url.PathEscape(dsp.ServerName), url.PathEscape(canonical(domain)))
+}
+
+// getZoneSettings returns the settings of a zone, without its records.
+func (dsp *powerdnsProvider) getZoneSettings(domain string) (*zoneSettings, error) {
+	var settings zoneSettings
+	if err := dsp.api.Get(context.Background(), dsp.zonePath(domain), &settings, pdnshttp.WithQueryValue("rrsets", "false")); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// setZoneSettings changes the settings of a zone.
+func (dsp *powerdnsProvider) setZoneSettings(domain string, settings zoneSettings) error {
+	return dsp.api.Put(context.Background(), dsp.zonePath(domain), nil, pdnshttp.WithJSONRequestBody(settings))
+}
+
+// tsigKeyIDs returns the IDs of the TSIG keys of a domain metadata.
+func (dsp *powerdnsProvider) tsigKeyIDs(value string) []string {
+	ids := []string{}
+	for _, name := range splitMetadataList(value) {
+		if key, ok := dsp.serverTSIGKeys[tsigKeyName(name)]; ok && key.ID != "" {
+			ids = append(ids, key.ID)
+		} else {
+			ids = append(ids, canonical(tsigKeyName(name)))
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// getZoneSettingsCorrections returns corrections that update the settings of
+// a zone. Only the settings set in the domain metadata are managed, and the
+// catalog membership when the catalog provider metadata is set. The TSIG
+// keys are created before they are assigned to the zone.
+func (dsp *powerdnsProvider) getZoneSettingsCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	corrections, err := dsp.getTSIGKeyCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, manageCatalog := dc.Metadata[metaCatalog]
+	if !manageCatalog && dsp.Catalog != "" {
+		catalog, manageCatalog = dsp.Catalog, true
+	}
+	managed := manageCatalog
+	for _, meta := range []string{metaZoneKind, metaMasters, metaTSIGAllowAXFR, metaAXFRMasterTSIG, metaSOAEdit, metaSOAEditAPI} {
+		if _, ok := dc.Metadata[meta]; ok {
+			managed = true
+		}
+	}
+	if !managed {
+		return corrections, nil
+	}
+	current, err := dsp.getZoneSettings(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	set := func(name string, existing, desired []string, settings zoneSettings) {
+		existing = append([]string{}, existing...)
+		sort.Strings(existing)
+		if strings.EqualFold(strings.Join(existing, ","), strings.Join(desired, ",")) {
+			return
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("Set %s to %q (was %q)", name, strings.Join(desired, ","), strings.Join(existing, ",")),
+			F: func() error {
+				return dsp.setZoneSettings(dc.Name, settings)
+			},
+		})
+	}
+
+	kind := deref(current.Kind)
+	if value, ok := dc.Metadata[metaZoneKind]; ok {
+		if err := kind.UnmarshalJSON([]byte(`"` + value + `"`)); err != nil {
+			return nil, fmt.Errorf("bad metadata value for %s: %q. Use Native, Master, Slave, Producer or Consumer", metaZoneKind, value)
+		}
+		set("kind", []string{zoneKindName(deref(current.Kind))}, []string{zoneKindName(kind)}, zoneSettings{Kind: &kind})
+	}
+	if value, ok := dc.Metadata[metaMasters]; ok {
+		masters := splitMetadataList(value)
+		set("masters", deref(current.Masters), masters, zoneSettings{Masters: &masters})
+	}
+	if value, ok := dc.Metadata[metaTSIGAllowAXFR]; ok {
+		ids := dsp.tsigKeyIDs(value)
+		set("TSIG-ALLOW-AXFR keys", deref(current.MasterTSIGKeyIDs), ids, zoneSettings{MasterTSIGKeyIDs: &ids})
+	}
+	if value, ok := dc.Metadata[metaAXFRMasterTSIG]; ok {
+		ids := dsp.tsigKeyIDs(value)
+		set("AXFR-MASTER-TSIG keys", deref(current.SlaveTSIGKeyIDs), ids, zoneSettings{SlaveTSIGKeyIDs: &ids})
+	}
+	if value, ok := dc.Metadata[metaSOAEdit]; ok {
+		set("SOA-EDIT", []string{deref(current.SOAEdit)}, []string{value}, zoneSettings{SOAEdit: &value})
+	}
+	if value, ok := dc.Metadata[metaSOAEditAPI]; ok {
+		set("SOA-EDIT-API", []string{deref(current.SOAEditAPI)}, []string{value}, zoneSettings{SOAEditAPI: &value})
+	}
+	if catalog := catalogName(catalog); manageCatalog && !strings.EqualFold(catalog, canonical(dc.Name)) {
+		// Only the primary and secondary zones are members of catalogs,
+		// the producer and consumer zones are the catalogs.
+		switch {
+		case kind == zones.ZoneKindProducer, kind == zones.ZoneKindConsumer:
+		case catalog != "" && kind != zones.ZoneKindMaster && kind != zones.ZoneKindSlave:
+			return nil, fmt.Errorf("%s is not a Master or Slave zone, it can't be a member of the catalog %s", dc.Name, catalog)
+		default:
+			set("catalog", []string{deref(current.Catalog)}, []string{catalog}, zoneSettings{Catalog: &catalog})
+		}
+	}
+	return corrections, nil
+}
+
+// zoneKindName returns the name of a zone kind, or an empty string if it is unknown.
+func zoneKindName(kind zones.ZoneKind) string {
+	name, err := kind.MarshalJSON()
+	if err != nil {
+		return ""
+	}
+	return strings.Trim(string(name), `"`)
+}
+
+// catalogName returns the name of a catalog zone as PowerDNS returns it.
+// An empty name removes the zone from its catalog.
+func catalogName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+	return canonical(strings.TrimSuffix(name, "."))
+}
+
+// getRectifyCorrection returns a correction that rectifies a DNSSEC-signed
+// zone after its records are changed, unless PowerDNS already does it
+// (API-RECTIFY) or it is disabled with the auto_rectify metadata.
+func (dsp *powerdnsProvider) getRectifyCorrection(dc *models.DomainConfig, corrections []*models.Correction) (*models.Correction, error) {
+	if dsp.AutoRectify != nil && !*dsp.AutoRectify {
+		return nil, nil
+	}
+	changed := false
+	for _, c := range corrections {
+		if c.F != nil {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	current, err := dsp.getZoneSettings(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !deref(current.DNSSec) || deref(current.APIRectify) {
+		return nil, nil
+	}
+	return &models.Correction{
+		Msg: "Rectify zone",
+		F: func() error {
+			return dsp.client.Zones().RectifyZone(context.Background(), dsp.ServerName, canonical(dc.Name))
+		},
+	}, nil
+}
+
+func deref[T any](p *T) (v T) {
+	if p != nil {
+		v = *p
+	}
+	return
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
 )
 
 // Registrar is an interface for a domain registrar. It can return a list of needed corrections to be applied in the future. Implement this only if the provider is a "registrar" (i.e. can update the NS records of the parent to a domain).
@@ -24,6 +26,39 @@ type ZoneCreator interface {
 	EnsureZoneExists(domain string) error
 }
 
+// ZoneMetadataCreator should be implemented by providers that create
+// zones with settings of the domain metadata (for example the private
+// zones of ROUTE53).
+type ZoneMetadataCreator interface {
+	EnsureZoneExistsWithMetadata(domain string, metadata map[string]string) error
+}
+
+// EnsureZoneExists creates a zone with a ZoneCreator, with the domain
+// metadata if it is also a ZoneMetadataCreator.
+func EnsureZoneExists(creator ZoneCreator, domain string, metadata map[string]string) error {
+	if c, ok := creator.(ZoneMetadataCreator); ok {
+		return c.EnsureZoneExistsWithMetadata(domain, metadata)
+	}
+	return creator.EnsureZoneExists(domain)
+}
+
+// DSReporter should be implemented by providers that sign the zones
+// with AUTODNSSEC_ON, to report the DS records of their keys. They are
+// published by the registrar of the domain if it can
+// (CanUseDSAtRegistrar).
+type DSReporter interface {
+	GetDSRecords(domain string) (models.Records, error)
+}
+
+// Snapshotter should be implemented by providers that can take a
+// snapshot of a zone before it is changed, and restore it. The ID of
+// the snapshot is recorded in the push history, for the "rollback"
+// command.
+type Snapshotter interface {
+	CreateSnapshot(domain string) (string, error)
+	RestoreSnapshot(domain, id string) error
+}
+
 // ZoneLister should be implemented by providers that have the
 // ability to list the zones they manage. This facilitates using the
 // "get-zones" command for "all" zones.
@@ -31,6 +66,25 @@ type ZoneLister interface {
 	ListZones() ([]string, error)
 }
 
+// CredentialsChecker should be implemented by providers whose API
+// reports the permissions of the credentials. This facilitates the
+// "check-creds --deep" command.
+type CredentialsChecker interface {
+	// CheckCredentials returns the permissions of required, among the
+	// RequiredScopes of the provider, that the credentials lack. known is
+	// false if the API does not tell.
+	CheckCredentials(required []string) (missing []string, known bool, err error)
+}
+
+// CredentialsExpirer should be implemented by providers whose API
+// reports the expiry of the credentials. This facilitates the warnings
+// of credentials about to expire.
+type CredentialsExpirer interface {
+	// CredentialsExpiry returns the expiry of the credentials, or the
+	// zero time if they do not expire.
+	CredentialsExpiry() (time.Time, error)
+}
+
 // RegistrarInitializer is a function to create a registrar. Function will be passed the unprocessed json payload from the configuration file for the given provider.
 type RegistrarInitializer func(map[string]string) (Registrar, error)
 
@@ -81,6 +135,25 @@ func RegisterMaintainer(
 	ProviderMaintainers[providerName] = gitHubUsername
 }
 
+// TLSProviders are the provider types which use the TLS options of
+// creds.json (see package tlsconfig).
+var TLSProviders = map[string]bool{}
+
+// RegisterTLSOptions records that the provider type uses the TLS options
+// of creds.json. The other types reject them.
+func RegisterTLSOptions(providerName string) {
+	TLSProviders[providerName] = true
+}
+
+// checkTLSOptions returns an error if the credentials of a provider type
+// which does not use the TLS options have some: they would be ignored.
+func checkTLSOptions(pType string, config map[string]string) error {
+	if k := tlsconfig.Option(config); k != "" && !TLSProviders[pType] {
+		return fmt.Errorf("%s does not support the TLS option %q of creds.json", pType, k)
+	}
+	return nil
+}
+
 // CreateRegistrar initializes a registrar instance from given credentials.
 func CreateRegistrar(rType string, config map[string]string) (Registrar, error) {
 	var err error
@@ -93,6 +166,9 @@ func CreateRegistrar(rType string, config map[string]string) (Registrar, error)
 	if !ok {
 		return nil, fmt.Errorf("no such registrar type: %q", rType)
 	}
+	if err := checkTLSOptions(rType, config); err != nil {
+		return nil, err
+	}
 	return initer(config)
 }
 
@@ -108,6 +184,9 @@ func CreateDNSProvider(providerTypeName string, config map[string]string, meta j
 	if !ok {
 		return nil, fmt.Errorf("no such DNS service provider: %q", providerTypeName)
 	}
+	if err := checkTLSOptions(providerTypeName, config); err != nil {
+		return nil, err
+	}
 	return p.Initializer(config, meta)
 }
 
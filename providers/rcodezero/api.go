@@ -0,0 +1,227 @@
+package rcodezero
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	baseURL  = "https://my.rcodezero.at/api/v1"
+	pageSize = 100
+)
+
+type rcodezeroProvider struct {
+	token     string
+	zoneType  string
+	masters   []string
+	tsig      *tsigKey
+	zoneCache map[string]*zone
+}
+
+var errNotFound = errors.New("not found")
+
+type zone struct {
+	Domain       string   `json:"domain"`
+	Type         string   `json:"type"`
+	Masters      []string `json:"masters"`
+	TSIGKey      string   `json:"tsigkey,omitempty"`
+	DNSSECStatus string   `json:"dnssec_status,omitempty"`
+}
+
+type zonesResponse struct {
+	Data        []zone `json:"data"`
+	CurrentPage int    `json:"current_page"`
+	LastPage    int    `json:"last_page"`
+}
+
+type recordContent struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+type rrSet struct {
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	TTL        uint32          `json:"ttl,omitempty"`
+	ChangeType string          `json:"changetype,omitempty"`
+	Records    []recordContent `json:"records"`
+}
+
+type rrSetsResponse struct {
+	Data        []rrSet `json:"data"`
+	CurrentPage int     `json:"current_page"`
+	LastPage    int     `json:"last_page"`
+}
+
+// tsigKey is a TSIG key used to transfer secondary zones from their primaries.
+type tsigKey struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	Secret    string `json:"secret"`
+}
+
+type errorResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// do sends a request and decodes the response into target.
+func (c *rcodezeroProvider) do(method, path string, body, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+retry:
+	req, err := http.NewRequest(method, baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries:
+		retrycnt++
+		printer.Printf("RcodeZero rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		var er errorResponse
+		if json.Unmarshal(data, &er) == nil && er.Message != "" {
+			return fmt.Errorf("rcodezero API error: %s: %s", resp.Status, er.Message)
+		}
+		return fmt.Errorf("rcodezero API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (c *rcodezeroProvider) listZones() ([]*zone, error) {
+	var zones []*zone
+	for page := 1; ; page++ {
+		var resp zonesResponse
+		path := fmt.Sprintf("/zones?page=%d&page_size=%d", page, pageSize)
+		if err := c.do(http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed listing zones from rcodezero: %w", err)
+		}
+		for i := range resp.Data {
+			zones = append(zones, &resp.Data[i])
+		}
+		if resp.CurrentPage >= resp.LastPage {
+			break
+		}
+	}
+	return zones, nil
+}
+
+func (c *rcodezeroProvider) getZone(domain string) (*zone, error) {
+	if z, ok := c.zoneCache[domain]; ok {
+		return z, nil
+	}
+	var z zone
+	if err := c.do(http.MethodGet, "/zones/"+url.PathEscape(domain), nil, &z); err != nil {
+		return nil, fmt.Errorf("failed fetching zone %s from rcodezero: %w", domain, err)
+	}
+	c.zoneCache[domain] = &z
+	return &z, nil
+}
+
+func (c *rcodezeroProvider) createZone(z *zone) error {
+	if err := c.do(http.MethodPost, "/zones", z, nil); err != nil {
+		return fmt.Errorf("failed creating zone (rcodezero): %w", err)
+	}
+	return nil
+}
+
+func (c *rcodezeroProvider) updateZone(z *zone) error {
+	body := zone{Type: z.Type, Masters: z.Masters, TSIGKey: z.TSIGKey}
+	if err := c.do(http.MethodPut, "/zones/"+url.PathEscape(z.Domain), body, nil); err != nil {
+		return fmt.Errorf("failed updating zone (rcodezero): %w", err)
+	}
+	delete(c.zoneCache, z.Domain)
+	return nil
+}
+
+func (c *rcodezeroProvider) getRRSets(domain string) ([]rrSet, error) {
+	var sets []rrSet
+	for page := 1; ; page++ {
+		var resp rrSetsResponse
+		path := fmt.Sprintf("/zones/%s/rrsets?page=%d&page_size=%d", url.PathEscape(domain), page, pageSize)
+		if err := c.do(http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed fetching record sets from rcodezero: %w", err)
+		}
+		sets = append(sets, resp.Data...)
+		if resp.CurrentPage >= resp.LastPage {
+			break
+		}
+	}
+	return sets, nil
+}
+
+func (c *rcodezeroProvider) patchRRSets(domain string, sets []rrSet) error {
+	if err := c.do(http.MethodPatch, "/zones/"+url.PathEscape(domain)+"/rrsets", sets, nil); err != nil {
+		return fmt.Errorf("failed updating record sets (rcodezero): %w", err)
+	}
+	return nil
+}
+
+func (c *rcodezeroProvider) signZone(domain string, sign bool) error {
+	action := "/unsign"
+	if sign {
+		action = "/sign"
+	}
+	if err := c.do(http.MethodPost, "/zones/"+url.PathEscape(domain)+action, nil, nil); err != nil {
+		return fmt.Errorf("failed updating DNSSEC (rcodezero): %w", err)
+	}
+	delete(c.zoneCache, domain)
+	return nil
+}
+
+// ensureTSIGKey creates the configured TSIG key, or updates its secret.
+func (c *rcodezeroProvider) ensureTSIGKey() error {
+	var existing tsigKey
+	err := c.do(http.MethodGet, "/tsigkeys/"+url.PathEscape(c.tsig.Name), nil, &existing)
+	switch {
+	case errors.Is(err, errNotFound):
+		err = c.do(http.MethodPost, "/tsigkeys", c.tsig, nil)
+	case err == nil && (existing.Algorithm != c.tsig.Algorithm || existing.Secret != c.tsig.Secret):
+		err = c.do(http.MethodPut, "/tsigkeys/"+url.PathEscape(c.tsig.Name), c.tsig, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed updating TSIG key %s (rcodezero): %w", c.tsig.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package rcodezero
+
+import (
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// toRc converts a record of a RcodeZero record set into a RecordConfig.
+// The content is in zone file format, as with PowerDNS.
+func toRc(domain string, set rrSet, r recordContent) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     set.Type,
+		TTL:      set.TTL,
+		Original: set,
+	}
+	rc.SetLabelFromFQDN(strings.TrimSuffix(set.Name, "."), domain)
+
+	if set.Type == "TXT" {
+		return rc, rc.SetTargetTXTs(parseTxt(r.Content))
+	}
+	return rc, rc.PopulateFromString(set.Type, r.Content, domain)
+}
+
+// parseTxt splits the quoted strings of a TXT content.
+func parseTxt(content string) (result []string) {
+	for _, r := range strings.Split(content, "\" ") {
+		result = append(result, strings.Trim(r, "\""))
+	}
+	return
+}
@@ -0,0 +1,33 @@
+package rcodezero
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *rcodezeroProvider) ListZones() ([]string, error) {
+	zones, err := c.listZones()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(zones))
+	for _, z := range zones {
+		names = append(names, z.Domain)
+	}
+	return names, nil
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *rcodezeroProvider) EnsureZoneExists(domain string) error {
+	zones, err := c.listZones()
+	if err != nil {
+		return err
+	}
+	for _, z := range zones {
+		if z.Domain == domain {
+			return nil
+		}
+	}
+	if c.tsig != nil && c.zoneType == "SLAVE" {
+		if err := c.ensureTSIGKey(); err != nil {
+			return err
+		}
+	}
+	return c.createZone(c.wantedZone(domain))
+}
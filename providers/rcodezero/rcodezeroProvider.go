@@ -0,0 +1,248 @@
+package rcodezero
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for RcodeZero Anycast DNS, by nic.at.
+// API Documentation: https://my.rcodezero.at/openapi/
+
+/*
+RcodeZero provider:
+
+Info required in `creds.json`:
+   - api_token
+   - zone_type (optional) "master" (default) or "slave"
+   - masters (optional) comma-separated primaries of secondary zones
+   - tsig_key_name, tsig_algorithm, tsig_secret (optional) TSIG key used to transfer secondary zones
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Can(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Can(),
+	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseLOC:              providers.Can(),
+	providers.CanUseNAPTR:            providers.Can(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Can(),
+	providers.CanUseSVCB:             providers.Can(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Can(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{
+	"sec1.rcode0.net",
+	"sec2.rcode0.net",
+}
+
+func init() {
+	const providerName = "RCODEZERO"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newRcodezero,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newRcodezero creates the provider.
+func newRcodezero(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &rcodezeroProvider{
+		token:     m["api_token"],
+		zoneType:  strings.ToUpper(m["zone_type"]),
+		zoneCache: map[string]*zone{},
+	}
+	if c.token == "" {
+		return nil, fmt.Errorf("missing RCODEZERO api_token")
+	}
+
+	switch c.zoneType {
+	case "":
+		c.zoneType = "MASTER"
+	case "MASTER", "SLAVE":
+	default:
+		return nil, fmt.Errorf("RCODEZERO zone_type must be master or slave, not %q", m["zone_type"])
+	}
+	for _, ip := range strings.Split(m["masters"], ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			c.masters = append(c.masters, ip)
+		}
+	}
+	if c.zoneType == "SLAVE" && len(c.masters) == 0 {
+		return nil, fmt.Errorf("RCODEZERO masters must be set for secondary zones")
+	}
+
+	if name := m["tsig_key_name"]; name != "" {
+		c.tsig = &tsigKey{
+			Name:      name,
+			Algorithm: m["tsig_algorithm"],
+			Secret:    m["tsig_secret"],
+		}
+		if c.tsig.Algorithm == "" {
+			c.tsig.Algorithm = "hmac-sha256"
+		}
+		if c.tsig.Secret == "" {
+			return nil, fmt.Errorf("RCODEZERO tsig_secret must be set with tsig_key_name")
+		}
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *rcodezeroProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *rcodezeroProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	sets, err := c.getRRSets(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var existingRecords models.Records
+	for _, set := range sets {
+		if set.Type == "SOA" || (set.Type == "NS" && set.Name == domain+".") {
+			// The SOA and apex NS records are managed by RcodeZero.
+			continue
+		}
+		for _, r := range set.Records {
+			if r.Disabled {
+				continue
+			}
+			rc, err := toRc(domain, set, r)
+			if err != nil {
+				return nil, err
+			}
+			existingRecords = append(existingRecords, rc)
+		}
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *rcodezeroProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	z, err := c.getZone(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	corrections := c.getZoneSettingsCorrections(z)
+
+	switch {
+	case dc.AutoDNSSEC == "on" && z.DNSSECStatus != "Signed":
+		corrections = append(corrections, &models.Correction{
+			Msg: "Enable DNSSEC",
+			F: func() error {
+				return c.signZone(dc.Name, true)
+			},
+		})
+	case dc.AutoDNSSEC == "off" && z.DNSSECStatus == "Signed":
+		corrections = append(corrections, &models.Correction{
+			Msg: "Disable DNSSEC",
+			F: func() error {
+				return c.signZone(dc.Name, false)
+			},
+		})
+	}
+
+	changes, err := diff2.ByRecordSet(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.zoneType == "SLAVE" {
+		// The records of secondary zones are transferred from their primaries.
+		if len(changes) > 0 {
+			corrections = append(corrections, &models.Correction{
+				Msg: fmt.Sprintf("Records of the secondary zone %s differ from dnsconfig.js. They are managed by its primaries: %s", dc.Name, strings.Join(c.masters, ", ")),
+			})
+		}
+		return corrections, nil
+	}
+
+	for _, change := range changes {
+		set := rrSet{
+			Name: change.Key.NameFQDN + ".",
+			Type: change.Key.Type,
+		}
+		switch change.Type {
+		case diff2.REPORT:
+			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
+			continue
+		case diff2.CREATE, diff2.CHANGE:
+			set.ChangeType = "update"
+			set.TTL = change.New[0].TTL
+			for _, rc := range change.New {
+				set.Records = append(set.Records, recordContent{Content: rc.GetTargetCombined()})
+			}
+		case diff2.DELETE:
+			set.ChangeType = "delete"
+			set.Records = []recordContent{}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: change.MsgsJoined,
+			F: func() error {
+				return c.patchRRSets(dc.Name, []rrSet{set})
+			},
+		})
+	}
+
+	return corrections, nil
+}
+
+// wantedZone returns the settings of a zone according to the configuration.
+func (c *rcodezeroProvider) wantedZone(domain string) *zone {
+	z := &zone{Domain: domain, Type: c.zoneType, Masters: []string{}}
+	if c.zoneType == "SLAVE" {
+		z.Masters = c.masters
+		if c.tsig != nil {
+			z.TSIGKey = c.tsig.Name
+		}
+	}
+	return z
+}
+
+// getZoneSettingsCorrections returns the corrections that update the type,
+// the primaries and the TSIG key of the zone.
+func (c *rcodezeroProvider) getZoneSettingsCorrections(z *zone) []*models.Correction {
+	want := c.wantedZone(z.Domain)
+	if strings.EqualFold(z.Type, want.Type) && slices.Equal(z.Masters, want.Masters) && z.TSIGKey == want.TSIGKey {
+		return nil
+	}
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update zone settings: type %s -> %s, masters [%s] -> [%s], TSIG key %q -> %q",
+				z.Type, want.Type, strings.Join(z.Masters, ", "), strings.Join(want.Masters, ", "), z.TSIGKey, want.TSIGKey),
+			F: func() error {
+				if c.tsig != nil {
+					if err := c.ensureTSIGKey(); err != nil {
+						return err
+					}
+				}
+				return c.updateZone(want)
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package route53
+
+import "strings"
+
+// The hosted zones of the AWS resources that R53_ALIAS records can target,
+// used when R53_ZONE() is not specified. See
+// https://docs.aws.amazon.com/general/latest/gr/rande.html
+const (
+	cloudFrontZoneID        = "Z2FDTNDATAQYW2" // Also the edge-optimized API Gateway and AppSync custom domains.
+	globalAcceleratorZoneID = "Z2BJ6XQ5FK7U4H"
+)
+
+// apiGatewayZoneIDs are the hosted zones of the regional API Gateway custom
+// domains (d-xxxxxxxxxx.execute-api.REGION.amazonaws.com), by region.
+var apiGatewayZoneIDs = map[string]string{
+	"af-south-1":     "Z2DHW2332DAMTN",
+	"ap-east-1":      "Z3FD1VL90ND7K5",
+	"ap-northeast-1": "Z1YSHQZHG15GKL",
+	"ap-northeast-2": "Z20JF4UZKIW1U8",
+	"ap-northeast-3": "Z2YQB5RD63NC85",
+	"ap-south-1":     "Z3VO1THU9YC4UR",
+	"ap-southeast-1": "ZL327KTPIQFUL",
+	"ap-southeast-2": "Z2RPCDW04V8134",
+	"ca-central-1":   "Z19DQILCV0OWEC",
+	"eu-central-1":   "Z1U9ULNL0V5AJ3",
+	"eu-north-1":     "Z3UWIKFBOOGXPP",
+	"eu-south-1":     "Z3BT4WSQ9TDYZV",
+	"eu-west-1":      "ZLY8HYME6SFDD",
+	"eu-west-2":      "ZJ5UAJN8Y3Z2Q",
+	"eu-west-3":      "Z3KY65QIEKYHQQ",
+	"me-south-1":     "Z20ZBPC0SS8806",
+	"sa-east-1":      "ZCMLWB8V5SYIT",
+	"us-east-1":      "Z1UJRXOUMOOFQ8",
+	"us-east-2":      "ZOJJZC49E0EPZ",
+	"us-west-1":      "Z2MUQ32089INYE",
+	"us-west-2":      "Z2OJLYMUO9EFXC",
+}
+
+// vpcEndpointZoneIDs are the hosted zones of the interface VPC endpoints
+// (vpce-xxxxxxxx.vpce-svc-xxxxxxxx.REGION.vpce.amazonaws.com), by region.
+var vpcEndpointZoneIDs = map[string]string{
+	"ap-northeast-1": "Z2E726K9Y6RL4W",
+	"ap-northeast-2": "Z27UANNT0PRK1T",
+	"ap-south-1":     "Z2KVTB3ZLFM7JR",
+	"ap-southeast-1": "Z18LLCSTV4NVNL",
+	"ap-southeast-2": "ZDK2GCRPAFKGO",
+	"ca-central-1":   "ZRCXCF510Y6P9",
+	"eu-central-1":   "Z273ZU8SZ5RJPC",
+	"eu-north-1":     "Z3NY0PNAVFBN4G",
+	"eu-west-1":      "Z38GZ743OKFT7T",
+	"eu-west-2":      "Z3SS7NLVA4XQ12",
+	"eu-west-3":      "Z1DWHTMFP0WECP",
+	"sa-east-1":      "Z2LXUWEVLCVSU8",
+	"us-east-1":      "Z7HUB22UULQXV",
+	"us-east-2":      "ZC8PG0KIFKBRI",
+	"us-west-1":      "Z12I86A8N7VCZO",
+	"us-west-2":      "Z1YSA3EXCYUU9Z",
+}
+
+// aliasTargetZoneID returns the hosted zone of the AWS resource targeted by an
+// R53_ALIAS record, or "" if the target is not a known AWS resource.
+func aliasTargetZoneID(target string) string {
+	target = strings.ToLower(strings.TrimSuffix(target, "."))
+	switch {
+	case strings.HasSuffix(target, ".cloudfront.net"):
+		return cloudFrontZoneID
+	case strings.HasSuffix(target, ".awsglobalaccelerator.com"):
+		return globalAcceleratorZoneID
+	}
+	labels := strings.Split(target, ".")
+	n := len(labels)
+	switch {
+	case n >= 5 && labels[n-4] == "execute-api" && labels[n-2] == "amazonaws" && labels[n-1] == "com":
+		return apiGatewayZoneIDs[labels[n-3]]
+	case n >= 6 && labels[n-3] == "vpce" && labels[n-2] == "amazonaws" && labels[n-1] == "com":
+		return vpcEndpointZoneIDs[labels[n-4]]
+	}
+	return ""
+}
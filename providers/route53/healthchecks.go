@@ -0,0 +1,353 @@
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	r53 "github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/fatih/color"
+)
+
+// The health checks are declared by R53_HEALTH_CHECK() in the domain
+// metadata, as a JSON object of the health checks by name. The records refer
+// to them by name.
+const (
+	metaHealthChecks = "route53_health_checks"
+	metaHealthCheck  = "route53_health_check"
+)
+
+// The health checks created by DNSControl have a caller reference with this
+// prefix, and a tag with the domain and the name of the health check.
+const (
+	healthCheckReferencePrefix = "dnscontrol-"
+	healthCheckTag             = "dnscontrol"
+)
+
+// healthCheckConfig is a health check declared by R53_HEALTH_CHECK().
+type healthCheckConfig struct {
+	Type             string `json:"type"`
+	IPAddress        string `json:"ip,omitempty"`
+	FQDN             string `json:"fqdn,omitempty"`
+	Port             int32  `json:"port,omitempty"`
+	Path             string `json:"path,omitempty"`
+	SearchString     string `json:"search_string,omitempty"`
+	RequestInterval  int32  `json:"request_interval,omitempty"`
+	FailureThreshold int32  `json:"failure_threshold,omitempty"`
+	Inverted         bool   `json:"inverted,omitempty"`
+	EnableSNI        *bool  `json:"enable_sni,omitempty"`
+}
+
+// normalize sets the defaults of Route53 in a health check.
+func (c *healthCheckConfig) normalize() {
+	c.Type = strings.ToUpper(c.Type)
+	https := strings.HasPrefix(c.Type, "HTTPS")
+	if c.Port == 0 {
+		if https {
+			c.Port = 443
+		} else if strings.HasPrefix(c.Type, "HTTP") {
+			c.Port = 80
+		}
+	}
+	if c.RequestInterval == 0 {
+		c.RequestInterval = 30
+	}
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = 3
+	}
+	if c.EnableSNI == nil {
+		c.EnableSNI = aws.Bool(https)
+	}
+}
+
+func (c healthCheckConfig) String() string {
+	host := c.FQDN
+	if c.IPAddress != "" {
+		host = c.IPAddress
+	}
+	s := fmt.Sprintf("%s %s:%d%s interval=%d threshold=%d", c.Type, host, c.Port, c.Path, c.RequestInterval, c.FailureThreshold)
+	if c.SearchString != "" {
+		s += fmt.Sprintf(" search=%q", c.SearchString)
+	}
+	if c.Inverted {
+		s += " inverted"
+	}
+	if strings.HasPrefix(c.Type, "HTTPS") && !aws.ToBool(c.EnableSNI) {
+		s += " sni=false"
+	}
+	return s
+}
+
+func healthCheckFromNative(c *r53Types.HealthCheckConfig) healthCheckConfig {
+	return healthCheckConfig{
+		Type:             string(c.Type),
+		IPAddress:        aws.ToString(c.IPAddress),
+		FQDN:             aws.ToString(c.FullyQualifiedDomainName),
+		Port:             aws.ToInt32(c.Port),
+		Path:             aws.ToString(c.ResourcePath),
+		SearchString:     aws.ToString(c.SearchString),
+		RequestInterval:  aws.ToInt32(c.RequestInterval),
+		FailureThreshold: aws.ToInt32(c.FailureThreshold),
+		Inverted:         aws.ToBool(c.Inverted),
+		EnableSNI:        aws.Bool(aws.ToBool(c.EnableSNI)),
+	}
+}
+
+// optionalString returns nil for the empty string.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
+
+func (c healthCheckConfig) toNative() *r53Types.HealthCheckConfig {
+	return &r53Types.HealthCheckConfig{
+		Type:                     r53Types.HealthCheckType(c.Type),
+		IPAddress:                optionalString(c.IPAddress),
+		FullyQualifiedDomainName: optionalString(c.FQDN),
+		Port:                     aws.Int32(c.Port),
+		ResourcePath:             optionalString(c.Path),
+		SearchString:             optionalString(c.SearchString),
+		RequestInterval:          aws.Int32(c.RequestInterval),
+		FailureThreshold:         aws.Int32(c.FailureThreshold),
+		Inverted:                 aws.Bool(c.Inverted),
+		EnableSNI:                c.EnableSNI,
+	}
+}
+
+// managedHealthCheck is a health check created by DNSControl.
+type managedHealthCheck struct {
+	ID      string
+	Version int64
+	Config  healthCheckConfig
+}
+
+// getManagedHealthChecks returns the health checks created by DNSControl for
+// a domain, by name.
+func (r *route53Provider) getManagedHealthChecks(domain string) (map[string]managedHealthCheck, error) {
+	var checks []r53Types.HealthCheck
+	var marker *string
+	for {
+		var list *r53.ListHealthChecksOutput
+		var err error
+		withRetry(func() error {
+			list, err = r.client.ListHealthChecks(context.Background(), &r53.ListHealthChecksInput{Marker: marker})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, check := range list.HealthChecks {
+			if strings.HasPrefix(aws.ToString(check.CallerReference), healthCheckReferencePrefix) {
+				checks = append(checks, check)
+			}
+		}
+		if !list.IsTruncated {
+			break
+		}
+		marker = list.NextMarker
+	}
+
+	managed := map[string]managedHealthCheck{}
+	for start := 0; start < len(checks); start += 10 {
+		batch := checks[start:min(start+10, len(checks))]
+		byID := map[string]r53Types.HealthCheck{}
+		var ids []string
+		for _, check := range batch {
+			byID[aws.ToString(check.Id)] = check
+			ids = append(ids, aws.ToString(check.Id))
+		}
+		var tags *r53.ListTagsForResourcesOutput
+		var err error
+		withRetry(func() error {
+			tags, err = r.client.ListTagsForResources(context.Background(), &r53.ListTagsForResourcesInput{
+				ResourceType: r53Types.TagResourceTypeHealthcheck,
+				ResourceIds:  ids,
+			})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range tags.ResourceTagSets {
+			for _, tag := range set.Tags {
+				d, name, ok := strings.Cut(aws.ToString(tag.Value), " ")
+				if aws.ToString(tag.Key) != healthCheckTag || !ok || d != domain {
+					continue
+				}
+				check := byID[aws.ToString(set.ResourceId)]
+				managed[name] = managedHealthCheck{
+					ID:      aws.ToString(check.Id),
+					Version: aws.ToInt64(check.HealthCheckVersion),
+					Config:  healthCheckFromNative(check.HealthCheckConfig),
+				}
+			}
+		}
+	}
+	return managed, nil
+}
+
+func (r *route53Provider) createHealthCheck(domain, name string, config healthCheckConfig) (string, error) {
+	var out *r53.CreateHealthCheckOutput
+	var err error
+	withRetry(func() error {
+		out, err = r.client.CreateHealthCheck(context.Background(), &r53.CreateHealthCheckInput{
+			CallerReference:   aws.String(healthCheckReferencePrefix + strconv.FormatInt(time.Now().UnixNano(), 36)),
+			HealthCheckConfig: config.toNative(),
+		})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	id := aws.ToString(out.HealthCheck.Id)
+	withRetry(func() error {
+		_, err = r.client.ChangeTagsForResource(context.Background(), &r53.ChangeTagsForResourceInput{
+			ResourceType: r53Types.TagResourceTypeHealthcheck,
+			ResourceId:   aws.String(id),
+			AddTags: []r53Types.Tag{
+				{Key: aws.String(healthCheckTag), Value: aws.String(domain + " " + name)},
+				{Key: aws.String("Name"), Value: aws.String(name)},
+			},
+		})
+		return err
+	})
+	return id, err
+}
+
+func (r *route53Provider) updateHealthCheck(check managedHealthCheck, config healthCheckConfig) error {
+	native := config.toNative()
+	input := &r53.UpdateHealthCheckInput{
+		HealthCheckId:            aws.String(check.ID),
+		HealthCheckVersion:       aws.Int64(check.Version),
+		IPAddress:                native.IPAddress,
+		FullyQualifiedDomainName: native.FullyQualifiedDomainName,
+		Port:                     native.Port,
+		ResourcePath:             native.ResourcePath,
+		SearchString:             native.SearchString,
+		FailureThreshold:         native.FailureThreshold,
+		Inverted:                 native.Inverted,
+		EnableSNI:                native.EnableSNI,
+	}
+	if config.FQDN == "" && check.Config.FQDN != "" {
+		input.ResetElements = append(input.ResetElements, r53Types.ResettableElementNameFullyQualifiedDomainName)
+	}
+	if config.Path == "" && check.Config.Path != "" {
+		input.ResetElements = append(input.ResetElements, r53Types.ResettableElementNameResourcePath)
+	}
+	var err error
+	withRetry(func() error {
+		_, err = r.client.UpdateHealthCheck(context.Background(), input)
+		return err
+	})
+	return err
+}
+
+func (r *route53Provider) deleteHealthCheck(id string) error {
+	var err error
+	withRetry(func() error {
+		_, err = r.client.DeleteHealthCheck(context.Background(), &r53.DeleteHealthCheckInput{HealthCheckId: aws.String(id)})
+		return err
+	})
+	return err
+}
+
+// getHealthCheckCorrections returns the corrections of the health checks of a
+// domain: the creations and updates, which must be done before the changes of
+// the records, and the deletions, which must be done after. The health checks
+// referred to by the records are resolved in their metadata, ids are the IDs
+// of the health checks by name, set when they are created.
+func (r *route53Provider) getHealthCheckCorrections(dc *models.DomainConfig) (before, after []*models.Correction, ids map[string]*string, err error) {
+	declared := map[string]healthCheckConfig{}
+	if value := dc.Metadata[metaHealthChecks]; value != "" {
+		if err := json.Unmarshal([]byte(value), &declared); err != nil {
+			return nil, nil, nil, fmt.Errorf("bad metadata value for %s: %w", metaHealthChecks, err)
+		}
+	} else {
+		for _, rc := range dc.Records {
+			if name := rc.Metadata[metaHealthCheck]; name != "" {
+				return nil, nil, nil, fmt.Errorf("%s %s: the health check %q is not declared with R53_HEALTH_CHECK()", rc.GetLabelFQDN(), rc.Type, name)
+			}
+		}
+		return nil, nil, nil, nil
+	}
+
+	existing, err := r.getManagedHealthChecks(dc.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ids = map[string]*string{}
+	for _, name := range names {
+		name, config := name, declared[name]
+		config.normalize()
+		check, ok := existing[name]
+		ids[name] = aws.String(check.ID)
+		switch {
+		case !ok:
+			id := ids[name]
+			before = append(before, &models.Correction{
+				Msg: color.GreenString("+ CREATE health check %s (%s)", name, config),
+				F: func() (err error) {
+					*id, err = r.createHealthCheck(dc.Name, name, config)
+					return err
+				},
+			})
+		case check.Config.Type != config.Type || check.Config.RequestInterval != config.RequestInterval:
+			return nil, nil, nil, fmt.Errorf("the type and the request interval of the health check %s can't be changed, rename it to replace it", name)
+		case check.Config.String() != config.String():
+			before = append(before, &models.Correction{
+				Msg: color.YellowString("± MODIFY health check %s (%s) -> (%s)", name, check.Config, config),
+				F:   func() error { return r.updateHealthCheck(check, config) },
+			})
+		}
+	}
+
+	var removed []string
+	for name := range existing {
+		if _, ok := declared[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		check := existing[name]
+		after = append(after, &models.Correction{
+			Msg: color.RedString("- DELETE health check %s (%s)", name, check.Config),
+			F:   func() error { return r.deleteHealthCheck(check.ID) },
+		})
+	}
+
+	// The records refer to the health checks by ID, those that will be
+	// created don't have one yet.
+	for _, rc := range dc.Records {
+		name := rc.Metadata[metaHealthCheck]
+		if name == "" {
+			continue
+		}
+		id, ok := ids[name]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("%s %s: the health check %q is not declared with R53_HEALTH_CHECK()", rc.GetLabelFQDN(), rc.Type, name)
+		}
+		if *id == "" {
+			rc.Metadata[metaHealthCheckID] = "(new " + name + ")"
+		} else {
+			rc.Metadata[metaHealthCheckID] = *id
+		}
+	}
+	return before, after, ids, nil
+}
@@ -15,15 +15,18 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/tlsconfig"
 	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	r53 "github.com/aws/aws-sdk-go-v2/service/route53"
 	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	r53d "github.com/aws/aws-sdk-go-v2/service/route53domains"
 	r53dTypes "github.com/aws/aws-sdk-go-v2/service/route53domains/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 type route53Provider struct {
@@ -32,6 +35,8 @@ type route53Provider struct {
 	delegationSet *string
 	zonesByID     map[string]r53Types.HostedZone
 	zonesByDomain map[string]r53Types.HostedZone
+	// The private zones, by domain. A domain with VPCs is a private zone.
+	privateZonesByDomain map[string]r53Types.HostedZone
 }
 
 func newRoute53Reg(conf map[string]string) (providers.Registrar, error) {
@@ -48,6 +53,7 @@ func newRoute53(m map[string]string, _ json.RawMessage) (*route53Provider, error
 		// currently only has a single regional endpoint in us-east-1
 		// https://docs.aws.amazon.com/general/latest/gr/rande.html#r53_region
 		config.WithRegion("us-east-1"),
+		config.WithHTTPClient(tlsconfig.NewSDKClient()),
 	}
 
 	keyID, secretKey, tokenID := m["KeyId"], m["SecretKey"], m["Token"]
@@ -60,6 +66,9 @@ func newRoute53(m map[string]string, _ json.RawMessage) (*route53Provider, error
 	if err != nil {
 		return nil, err
 	}
+	if roleARN := m["RoleArn"]; roleARN != "" {
+		config.Credentials = assumeRoleCredentials(config, roleARN, m)
+	}
 
 	var dls *string
 	if val, ok := m["DelegationSet"]; ok {
@@ -74,6 +83,35 @@ func newRoute53(m map[string]string, _ json.RawMessage) (*route53Provider, error
 	return api, nil
 }
 
+// assumeRoleCredentials returns the credentials of the IAM role roleARN. The
+// role is assumed with the web identity token of WebIdentityTokenFile (the
+// OIDC token of a CI job or a Kubernetes service account) if it is set, or
+// else with the credentials of cfg.
+func assumeRoleCredentials(cfg aws.Config, roleARN string, m map[string]string) aws.CredentialsProvider {
+	sessionName := m["RoleSessionName"]
+	if sessionName == "" {
+		sessionName = "dnscontrol"
+	}
+	client := sts.NewFromConfig(cfg)
+	if tokenFile := m["WebIdentityTokenFile"]; tokenFile != "" {
+		return aws.NewCredentialsCache(stscreds.NewWebIdentityRoleProvider(client, roleARN, stscreds.IdentityTokenFile(tokenFile), func(o *stscreds.WebIdentityRoleOptions) {
+			o.RoleSessionName = sessionName
+		}))
+	}
+	return aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(client, roleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = sessionName
+		if externalID := m["ExternalId"]; externalID != "" {
+			o.ExternalID = aws.String(externalID)
+		}
+	}))
+}
+
+// scopes are the IAM actions which the provider needs for the records.
+var scopes = providers.RequiredScopes{
+	Read:  []string{"route53:ListHostedZones", "route53:GetHostedZone", "route53:ListResourceRecordSets"},
+	Write: []string{"route53:ChangeResourceRecordSets", "route53:CreateHostedZone", "route53:UpdateHostedZoneComment"},
+}
+
 var features = providers.DocumentationNotes{
 	// The default for unlisted capabilities is 'Cannot'.
 	// See providers/capabilities.go for the entire list of capabilities.
@@ -97,7 +135,7 @@ func init() {
 		Initializer:   newRoute53Dsp,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, scopes)
 	providers.RegisterRegistrarType(providerName, newRoute53Reg)
 	providers.RegisterCustomRecordType("R53_ALIAS", providerName, "")
 	providers.RegisterMaintainer(providerName, providerMaintainer)
@@ -147,6 +185,7 @@ func (r *route53Provider) getZones() error {
 	var nextMarker *string
 	r.zonesByDomain = make(map[string]r53Types.HostedZone)
 	r.zonesByID = make(map[string]r53Types.HostedZone)
+	r.privateZonesByDomain = make(map[string]r53Types.HostedZone)
 	for {
 		var out *r53.ListHostedZonesOutput
 		var err error
@@ -162,8 +201,15 @@ func (r *route53Provider) getZones() error {
 		}
 		for _, z := range out.HostedZones {
 			domain := strings.TrimSuffix(aws.ToString(z.Name), ".")
-			r.zonesByDomain[domain] = z
 			r.zonesByID[parseZoneID(aws.ToString(z.Id))] = z
+			if z.Config != nil && z.Config.PrivateZone {
+				// The public zone of a split horizon is preferred.
+				r.privateZonesByDomain[domain] = z
+				if _, ok := r.zonesByDomain[domain]; ok {
+					continue
+				}
+			}
+			r.zonesByDomain[domain] = z
 		}
 		if out.NextMarker != nil {
 			nextMarker = out.NextMarker
@@ -235,7 +281,7 @@ func (r *route53Provider) GetZoneRecords(domain string, meta map[string]string)
 	//	}
 
 	// Otherwise, use the domain name to look up the zone.
-	if zone, ok := r.zonesByDomain[domain]; ok {
+	if zone, ok := r.lookupZone(domain, meta); ok {
 		return r.getZoneRecords(zone)
 	}
 
@@ -257,7 +303,7 @@ func (r *route53Provider) getZone(dc *models.DomainConfig) (r53Types.HostedZone,
 		return zone, nil
 	}
 
-	if zone, ok := r.zonesByDomain[dc.Name]; ok {
+	if zone, ok := r.lookupZone(dc.Name, dc.Metadata); ok {
 		return zone, nil
 	}
 
@@ -299,9 +345,30 @@ func (r *route53Provider) GetZoneRecordsCorrections(dc *models.DomainConfig, exi
 	changes := []r53Types.Change{}
 	changeDesc := []string{} // TODO(tlim): This should be a [][]string so that we aren't joining strings until the last moment.
 
+	healthCheckCorrections, healthCheckDeletions, healthCheckIDs, err := r.getHealthCheckCorrections(dc)
+	if err != nil {
+		return nil, err
+	}
+	vpcCorrections, err := r.getVPCCorrections(dc, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	// The record sets with a routing policy are identified by their set
+	// identifier too. diff2 compares their records with the routing policy,
+	// the changes of a label and a type are then made by record set.
+	for _, want := range dc.Records {
+		if err := checkRoutingPolicy(want); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkRoutingMix(dc.Records); err != nil {
+		return nil, err
+	}
+
 	// Amazon Route53 is a "ByRecordSet" API.
 	// At each label:rtype pair, we either delete all records or UPSERT the desired records.
-	instructions, err := diff2.ByRecordSet(existingRecords, dc, nil)
+	instructions, err := diff2.ByRecordSet(existingRecords, dc, routingComparable)
 	if err != nil {
 		return nil, err
 	}
@@ -314,6 +381,14 @@ func (r *route53Provider) GetZoneRecordsCorrections(dc *models.DomainConfig, exi
 		instType := inst.Key.Type
 		var chg r53Types.Change
 
+		if inst.Type != diff2.REPORT && (hasRoutingPolicy(inst.Old) || hasRoutingPolicy(inst.New)) {
+			// The record sets with a routing policy are changed one by one.
+			routingChanges, routingDesc := getRoutingChanges(zone, recordSets(inst.Old), recordSets(inst.New), healthCheckIDs)
+			changes = append(changes, routingChanges...)
+			changeDesc = append(changeDesc, routingDesc...)
+			continue
+		}
+
 		switch inst.Type {
 
 		case diff2.REPORT:
@@ -330,30 +405,7 @@ func (r *route53Provider) GetZoneRecordsCorrections(dc *models.DomainConfig, exi
 			// To CREATE/CHANGE, build a new record set from the desired state and UPSERT it.
 
 			// Make the rrset to be UPSERTed:
-			var rrset *r53Types.ResourceRecordSet
-			if instType == "R53_ALIAS" || strings.HasPrefix(instType, "R53_ALIAS_") {
-				// A R53_ALIAS_* requires ResourceRecordSet to a a single item, not a list.
-				if len(inst.New) != 1 {
-					log.Fatal("Only one R53_ALIAS_ permitted on a label")
-				}
-				rrset = aliasToRRSet(zone, inst.New[0])
-				rrset.Name = aws.String(instNameFQDN)
-			} else {
-				// Make a list of all the records to be installed at label:rtype
-				rrset = &r53Types.ResourceRecordSet{
-					Name: aws.String(instNameFQDN),
-					Type: r53Types.RRType(instType),
-				}
-
-				for _, r := range inst.New {
-					rr := r53Types.ResourceRecord{
-						Value: aws.String(r.GetTargetCombinedFunc(txtutil.EncodeQuoted)),
-					}
-					rrset.ResourceRecords = append(rrset.ResourceRecords, rr)
-					i := int64(r.TTL)
-					rrset.TTL = &i
-				}
-			}
+			rrset := newRRSet(zone, instNameFQDN, instType, inst.New)
 			chg = r53Types.Change{
 				Action:            r53Types.ChangeActionUpsert,
 				ResourceRecordSet: rrset,
@@ -406,6 +458,11 @@ func (r *route53Provider) GetZoneRecordsCorrections(dc *models.DomainConfig, exi
 		return nil, err
 	}
 
+	// The health checks are created before the records that refer to them,
+	// and deleted after.
+	corrections = append(healthCheckCorrections, corrections...)
+	corrections = append(corrections, healthCheckDeletions...)
+	corrections = append(corrections, vpcCorrections...)
 	return append(reports, corrections...), nil
 
 }
@@ -449,6 +506,7 @@ func nativeToRecords(set r53Types.ResourceRecordSet, origin string) ([]*models.R
 		// r53Types.ChangeActionDelete and anything else that needs the
 		// native record verbatim.
 		rc.Original = set
+		setRoutingMetadata(rc, set)
 		results = append(results, rc)
 	} else if set.TrafficPolicyInstanceId != nil {
 		// skip traffic policy records
@@ -496,6 +554,7 @@ func nativeToRecords(set r53Types.ResourceRecordSet, origin string) ([]*models.R
 				if err := rc.PopulateFromStringFunc(rtypeString, val, origin, txtutil.ParseQuoted); err != nil {
 					return nil, fmt.Errorf("unparsable record type=%q received from ROUTE53: %w", rtypeString, err)
 				}
+				setRoutingMetadata(rc, set)
 
 				results = append(results, rc)
 			}
@@ -504,6 +563,34 @@ func nativeToRecords(set r53Types.ResourceRecordSet, origin string) ([]*models.R
 	return results, nil
 }
 
+// newRRSet returns the record set of the records at label:rtype.
+func newRRSet(zone r53Types.HostedZone, nameFQDN, rtype string, recs []*models.RecordConfig) *r53Types.ResourceRecordSet {
+	if rtype == "R53_ALIAS" || strings.HasPrefix(rtype, "R53_ALIAS_") || recs[0].Type == "R53_ALIAS" {
+		// A R53_ALIAS_* requires ResourceRecordSet to a a single item, not a list.
+		if len(recs) != 1 {
+			log.Fatal("Only one R53_ALIAS_ permitted on a label")
+		}
+		rrset := aliasToRRSet(zone, recs[0])
+		rrset.Name = aws.String(nameFQDN)
+		return rrset
+	}
+
+	// Make a list of all the records to be installed at label:rtype
+	rrset := &r53Types.ResourceRecordSet{
+		Name: aws.String(nameFQDN),
+		Type: r53Types.RRType(rtype),
+	}
+	for _, r := range recs {
+		rr := r53Types.ResourceRecord{
+			Value: aws.String(r.GetTargetCombinedFunc(txtutil.EncodeQuoted)),
+		}
+		rrset.ResourceRecords = append(rrset.ResourceRecords, rr)
+		i := int64(r.TTL)
+		rrset.TTL = &i
+	}
+	return rrset
+}
+
 func aliasToRRSet(zone r53Types.HostedZone, r *models.RecordConfig) *r53Types.ResourceRecordSet {
 	target := r.GetTargetField()
 	zoneID := getZoneID(zone, r)
@@ -524,6 +611,9 @@ func aliasToRRSet(zone r53Types.HostedZone, r *models.RecordConfig) *r53Types.Re
 
 func getZoneID(zone r53Types.HostedZone, r *models.RecordConfig) string {
 	zoneID := r.R53Alias["zone_id"]
+	if zoneID == "" {
+		zoneID = aliasTargetZoneID(r.GetTargetField())
+	}
 	if zoneID == "" {
 		zoneID = aws.ToString(zone.Id)
 	}
@@ -610,7 +700,7 @@ func (r *route53Provider) fetchRecordSets(zoneID *string) ([]r53Types.ResourceRe
 	if zoneID == nil || *zoneID == "" {
 		return nil, nil
 	}
-	var next *string
+	var next, nextIdentifier *string
 	var nextType r53Types.RRType
 	var records []r53Types.ResourceRecordSet
 	for {
@@ -618,7 +708,9 @@ func (r *route53Provider) fetchRecordSets(zoneID *string) ([]r53Types.ResourceRe
 			HostedZoneId:    zoneID,
 			StartRecordName: next,
 			StartRecordType: nextType,
-			MaxItems:        aws.Int32(100),
+			// The record sets with a routing policy share their name and type.
+			StartRecordIdentifier: nextIdentifier,
+			MaxItems:              aws.Int32(100),
 		}
 		var list *r53.ListResourceRecordSetsOutput
 		var err error
@@ -634,6 +726,7 @@ func (r *route53Provider) fetchRecordSets(zoneID *string) ([]r53Types.ResourceRe
 		if list.NextRecordName != nil {
 			next = list.NextRecordName
 			nextType = list.NextRecordType
+			nextIdentifier = list.NextRecordIdentifier
 		} else {
 			break
 		}
@@ -673,6 +766,7 @@ func (r *route53Provider) EnsureZoneExists(domain string) error {
 	// reset zone cache
 	r.zonesByDomain = nil
 	r.zonesByID = nil
+	r.privateZonesByDomain = nil
 
 	var err error
 	withRetry(func() error {
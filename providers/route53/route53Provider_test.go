@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 )
@@ -30,6 +32,26 @@ func TestUnescape(t *testing.T) {
 	}
 }
 
+func TestAliasTargetZoneID(t *testing.T) {
+	var tests = []struct {
+		target, expected string
+	}{
+		{"d111111abcdef8.cloudfront.net.", "Z2FDTNDATAQYW2"},
+		{"a1234567890abcdef.awsglobalaccelerator.com.", "Z2BJ6XQ5FK7U4H"},
+		{"d-abcdef1234.execute-api.eu-west-1.amazonaws.com.", "ZLY8HYME6SFDD"},
+		{"vpce-0123-abcd.vpce-svc-0123.us-east-1.vpce.amazonaws.com.", "Z7HUB22UULQXV"},
+		{"d-abcdef1234.execute-api.xx-nowhere-1.amazonaws.com.", ""},
+		{"bar", ""},
+	}
+
+	for i, test := range tests {
+		actual := aliasTargetZoneID(test.target)
+		if test.expected != actual {
+			t.Errorf("%d: Expected %s, got %s", i, test.expected, actual)
+		}
+	}
+}
+
 type batch struct {
 	start int
 	end   int
@@ -141,3 +163,162 @@ func Test_changeBatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestRoutingChanges(t *testing.T) {
+	weighted := func(id, weight, ip string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "A", TTL: 300, Metadata: map[string]string{metaSetIdentifier: id, metaWeight: weight}}
+		rc.SetLabel("www", "example.com")
+		rc.SetTarget(ip)
+		return rc
+	}
+	zone := r53Types.HostedZone{Id: aws.String("/hostedzone/Z1")}
+
+	native := r53Types.ResourceRecordSet{
+		Name:            aws.String("www.example.com."),
+		Type:            r53Types.RRTypeA,
+		TTL:             aws.Int64(300),
+		SetIdentifier:   aws.String("blue"),
+		Weight:          aws.Int64(90),
+		ResourceRecords: []r53Types.ResourceRecord{{Value: aws.String("192.0.2.1")}},
+	}
+	existing, err := nativeToRecords(native, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := recordSets(existing)
+	d := recordSets(models.Records{weighted("blue", "90", "192.0.2.1"), weighted("green", "10", "192.0.2.2")})
+
+	changes, descs := getRoutingChanges(zone, e, d, nil)
+	if len(changes) != 1 || aws.ToString(changes[0].ResourceRecordSet.SetIdentifier) != "green" || aws.ToInt64(changes[0].ResourceRecordSet.Weight) != 10 {
+		t.Fatalf("Expected the creation of green, got %v", descs)
+	}
+
+	d[routingKey{"www.example.com.", "A", "blue"}][0].Metadata[metaWeight] = "50"
+	delete(d, routingKey{"www.example.com.", "A", "green"})
+	changes, descs = getRoutingChanges(zone, e, d, nil)
+	if len(changes) != 1 || changes[0].Action != r53Types.ChangeActionUpsert || aws.ToInt64(changes[0].ResourceRecordSet.Weight) != 50 {
+		t.Fatalf("Expected the modification of blue, got %v", descs)
+	}
+
+	changes, _ = getRoutingChanges(zone, e, nil, nil)
+	if len(changes) != 1 || changes[0].Action != r53Types.ChangeActionDelete {
+		t.Fatalf("Expected the deletion of blue, got %v", changes)
+	}
+
+	bad := weighted("blue", "90", "192.0.2.1")
+	bad.Metadata[metaFailover] = "PRIMARY"
+	if checkRoutingPolicy(bad) == nil {
+		t.Fatal("Expected an error for two routing policies")
+	}
+	plain := weighted("", "", "192.0.2.3")
+	delete(plain.Metadata, metaSetIdentifier)
+	if checkRoutingMix(models.Records{weighted("blue", "90", "192.0.2.1"), plain}) == nil {
+		t.Fatal("Expected an error for records with and without a routing policy")
+	}
+}
+
+// routingDiff returns the changes of the record sets with a routing policy
+// from existing to dc, as GetZoneRecordsCorrections.
+func routingDiff(t *testing.T, existing models.Records, dc *models.DomainConfig) []r53Types.Change {
+	t.Helper()
+	instructions, err := diff2.ByRecordSet(existing, dc, routingComparable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changes []r53Types.Change
+	for _, inst := range instructions {
+		if inst.Type != diff2.REPORT && (hasRoutingPolicy(inst.Old) || hasRoutingPolicy(inst.New)) {
+			c, _ := getRoutingChanges(r53Types.HostedZone{Id: aws.String("/hostedzone/Z1")}, recordSets(inst.Old), recordSets(inst.New), nil)
+			changes = append(changes, c...)
+		}
+	}
+	return changes
+}
+
+func TestRoutingChangesHandsoff(t *testing.T) {
+	var existing models.Records
+	for _, set := range []r53Types.ResourceRecordSet{
+		{Name: aws.String("www.example.com."), Type: r53Types.RRTypeA, TTL: aws.Int64(300), SetIdentifier: aws.String("blue"), Weight: aws.Int64(90),
+			ResourceRecords: []r53Types.ResourceRecord{{Value: aws.String("192.0.2.1")}}},
+		{Name: aws.String("www.example.com."), Type: r53Types.RRTypeA, TTL: aws.Int64(300), SetIdentifier: aws.String("green"), Weight: aws.Int64(10),
+			ResourceRecords: []r53Types.ResourceRecord{{Value: aws.String("192.0.2.2")}}},
+	} {
+		recs, err := nativeToRecords(set, "example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		existing = append(existing, recs...)
+	}
+	domain := func() *models.DomainConfig {
+		return &models.DomainConfig{Name: "example.com", Records: models.Records{}}
+	}
+
+	// The weighted record sets which are not declared are deleted...
+	if changes := routingDiff(t, existing, domain()); len(changes) != 2 || changes[0].Action != r53Types.ChangeActionDelete {
+		t.Fatalf("Expected the deletion of blue and green, got %v", changes)
+	}
+
+	// ...unless they are ignored.
+	dc := domain()
+	dc.Unmanaged = []*models.UnmanagedConfig{{LabelPattern: "www"}}
+	if changes := routingDiff(t, existing, dc); len(changes) != 0 {
+		t.Fatalf("Expected no changes of the ignored record sets, got %v", changes)
+	}
+
+	dc = domain()
+	dc.KeepUnknown = true
+	if changes := routingDiff(t, existing, dc); len(changes) != 0 {
+		t.Fatalf("Expected no changes with NO_PURGE, got %v", changes)
+	}
+
+	// The sets are compared with their routing policy.
+	dc = domain()
+	for _, rc := range existing {
+		c := *rc
+		c.Metadata = map[string]string{}
+		for k, v := range rc.Metadata {
+			c.Metadata[k] = v
+		}
+		dc.Records = append(dc.Records, &c)
+	}
+	if changes := routingDiff(t, existing, dc); len(changes) != 0 {
+		t.Fatalf("Expected no changes, got %v", changes)
+	}
+	dc.Records[1].Metadata[metaWeight] = "20"
+	changes := routingDiff(t, existing, dc)
+	if len(changes) != 1 || aws.ToString(changes[0].ResourceRecordSet.SetIdentifier) != "green" || aws.ToInt64(changes[0].ResourceRecordSet.Weight) != 20 {
+		t.Fatalf("Expected the modification of green, got %v", changes)
+	}
+}
+
+func TestHealthCheckConfig(t *testing.T) {
+	config := healthCheckConfig{Type: "https", FQDN: "api.example.com", Path: "/health"}
+	config.normalize()
+	if expected := "HTTPS api.example.com:443/health interval=30 threshold=3"; config.String() != expected {
+		t.Fatalf("Expected %s, got %s", expected, config)
+	}
+	if native := healthCheckFromNative(config.toNative()); native.String() != config.String() {
+		t.Fatalf("Expected %s, got %s", config, native)
+	}
+}
+
+func TestDiffVPCs(t *testing.T) {
+	existing, err := parseVPCs("us-east-1:vpc-1, eu-west-1:vpc-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	desired, err := parseVPCs("eu-west-1:vpc-2,eu-west-1:vpc-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, removed := diffVPCs(existing, desired)
+	if len(added) != 1 || vpcString(added[0]) != "eu-west-1:vpc-3" {
+		t.Errorf("Expected eu-west-1:vpc-3 to be added, got %v", added)
+	}
+	if len(removed) != 1 || vpcString(removed[0]) != "us-east-1:vpc-1" {
+		t.Errorf("Expected us-east-1:vpc-1 to be removed, got %v", removed)
+	}
+	if _, err := parseVPCs("vpc-1"); err == nil {
+		t.Errorf("Expected an error for a VPC without region")
+	}
+}
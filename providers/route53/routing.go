@@ -0,0 +1,265 @@
+package route53
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/fatih/color"
+)
+
+// The record metadata of the routing policies. The records of a record set
+// with a routing policy all have the same set identifier and policy.
+const (
+	metaSetIdentifier = "route53_set_identifier"
+	metaWeight        = "route53_weight"          // Weighted
+	metaRegion        = "route53_region"          // Latency
+	metaContinent     = "route53_geo_continent"   // Geolocation
+	metaCountry       = "route53_geo_country"     // Geolocation
+	metaSubdivision   = "route53_geo_subdivision" // Geolocation
+	metaFailover      = "route53_failover"        // Failover: PRIMARY or SECONDARY
+	metaHealthCheckID = "route53_health_check_id"
+	metaMultiValue    = "route53_multivalue" // Multivalue answer: true
+)
+
+var routingMetas = []string{metaSetIdentifier, metaWeight, metaRegion, metaContinent, metaCountry, metaSubdivision, metaFailover, metaHealthCheckID, metaMultiValue}
+
+// routingKey identifies a record set with a routing policy.
+type routingKey struct {
+	NameFQDN, Type, SetIdentifier string
+}
+
+func (k routingKey) String() string {
+	return fmt.Sprintf("%s %s set=%s", k.NameFQDN, k.Type, k.SetIdentifier)
+}
+
+// routingKeyOf returns the record set of a record, and whether it has a
+// routing policy.
+func routingKeyOf(rc *models.RecordConfig) (routingKey, bool) {
+	key := routingKey{rc.GetLabelFQDN() + ".", rc.Type, rc.Metadata[metaSetIdentifier]}
+	if rc.Type == "R53_ALIAS" {
+		key.Type = rc.R53Alias["type"]
+	}
+	return key, key.SetIdentifier != ""
+}
+
+// checkRoutingPolicy returns an error if the routing policy of a record is
+// incomplete or ambiguous.
+func checkRoutingPolicy(rc *models.RecordConfig) error {
+	policies := 0
+	for _, meta := range []string{metaWeight, metaRegion, metaFailover, metaMultiValue} {
+		if rc.Metadata[meta] != "" {
+			policies++
+		}
+	}
+	if rc.Metadata[metaContinent] != "" || rc.Metadata[metaCountry] != "" {
+		policies++
+	} else if rc.Metadata[metaSubdivision] != "" {
+		return fmt.Errorf("%s of %s requires %s", metaSubdivision, rc.GetLabelFQDN(), metaCountry)
+	}
+
+	switch {
+	case rc.Metadata[metaSetIdentifier] == "":
+		if policies > 0 || rc.Metadata[metaHealthCheckID] != "" {
+			return fmt.Errorf("the routing policy of %s %s requires %s", rc.GetLabelFQDN(), rc.Type, metaSetIdentifier)
+		}
+	case policies != 1:
+		return fmt.Errorf("%s %s (%s) must have exactly one routing policy (weight, region, geolocation, failover or multivalue)", rc.GetLabelFQDN(), rc.Type, rc.Metadata[metaSetIdentifier])
+	}
+	if v := rc.Metadata[metaWeight]; v != "" {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("bad metadata value for %s: %q", metaWeight, v)
+		}
+	}
+	if v := rc.Metadata[metaFailover]; v != "" && v != string(r53Types.ResourceRecordSetFailoverPrimary) && v != string(r53Types.ResourceRecordSetFailoverSecondary) {
+		return fmt.Errorf("bad metadata value for %s: %q. Use PRIMARY or SECONDARY", metaFailover, v)
+	}
+	if v := rc.Metadata[metaMultiValue]; v != "" && v != "true" {
+		return fmt.Errorf("bad metadata value for %s: %q. Use true", metaMultiValue, v)
+	}
+	return nil
+}
+
+// setRoutingMetadata sets the routing policy of a record set in the metadata
+// of a record.
+func setRoutingMetadata(rc *models.RecordConfig, set r53Types.ResourceRecordSet) {
+	if set.SetIdentifier == nil {
+		return
+	}
+	if rc.Metadata == nil {
+		rc.Metadata = map[string]string{}
+	}
+	rc.Metadata[metaSetIdentifier] = aws.ToString(set.SetIdentifier)
+	if set.Weight != nil {
+		rc.Metadata[metaWeight] = strconv.FormatInt(*set.Weight, 10)
+	}
+	if set.Region != "" {
+		rc.Metadata[metaRegion] = string(set.Region)
+	}
+	if geo := set.GeoLocation; geo != nil {
+		rc.Metadata[metaContinent] = aws.ToString(geo.ContinentCode)
+		rc.Metadata[metaCountry] = aws.ToString(geo.CountryCode)
+		rc.Metadata[metaSubdivision] = aws.ToString(geo.SubdivisionCode)
+	}
+	if set.Failover != "" {
+		rc.Metadata[metaFailover] = string(set.Failover)
+	}
+	if set.HealthCheckId != nil {
+		rc.Metadata[metaHealthCheckID] = aws.ToString(set.HealthCheckId)
+	}
+	if aws.ToBool(set.MultiValueAnswer) {
+		rc.Metadata[metaMultiValue] = "true"
+	}
+	for _, meta := range routingMetas {
+		if rc.Metadata[meta] == "" {
+			delete(rc.Metadata, meta)
+		}
+	}
+}
+
+// applyRoutingPolicy sets the routing policy of a record set from the
+// metadata of one of its records.
+func applyRoutingPolicy(rrset *r53Types.ResourceRecordSet, rc *models.RecordConfig) {
+	optional := func(meta string) *string {
+		if v := rc.Metadata[meta]; v != "" {
+			return aws.String(v)
+		}
+		return nil
+	}
+	rrset.SetIdentifier = optional(metaSetIdentifier)
+	if v := rc.Metadata[metaWeight]; v != "" {
+		weight, _ := strconv.ParseInt(v, 10, 64) // Checked by checkRoutingPolicy.
+		rrset.Weight = &weight
+	}
+	rrset.Region = r53Types.ResourceRecordSetRegion(rc.Metadata[metaRegion])
+	if rc.Metadata[metaContinent] != "" || rc.Metadata[metaCountry] != "" {
+		rrset.GeoLocation = &r53Types.GeoLocation{
+			ContinentCode:   optional(metaContinent),
+			CountryCode:     optional(metaCountry),
+			SubdivisionCode: optional(metaSubdivision),
+		}
+	}
+	rrset.Failover = r53Types.ResourceRecordSetFailover(rc.Metadata[metaFailover])
+	rrset.HealthCheckId = optional(metaHealthCheckID)
+	if rc.Metadata[metaMultiValue] == "true" {
+		rrset.MultiValueAnswer = aws.Bool(true)
+	}
+}
+
+// routingComparable returns the set identifier and the routing policy of a
+// record for diff2, so that the records of the record sets of a label and a
+// type are told apart.
+func routingComparable(rc *models.RecordConfig) string {
+	var policy []string
+	for _, meta := range routingMetas {
+		if v := rc.Metadata[meta]; v != "" {
+			policy = append(policy, strings.TrimPrefix(meta, "route53_")+"="+v)
+		}
+	}
+	return strings.Join(policy, " ")
+}
+
+// setComparable returns a string that is equal for two record sets with
+// the same records and routing policy.
+func setComparable(recs []*models.RecordConfig) string {
+	var parts []string
+	for _, rc := range recs {
+		part := fmt.Sprintf("%s ttl=%d", rc.GetTargetCombinedFunc(txtutil.EncodeQuoted), rc.TTL)
+		if rc.Type == "R53_ALIAS" {
+			part = fmt.Sprintf("%s zone_id=%s evaluate_target_health=%s", rc.GetTargetField(), rc.R53Alias["zone_id"], rc.R53Alias["evaluate_target_health"])
+		}
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	var policy []string
+	for _, meta := range routingMetas[1:] {
+		if v := recs[0].Metadata[meta]; v != "" {
+			policy = append(policy, strings.TrimPrefix(meta, "route53_")+"="+v)
+		}
+	}
+	return strings.Join(parts, ", ") + " " + strings.Join(policy, " ")
+}
+
+// hasRoutingPolicy reports whether one of the records is in a record set
+// with a routing policy.
+func hasRoutingPolicy(records models.Records) bool {
+	for _, rc := range records {
+		if _, ok := routingKeyOf(rc); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// recordSets groups the records of a label and a type by record set.
+func recordSets(records models.Records) map[routingKey][]*models.RecordConfig {
+	sets := map[routingKey][]*models.RecordConfig{}
+	for _, rc := range records {
+		key, _ := routingKeyOf(rc)
+		sets[key] = append(sets[key], rc)
+	}
+	return sets
+}
+
+// checkRoutingMix returns an error if the records of a label and a type
+// are both in record sets with and without a routing policy.
+func checkRoutingMix(records models.Records) error {
+	routed := map[routingKey]bool{}
+	for _, rc := range records {
+		key, ok := routingKeyOf(rc)
+		key.SetIdentifier = ""
+		if seen, found := routed[key]; found && seen != ok {
+			return fmt.Errorf("%s %s: the records of a routing policy can't be mixed with records without %s", key.NameFQDN, key.Type, metaSetIdentifier)
+		}
+		routed[key] = ok
+	}
+	return nil
+}
+
+// getRoutingChanges returns the changes of the record sets of a label and a
+// type with a routing policy, from the existing to the desired records
+// given by diff2, with their description. healthChecks are the IDs of the
+// health checks declared by R53_HEALTH_CHECK(), by name.
+func getRoutingChanges(zone r53Types.HostedZone, existing, desired map[routingKey][]*models.RecordConfig, healthChecks map[string]*string) ([]r53Types.Change, []string) {
+	keys := make([]routingKey, 0, len(existing)+len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	for key := range existing {
+		if _, ok := desired[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	var changes []r53Types.Change
+	var descs []string
+	for _, key := range keys {
+		old, recs := existing[key], desired[key]
+		switch {
+		case len(recs) == 0:
+			rrset := old[0].Original.(r53Types.ResourceRecordSet)
+			changes = append(changes, r53Types.Change{Action: r53Types.ChangeActionDelete, ResourceRecordSet: &rrset})
+			descs = append(descs, color.RedString("- DELETE %s %s", key, setComparable(old)))
+		case len(old) == 0 || setComparable(old) != setComparable(recs):
+			rrset := newRRSet(zone, key.NameFQDN, key.Type, recs)
+			applyRoutingPolicy(rrset, recs[0])
+			if name := recs[0].Metadata[metaHealthCheck]; name != "" {
+				// The ID of a new health check is set when it is created.
+				rrset.HealthCheckId = healthChecks[name]
+			}
+			changes = append(changes, r53Types.Change{Action: r53Types.ChangeActionUpsert, ResourceRecordSet: rrset})
+			if len(old) == 0 {
+				descs = append(descs, color.GreenString("+ CREATE %s %s", key, setComparable(recs)))
+			} else {
+				descs = append(descs, color.YellowString("± MODIFY %s (%s) -> (%s)", key, setComparable(old), setComparable(recs)))
+			}
+		}
+	}
+	return changes, descs
+}
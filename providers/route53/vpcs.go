@@ -0,0 +1,251 @@
+package route53
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	r53 "github.com/aws/aws-sdk-go-v2/service/route53"
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/fatih/color"
+)
+
+// The VPCs of a private hosted zone are set in the domain metadata, as
+// comma-separated lists of "region:vpc-id". The zone is private when
+// route53_vpcs is set. The VPCs of route53_vpc_authorizations belong to
+// other accounts, which associate them with the zone.
+const (
+	metaVPCs              = "route53_vpcs"
+	metaVPCAuthorizations = "route53_vpc_authorizations"
+)
+
+// parseVPCs parses a list of VPCs of the domain metadata.
+func parseVPCs(s string) ([]r53Types.VPC, error) {
+	var vpcs []r53Types.VPC
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		region, id, ok := strings.Cut(item, ":")
+		if !ok || region == "" || id == "" {
+			return nil, fmt.Errorf("invalid VPC %q, expected region:vpc-id", item)
+		}
+		vpcs = append(vpcs, r53Types.VPC{VPCRegion: r53Types.VPCRegion(region), VPCId: aws.String(id)})
+	}
+	return vpcs, nil
+}
+
+func vpcString(vpc r53Types.VPC) string {
+	return string(vpc.VPCRegion) + ":" + aws.ToString(vpc.VPCId)
+}
+
+// diffVPCs returns the VPCs of desired which are not in existing, and those
+// of existing which are not in desired.
+func diffVPCs(existing, desired []r53Types.VPC) (added, removed []r53Types.VPC) {
+	index := func(vpcs []r53Types.VPC) map[string]r53Types.VPC {
+		m := make(map[string]r53Types.VPC, len(vpcs))
+		for _, vpc := range vpcs {
+			m[vpcString(vpc)] = vpc
+		}
+		return m
+	}
+	have, want := index(existing), index(desired)
+	for key, vpc := range want {
+		if _, ok := have[key]; !ok {
+			added = append(added, vpc)
+		}
+	}
+	for key, vpc := range have {
+		if _, ok := want[key]; !ok {
+			removed = append(removed, vpc)
+		}
+	}
+	byName := func(vpcs []r53Types.VPC) {
+		sort.Slice(vpcs, func(i, j int) bool { return vpcString(vpcs[i]) < vpcString(vpcs[j]) })
+	}
+	byName(added)
+	byName(removed)
+	return added, removed
+}
+
+// lookupZone returns the hosted zone of a domain, the private one if the
+// domain has VPCs.
+func (r *route53Provider) lookupZone(domain string, metadata map[string]string) (r53Types.HostedZone, bool) {
+	if metadata[metaVPCs] != "" {
+		zone, ok := r.privateZonesByDomain[domain]
+		return zone, ok
+	}
+	zone, ok := r.zonesByDomain[domain]
+	return zone, ok
+}
+
+// EnsureZoneExistsWithMetadata creates the zone, as a private hosted zone
+// associated with its first VPC if the domain has VPCs. The other VPCs are
+// associated by the corrections of the zone.
+func (r *route53Provider) EnsureZoneExistsWithMetadata(domain string, metadata map[string]string) error {
+	vpcs, err := parseVPCs(metadata[metaVPCs])
+	if err != nil {
+		return err
+	}
+	if len(vpcs) == 0 {
+		return r.EnsureZoneExists(domain)
+	}
+
+	if err := r.getZones(); err != nil {
+		return err
+	}
+	if _, ok := r.lookupZone(domain, metadata); ok {
+		return nil
+	}
+	printer.Printf("Adding private zone for %s to route 53 account with VPC %s\n", domain, vpcString(vpcs[0]))
+	in := &r53.CreateHostedZoneInput{
+		Name:             &domain,
+		VPC:              &vpcs[0],
+		HostedZoneConfig: &r53Types.HostedZoneConfig{PrivateZone: true},
+		CallerReference:  aws.String(fmt.Sprint(time.Now().UnixNano())),
+	}
+
+	// reset zone cache
+	r.zonesByDomain = nil
+	r.zonesByID = nil
+	r.privateZonesByDomain = nil
+
+	withRetry(func() error {
+		_, err = r.client.CreateHostedZone(context.Background(), in)
+		return err
+	})
+	return err
+}
+
+// getVPCCorrections returns the corrections of the VPCs associated with a
+// private hosted zone and of the association authorizations of the other
+// accounts. The VPCs are only managed when they are set in the metadata.
+func (r *route53Provider) getVPCCorrections(dc *models.DomainConfig, zone r53Types.HostedZone) ([]*models.Correction, error) {
+	var corrections []*models.Correction
+
+	if dc.Metadata[metaVPCs] != "" {
+		if zone.Config == nil || !zone.Config.PrivateZone {
+			return nil, fmt.Errorf("%s is set but the hosted zone %s of %s is not private", metaVPCs, parseZoneID(aws.ToString(zone.Id)), dc.Name)
+		}
+		desired, err := parseVPCs(dc.Metadata[metaVPCs])
+		if err != nil {
+			return nil, err
+		}
+		var z *r53.GetHostedZoneOutput
+		withRetry(func() error {
+			z, err = r.client.GetHostedZone(context.Background(), &r53.GetHostedZoneInput{Id: zone.Id})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// A private hosted zone has at least one VPC, the new VPCs are
+		// associated before the others are disassociated.
+		added, removed := diffVPCs(z.VPCs, desired)
+		for _, vpc := range added {
+			corrections = append(corrections, &models.Correction{
+				Msg: color.GreenString("+ ASSOCIATE VPC %s", vpcString(vpc)),
+				F: func() (err error) {
+					withRetry(func() error {
+						_, err = r.client.AssociateVPCWithHostedZone(context.Background(), &r53.AssociateVPCWithHostedZoneInput{
+							HostedZoneId: zone.Id,
+							VPC:          &vpc,
+						})
+						return err
+					})
+					return err
+				},
+			})
+		}
+		for _, vpc := range removed {
+			corrections = append(corrections, &models.Correction{
+				Msg: color.RedString("- DISASSOCIATE VPC %s", vpcString(vpc)),
+				F: func() (err error) {
+					withRetry(func() error {
+						_, err = r.client.DisassociateVPCFromHostedZone(context.Background(), &r53.DisassociateVPCFromHostedZoneInput{
+							HostedZoneId: zone.Id,
+							VPC:          &vpc,
+						})
+						return err
+					})
+					return err
+				},
+			})
+		}
+	}
+
+	if authorizations, ok := dc.Metadata[metaVPCAuthorizations]; ok {
+		desired, err := parseVPCs(authorizations)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := r.getVPCAssociationAuthorizations(zone.Id)
+		if err != nil {
+			return nil, err
+		}
+		added, removed := diffVPCs(existing, desired)
+		for _, vpc := range added {
+			corrections = append(corrections, &models.Correction{
+				Msg: color.GreenString("+ AUTHORIZE VPC association %s", vpcString(vpc)),
+				F: func() (err error) {
+					withRetry(func() error {
+						_, err = r.client.CreateVPCAssociationAuthorization(context.Background(), &r53.CreateVPCAssociationAuthorizationInput{
+							HostedZoneId: zone.Id,
+							VPC:          &vpc,
+						})
+						return err
+					})
+					return err
+				},
+			})
+		}
+		for _, vpc := range removed {
+			corrections = append(corrections, &models.Correction{
+				Msg: color.RedString("- DELETE VPC association authorization %s", vpcString(vpc)),
+				F: func() (err error) {
+					withRetry(func() error {
+						_, err = r.client.DeleteVPCAssociationAuthorization(context.Background(), &r53.DeleteVPCAssociationAuthorizationInput{
+							HostedZoneId: zone.Id,
+							VPC:          &vpc,
+						})
+						return err
+					})
+					return err
+				},
+			})
+		}
+	}
+
+	return corrections, nil
+}
+
+func (r *route53Provider) getVPCAssociationAuthorizations(zoneID *string) ([]r53Types.VPC, error) {
+	var vpcs []r53Types.VPC
+	var nextToken *string
+	for {
+		var out *r53.ListVPCAssociationAuthorizationsOutput
+		var err error
+		withRetry(func() error {
+			out, err = r.client.ListVPCAssociationAuthorizations(context.Background(), &r53.ListVPCAssociationAuthorizationsInput{
+				HostedZoneId: zoneID,
+				NextToken:    nextToken,
+			})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		vpcs = append(vpcs, out.VPCs...)
+		if out.NextToken == nil {
+			return vpcs, nil
+		}
+		nextToken = out.NextToken
+	}
+}
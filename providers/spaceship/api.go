@@ -0,0 +1,209 @@
+package spaceship
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	baseURL = "https://spaceship.dev/api/v1"
+
+	// Maximum page sizes accepted by the API.
+	domainsPageSize = 100
+	recordsPageSize = 500
+)
+
+type spaceshipProvider struct {
+	apiKey    string
+	apiSecret string
+}
+
+type nameservers struct {
+	Provider string   `json:"provider"`
+	Hosts    []string `json:"hosts,omitempty"`
+}
+
+type domain struct {
+	Name        string      `json:"name"`
+	Nameservers nameservers `json:"nameservers"`
+}
+
+type domainsResponse struct {
+	Items []domain `json:"items"`
+	Total int      `json:"total"`
+}
+
+type recordGroup struct {
+	Type string `json:"type"`
+}
+
+// record holds the fields of all record types. Only the fields of the
+// record's type are set.
+type record struct {
+	Type       string       `json:"type"`
+	Name       string       `json:"name"`
+	TTL        uint32       `json:"ttl,omitempty"`
+	Group      *recordGroup `json:"group,omitempty"`
+	Address    string       `json:"address,omitempty"`
+	CName      string       `json:"cname,omitempty"`
+	AliasName  string       `json:"aliasName,omitempty"`
+	Exchange   string       `json:"exchange,omitempty"`
+	Preference *uint16      `json:"preference,omitempty"`
+	Value      string       `json:"value,omitempty"`
+	Flag       *uint8       `json:"flag,omitempty"`
+	Tag        string       `json:"tag,omitempty"`
+	Nameserver string       `json:"nameserver,omitempty"`
+	Pointer    string       `json:"pointer,omitempty"`
+	Service    string       `json:"service,omitempty"`
+	Protocol   string       `json:"protocol,omitempty"`
+	Priority   *uint16      `json:"priority,omitempty"`
+	Weight     *uint16      `json:"weight,omitempty"`
+	Port       *uint16      `json:"port,omitempty"`
+	Target     string       `json:"target,omitempty"`
+}
+
+type recordsResponse struct {
+	Items []record `json:"items"`
+	Total int      `json:"total"`
+}
+
+type errorResponse struct {
+	Detail string `json:"detail"`
+}
+
+// do sends a request and decodes the response into target.
+func (c *spaceshipProvider) do(method, path string, body, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+retry:
+	req, err := http.NewRequest(method, baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+	req.Header.Set("X-API-Secret", c.apiSecret)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries {
+		retrycnt++
+		wait := retrycnt
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > wait {
+			wait = s
+		}
+		printer.Printf("Spaceship rate limit exceeded. Waiting %d second(s) to retry.\n", wait)
+		time.Sleep(time.Duration(wait) * time.Second)
+		goto retry
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var er errorResponse
+		if json.Unmarshal(data, &er) == nil && er.Detail != "" {
+			return fmt.Errorf("spaceship API error: %s: %s", resp.Status, er.Detail)
+		}
+		return fmt.Errorf("spaceship API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (c *spaceshipProvider) listDomains() ([]string, error) {
+	var domains []string
+	for skip := 0; ; skip += domainsPageSize {
+		var resp domainsResponse
+		path := fmt.Sprintf("/domains?take=%d&skip=%d", domainsPageSize, skip)
+		if err := c.do(http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed listing domains from spaceship: %w", err)
+		}
+		for _, d := range resp.Items {
+			domains = append(domains, d.Name)
+		}
+		if skip+len(resp.Items) >= resp.Total || len(resp.Items) == 0 {
+			break
+		}
+	}
+	return domains, nil
+}
+
+func (c *spaceshipProvider) getDomain(name string) (*domain, error) {
+	var d domain
+	if err := c.do(http.MethodGet, "/domains/"+url.PathEscape(name), nil, &d); err != nil {
+		return nil, fmt.Errorf("failed fetching domain from spaceship: %w", err)
+	}
+	return &d, nil
+}
+
+func (c *spaceshipProvider) updateNameservers(name, provider string, ns []string) error {
+	body := nameservers{Provider: provider, Hosts: ns}
+	if err := c.do(http.MethodPut, "/domains/"+url.PathEscape(name)+"/nameservers", body, nil); err != nil {
+		return fmt.Errorf("failed updating nameservers (spaceship): %w", err)
+	}
+	return nil
+}
+
+func (c *spaceshipProvider) getRecords(domain string) ([]record, error) {
+	var records []record
+	for skip := 0; ; skip += recordsPageSize {
+		var resp recordsResponse
+		path := fmt.Sprintf("/dns/records/%s?take=%d&skip=%d", url.PathEscape(domain), recordsPageSize, skip)
+		if err := c.do(http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed fetching record list from spaceship: %w", err)
+		}
+		records = append(records, resp.Items...)
+		if skip+len(resp.Items) >= resp.Total || len(resp.Items) == 0 {
+			break
+		}
+	}
+	return records, nil
+}
+
+// createRecords adds records to the zone. Spaceship records have no ID,
+// a record is identified by its content.
+func (c *spaceshipProvider) createRecords(domain string, recs []*record) error {
+	body := struct {
+		Force bool      `json:"force"`
+		Items []*record `json:"items"`
+	}{Items: recs}
+	if err := c.do(http.MethodPut, "/dns/records/"+url.PathEscape(domain), body, nil); err != nil {
+		return fmt.Errorf("failed create record (spaceship): %w", err)
+	}
+	return nil
+}
+
+func (c *spaceshipProvider) deleteRecords(domain string, recs []*record) error {
+	if err := c.do(http.MethodDelete, "/dns/records/"+url.PathEscape(domain), recs, nil); err != nil {
+		return fmt.Errorf("failed delete record (spaceship): %w", err)
+	}
+	return nil
+}
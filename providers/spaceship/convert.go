@@ -0,0 +1,118 @@
+package spaceship
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+func deref16(p *uint16) uint16 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// toRc converts a Spaceship record into a RecordConfig.
+func toRc(domain string, r *record) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+	}
+
+	label := r.Name
+	if r.Type == "SRV" {
+		// The service and protocol are not part of the name.
+		label = r.Service + "." + r.Protocol
+		if r.Name != "@" && r.Name != "" {
+			label += "." + r.Name
+		}
+	}
+	rc.SetLabel(label, domain)
+
+	var err error
+	switch r.Type {
+	case "A", "AAAA":
+		err = rc.SetTarget(r.Address)
+	case "ALIAS":
+		err = rc.SetTarget(dot(r.AliasName))
+	case "CNAME":
+		err = rc.SetTarget(dot(r.CName))
+	case "NS":
+		err = rc.SetTarget(dot(r.Nameserver))
+	case "PTR":
+		err = rc.SetTarget(dot(r.Pointer))
+	case "MX":
+		err = rc.SetTargetMX(deref16(r.Preference), dot(r.Exchange))
+	case "SRV":
+		err = rc.SetTargetSRV(deref16(r.Priority), deref16(r.Weight), deref16(r.Port), dot(r.Target))
+	case "TXT":
+		err = rc.SetTargetTXT(r.Value)
+	case "CAA":
+		var flag uint8
+		if r.Flag != nil {
+			flag = *r.Flag
+		}
+		err = rc.SetTargetCAA(flag, r.Tag, r.Value)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+// toRecord converts a RecordConfig into a Spaceship record.
+func toRecord(rc *models.RecordConfig) (*record, error) {
+	r := &record{
+		Type: rc.Type,
+		Name: rc.GetLabel(),
+		TTL:  rc.TTL,
+	}
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "A", "AAAA":
+		r.Address = target
+	case "ALIAS":
+		r.AliasName = target
+	case "CNAME":
+		r.CName = target
+	case "NS":
+		r.Nameserver = target
+	case "PTR":
+		r.Pointer = target
+	case "MX":
+		pref := rc.MxPreference
+		r.Preference = &pref
+		r.Exchange = target
+	case "SRV":
+		parts := strings.SplitN(r.Name, ".", 3)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid SRV label %q", r.Name)
+		}
+		r.Service, r.Protocol, r.Name = parts[0], parts[1], "@"
+		if len(parts) == 3 {
+			r.Name = parts[2]
+		}
+		prio, weight, port := rc.SrvPriority, rc.SrvWeight, rc.SrvPort
+		r.Priority, r.Weight, r.Port = &prio, &weight, &port
+		r.Target = target
+	case "TXT":
+		r.Value = rc.GetTargetTXTJoined()
+	case "CAA":
+		flag := rc.CaaFlag
+		r.Flag = &flag
+		r.Tag = rc.CaaTag
+		r.Value = rc.GetTargetField()
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", rc.Type)
+	}
+	return r, nil
+}
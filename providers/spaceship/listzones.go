@@ -0,0 +1,6 @@
+package spaceship
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *spaceshipProvider) ListZones() ([]string, error) {
+	return c.listDomains()
+}
@@ -0,0 +1,218 @@
+package spaceship
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Spaceship.
+// API Documentation: https://docs.spaceship.dev/
+
+/*
+Spaceship provider:
+
+Info required in `creds.json`:
+   - api_key
+   - api_secret
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Can(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Cannot(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{
+	"launch1.spaceship.net",
+	"launch2.spaceship.net",
+}
+
+func init() {
+	const providerName = "SPACESHIP"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	providers.RegisterRegistrarType(providerName, newReg)
+	fns := providers.DspFuncs{
+		Initializer:   newDsp,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+func newReg(conf map[string]string) (providers.Registrar, error) {
+	return newSpaceship(conf)
+}
+
+func newDsp(conf map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	return newSpaceship(conf)
+}
+
+// newSpaceship creates the provider.
+func newSpaceship(m map[string]string) (*spaceshipProvider, error) {
+	c := &spaceshipProvider{
+		apiKey:    m["api_key"],
+		apiSecret: m["api_secret"],
+	}
+	if c.apiKey == "" || c.apiSecret == "" {
+		return nil, fmt.Errorf("missing SPACESHIP api_key or api_secret")
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *spaceshipProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *spaceshipProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		r := &records[i]
+		if r.Group != nil && r.Group.Type != "custom" {
+			// Records managed by other Spaceship products are read-only.
+			continue
+		}
+		rc, err := toRc(domain, r)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *spaceshipProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			rec, err := toRecord(change.New[0])
+			if err != nil {
+				return nil, err
+			}
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecords(dc.Name, []*record{rec})
+				},
+			}
+		case diff2.CHANGE:
+			// Records have no ID, the old record is deleted and the new one created.
+			old := deleteCopy(change.Old[0])
+			rec, err := toRecord(change.New[0])
+			if err != nil {
+				return nil, err
+			}
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					if err := c.deleteRecords(dc.Name, []*record{old}); err != nil {
+						return err
+					}
+					return c.createRecords(dc.Name, []*record{rec})
+				},
+			}
+		case diff2.DELETE:
+			old := deleteCopy(change.Old[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.deleteRecords(dc.Name, []*record{old})
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
+
+// deleteCopy returns the record to send to delete rc.
+func deleteCopy(rc *models.RecordConfig) *record {
+	r := *rc.Original.(*record)
+	r.Group = nil
+	r.TTL = 0
+	return &r
+}
+
+// GetRegistrarCorrections returns a list of corrections for this registrar.
+func (c *spaceshipProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	d, err := c.getDomain(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	found := d.Nameservers.Hosts
+	if d.Nameservers.Provider == "basic" && len(found) == 0 {
+		found = defaultNS
+	}
+	foundNameservers := make([]string, 0, len(found))
+	for _, ns := range found {
+		foundNameservers = append(foundNameservers, strings.ToLower(strings.TrimSuffix(ns, ".")))
+	}
+	sort.Strings(foundNameservers)
+
+	expected := make([]string, 0, len(dc.Nameservers))
+	for _, ns := range dc.Nameservers {
+		expected = append(expected, ns.Name)
+	}
+	sort.Strings(expected)
+
+	foundStr := strings.Join(foundNameservers, ",")
+	expectedStr := strings.Join(expected, ",")
+	if foundStr == expectedStr {
+		return nil, nil
+	}
+
+	return []*models.Correction{
+		{
+			Msg: fmt.Sprintf("Update nameservers %s -> %s", foundStr, expectedStr),
+			F: func() error {
+				if expectedStr == strings.Join(defaultNS, ",") {
+					// Spaceship's own nameservers are selected by provider.
+					return c.updateNameservers(dc.Name, "basic", nil)
+				}
+				return c.updateNameservers(dc.Name, "custom", expected)
+			},
+		},
+	}, nil
+}
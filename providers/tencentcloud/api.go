@@ -0,0 +1,330 @@
+package tencentcloud
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	apiHost    = "dnspod.tencentcloudapi.com"
+	apiService = "dnspod"
+	apiVersion = "2021-03-23"
+
+	// Maximum page sizes accepted by the API.
+	recordsPageSize = 3000
+	domainsPageSize = 3000
+
+	// The free plan requires a TTL of at least 600 seconds. The paid plans
+	// lower the limit, and the API rejects the TTLs below theirs.
+	freeMinTTL = 600
+)
+
+type tencentcloudProvider struct {
+	secretID   string
+	secretKey  string
+	region     string
+	domainInfo map[string]*domainInfo
+}
+
+type apiError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+type apiResponse struct {
+	Response json.RawMessage `json:"Response"`
+}
+
+type responseHeader struct {
+	Error     *apiError `json:"Error"`
+	RequestID string    `json:"RequestId"`
+}
+
+type recordListItem struct {
+	RecordID uint64 `json:"RecordId"`
+	Value    string `json:"Value"`
+	Status   string `json:"Status"`
+	Name     string `json:"Name"`
+	Line     string `json:"Line"`
+	LineID   string `json:"LineId"`
+	Type     string `json:"Type"`
+	Remark   string `json:"Remark"`
+	TTL      uint32 `json:"TTL"`
+	MX       uint16 `json:"MX"`
+	Weight   *int   `json:"Weight"`
+}
+
+type describeRecordListResponse struct {
+	RecordCountInfo struct {
+		TotalCount uint64 `json:"TotalCount"`
+	} `json:"RecordCountInfo"`
+	RecordList []recordListItem `json:"RecordList"`
+}
+
+type domainListItem struct {
+	DomainID    uint64   `json:"DomainId"`
+	Name        string   `json:"Name"`
+	Status      string   `json:"Status"`
+	DNSStatus   string   `json:"DNSStatus"`
+	GradeTitle  string   `json:"GradeTitle"`
+	EffectiveNS []string `json:"EffectiveDNS"`
+}
+
+type describeDomainListResponse struct {
+	DomainCountInfo struct {
+		AllTotal uint64 `json:"AllTotal"`
+	} `json:"DomainCountInfo"`
+	DomainList []domainListItem `json:"DomainList"`
+}
+
+type domainInfo struct {
+	Domain       string   `json:"Domain"`
+	DnspodNsList []string `json:"DnspodNsList"`
+	Grade        string   `json:"Grade"`
+}
+
+// minTTL returns the minimum TTL of the plan of the domain, or 0 if it is
+// not known.
+func (d *domainInfo) minTTL() uint32 {
+	// The free plan is "DP_FREE", or "D_FREE" for the older domains.
+	if strings.HasSuffix(strings.ToUpper(d.Grade), "_FREE") {
+		return freeMinTTL
+	}
+	return 0
+}
+
+type describeDomainResponse struct {
+	DomainInfo domainInfo `json:"DomainInfo"`
+}
+
+// recordRequest is the common payload of CreateRecord and ModifyRecord.
+type recordRequest struct {
+	Domain     string  `json:"Domain"`
+	RecordID   uint64  `json:"RecordId,omitempty"`
+	SubDomain  string  `json:"SubDomain"`
+	RecordType string  `json:"RecordType"`
+	RecordLine string  `json:"RecordLine"`
+	Value      string  `json:"Value"`
+	MX         *uint16 `json:"MX,omitempty"`
+	TTL        uint32  `json:"TTL"`
+	Remark     string  `json:"Remark,omitempty"`
+}
+
+func hmacSHA256(key []byte, msg string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msg))
+	return mac.Sum(nil)
+}
+
+func sha256hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// authorization computes the TC3-HMAC-SHA256 Authorization header value of
+// a request to the service at host.
+// https://www.tencentcloud.com/document/api/1157/49029
+func authorization(host, service, secretID, secretKey string, timestamp int64, payload string) string {
+	const algorithm = "TC3-HMAC-SHA256"
+	const signedHeaders = "content-type;host"
+
+	canonicalHeaders := "content-type:application/json; charset=utf-8\nhost:" + host + "\n"
+	canonicalRequest := "POST\n/\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" + sha256hex(payload)
+
+	date := time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+	credentialScope := date + "/" + service + "/tc3_request"
+	stringToSign := algorithm + "\n" + strconv.FormatInt(timestamp, 10) + "\n" + credentialScope + "\n" + sha256hex(canonicalRequest)
+
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, service)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	return fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, secretID, credentialScope, signedHeaders, signature)
+}
+
+// call invokes an API action and decodes the "Response" object into target.
+// The error code is returned separately so callers can handle expected errors.
+func (c *tencentcloudProvider) call(action string, params any, target any) (string, error) {
+	const maxRetries = 10
+	retrycnt := 0
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+retry:
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequest(http.MethodPost, "https://"+apiHost+"/", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Host", apiHost)
+	req.Header.Set("X-TC-Action", action)
+	req.Header.Set("X-TC-Version", apiVersion)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(timestamp, 10))
+	if c.region != "" {
+		req.Header.Set("X-TC-Region", c.region)
+	}
+	req.Header.Set("Authorization", authorization(apiHost, apiService, c.secretID, c.secretKey, timestamp, string(payload)))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return "", err
+	}
+
+	var ar apiResponse
+	if err := json.Unmarshal(body, &ar); err != nil {
+		return "", fmt.Errorf("tencentcloud API error: %s: %s", resp.Status, string(body))
+	}
+	var hdr responseHeader
+	if err := json.Unmarshal(ar.Response, &hdr); err != nil {
+		return "", fmt.Errorf("tencentcloud API error: %s: %s", resp.Status, string(body))
+	}
+	if hdr.Error != nil {
+		if hdr.Error.Code == "RequestLimitExceeded" && retrycnt < maxRetries {
+			retrycnt++
+			printer.Printf("Tencent Cloud rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+			time.Sleep(time.Duration(retrycnt) * time.Second)
+			goto retry
+		}
+		return hdr.Error.Code, fmt.Errorf("tencentcloud API error: %s: %s (RequestId: %s)", hdr.Error.Code, hdr.Error.Message, hdr.RequestID)
+	}
+
+	if target == nil {
+		return "", nil
+	}
+	return "", json.Unmarshal(ar.Response, target)
+}
+
+func (c *tencentcloudProvider) getRecords(domain string) ([]recordListItem, error) {
+	var records []recordListItem
+	offset := 0
+	for {
+		var resp describeRecordListResponse
+		code, err := c.call("DescribeRecordList", map[string]any{
+			"Domain": domain,
+			"Offset": offset,
+			"Limit":  recordsPageSize,
+		}, &resp)
+		if code == "ResourceNotFound.NoDataOfRecord" {
+			// An empty zone is reported as an error.
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching record list from tencentcloud: %w", err)
+		}
+		records = append(records, resp.RecordList...)
+		offset += len(resp.RecordList)
+		if uint64(offset) >= resp.RecordCountInfo.TotalCount || len(resp.RecordList) == 0 {
+			break
+		}
+	}
+	return records, nil
+}
+
+func (c *tencentcloudProvider) createRecord(req *recordRequest) error {
+	if _, err := c.call("CreateRecord", req, nil); err != nil {
+		return fmt.Errorf("failed create record (tencentcloud): %w", err)
+	}
+	return nil
+}
+
+func (c *tencentcloudProvider) modifyRecord(req *recordRequest, oldRemark string) error {
+	if _, err := c.call("ModifyRecord", req, nil); err != nil {
+		return fmt.Errorf("failed update record (tencentcloud): %w", err)
+	}
+	// The remark may only be cleared through the dedicated action.
+	if req.Remark != oldRemark {
+		if _, err := c.call("ModifyRecordRemark", map[string]any{
+			"Domain":   req.Domain,
+			"RecordId": req.RecordID,
+			"Remark":   req.Remark,
+		}, nil); err != nil {
+			return fmt.Errorf("failed update record remark (tencentcloud): %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *tencentcloudProvider) deleteRecord(domain string, recordID uint64) error {
+	if _, err := c.call("DeleteRecord", map[string]any{
+		"Domain":   domain,
+		"RecordId": recordID,
+	}, nil); err != nil {
+		return fmt.Errorf("failed delete record (tencentcloud): %w", err)
+	}
+	return nil
+}
+
+// getDomainInfo returns the information of the domain, which includes its
+// nameservers and its plan.
+func (c *tencentcloudProvider) getDomainInfo(domain string) (*domainInfo, error) {
+	if info, ok := c.domainInfo[domain]; ok {
+		return info, nil
+	}
+	var resp describeDomainResponse
+	if _, err := c.call("DescribeDomain", map[string]any{"Domain": domain}, &resp); err != nil {
+		return nil, fmt.Errorf("failed fetching domain from tencentcloud: %w", err)
+	}
+	c.domainInfo[domain] = &resp.DomainInfo
+	return &resp.DomainInfo, nil
+}
+
+func (c *tencentcloudProvider) getNameservers(domain string) ([]string, error) {
+	info, err := c.getDomainInfo(domain)
+	if err != nil {
+		return nil, err
+	}
+	return info.DnspodNsList, nil
+}
+
+func (c *tencentcloudProvider) listAllDomains() ([]string, error) {
+	var domains []string
+	offset := 0
+	for {
+		var resp describeDomainListResponse
+		if _, err := c.call("DescribeDomainList", map[string]any{
+			"Offset": offset,
+			"Limit":  domainsPageSize,
+		}, &resp); err != nil {
+			return nil, fmt.Errorf("failed listing domains from tencentcloud: %w", err)
+		}
+		for _, d := range resp.DomainList {
+			domains = append(domains, d.Name)
+		}
+		offset += len(resp.DomainList)
+		if uint64(offset) >= resp.DomainCountInfo.AllTotal || len(resp.DomainList) == 0 {
+			break
+		}
+	}
+	sort.Strings(domains)
+	return domains, nil
+}
+
+func (c *tencentcloudProvider) createDomain(domain string) error {
+	if _, err := c.call("CreateDomain", map[string]any{"Domain": domain}, nil); err != nil {
+		return fmt.Errorf("failed creating domain (tencentcloud): %w", err)
+	}
+	return nil
+}
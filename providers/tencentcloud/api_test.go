@@ -0,0 +1,33 @@
+package tencentcloud
+
+import "testing"
+
+func TestAuthorization(t *testing.T) {
+	// The example of the documentation of the signature:
+	// https://www.tencentcloud.com/document/api/1157/49029
+	payload := `{"Limit": 1, "Filters": [{"Values": ["\u672a\u547d\u540d"], "Name": "instance-name"}]}`
+	got := authorization("cvm.tencentcloudapi.com", "cvm",
+		"AKIDz8krbsJ5yKBZQpn74WFkmLPx3EXAMPLE", "Gu5t9xGARNpq86cd98joQYCN3EXAMPLE",
+		1551113065, payload)
+	want := "TC3-HMAC-SHA256 Credential=AKIDz8krbsJ5yKBZQpn74WFkmLPx3EXAMPLE/2019-02-25/cvm/tc3_request, " +
+		"SignedHeaders=content-type;host, " +
+		"Signature=72e494ea809ad7a8c8f7a4507b9bddcbaa8e581f516e8da2f66e2c5a96525168"
+	if got != want {
+		t.Errorf("authorization() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMinTTL(t *testing.T) {
+	for grade, want := range map[string]uint32{
+		"DP_FREE":  freeMinTTL,
+		"D_Free":   freeMinTTL,
+		"DP_PLUS":  0,
+		"DP_ULTRA": 0,
+		"":         0,
+	} {
+		info := &domainInfo{Grade: grade}
+		if got := info.minTTL(); got != want {
+			t.Errorf("minTTL(%q) = %d, want %d", grade, got, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package tencentcloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// toRc converts the DNSPod record format into our standard RecordConfig.
+func toRc(domain string, r *recordListItem) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+		Metadata: map[string]string{
+			metaLine:   r.Line,
+			metaRemark: r.Remark,
+		},
+	}
+	rc.SetLabel(r.Name, domain)
+
+	var err error
+	switch rtype := r.Type; rtype { // #rtype_variations
+	case "TXT":
+		err = rc.SetTargetTXT(r.Value)
+	case "MX":
+		err = rc.SetTargetMX(r.MX, fqdn(r.Value))
+	case "CNAME", "NS", "PTR":
+		err = rc.SetTarget(fqdn(r.Value))
+	case "SRV":
+		// priority weight port target
+		err = rc.SetTargetSRVString(fqdn(r.Value))
+	default:
+		err = rc.PopulateFromString(rtype, r.Value, domain)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unparsable record received from tencentcloud: %w", err)
+	}
+	return rc, nil
+}
+
+// toReq takes a RecordConfig and turns it into the native format used by the API.
+func toReq(domain string, rc *models.RecordConfig) (*recordRequest, error) {
+	req := &recordRequest{
+		Domain:     domain,
+		SubDomain:  rc.GetLabel(),
+		RecordType: rc.Type,
+		RecordLine: rc.Metadata[metaLine],
+		Value:      rc.GetTargetField(),
+		TTL:        rc.TTL,
+		Remark:     rc.Metadata[metaRemark],
+	}
+
+	switch rc.Type { // #rtype_variations
+	case "A", "AAAA", "CNAME", "NS", "PTR":
+		// Nothing special.
+	case "TXT":
+		req.Value = rc.GetTargetTXTJoined()
+	case "MX":
+		mx := rc.MxPreference
+		req.MX = &mx
+	case "SRV":
+		req.Value = fmt.Sprintf("%d %d %d %s", rc.SrvPriority, rc.SrvWeight, rc.SrvPort, rc.GetTargetField())
+	case "CAA":
+		req.Value = fmt.Sprintf("%d %s \"%s\"", rc.CaaFlag, rc.CaaTag, rc.GetTargetField())
+	default:
+		return nil, fmt.Errorf("tencentcloud.toReq rtype %q unimplemented", rc.Type)
+	}
+
+	return req, nil
+}
+
+// fqdn adds the trailing dot DNSPod sometimes omits from hostnames.
+func fqdn(s string) string {
+	if strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
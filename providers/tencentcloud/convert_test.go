@@ -0,0 +1,49 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	mx := uint16(10)
+	for _, tst := range []struct {
+		item  recordListItem
+		value string
+	}{
+		{recordListItem{Name: "www", Type: "A", Value: "192.0.2.1"}, "192.0.2.1"},
+		{recordListItem{Name: "@", Type: "MX", Value: "mail.example.com.", MX: mx}, "mail.example.com."},
+		{recordListItem{Name: "alias", Type: "CNAME", Value: "target.example.net"}, "target.example.net."},
+		{recordListItem{Name: "_sip._tcp", Type: "SRV", Value: "10 20 5060 sip.example.com."}, "10 20 5060 sip.example.com."},
+		{recordListItem{Name: "@", Type: "CAA", Value: `0 issue "letsencrypt.org"`}, `0 issue "letsencrypt.org"`},
+		{recordListItem{Name: "txt", Type: "TXT", Value: "v=spf1 -all"}, "v=spf1 -all"},
+	} {
+		tst.item.TTL = 600
+		tst.item.Line = defaultLine
+		tst.item.Remark = "managed"
+		rc, err := toRc("example.com", &tst.item)
+		if err != nil {
+			t.Fatalf("toRc(%+v): %v", tst.item, err)
+		}
+		req, err := toReq("example.com", rc)
+		if err != nil {
+			t.Fatalf("toReq(%s): %v", rc.Type, err)
+		}
+		if req.SubDomain != tst.item.Name || req.RecordType != tst.item.Type || req.Value != tst.value ||
+			req.TTL != 600 || req.RecordLine != defaultLine || req.Remark != "managed" {
+			t.Errorf("%s: got %+v", tst.item.Type, req)
+		}
+		if tst.item.Type == "MX" && (req.MX == nil || *req.MX != mx) {
+			t.Errorf("MX: got the preference %v, want %d", req.MX, mx)
+		}
+	}
+}
+
+func TestToReqUnsupported(t *testing.T) {
+	rc := &models.RecordConfig{Type: "LOC", Metadata: map[string]string{}}
+	rc.SetLabel("@", "example.com")
+	if _, err := toReq("example.com", rc); err == nil {
+		t.Error("Expected an error for LOC, got none")
+	}
+}
@@ -0,0 +1,201 @@
+package tencentcloud
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for DNSPod through the Tencent Cloud API 3.0.
+// API Documentation: https://www.tencentcloud.com/document/api/1157/49025
+
+/*
+Tencent Cloud DNSPod API DNS provider:
+
+Info required in `creds.json`:
+   - secret_id
+   - secret_key
+   - region (optional)
+
+Record level metadata available:
+   - dnspod_line (record line name, default "默认")
+   - dnspod_remark (free-form remark, default "")
+
+*/
+
+const (
+	metaLine   = "dnspod_line"
+	metaRemark = "dnspod_remark"
+
+	// DNSPod names its default line "默认" ("default") in every locale.
+	defaultLine = "默认"
+)
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Unimplemented(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "TENCENTCLOUD"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newTencentcloud,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newTencentcloud creates the provider.
+func newTencentcloud(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &tencentcloudProvider{
+		secretID:   m["secret_id"],
+		secretKey:  m["secret_key"],
+		region:     m["region"],
+		domainInfo: map[string]*domainInfo{},
+	}
+	if c.secretID == "" || c.secretKey == "" {
+		return nil, fmt.Errorf("missing TENCENTCLOUD secret_id or secret_key")
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *tencentcloudProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	ns, err := c.getNameservers(domain)
+	if err != nil {
+		return nil, err
+	}
+	return models.ToNameserversStripTD(ns)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *tencentcloudProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		// The apex NS records are managed by DNSPod and can't be modified.
+		if records[i].Type == "NS" && records[i].Name == "@" {
+			continue
+		}
+		rc, err := toRc(domain, &records[i])
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// clampTTLs raises the TTLs below the minimum TTL of the plan of the domain.
+func clampTTLs(recs models.Records, minTTL uint32) {
+	for _, rec := range recs {
+		if rec.TTL < minTTL {
+			printer.Warnf("%s %s: TTL %d is below the minimum of the DNSPod plan, using %d\n", rec.GetLabelFQDN(), rec.Type, rec.TTL, minTTL)
+			rec.TTL = minTTL
+		}
+	}
+}
+
+func genComparable(rec *models.RecordConfig) string {
+	line := rec.Metadata[metaLine]
+	if line == "" {
+		line = defaultLine
+	}
+	return fmt.Sprintf("line=%s remark=%q", line, rec.Metadata[metaRemark])
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *tencentcloudProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	info, err := c.getDomainInfo(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+	clampTTLs(dc.Records, info.minTTL())
+
+	for _, rec := range dc.Records {
+		if rec.Metadata == nil {
+			rec.Metadata = make(map[string]string)
+		}
+		if rec.Metadata[metaLine] == "" {
+			rec.Metadata[metaLine] = defaultLine
+		}
+	}
+
+	changes, err := diff2.ByRecord(existingRecords, dc, genComparable)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			req, err := toReq(dc.Name, change.New[0])
+			if err != nil {
+				return nil, err
+			}
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(req)
+				},
+			}
+		case diff2.CHANGE:
+			old := change.Old[0].Original.(*recordListItem)
+			req, err := toReq(dc.Name, change.New[0])
+			if err != nil {
+				return nil, err
+			}
+			req.RecordID = old.RecordID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, tencentcloud ID: %d", change.Msgs[0], old.RecordID),
+				F: func() error {
+					return c.modifyRecord(req, old.Remark)
+				},
+			}
+		case diff2.DELETE:
+			id := change.Old[0].Original.(*recordListItem).RecordID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, tencentcloud ID: %d", change.Msgs[0], id),
+				F: func() error {
+					return c.deleteRecord(dc.Name, id)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
@@ -0,0 +1,23 @@
+package tencentcloud
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestClampTTLs(t *testing.T) {
+	low := &models.RecordConfig{Type: "A", TTL: 300}
+	low.SetLabel("www", "example.com")
+	high := &models.RecordConfig{Type: "A", TTL: 3600}
+	high.SetLabel("@", "example.com")
+
+	clampTTLs(models.Records{low, high}, 0)
+	if low.TTL != 300 {
+		t.Errorf("Expected no clamp without a minimum, got %d", low.TTL)
+	}
+	clampTTLs(models.Records{low, high}, freeMinTTL)
+	if low.TTL != freeMinTTL || high.TTL != 3600 {
+		t.Errorf("Expected the TTLs %d and 3600, got %d and %d", freeMinTTL, low.TTL, high.TTL)
+	}
+}
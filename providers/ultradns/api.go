@@ -0,0 +1,326 @@
+package ultradns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	defaultBaseURL = "https://api.ultradns.com"
+	pageSize       = 1000
+)
+
+type ultradnsProvider struct {
+	baseURL     string
+	username    string
+	password    string
+	accountName string
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    string `json:"expiresIn"` // seconds
+}
+
+type apiError struct {
+	ErrorCode    int    `json:"errorCode"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+type resultInfo struct {
+	TotalCount    int `json:"totalCount"`
+	Offset        int `json:"offset"`
+	ReturnedCount int `json:"returnedCount"`
+}
+
+type zoneProperties struct {
+	Name        string `json:"name"`
+	AccountName string `json:"accountName,omitempty"`
+	Type        string `json:"type"`
+}
+
+type zone struct {
+	Properties zoneProperties `json:"properties"`
+}
+
+type listZonesResponse struct {
+	Zones      []zone     `json:"zones"`
+	ResultInfo resultInfo `json:"resultInfo"`
+}
+
+type createZoneRequest struct {
+	Properties        zoneProperties `json:"properties"`
+	PrimaryCreateInfo struct {
+		ForceImport bool   `json:"forceImport"`
+		CreateType  string `json:"createType"`
+	} `json:"primaryCreateInfo"`
+}
+
+type rrSet struct {
+	OwnerName string   `json:"ownerName,omitempty"`
+	RRType    string   `json:"rrtype,omitempty"` // e.g. "A (1)"
+	TTL       uint32   `json:"ttl"`
+	RData     []string `json:"rdata"`
+	Profile   *profile `json:"profile,omitempty"`
+}
+
+// profile describes a pool attached to an rrset. Only the fields of
+// resource distribution (RD) and directional (Dir) pools are modeled.
+type profile struct {
+	Context     string     `json:"@context"`
+	Order       string     `json:"order,omitempty"`
+	Description string     `json:"description,omitempty"`
+	RDataInfo   []dirRData `json:"rdataInfo,omitempty"`
+}
+
+type dirRData struct {
+	AllNonConfigured bool     `json:"allNonConfigured,omitempty"`
+	GeoInfo          *geoInfo `json:"geoInfo,omitempty"`
+	TTL              uint32   `json:"ttl,omitempty"`
+}
+
+type geoInfo struct {
+	Name  string   `json:"name"`
+	Codes []string `json:"codes"`
+}
+
+const (
+	contextRDPool  = "http://schemas.ultradns.com/RDPool.jsonschema"
+	contextDirPool = "http://schemas.ultradns.com/DirPool.jsonschema"
+)
+
+type listRRSetsResponse struct {
+	RRSets     []rrSet    `json:"rrSets"`
+	ResultInfo resultInfo `json:"resultInfo"`
+}
+
+// token returns a valid access token, logging in or refreshing as needed.
+func (c *ultradnsProvider) token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Now().Add(time.Minute).Before(c.expiresAt) {
+		return c.accessToken, nil
+	}
+
+	var tr *tokenResponse
+	var err error
+	if c.refreshToken != "" {
+		// Fall back to a new login if the refresh token expired.
+		tr, err = c.fetchToken(url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {c.refreshToken},
+		})
+	}
+	if tr == nil {
+		tr, err = c.fetchToken(url.Values{
+			"grant_type": {"password"},
+			"username":   {c.username},
+			"password":   {c.password},
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+
+	expiresIn, _ := strconv.Atoi(tr.ExpiresIn)
+	if expiresIn == 0 {
+		expiresIn = 3600
+	}
+	c.accessToken = tr.AccessToken
+	c.refreshToken = tr.RefreshToken
+	c.expiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	return c.accessToken, nil
+}
+
+func (c *ultradnsProvider) fetchToken(form url.Values) (*tokenResponse, error) {
+	resp, err := http.PostForm(c.baseURL+"/v2/authorization/token", form)
+	if err != nil {
+		return nil, fmt.Errorf("ULTRADNS authentication failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ULTRADNS authentication failed: %s: %s", resp.Status, string(data))
+	}
+	var tr tokenResponse
+	if err := json.Unmarshal(data, &tr); err != nil {
+		return nil, fmt.Errorf("ULTRADNS authentication failed: %w", err)
+	}
+	return &tr, nil
+}
+
+// request sends an API request and decodes the JSON response into target.
+func (c *ultradnsProvider) request(method, path string, body any, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+retry:
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("UltraDNS rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		var errs []apiError
+		if json.Unmarshal(data, &errs) == nil && len(errs) != 0 {
+			msgs := make([]string, 0, len(errs))
+			for _, e := range errs {
+				msgs = append(msgs, fmt.Sprintf("%s (%d)", e.ErrorMessage, e.ErrorCode))
+			}
+			return &requestError{status: resp.StatusCode, code: errs[0].ErrorCode, msg: strings.Join(msgs, "; ")}
+		}
+		return &requestError{status: resp.StatusCode, msg: string(data)}
+	}
+
+	if target == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+type requestError struct {
+	status int
+	code   int
+	msg    string
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("ULTRADNS API error: %d: %s", e.status, e.msg)
+}
+
+// errorCodeNoData is returned when listing the rrsets of an empty zone.
+const errorCodeNoData = 70002
+
+func (c *ultradnsProvider) listZones() ([]string, error) {
+	var zones []string
+	for offset := 0; ; {
+		var resp listZonesResponse
+		path := fmt.Sprintf("/v3/zones?q=zone_type:PRIMARY&limit=%d&offset=%d", pageSize, offset)
+		if err := c.request(http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed listing zones from ULTRADNS: %w", err)
+		}
+		for _, z := range resp.Zones {
+			zones = append(zones, strings.TrimSuffix(z.Properties.Name, "."))
+		}
+		offset += len(resp.Zones)
+		if len(resp.Zones) == 0 || offset >= resp.ResultInfo.TotalCount {
+			break
+		}
+	}
+	return zones, nil
+}
+
+func (c *ultradnsProvider) createZone(domain string) error {
+	req := createZoneRequest{
+		Properties: zoneProperties{
+			Name:        domain + ".",
+			AccountName: c.accountName,
+			Type:        "PRIMARY",
+		},
+	}
+	req.PrimaryCreateInfo.ForceImport = true
+	req.PrimaryCreateInfo.CreateType = "NEW"
+	if err := c.request(http.MethodPost, "/v2/zones", &req, nil); err != nil {
+		return fmt.Errorf("failed creating zone (ULTRADNS): %w", err)
+	}
+	return nil
+}
+
+func (c *ultradnsProvider) getRRSets(domain string) ([]rrSet, error) {
+	var sets []rrSet
+	for offset := 0; ; {
+		var resp listRRSetsResponse
+		path := fmt.Sprintf("/v2/zones/%s./rrsets?limit=%d&offset=%d", domain, pageSize, offset)
+		err := c.request(http.MethodGet, path, nil, &resp)
+		if re, ok := err.(*requestError); ok && re.code == errorCodeNoData {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching rrsets from ULTRADNS: %w", err)
+		}
+		sets = append(sets, resp.RRSets...)
+		offset += len(resp.RRSets)
+		if len(resp.RRSets) == 0 || offset >= resp.ResultInfo.TotalCount {
+			break
+		}
+	}
+	return sets, nil
+}
+
+func rrSetPath(domain, rtype, owner string) string {
+	return fmt.Sprintf("/v2/zones/%s./rrsets/%s/%s", domain, rtype, owner)
+}
+
+func (c *ultradnsProvider) createRRSet(domain, rtype, owner string, set *rrSet) error {
+	if err := c.request(http.MethodPost, rrSetPath(domain, rtype, owner), set, nil); err != nil {
+		return fmt.Errorf("failed create rrset (ULTRADNS): %w", err)
+	}
+	return nil
+}
+
+func (c *ultradnsProvider) updateRRSet(domain, rtype, owner string, set *rrSet) error {
+	if err := c.request(http.MethodPut, rrSetPath(domain, rtype, owner), set, nil); err != nil {
+		return fmt.Errorf("failed update rrset (ULTRADNS): %w", err)
+	}
+	return nil
+}
+
+func (c *ultradnsProvider) deleteRRSet(domain, rtype, owner string) error {
+	if err := c.request(http.MethodDelete, rrSetPath(domain, rtype, owner), nil, nil); err != nil {
+		return fmt.Errorf("failed delete rrset (ULTRADNS): %w", err)
+	}
+	return nil
+}
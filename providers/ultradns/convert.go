@@ -0,0 +1,165 @@
+package ultradns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+const (
+	metaPool      = "ultradns_pool"
+	metaPoolOrder = "ultradns_pool_order"
+	metaDirGeo    = "ultradns_dir_geo"
+	metaDirName   = "ultradns_dir_name"
+
+	poolRD           = "RD"
+	defaultPoolOrder = "ROUND_ROBIN"
+
+	// allNonConfigured is the ultradns_dir_geo value of the catch-all record.
+	allNonConfigured = "*"
+)
+
+// normalizeMetadata fills in the defaults of the pool metadata so that
+// desired and existing records compare equal.
+func normalizeMetadata(rc *models.RecordConfig) {
+	if rc.Metadata == nil {
+		rc.Metadata = map[string]string{}
+	}
+	if rc.Metadata[metaPool] == poolRD && rc.Metadata[metaPoolOrder] == "" {
+		rc.Metadata[metaPoolOrder] = defaultPoolOrder
+	}
+	if geo := rc.Metadata[metaDirGeo]; geo != "" {
+		codes := splitCodes(geo)
+		rc.Metadata[metaDirGeo] = strings.Join(codes, ",")
+		if rc.Metadata[metaDirName] == "" && geo != allNonConfigured {
+			rc.Metadata[metaDirName] = strings.Join(codes, "-")
+		}
+	}
+}
+
+func splitCodes(s string) []string {
+	var codes []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}
+
+func genComparable(rc *models.RecordConfig) string {
+	switch {
+	case rc.Metadata[metaDirGeo] != "":
+		return fmt.Sprintf("dir=%s name=%q", rc.Metadata[metaDirGeo], rc.Metadata[metaDirName])
+	case rc.Metadata[metaPool] != "":
+		return fmt.Sprintf("pool=%s order=%s", rc.Metadata[metaPool], rc.Metadata[metaPoolOrder])
+	}
+	return ""
+}
+
+// rrType strips the numeric type from values such as "A (1)".
+func rrType(s string) string {
+	t, _, _ := strings.Cut(s, " ")
+	return t
+}
+
+// toRecordConfigs converts a native rrset into RecordConfigs, one per rdata.
+func toRecordConfigs(domain string, set *rrSet) ([]*models.RecordConfig, error) {
+	rtype := rrType(set.RRType)
+	rcs := make([]*models.RecordConfig, 0, len(set.RData))
+	for i, rdata := range set.RData {
+		rc := &models.RecordConfig{
+			Type:     rtype,
+			TTL:      set.TTL,
+			Metadata: map[string]string{},
+			Original: set,
+		}
+		rc.SetLabelFromFQDN(set.OwnerName, domain)
+
+		if p := set.Profile; p != nil {
+			switch p.Context {
+			case contextRDPool:
+				rc.Metadata[metaPool] = poolRD
+				rc.Metadata[metaPoolOrder] = p.Order
+			case contextDirPool:
+				if i < len(p.RDataInfo) {
+					info := p.RDataInfo[i]
+					if info.AllNonConfigured {
+						rc.Metadata[metaDirGeo] = allNonConfigured
+					} else if info.GeoInfo != nil {
+						rc.Metadata[metaDirGeo] = strings.Join(info.GeoInfo.Codes, ",")
+						rc.Metadata[metaDirName] = info.GeoInfo.Name
+					}
+				}
+			}
+		}
+
+		var err error
+		if rtype == "TXT" {
+			err = rc.SetTargetTXT(rdata)
+		} else {
+			err = rc.PopulateFromString(rtype, rdata, domain)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rcs = append(rcs, rc)
+	}
+	return rcs, nil
+}
+
+// toRRSet converts the records of one label and type into a native rrset.
+func toRRSet(records models.Records) (*rrSet, error) {
+	set := &rrSet{TTL: records[0].TTL}
+
+	var dir, rd int
+	for _, rc := range records {
+		if rc.Type == "TXT" {
+			set.RData = append(set.RData, rc.GetTargetTXTJoined())
+		} else {
+			set.RData = append(set.RData, rc.GetTargetCombined())
+		}
+		if rc.Metadata[metaDirGeo] != "" {
+			dir++
+		}
+		switch rc.Metadata[metaPool] {
+		case "":
+		case poolRD:
+			rd++
+		default:
+			return nil, fmt.Errorf("%s: unsupported %s %q", rc.GetLabelFQDN(), metaPool, rc.Metadata[metaPool])
+		}
+	}
+
+	name := records[0].GetLabelFQDN()
+	switch {
+	case dir != 0:
+		if dir != len(records) {
+			return nil, fmt.Errorf("%s %s: %s must be set on all records or none", name, records[0].Type, metaDirGeo)
+		}
+		p := &profile{Context: contextDirPool, Description: name}
+		for _, rc := range records {
+			geo := rc.Metadata[metaDirGeo]
+			if geo == allNonConfigured {
+				p.RDataInfo = append(p.RDataInfo, dirRData{AllNonConfigured: true})
+				continue
+			}
+			p.RDataInfo = append(p.RDataInfo, dirRData{GeoInfo: &geoInfo{
+				Name:  rc.Metadata[metaDirName],
+				Codes: splitCodes(geo),
+			}})
+		}
+		set.Profile = p
+	case rd != 0:
+		if rd != len(records) {
+			return nil, fmt.Errorf("%s %s: %s must be set on all records or none", name, records[0].Type, metaPool)
+		}
+		set.Profile = &profile{
+			Context:     contextRDPool,
+			Order:       records[0].Metadata[metaPoolOrder],
+			Description: name,
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,55 @@
+package ultradns
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestDirectionalRoundTrip(t *testing.T) {
+	var records models.Records
+	for ip, geo := range map[string]string{"192.0.2.1": "eur", "192.0.2.2": "*", "192.0.2.3": "US, CA"} {
+		rc := &models.RecordConfig{Type: "A", TTL: 300, Metadata: map[string]string{metaDirGeo: geo}}
+		rc.SetLabel("www", "example.com")
+		if err := rc.SetTarget(ip); err != nil {
+			t.Fatal(err)
+		}
+		normalizeMetadata(rc)
+		records = append(records, rc)
+	}
+
+	set, err := toRRSet(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Profile == nil || set.Profile.Context != contextDirPool || len(set.Profile.RDataInfo) != 3 {
+		t.Fatalf("unexpected profile %+v", set.Profile)
+	}
+	set.OwnerName = "www.example.com."
+	set.RRType = "A (1)"
+
+	rcs, err := toRecordConfigs("example.com", set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rcs {
+		if rcs[i].GetTargetField() != records[i].GetTargetField() {
+			t.Errorf("target %d: got %q, want %q", i, rcs[i].GetTargetField(), records[i].GetTargetField())
+		}
+		if got, want := genComparable(rcs[i]), genComparable(records[i]); got != want {
+			t.Errorf("comparable %d: got %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestMixedPoolRejected(t *testing.T) {
+	a := &models.RecordConfig{Type: "A", Metadata: map[string]string{metaPool: poolRD}}
+	b := &models.RecordConfig{Type: "A", Metadata: map[string]string{}}
+	for _, rc := range []*models.RecordConfig{a, b} {
+		rc.SetLabel("www", "example.com")
+		_ = rc.SetTarget("192.0.2.1")
+	}
+	if _, err := toRRSet(models.Records{a, b}); err == nil {
+		t.Error("expected an error for a partial RD pool")
+	}
+}
@@ -0,0 +1,25 @@
+package ultradns
+
+import "fmt"
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *ultradnsProvider) ListZones() ([]string, error) {
+	return c.listZones()
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *ultradnsProvider) EnsureZoneExists(domain string) error {
+	zones, err := c.listZones()
+	if err != nil {
+		return err
+	}
+	for _, z := range zones {
+		if z == domain {
+			return nil
+		}
+	}
+	if c.accountName == "" {
+		return fmt.Errorf("ULTRADNS account_name is required to create zone %s", domain)
+	}
+	return c.createZone(domain)
+}
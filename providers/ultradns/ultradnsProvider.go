@@ -0,0 +1,185 @@
+package ultradns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for UltraDNS (Vercara).
+// API Documentation: https://docs.ultradns.com/Content/REST%20API/Content/REST%20API/REST%20API%20Introduction.htm
+
+/*
+UltraDNS provider:
+
+Info required in `creds.json`:
+   - username
+   - password
+   - account_name (required to create zones)
+   - base_url (optional)
+
+Record level metadata available:
+   - ultradns_pool ("RD" for a resource distribution pool)
+   - ultradns_pool_order (ROUND_ROBIN, FIXED or RANDOM, default ROUND_ROBIN)
+   - ultradns_dir_geo (comma-separated geo codes of a directional record, or "*")
+   - ultradns_dir_name (name of the geo group, default the codes joined with "-")
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Unimplemented(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "ULTRADNS"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newUltradns,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newUltradns creates the provider.
+func newUltradns(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &ultradnsProvider{
+		baseURL:     strings.TrimSuffix(m["base_url"], "/"),
+		username:    m["username"],
+		password:    m["password"],
+		accountName: m["account_name"],
+	}
+	if c.username == "" || c.password == "" {
+		return nil, fmt.Errorf("missing ULTRADNS username or password")
+	}
+	if c.baseURL == "" {
+		c.baseURL = defaultBaseURL
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *ultradnsProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	sets, err := c.getRRSets(domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range sets {
+		if rrType(set.RRType) == "NS" && strings.TrimSuffix(set.OwnerName, ".") == domain {
+			return models.ToNameserversStripTD(set.RData)
+		}
+	}
+	return nil, nil
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *ultradnsProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	sets, err := c.getRRSets(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(sets))
+	for i := range sets {
+		set := &sets[i]
+		rtype := rrType(set.RRType)
+		if rtype == "SOA" {
+			continue
+		}
+		// The apex NS records are managed by UltraDNS.
+		if rtype == "NS" && strings.TrimSuffix(set.OwnerName, ".") == domain {
+			continue
+		}
+		// Other pools (SiteBacker, Traffic Controller, ...) are managed in the portal.
+		if set.Profile != nil && set.Profile.Context != contextRDPool && set.Profile.Context != contextDirPool {
+			printer.Warnf("ULTRADNS: ignoring %s %s with profile %s\n", set.OwnerName, rtype, set.Profile.Context)
+			continue
+		}
+		rcs, err := toRecordConfigs(domain, set)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rcs...)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *ultradnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	for _, rc := range dc.Records {
+		normalizeMetadata(rc)
+	}
+
+	changes, err := diff2.ByRecordSet(existingRecords, dc, genComparable)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		owner := change.Key.NameFQDN + "."
+		rtype := change.Key.Type
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			set, err := toRRSet(change.New)
+			if err != nil {
+				return nil, err
+			}
+			corr = &models.Correction{
+				Msg: change.MsgsJoined,
+				F: func() error {
+					return c.createRRSet(dc.Name, rtype, owner, set)
+				},
+			}
+		case diff2.CHANGE:
+			set, err := toRRSet(change.New)
+			if err != nil {
+				return nil, err
+			}
+			corr = &models.Correction{
+				Msg: change.MsgsJoined,
+				F: func() error {
+					return c.updateRRSet(dc.Name, rtype, owner, set)
+				},
+			}
+		case diff2.DELETE:
+			corr = &models.Correction{
+				Msg: change.MsgsJoined,
+				F: func() error {
+					return c.deleteRRSet(dc.Name, rtype, owner)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
@@ -0,0 +1,69 @@
+package unbound
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// run runs an unbound-control command and returns its output.
+// The lines of stdin are read by the commands ending in "s", such as local_datas.
+func (c *unboundProvider) run(stdin []string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(c.command[0], append(append(c.command[1:], c.options...), args...)...)
+	if stdin != nil {
+		cmd.Stdin = strings.NewReader(strings.Join(stdin, "\n") + "\n")
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	out := stdout.String()
+	if strings.HasPrefix(out, "error") {
+		return "", fmt.Errorf("unbound-control %s: %s", args[0], strings.TrimSpace(out))
+	}
+	if err != nil {
+		return "", fmt.Errorf("unbound-control %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()+out))
+	}
+	return out, nil
+}
+
+// listLocalZones returns the local zones and their types.
+func (c *unboundProvider) listLocalZones() (map[string]string, error) {
+	out, err := c.run(nil, "list_local_zones")
+	if err != nil {
+		return nil, err
+	}
+	zones := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if f := strings.Fields(line); len(f) == 2 {
+			zones[strings.ToLower(dns.Fqdn(f[0]))] = f[1]
+		}
+	}
+	return zones, nil
+}
+
+// listLocalData returns the local data of all the zones, in the zone file format.
+func (c *unboundProvider) listLocalData() (string, error) {
+	return c.run(nil, "list_local_data")
+}
+
+func (c *unboundProvider) addLocalZone(domain string) error {
+	_, err := c.run(nil, "local_zone", dns.Fqdn(domain), c.zoneType)
+	return err
+}
+
+// addLocalData adds resource records, in the zone file format.
+func (c *unboundProvider) addLocalData(rrs []string) error {
+	_, err := c.run(rrs, "local_datas")
+	return err
+}
+
+// removeLocalData removes all the local data of names.
+func (c *unboundProvider) removeLocalData(names []string) error {
+	_, err := c.run(names, "local_datas_remove")
+	return err
+}
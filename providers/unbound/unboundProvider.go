@@ -0,0 +1,219 @@
+package unbound
+
+/*
+
+unbound -
+  Manage the local zones of Unbound with unbound-control.
+
+	Each zone is a local-zone of Unbound, and its records are local-data.
+	The changes are made in the running server, they are lost when it
+	restarts unless they are also in its configuration.
+
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/google/shlex"
+	"github.com/miekg/dns"
+)
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseLOC:              providers.Can(),
+	providers.CanUseNAPTR:            providers.Can(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Can(),
+	providers.CanUseSVCB:             providers.Can(),
+	providers.CanUseTLSA:             providers.Can(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+func init() {
+	const providerName = "UNBOUND"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newUnbound,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// unboundProvider is the provider handle for the unbound driver.
+type unboundProvider struct {
+	command  []string // The unbound-control command, possibly run through ssh.
+	options  []string // The options of unbound-control (-c, -s).
+	zoneType string   // The type of the local zones created.
+}
+
+func newUnbound(config map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &unboundProvider{
+		zoneType: config["zone_type"],
+	}
+
+	command := config["command"]
+	if command == "" {
+		command = "unbound-control"
+	}
+	var err error
+	if c.command, err = shlex.Split(command); err != nil || len(c.command) == 0 {
+		return nil, fmt.Errorf("UNBOUND invalid command %q: %v", command, err)
+	}
+	if config["config"] != "" {
+		c.options = append(c.options, "-c", config["config"])
+	}
+	if config["server"] != "" {
+		c.options = append(c.options, "-s", config["server"])
+	}
+	if c.zoneType == "" {
+		c.zoneType = "transparent"
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *unboundProvider) GetNameservers(string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+// ListZones returns all the local zones.
+func (c *unboundProvider) ListZones() ([]string, error) {
+	zones, err := c.listLocalZones()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for zone := range zones {
+		names = append(names, strings.TrimSuffix(zone, "."))
+	}
+	return names, nil
+}
+
+// EnsureZoneExists creates the local zone if it does not exist.
+func (c *unboundProvider) EnsureZoneExists(domain string) error {
+	zones, err := c.listLocalZones()
+	if err != nil {
+		return err
+	}
+	if _, ok := zones[strings.ToLower(dns.Fqdn(domain))]; ok {
+		return nil
+	}
+	return c.addLocalZone(domain)
+}
+
+// localZoneOf returns the most specific local zone of a name.
+func localZoneOf(zones map[string]string, name string) string {
+	name = strings.ToLower(name)
+	for {
+		if _, ok := zones[name]; ok || name == "." {
+			return name
+		}
+		_, parent, found := strings.Cut(name, ".")
+		if !found || parent == "" {
+			return "."
+		}
+		name = parent
+	}
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *unboundProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	zones, err := c.listLocalZones()
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.listLocalData()
+	if err != nil {
+		return nil, err
+	}
+
+	// The local data of all the zones are listed, only the names whose most
+	// specific local zone is this one are kept.
+	zone := strings.ToLower(dns.Fqdn(domain))
+	zp := dns.NewZoneParser(strings.NewReader(data), "", "unbound-control")
+	var existingRecords models.Records
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if rr.Header().Rrtype == dns.TypeSOA || localZoneOf(zones, rr.Header().Name) != zone {
+			continue
+		}
+		rc, err := models.RRtoRCTxtBug(rr, domain)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, &rc)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("error while parsing the local data: %w", err)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+// All the local data of a name is removed at once, so the records are updated by label.
+func (c *unboundProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByLabel(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		name := dns.Fqdn(change.Key.NameFQDN)
+		var rrs []string
+		for _, rc := range change.New {
+			rrs = append(rrs, rc.ToRR().String())
+		}
+
+		switch change.Type {
+		case diff2.REPORT:
+			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
+		case diff2.CREATE:
+			corrections = append(corrections, &models.Correction{
+				Msg: change.MsgsJoined,
+				F: func() error {
+					return c.addLocalData(rrs)
+				},
+			})
+		case diff2.CHANGE:
+			corrections = append(corrections, &models.Correction{
+				Msg: change.MsgsJoined,
+				F: func() error {
+					if err := c.removeLocalData([]string{name}); err != nil {
+						return err
+					}
+					return c.addLocalData(rrs)
+				},
+			})
+		case diff2.DELETE:
+			corrections = append(corrections, &models.Correction{
+				Msg: change.MsgsJoined,
+				F: func() error {
+					return c.removeLocalData([]string{name})
+				},
+			})
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+	}
+
+	return corrections, nil
+}
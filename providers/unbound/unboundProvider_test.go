@@ -0,0 +1,22 @@
+package unbound
+
+import "testing"
+
+func TestLocalZoneOf(t *testing.T) {
+	zones := map[string]string{
+		"example.com.":          "transparent",
+		"internal.example.com.": "static",
+	}
+	for name, want := range map[string]string{
+		"example.com.":             "example.com.",
+		"www.example.com.":         "example.com.",
+		"internal.example.com.":    "internal.example.com.",
+		"db.Internal.example.com.": "internal.example.com.",
+		"www.example.net.":         ".",
+		"example.com.example.net.": ".",
+	} {
+		if got := localZoneOf(zones, name); got != want {
+			t.Errorf("localZoneOf(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
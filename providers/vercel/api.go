@@ -0,0 +1,212 @@
+package vercel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	baseURL  = "https://api.vercel.com"
+	pageSize = 100
+)
+
+type vercelProvider struct {
+	token  string
+	teamID string
+}
+
+type pagination struct {
+	Count int    `json:"count"`
+	Next  *int64 `json:"next"`
+}
+
+type domain struct {
+	Name string `json:"name"`
+}
+
+type listDomainsResponse struct {
+	Domains    []domain   `json:"domains"`
+	Pagination pagination `json:"pagination"`
+}
+
+type record struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Value      string `json:"value"`
+	Creator    string `json:"creator"`
+	TTL        uint32 `json:"ttl"`
+	MXPriority uint16 `json:"mxPriority"`
+	Priority   uint16 `json:"priority"`
+	Comment    string `json:"comment"`
+}
+
+type listRecordsResponse struct {
+	Records    []record   `json:"records"`
+	Pagination pagination `json:"pagination"`
+}
+
+type srvValue struct {
+	Priority uint16 `json:"priority"`
+	Weight   uint16 `json:"weight"`
+	Port     uint16 `json:"port"`
+	Target   string `json:"target"`
+}
+
+type httpsValue struct {
+	Priority uint16 `json:"priority"`
+	Target   string `json:"target"`
+	Params   string `json:"params,omitempty"`
+}
+
+// recordRequest is the payload of the record create and update endpoints.
+type recordRequest struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Value      string      `json:"value,omitempty"`
+	TTL        uint32      `json:"ttl"`
+	MXPriority *uint16     `json:"mxPriority,omitempty"`
+	SRV        *srvValue   `json:"srv,omitempty"`
+	HTTPS      *httpsValue `json:"https,omitempty"`
+	Comment    string      `json:"comment,omitempty"`
+}
+
+type apiError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *vercelProvider) request(method, path string, query url.Values, body, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	if c.teamID != "" {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("teamId", c.teamID)
+	}
+	u := baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+retry:
+	req, err := http.NewRequest(method, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries {
+		retrycnt++
+		wait := time.Duration(retrycnt) * time.Second
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if d := time.Until(time.Unix(reset, 0)); d > 0 && d < time.Minute {
+				wait = d
+			}
+		}
+		printer.Printf("Vercel rate limit exceeded. Waiting %s to retry.\n", wait.Round(time.Second))
+		time.Sleep(wait)
+		goto retry
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var ae apiError
+		if json.Unmarshal(data, &ae) == nil && ae.Error.Message != "" {
+			return fmt.Errorf("vercel API error: %s: %s (%s)", resp.Status, ae.Error.Message, ae.Error.Code)
+		}
+		return fmt.Errorf("vercel API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+func (c *vercelProvider) listDomains() ([]string, error) {
+	var domains []string
+	query := url.Values{"limit": {strconv.Itoa(pageSize)}}
+	for {
+		var resp listDomainsResponse
+		if err := c.request(http.MethodGet, "/v5/domains", query, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed listing domains from vercel: %w", err)
+		}
+		for _, d := range resp.Domains {
+			domains = append(domains, d.Name)
+		}
+		if resp.Pagination.Next == nil || len(resp.Domains) == 0 {
+			break
+		}
+		query.Set("until", strconv.FormatInt(*resp.Pagination.Next, 10))
+	}
+	return domains, nil
+}
+
+func (c *vercelProvider) getRecords(domain string) ([]record, error) {
+	var records []record
+	query := url.Values{"limit": {strconv.Itoa(pageSize)}}
+	for {
+		var resp listRecordsResponse
+		if err := c.request(http.MethodGet, "/v4/domains/"+url.PathEscape(domain)+"/records", query, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed fetching record list from vercel: %w", err)
+		}
+		records = append(records, resp.Records...)
+		if resp.Pagination.Next == nil || len(resp.Records) == 0 {
+			break
+		}
+		query.Set("until", strconv.FormatInt(*resp.Pagination.Next, 10))
+	}
+	return records, nil
+}
+
+func (c *vercelProvider) createRecord(domain string, req *recordRequest) error {
+	if err := c.request(http.MethodPost, "/v2/domains/"+url.PathEscape(domain)+"/records", nil, req, nil); err != nil {
+		return fmt.Errorf("failed create record (vercel): %w", err)
+	}
+	return nil
+}
+
+func (c *vercelProvider) updateRecord(id string, req *recordRequest) error {
+	if err := c.request(http.MethodPatch, "/v1/domains/records/"+url.PathEscape(id), nil, req, nil); err != nil {
+		return fmt.Errorf("failed update record (vercel): %w", err)
+	}
+	return nil
+}
+
+func (c *vercelProvider) deleteRecord(domain, id string) error {
+	if err := c.request(http.MethodDelete, "/v2/domains/"+url.PathEscape(domain)+"/records/"+url.PathEscape(id), nil, nil, nil); err != nil {
+		return fmt.Errorf("failed delete record (vercel): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package vercel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// toRc converts a Vercel record into a RecordConfig.
+func toRc(domain string, r *record) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      r.TTL,
+		Original: r,
+	}
+	rc.SetLabel(r.Name, domain)
+
+	var err error
+	switch r.Type {
+	case "A", "AAAA":
+		err = rc.SetTarget(r.Value)
+	case "ALIAS", "CNAME", "NS":
+		err = rc.SetTarget(dot(r.Value))
+	case "MX":
+		err = rc.SetTargetMX(r.MXPriority, dot(r.Value))
+	case "SRV":
+		// The priority is returned separately from the "weight port target" value.
+		fields := strings.Fields(r.Value)
+		if len(fields) == 3 {
+			fields = append([]string{strconv.Itoa(int(r.Priority))}, fields...)
+		}
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid SRV value %q of %s", r.Value, r.Name)
+		}
+		err = rc.SetTargetSRVStrings(fields[0], fields[1], fields[2], dot(fields[3]))
+	case "HTTPS":
+		value := r.Value
+		if fields := strings.Fields(value); len(fields) > 0 {
+			if _, perr := strconv.ParseUint(fields[0], 10, 16); perr != nil {
+				value = strconv.Itoa(int(r.Priority)) + " " + value
+			}
+		}
+		err = rc.SetTargetSVCBString(domain, value)
+	case "CAA":
+		err = rc.SetTargetCAAString(r.Value)
+	case "TXT":
+		err = rc.SetTargetTXT(r.Value)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+// toReq converts a RecordConfig into the payload of the create and update endpoints.
+func toReq(rc *models.RecordConfig) *recordRequest {
+	req := &recordRequest{
+		Name: rc.GetLabel(),
+		Type: rc.Type,
+		TTL:  rc.TTL,
+	}
+	if req.Name == "@" {
+		req.Name = ""
+	}
+	target := strings.TrimSuffix(rc.GetTargetField(), ".")
+	switch rc.Type {
+	case "MX":
+		pref := rc.MxPreference
+		req.MXPriority = &pref
+		req.Value = target
+	case "SRV":
+		req.SRV = &srvValue{
+			Priority: rc.SrvPriority,
+			Weight:   rc.SrvWeight,
+			Port:     rc.SrvPort,
+			Target:   target,
+		}
+	case "HTTPS":
+		req.HTTPS = &httpsValue{
+			Priority: rc.SvcPriority,
+			Target:   rc.GetTargetField(),
+			Params:   rc.SvcParams,
+		}
+	case "CAA":
+		req.Value = rc.GetTargetCombined()
+	case "TXT":
+		req.Value = rc.GetTargetTXTJoined()
+	default:
+		req.Value = target
+	}
+	return req
+}
@@ -0,0 +1,6 @@
+package vercel
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *vercelProvider) ListZones() ([]string, error) {
+	return c.listDomains()
+}
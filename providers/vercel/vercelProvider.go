@@ -0,0 +1,153 @@
+package vercel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Vercel DNS.
+// API Documentation: https://vercel.com/docs/rest-api/endpoints/dns
+
+/*
+Vercel DNS provider:
+
+Info required in `creds.json`:
+   - api_token
+   - team_id (optional, for domains owned by a team)
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Can(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseSVCB:             providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Cannot("Domains must be added to Vercel before they can be managed"),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNameservers = []string{"ns1.vercel-dns.com", "ns2.vercel-dns.com"}
+
+func init() {
+	const providerName = "VERCEL"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newVercel,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newVercel creates the provider.
+func newVercel(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &vercelProvider{
+		token:  m["api_token"],
+		teamID: m["team_id"],
+	}
+	if c.token == "" {
+		return nil, fmt.Errorf("missing VERCEL api_token")
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *vercelProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNameservers)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *vercelProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		r := &records[i]
+		if r.Type == "NS" && r.Name == "" {
+			// The apex NS records are managed by Vercel.
+			continue
+		}
+		if r.Creator == "system" {
+			// Vercel creates records (such as the ALIAS records of
+			// deployments) that can't be modified through the API.
+			printer.Debugf("VERCEL: ignoring system record %s %s %q\n", r.Type, r.Name, r.Value)
+			continue
+		}
+		rc, err := toRc(domain, r)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *vercelProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var corr *models.Correction
+		switch change.Type {
+		case diff2.REPORT:
+			corr = &models.Correction{Msg: change.MsgsJoined}
+		case diff2.CREATE:
+			req := toReq(change.New[0])
+			corr = &models.Correction{
+				Msg: change.Msgs[0],
+				F: func() error {
+					return c.createRecord(dc.Name, req)
+				},
+			}
+		case diff2.CHANGE:
+			id := change.Old[0].Original.(*record).ID
+			req := toReq(change.New[0])
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Vercel ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.updateRecord(id, req)
+				},
+			}
+		case diff2.DELETE:
+			id := change.Old[0].Original.(*record).ID
+			corr = &models.Correction{
+				Msg: fmt.Sprintf("%s, Vercel ID: %s", change.Msgs[0], id),
+				F: func() error {
+					return c.deleteRecord(dc.Name, id)
+				},
+			}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, corr)
+	}
+
+	return corrections, nil
+}
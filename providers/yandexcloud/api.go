@@ -0,0 +1,252 @@
+package yandexcloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+const (
+	apiBase = "https://dns.api.cloud.yandex.net/dns/v1"
+
+	// Maximum page size accepted by the list methods.
+	pageSize = "1000"
+)
+
+type yandexcloudProvider struct {
+	tokens     *tokenSource
+	folderID   string
+	private    bool
+	networkIDs []string
+}
+
+type dnsZone struct {
+	ID                string             `json:"id"`
+	FolderID          string             `json:"folderId"`
+	Name              string             `json:"name"`
+	Zone              string             `json:"zone"`
+	PublicVisibility  *struct{}          `json:"publicVisibility,omitempty"`
+	PrivateVisibility *privateVisibility `json:"privateVisibility,omitempty"`
+}
+
+type privateVisibility struct {
+	NetworkIDs []string `json:"networkIds"`
+}
+
+type listZonesResponse struct {
+	DNSZones      []dnsZone `json:"dnsZones"`
+	NextPageToken string    `json:"nextPageToken"`
+}
+
+type recordSet struct {
+	Name string   `json:"name"`
+	Type string   `json:"type"`
+	TTL  string   `json:"ttl"` // int64 is encoded as a string
+	Data []string `json:"data"`
+}
+
+type listRecordSetsResponse struct {
+	RecordSets    []recordSet `json:"recordSets"`
+	NextPageToken string      `json:"nextPageToken"`
+}
+
+type updateRecordSetsRequest struct {
+	Deletions []recordSet `json:"deletions,omitempty"`
+	Additions []recordSet `json:"additions,omitempty"`
+}
+
+// operation is returned by the mutating methods. The DNS service applies
+// changes synchronously, so only the error needs to be checked.
+type operation struct {
+	ID    string    `json:"id"`
+	Done  bool      `json:"done"`
+	Error *apiError `json:"error"`
+}
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// request sends an API request and decodes the JSON response into target.
+func (c *yandexcloudProvider) request(method, path string, query url.Values, body any, target any) error {
+	const maxRetries = 10
+	retrycnt := 0
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	u := apiBase + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+retry:
+	token, err := c.tokens.Token()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("Yandex Cloud rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+	if resp.StatusCode != http.StatusOK {
+		var ae apiError
+		if json.Unmarshal(data, &ae) == nil && ae.Message != "" {
+			return fmt.Errorf("yandexcloud API error: %s: %s", resp.Status, ae.Message)
+		}
+		return fmt.Errorf("yandexcloud API error: %s: %s", resp.Status, string(data))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return json.Unmarshal(data, target)
+}
+
+// getZones returns the zones of the folder that match the configured visibility.
+func (c *yandexcloudProvider) getZones() ([]dnsZone, error) {
+	var zones []dnsZone
+	token := ""
+	for {
+		q := url.Values{"folderId": {c.folderID}, "pageSize": {pageSize}}
+		if token != "" {
+			q.Set("pageToken", token)
+		}
+		var resp listZonesResponse
+		if err := c.request(http.MethodGet, "/zones", q, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed listing zones from yandexcloud: %w", err)
+		}
+		for _, z := range resp.DNSZones {
+			if (z.PrivateVisibility != nil) == c.private {
+				zones = append(zones, z)
+			}
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		token = resp.NextPageToken
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Zone < zones[j].Zone })
+	return zones, nil
+}
+
+func (c *yandexcloudProvider) getZone(domain string) (*dnsZone, error) {
+	zones, err := c.getZones()
+	if err != nil {
+		return nil, err
+	}
+	for i := range zones {
+		if strings.TrimSuffix(zones[i].Zone, ".") == domain {
+			return &zones[i], nil
+		}
+	}
+	return nil, fmt.Errorf("zone %q not found in yandexcloud folder %s", domain, c.folderID)
+}
+
+func (c *yandexcloudProvider) getRecordSets(zoneID string) ([]recordSet, error) {
+	var sets []recordSet
+	token := ""
+	for {
+		q := url.Values{"pageSize": {pageSize}}
+		if token != "" {
+			q.Set("pageToken", token)
+		}
+		var resp listRecordSetsResponse
+		if err := c.request(http.MethodGet, "/zones/"+zoneID+":listRecordSets", q, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed fetching record sets from yandexcloud: %w", err)
+		}
+		sets = append(sets, resp.RecordSets...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		token = resp.NextPageToken
+	}
+	return sets, nil
+}
+
+func (c *yandexcloudProvider) updateRecordSets(zoneID string, req *updateRecordSetsRequest) error {
+	var op operation
+	if err := c.request(http.MethodPost, "/zones/"+zoneID+":updateRecordSets", nil, req, &op); err != nil {
+		return fmt.Errorf("failed updating record sets (yandexcloud): %w", err)
+	}
+	if op.Error != nil {
+		return fmt.Errorf("failed updating record sets (yandexcloud): %s", op.Error.Message)
+	}
+	return nil
+}
+
+func (c *yandexcloudProvider) createZone(domain string) error {
+	z := dnsZone{
+		FolderID: c.folderID,
+		Name:     zoneName(domain),
+		Zone:     domain + ".",
+	}
+	if c.private {
+		z.PrivateVisibility = &privateVisibility{NetworkIDs: c.networkIDs}
+	} else {
+		z.PublicVisibility = &struct{}{}
+	}
+	var op operation
+	if err := c.request(http.MethodPost, "/zones", nil, &z, &op); err != nil {
+		return fmt.Errorf("failed creating zone (yandexcloud): %w", err)
+	}
+	if op.Error != nil {
+		return fmt.Errorf("failed creating zone (yandexcloud): %s", op.Error.Message)
+	}
+	return nil
+}
+
+// zoneName derives a resource name from a domain. Names must match
+// [a-z]([-a-z0-9]{0,61}[a-z0-9])?, so "example.com" becomes "example-com".
+func zoneName(domain string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(domain) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	name := strings.Trim(b.String(), "-")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "zone-" + name
+	}
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
@@ -0,0 +1,180 @@
+package yandexcloud
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const iamTokensURL = "https://iam.api.cloud.yandex.net/iam/v1/tokens"
+
+// serviceAccountKey is the authorized key JSON file created by
+// "yc iam key create".
+type serviceAccountKey struct {
+	ID               string `json:"id"`
+	ServiceAccountID string `json:"service_account_id"`
+	PrivateKey       string `json:"private_key"`
+}
+
+// tokenSource returns a valid IAM token, refreshing it when needed.
+type tokenSource struct {
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+
+	// Exactly one of these is set.
+	staticToken string
+	oauthToken  string
+	key         *serviceAccountKey
+	rsaKey      *rsa.PrivateKey
+}
+
+func newTokenSource(m map[string]string) (*tokenSource, error) {
+	ts := &tokenSource{
+		staticToken: m["iam_token"],
+		oauthToken:  m["oauth_token"],
+	}
+	if file := m["service_account_key_file"]; file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("YANDEXCLOUD: reading service_account_key_file: %w", err)
+		}
+		if err := ts.loadKey(data); err != nil {
+			return nil, err
+		}
+	}
+
+	n := 0
+	for _, set := range []bool{ts.staticToken != "", ts.oauthToken != "", ts.key != nil} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("YANDEXCLOUD: exactly one of iam_token, oauth_token or service_account_key_file must be set")
+	}
+	return ts, nil
+}
+
+func (ts *tokenSource) loadKey(data []byte) error {
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return fmt.Errorf("YANDEXCLOUD: parsing service account key: %w", err)
+	}
+	if key.ID == "" || key.ServiceAccountID == "" {
+		return fmt.Errorf("YANDEXCLOUD: service account key is missing id or service_account_id")
+	}
+	// The key may be preceded by a comment line; pem.Decode skips it.
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return fmt.Errorf("YANDEXCLOUD: service account key has no PEM private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("YANDEXCLOUD: parsing service account private key: %w", err)
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf("YANDEXCLOUD: service account private key is not an RSA key")
+	}
+	ts.key = &key
+	ts.rsaKey = rsaKey
+	return nil
+}
+
+// signedJWT builds the PS256 JWT exchanged for an IAM token.
+// https://yandex.cloud/en/docs/iam/operations/iam-token/create-for-sa
+func (ts *tokenSource) signedJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"typ": "JWT",
+		"alg": "PS256",
+		"kid": ts.key.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iss": ts.key.ServiceAccountID,
+		"aud": iamTokensURL,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPSS(rand.Reader, ts.rsaKey, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// Token returns an IAM token for the Authorization header.
+func (ts *tokenSource) Token() (string, error) {
+	if ts.staticToken != "" {
+		return ts.staticToken, nil
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	// Refresh a few minutes early so that long pushes don't fail midway.
+	if ts.token != "" && time.Now().Add(5*time.Minute).Before(ts.expiresAt) {
+		return ts.token, nil
+	}
+
+	var body map[string]string
+	if ts.oauthToken != "" {
+		body = map[string]string{"yandexPassportOauthToken": ts.oauthToken}
+	} else {
+		jwt, err := ts.signedJWT(time.Now())
+		if err != nil {
+			return "", fmt.Errorf("YANDEXCLOUD: signing JWT: %w", err)
+		}
+		body = map[string]string{"jwt": jwt}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.Post(iamTokensURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("YANDEXCLOUD: requesting IAM token: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("YANDEXCLOUD: requesting IAM token: %s: %s", resp.Status, string(data))
+	}
+
+	var tr struct {
+		IamToken  string    `json:"iamToken"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	if err := json.Unmarshal(data, &tr); err != nil {
+		return "", fmt.Errorf("YANDEXCLOUD: decoding IAM token: %w", err)
+	}
+	ts.token = tr.IamToken
+	ts.expiresAt = tr.ExpiresAt
+	return ts.token, nil
+}
@@ -0,0 +1,84 @@
+package yandexcloud
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignedJWT(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile, _ := json.Marshal(map[string]string{
+		"id":                 "ajeKEYID",
+		"service_account_id": "ajeSAID",
+		"private_key":        "PLEASE DO NOT REMOVE THIS LINE! Yandex.Cloud SA Key ID <ajeKEYID>\n" + string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	})
+
+	ts := &tokenSource{}
+	if err := ts.loadKey(keyFile); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	jwt, err := ts.signedJWT(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected 3 JWT parts, got %d", len(parts))
+	}
+	var header map[string]string
+	var claims map[string]any
+	for i, v := range []any{&header, &claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if header["alg"] != "PS256" || header["kid"] != "ajeKEYID" {
+		t.Errorf("unexpected header %v", header)
+	}
+	if claims["iss"] != "ajeSAID" || claims["aud"] != iamTokensURL || claims["exp"].(float64) != 1700003600 {
+		t.Errorf("unexpected claims %v", claims)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPSS(&priv.PublicKey, crypto.SHA256, digest[:], sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+}
+
+func TestZoneName(t *testing.T) {
+	for domain, want := range map[string]string{
+		"example.com":            "example-com",
+		"sub.Example.co.uk":      "sub-example-co-uk",
+		"1.168.192.in-addr.arpa": "zone-1-168-192-in-addr-arpa",
+	} {
+		if got := zoneName(domain); got != want {
+			t.Errorf("zoneName(%q) = %q, want %q", domain, got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package yandexcloud
+
+import (
+	"strconv"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
+)
+
+// toRecordConfigs converts a native record set into RecordConfigs, one per value.
+func toRecordConfigs(domain string, rs *recordSet) ([]*models.RecordConfig, error) {
+	ttl, err := strconv.ParseUint(rs.TTL, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	rcs := make([]*models.RecordConfig, 0, len(rs.Data))
+	for _, value := range rs.Data {
+		rc := &models.RecordConfig{
+			Type:     rs.Type,
+			TTL:      uint32(ttl),
+			Original: rs,
+		}
+		rc.SetLabelFromFQDN(rs.Name, domain)
+		if err := rc.PopulateFromStringFunc(rs.Type, value, domain, txtutil.ParseQuoted); err != nil {
+			return nil, err
+		}
+		rcs = append(rcs, rc)
+	}
+	return rcs, nil
+}
+
+// toRecordSet converts the records of one label and type into a native record set.
+func toRecordSet(records models.Records) recordSet {
+	rs := recordSet{
+		Name: records[0].GetLabelFQDN() + ".",
+		Type: records[0].Type,
+		TTL:  strconv.FormatUint(uint64(records[0].TTL), 10),
+	}
+	for _, r := range records {
+		rs.Data = append(rs.Data, r.GetTargetCombinedFunc(txtutil.EncodeQuoted))
+	}
+	return rs
+}
@@ -0,0 +1,30 @@
+package yandexcloud
+
+import "strings"
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *yandexcloudProvider) ListZones() ([]string, error) {
+	zones, err := c.getZones()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(zones))
+	for _, z := range zones {
+		names = append(names, strings.TrimSuffix(z.Zone, "."))
+	}
+	return names, nil
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *yandexcloudProvider) EnsureZoneExists(domain string) error {
+	zones, err := c.ListZones()
+	if err != nil {
+		return err
+	}
+	for _, z := range zones {
+		if z == domain {
+			return nil
+		}
+	}
+	return c.createZone(domain)
+}
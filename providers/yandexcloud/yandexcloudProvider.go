@@ -0,0 +1,177 @@
+package yandexcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Yandex Cloud DNS.
+// API Documentation: https://yandex.cloud/en/docs/dns/api-ref/
+
+/*
+Yandex Cloud DNS provider:
+
+Info required in `creds.json`:
+   - folder_id
+   - one of:
+     - iam_token
+     - oauth_token
+     - service_account_key_file
+   - visibility (optional, "public" or "private", default "public")
+   - network_ids (optional, comma-separated, required to create private zones)
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Can(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseSVCB:             providers.Can(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+// Nameservers of public zones. Private zones are not delegated.
+var defaultNameservers = []string{"ns1.yandexcloud.net", "ns2.yandexcloud.net"}
+
+func init() {
+	const providerName = "YANDEXCLOUD"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newYandexcloud,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newYandexcloud creates the provider.
+func newYandexcloud(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &yandexcloudProvider{
+		folderID: m["folder_id"],
+	}
+	if c.folderID == "" {
+		return nil, fmt.Errorf("missing YANDEXCLOUD folder_id")
+	}
+
+	switch m["visibility"] {
+	case "", "public":
+	case "private":
+		c.private = true
+	default:
+		return nil, fmt.Errorf("YANDEXCLOUD visibility must be \"public\" or \"private\", got %q", m["visibility"])
+	}
+	for _, id := range strings.Split(m["network_ids"], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			c.networkIDs = append(c.networkIDs, id)
+		}
+	}
+
+	ts, err := newTokenSource(m)
+	if err != nil {
+		return nil, err
+	}
+	c.tokens = ts
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *yandexcloudProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	if c.private {
+		return nil, nil
+	}
+	return models.ToNameservers(defaultNameservers)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *yandexcloudProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	zone, err := c.getZone(domain)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := c.getRecordSets(zone.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(sets))
+	for i := range sets {
+		rs := &sets[i]
+		if rs.Type == "SOA" {
+			continue
+		}
+		// The apex NS records are managed by Yandex Cloud.
+		if rs.Type == "NS" && strings.TrimSuffix(rs.Name, ".") == domain {
+			continue
+		}
+		rcs, err := toRecordConfigs(domain, rs)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rcs...)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+func (c *yandexcloudProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecordSet(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	zone, err := c.getZone(dc.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		req := &updateRecordSetsRequest{}
+		switch change.Type {
+		case diff2.REPORT:
+			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
+			continue
+		case diff2.CREATE:
+			req.Additions = []recordSet{toRecordSet(change.New)}
+		case diff2.CHANGE:
+			req.Deletions = []recordSet{*change.Old[0].Original.(*recordSet)}
+			req.Additions = []recordSet{toRecordSet(change.New)}
+		case diff2.DELETE:
+			req.Deletions = []recordSet{*change.Old[0].Original.(*recordSet)}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: change.MsgsJoined,
+			F: func() error {
+				return c.updateRecordSets(zone.ID, req)
+			},
+		})
+	}
+
+	return corrections, nil
+}
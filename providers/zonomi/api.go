@@ -0,0 +1,166 @@
+package zonomi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// The Zonomi API is also served by RimuHosting, on another host.
+var endpoints = map[string]string{
+	"zonomi":      "https://zonomi.com/app/dns/",
+	"rimuhosting": "https://rimuhosting.com/dns/",
+}
+
+type zonomiProvider struct {
+	apiKey  string
+	baseURL string
+}
+
+// action is one action of a request. A request may carry several actions,
+// which are applied in order.
+type action struct {
+	Action string
+	Name   string
+	Type   string
+	Value  string
+	TTL    uint32
+	Prio   uint16
+}
+
+type record struct {
+	Name    string `xml:"name,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:"content,attr"`
+	TTL     string `xml:"ttl,attr"`
+	Prio    string `xml:"prio,attr"`
+}
+
+type zone struct {
+	Name string `xml:"name,attr"`
+}
+
+type result struct {
+	Actions []struct {
+		Records []record `xml:"record"`
+		Zones   []zone   `xml:"zone"`
+	} `xml:"actions>action"`
+	Error string `xml:"error"`
+}
+
+// do sends a request with the actions to the dyndns.jsp endpoint.
+func (c *zonomiProvider) do(actions []action) (*result, error) {
+	params := url.Values{"api_key": {c.apiKey}}
+	for i, a := range actions {
+		// Multi-action requests suffix the parameters with the action number.
+		suffix := ""
+		if len(actions) > 1 {
+			suffix = "[" + strconv.Itoa(i+1) + "]"
+		}
+		params.Set("action"+suffix, a.Action)
+		if a.Name != "" {
+			params.Set("name"+suffix, a.Name)
+		}
+		if a.Type != "" {
+			params.Set("type"+suffix, a.Type)
+		}
+		if a.Value != "" {
+			params.Set("value"+suffix, a.Value)
+		}
+		if a.TTL != 0 {
+			params.Set("ttl"+suffix, strconv.Itoa(int(a.TTL)))
+		}
+		if a.Type == "MX" && a.Action == "SET" {
+			params.Set("prio"+suffix, strconv.Itoa(int(a.Prio)))
+		}
+	}
+	return c.get("dyndns.jsp", params)
+}
+
+func (c *zonomiProvider) get(page string, params url.Values) (*result, error) {
+	const maxRetries = 10
+	retrycnt := 0
+
+	u := c.baseURL + page + "?" + params.Encode()
+
+retry:
+	resp, err := http.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) && retrycnt < maxRetries {
+		retrycnt++
+		printer.Printf("Zonomi rate limit exceeded. Waiting %d second(s) to retry.\n", retrycnt)
+		time.Sleep(time.Duration(retrycnt) * time.Second)
+		goto retry
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("zonomi API error: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var r result
+	if err := xml.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("zonomi API error: %s", strings.TrimSpace(string(body)))
+	}
+	if r.Error != "" {
+		return nil, fmt.Errorf("zonomi API error: %s", r.Error)
+	}
+	return &r, nil
+}
+
+func (c *zonomiProvider) listZones() ([]string, error) {
+	r, err := c.do([]action{{Action: "QUERYZONES"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed listing zones from zonomi: %w", err)
+	}
+	var zones []string
+	for _, a := range r.Actions {
+		for _, z := range a.Zones {
+			zones = append(zones, z.Name)
+		}
+	}
+	return zones, nil
+}
+
+// getRecords returns the records of the apex and of all the names below it.
+func (c *zonomiProvider) getRecords(domain string) ([]record, error) {
+	r, err := c.do([]action{
+		{Action: "QUERY", Name: domain},
+		{Action: "QUERY", Name: "**." + domain},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching record list from zonomi: %w", err)
+	}
+	var records []record
+	for _, a := range r.Actions {
+		records = append(records, a.Records...)
+	}
+	return records, nil
+}
+
+func (c *zonomiProvider) apply(actions []action) error {
+	if _, err := c.do(actions); err != nil {
+		return fmt.Errorf("failed update records (zonomi): %w", err)
+	}
+	return nil
+}
+
+func (c *zonomiProvider) createZone(domain string) error {
+	if _, err := c.get("addzone.jsp", url.Values{"api_key": {c.apiKey}, "name": {domain}}); err != nil {
+		return fmt.Errorf("failed creating zone (zonomi): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package zonomi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func dot(s string) string {
+	if s == "" || strings.HasSuffix(s, ".") {
+		return s
+	}
+	return s + "."
+}
+
+// parseNumber parses the leading number of a value such as "86400 seconds".
+func parseNumber(s string) uint64 {
+	f := strings.Fields(s)
+	if len(f) == 0 {
+		return 0
+	}
+	n, _ := strconv.ParseUint(f[0], 10, 32)
+	return n
+}
+
+// toRc converts a Zonomi record into a RecordConfig.
+func toRc(domain string, r *record) (*models.RecordConfig, error) {
+	rc := &models.RecordConfig{
+		Type:     r.Type,
+		TTL:      uint32(parseNumber(r.TTL)),
+		Original: r,
+	}
+	rc.SetLabelFromFQDN(r.Name, domain)
+
+	var err error
+	switch r.Type {
+	case "A", "AAAA":
+		err = rc.SetTarget(r.Content)
+	case "CNAME", "NS":
+		err = rc.SetTarget(dot(r.Content))
+	case "MX":
+		err = rc.SetTargetMX(uint16(parseNumber(r.Prio)), dot(r.Content))
+	case "TXT":
+		err = rc.SetTargetTXT(r.Content)
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	return rc, err
+}
+
+// setAction returns the action that adds rc.
+func setAction(rc *models.RecordConfig) action {
+	a := action{
+		Action: "SET",
+		Name:   rc.GetLabelFQDN(),
+		Type:   rc.Type,
+		TTL:    rc.TTL,
+	}
+	switch rc.Type {
+	case "MX":
+		a.Prio = rc.MxPreference
+		a.Value = strings.TrimSuffix(rc.GetTargetField(), ".")
+	case "TXT":
+		a.Value = rc.GetTargetTXTJoined()
+	default:
+		a.Value = strings.TrimSuffix(rc.GetTargetField(), ".")
+	}
+	return a
+}
@@ -0,0 +1,20 @@
+package zonomi
+
+// ListZones returns all DNS zones managed by this provider.
+func (c *zonomiProvider) ListZones() ([]string, error) {
+	return c.listZones()
+}
+
+// EnsureZoneExists creates a zone if it does not exist
+func (c *zonomiProvider) EnsureZoneExists(domain string) error {
+	zones, err := c.listZones()
+	if err != nil {
+		return err
+	}
+	for _, d := range zones {
+		if d == domain {
+			return nil
+		}
+	}
+	return c.createZone(domain)
+}
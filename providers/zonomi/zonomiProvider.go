@@ -0,0 +1,147 @@
+package zonomi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// Support for Zonomi and RimuHosting.
+// API Documentation: https://zonomi.com/app/dns/dyndns.jsp
+
+/*
+Zonomi provider:
+
+Info required in `creds.json`:
+   - api_key
+   - endpoint (optional) "zonomi" (default) or "rimuhosting"
+
+*/
+
+var features = providers.DocumentationNotes{
+	// The default for unlisted capabilities is 'Cannot'.
+	// See providers/capabilities.go for the entire list of capabilities.
+	providers.CanAutoDNSSEC:          providers.Cannot(),
+	providers.CanGetZones:            providers.Can(),
+	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAlias:            providers.Cannot(),
+	providers.CanUseCAA:              providers.Cannot(),
+	providers.CanUseDS:               providers.Cannot(),
+	providers.CanUseDSForChildren:    providers.Cannot(),
+	providers.CanUseLOC:              providers.Cannot(),
+	providers.CanUseNAPTR:            providers.Cannot(),
+	providers.CanUsePTR:              providers.Cannot(),
+	providers.CanUseSOA:              providers.Cannot(),
+	providers.CanUseSRV:              providers.Cannot(),
+	providers.CanUseSSHFP:            providers.Cannot(),
+	providers.CanUseTLSA:             providers.Cannot(),
+	providers.DocCreateDomains:       providers.Can(),
+	providers.DocDualHost:            providers.Cannot(),
+	providers.DocOfficiallySupported: providers.Cannot(),
+}
+
+var defaultNS = []string{
+	"ns1.zonomi.com",
+	"ns2.zonomi.com",
+	"ns3.zonomi.com",
+}
+
+func init() {
+	const providerName = "ZONOMI"
+	const providerMaintainer = "NEEDS VOLUNTEER"
+	fns := providers.DspFuncs{
+		Initializer:   newZonomi,
+		RecordAuditor: AuditRecords,
+	}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterMaintainer(providerName, providerMaintainer)
+}
+
+// newZonomi creates the provider.
+func newZonomi(m map[string]string, _ json.RawMessage) (providers.DNSServiceProvider, error) {
+	c := &zonomiProvider{
+		apiKey: m["api_key"],
+	}
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("missing ZONOMI api_key")
+	}
+	endpoint := m["endpoint"]
+	if endpoint == "" {
+		endpoint = "zonomi"
+	}
+	var ok bool
+	if c.baseURL, ok = endpoints[endpoint]; !ok {
+		return nil, fmt.Errorf("unknown ZONOMI endpoint %q, expected zonomi or rimuhosting", endpoint)
+	}
+	return c, nil
+}
+
+// GetNameservers returns the nameservers for a domain.
+func (c *zonomiProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers(defaultNS)
+}
+
+// GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
+func (c *zonomiProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	records, err := c.getRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRecords := make([]*models.RecordConfig, 0, len(records))
+	for i := range records {
+		r := &records[i]
+		if r.Type == "NS" && r.Name == domain {
+			// The apex NS records are managed by Zonomi.
+			continue
+		}
+		if r.Type == "SOA" {
+			continue
+		}
+		rc, err := toRc(domain, r)
+		if err != nil {
+			return nil, err
+		}
+		existingRecords = append(existingRecords, rc)
+	}
+	return existingRecords, nil
+}
+
+// GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
+// A SET action replaces all the records of a name and type, so each record set
+// is updated in a single request.
+func (c *zonomiProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecordSet(existingRecords, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var corrections []*models.Correction
+	for _, change := range changes {
+		var actions []action
+		switch change.Type {
+		case diff2.REPORT:
+			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
+			continue
+		case diff2.CREATE, diff2.CHANGE:
+			for _, rc := range change.New {
+				actions = append(actions, setAction(rc))
+			}
+		case diff2.DELETE:
+			actions = []action{{Action: "DELETE", Name: change.Key.NameFQDN, Type: change.Key.Type}}
+		default:
+			panic(fmt.Sprintf("unhandled change.Type %s", change.Type))
+		}
+		corrections = append(corrections, &models.Correction{
+			Msg: change.MsgsJoined,
+			F: func() error {
+				return c.apply(actions)
+			},
+		})
+	}
+
+	return corrections, nil
+}